@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/go-logr/logr"
@@ -12,6 +13,10 @@ func main() {
 	log.SetLogger(logr.Discard())
 	root := commands.RootCommand()
 	if err := root.Execute(); err != nil {
+		var coder interface{ ExitCode() int }
+		if errors.As(err, &coder) {
+			os.Exit(coder.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
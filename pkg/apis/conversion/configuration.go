@@ -39,6 +39,11 @@ func Convert_v1alpha1_ConfigurationSpec_To_v1alpha2_ConfigurationSpec(in *v1alph
 		SkipDelete:         in.SkipDelete,
 		DelayBeforeCleanup: in.DelayBeforeCleanup,
 	}
+	// v1alpha1 has no client options, use the v1alpha2 defaults
+	out.Client = v1alpha2.ClientOptions{
+		QPS:   300,
+		Burst: 300,
+	}
 	out.Clusters = in.Clusters
 	out.Deletion = v1alpha2.DeletionOptions{
 		Propagation: in.DeletionPropagationPolicy,
@@ -3,8 +3,17 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// ActionAnnotationSelector contains annotation selector options for an action.
+type ActionAnnotationSelector struct {
+	// AnnotationSelector filters resources by annotations, it is a comma separated list of key=value, key!=value, key or !key requirements (values are compared as raw strings).
+	// Resources are listed and filtered client side.
+	// +optional
+	AnnotationSelector Expression `json:"annotationSelector,omitempty"`
+}
+
 // ActionBindings contains bindings options for an action.
 type ActionBindings struct {
 	// Bindings defines additional binding key/values.
@@ -12,6 +21,13 @@ type ActionBindings struct {
 	Bindings []Binding `json:"bindings,omitempty"`
 }
 
+// ActionCapture contains stdout capture options for an action.
+type ActionCapture struct {
+	// Capture registers the parsed stdout of the action as an output binding.
+	// +optional
+	Capture *Capture `json:"capture,omitempty"`
+}
+
 // ActionCheck contains check for an action.
 type ActionCheck struct {
 	// Check is an assertion tree to validate the operation outcome.
@@ -36,6 +52,7 @@ type ActionCheckRef struct {
 // ActionClusters contains clusters options for an action.
 type ActionClusters struct {
 	// Cluster defines the target cluster (will be inherited if not specified).
+	// It can be an expression, resolved with the bindings available at execution time.
 	// +optional
 	Cluster *string `json:"cluster,omitempty"`
 
@@ -69,6 +86,14 @@ type ActionExpectations struct {
 	Expect []Expectation `json:"expect,omitempty"`
 }
 
+// ActionFieldSelector contains field selector options for an action.
+type ActionFieldSelector struct {
+	// FieldSelector defines a field selector (e.g. status.phase=Running).
+	// It can't be combined with a name.
+	// +optional
+	FieldSelector Expression `json:"fieldSelector,omitempty"`
+}
+
 // ActionFormat contains format for an action.
 type ActionFormat struct {
 	// Format determines the output format (json or yaml).
@@ -90,6 +115,17 @@ type ActionObject struct {
 	ActionObjectSelector `json:",inline"`
 }
 
+// ActionNode contains the nodes targeted by a node action.
+type ActionNode struct {
+	// Name of the node.
+	// +optional
+	Name Expression `json:"name,omitempty"`
+
+	// Selector defines labels selector to select nodes.
+	// +optional
+	Selector Expression `json:"selector,omitempty"`
+}
+
 // ActionObjectSelector contains object selector options for an action.
 // +kubebuilder:not:={required:{name,selector}}
 type ActionObjectSelector struct {
@@ -112,7 +148,21 @@ type FileRef struct {
 	// File is the path to the referenced file. This can be a direct path to a file
 	// or an expression that matches multiple files, such as "manifest/*.yaml" for all YAML
 	// files within the "manifest" directory.
+	// Parts of the path can be expressions resolved with the bindings, such as "manifests/($kind).yaml".
 	File Expression `json:"file,omitempty"`
+
+	// AllowEmpty determines whether a file expression matching no files is allowed.
+	// When true, the operation is skipped instead of failing. Defaults to false.
+	// +optional
+	AllowEmpty *bool `json:"allowEmpty,omitempty"`
+}
+
+// ActionRendered contains an assertion on the rendered resource for an action.
+type ActionRendered struct {
+	// Rendered is an assertion tree the resource must satisfy once rendered (after templating).
+	// It is evaluated before the resource is submitted to the cluster.
+	// +optional
+	Rendered *Check `json:"rendered,omitempty"`
 }
 
 // ActionResourceRef contains resource reference options for an action.
@@ -134,34 +184,200 @@ type ActionResourceRef struct {
 // ActionTimeout contains timeout options for an action.
 type ActionTimeout struct {
 	// Timeout for the operation. Overrides the global timeout set in the Configuration.
+	// It can reference a named timeout declared in the Configuration (like `@slowApply`).
 	// +optional
-	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	Timeout *Timeout `json:"timeout,omitempty"`
 }
 
 // Apply represents a set of configurations or resources that
 // should be applied during testing.
+// +kubebuilder:not:={anyOf:{{required:{kustomize,file}},{required:{kustomize,resource}}}}
 type Apply struct {
 	ActionBindings     `json:",inline"`
 	ActionClusters     `json:",inline"`
 	ActionDryRun       `json:",inline"`
 	ActionExpectations `json:",inline"`
 	ActionOutputs      `json:",inline"`
+	ActionRendered     `json:",inline"`
 	ActionResourceRef  `json:",inline"`
 	ActionTimeout      `json:",inline"`
+
+	// Kustomize is the path to a kustomization directory, the resources built from it (like `kustomize build` does) are applied.
+	// Relative paths are resolved against the test folder.
+	// +optional
+	Kustomize Expression `json:"kustomize,omitempty"`
+	// ServerSide determines whether the resources are applied with server-side apply instead of a client-side merge patch.
+	// +optional
+	ServerSide *bool `json:"serverSide,omitempty"`
+	// ForceConflicts determines whether conflicts with other field managers are forced, otherwise they make the apply fail.
+	// It is only used with server-side apply.
+	// +optional
+	ForceConflicts *bool `json:"forceConflicts,omitempty"`
 }
 
 // Assert represents a test condition that is expected to hold true
 // during the testing process.
 type Assert struct {
-	ActionBindings `json:",inline"`
-	ActionCheckRef `json:",inline"`
+	ActionAnnotationSelector `json:",inline"`
+	ActionBindings           `json:",inline"`
+	ActionCheckRef           `json:",inline"`
+	ActionClusters           `json:",inline"`
+	ActionTimeout            `json:",inline"`
+
+	// Schema is the path to a JSON schema file (JSON or YAML) the actual resources must conform to.
+	// Relative paths are resolved against the test folder.
+	// +optional
+	Schema Expression `json:"schema,omitempty"`
+	// Revision asserts against a historical revision of the actual resources instead of their current state.
+	// Supported for Deployments (through ReplicaSets), StatefulSets and DaemonSets (through ControllerRevisions).
+	// A zero or negative value is relative to the latest revision (0 is the latest, -1 the previous one, etc...).
+	// It can be an integer or an expression evaluating to an integer.
+	// +optional
+	Revision *intstr.IntOrString `json:"revision,omitempty"`
+	// ObservedGeneration additionally asserts that the actual resources status.observedGeneration
+	// equals their metadata.generation, meaning their controller has observed the latest spec.
+	// +optional
+	ObservedGeneration *bool `json:"observedGeneration,omitempty"`
+	// Namespaces asserts the expected resources in each of the given namespaces instead of the test namespace.
+	// +optional
+	Namespaces []Expression `json:"namespaces,omitempty"`
+	// NamespaceSelector asserts the expected resources in each namespace matching the label selector.
+	// It can be combined with namespaces.
+	// +optional
+	NamespaceSelector Expression `json:"namespaceSelector,omitempty"`
+	// Tolerances allow numeric fields at the given paths to match within an epsilon instead of exactly.
+	// +optional
+	Tolerances []Tolerance `json:"tolerances,omitempty"`
+	// Subresource asserts against a subresource (scale or status) of the actual resources instead of the resources themselves.
+	// The apiVersion, kind and metadata of the expected resource are only used to look up the actual resources.
+	// +optional
+	// +kubebuilder:validation:Enum:=scale;status
+	Subresource string `json:"subresource,omitempty"`
+	// Aggregate asserts once against all the actual resources instead of against each of them.
+	// The check is evaluated against an object with an `items` field containing the actual resources,
+	// the apiVersion, kind and metadata of the expected resource are only used to look up the actual resources.
+	// +optional
+	Aggregate bool `json:"aggregate,omitempty"`
+	// StableFor requires the assertion to keep holding for the given duration once it first holds.
+	// The operation fails as soon as the assertion regresses during the stability window.
+	// +optional
+	StableFor *metav1.Duration `json:"stableFor,omitempty"`
+	// AnyOf additionally requires the actual resources to match at least one of the given checks.
+	// The checks are evaluated against the same object as the expected resource,
+	// the assertion fails only if none of them match.
+	// +optional
+	AnyOf []Check `json:"anyOf,omitempty"`
+}
+
+// Tolerance defines how close a numeric field must be to the expected value.
+// A value matches when it is within the absolute or the relative epsilon.
+type Tolerance struct {
+	// Path is the path of the numeric field in the assertion, e.g. status.ratio or spec.containers[0].weight.
+	Path string `json:"path"`
+	// Absolute is the maximum absolute difference between the actual and expected values.
+	// +optional
+	// +kubebuilder:validation:Pattern:=`^[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?$`
+	Absolute string `json:"absolute,omitempty"`
+	// Relative is the maximum difference relative to the expected value, e.g. 0.01 for 1%.
+	// +optional
+	// +kubebuilder:validation:Pattern:=`^[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?$`
+	Relative string `json:"relative,omitempty"`
+}
+
+// CanI checks whether the current identity is allowed to perform an action in the cluster.
+type CanI struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Verb is the kubernetes resource API verb, like: get, list, watch, create, update, delete, proxy.
+	Verb string `json:"verb"`
+
+	// Group is the API group of the resource.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Resource is one of the existing resource types.
+	Resource string `json:"resource"`
+
+	// Subresource is one of the existing resource subresources.
+	// +optional
+	Subresource string `json:"subresource,omitempty"`
+
+	// Namespace is the namespace of the action being requested.
+	// Defaults to the test namespace, set it to "*" to check access across all namespaces.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Skip determines whether the test should be skipped instead of failed when access is denied.
+	// +optional
+	Skip *bool `json:"skip,omitempty"`
+}
+
+// CaptureParser determines how the captured stdout is parsed.
+// +kubebuilder:validation:Enum:=string;json;lines
+type CaptureParser string
+
+const (
+	CaptureParserString CaptureParser = "string"
+	CaptureParserJSON   CaptureParser = "json"
+	CaptureParserLines  CaptureParser = "lines"
+)
+
+// Capture defines how the stdout of an action is parsed and registered as an output binding.
+type Capture struct {
+	// As is the name of the output binding the parsed stdout is registered with.
+	// +kubebuilder:validation:Pattern:=`^\w+$`
+	As string `json:"as"`
+
+	// Parser determines how stdout is parsed, string (the default) keeps the trimmed output,
+	// json decodes it into a structured value and lines splits it into a list of non empty lines.
+	// +optional
+	Parser CaptureParser `json:"parser,omitempty"`
+}
+
+// Chart renders a Helm chart and asserts the resources in the cluster match the rendered templates.
+type Chart struct {
 	ActionClusters `json:",inline"`
 	ActionTimeout  `json:",inline"`
+
+	// Path is the path to the chart folder, relative to the test folder.
+	Path string `json:"path"`
+
+	// Release is the name of the release the chart is rendered for (defaults to the chart name).
+	// +optional
+	Release Expression `json:"release,omitempty"`
+
+	// Namespace is the namespace of the release (defaults to the test namespace).
+	// +optional
+	Namespace Expression `json:"namespace,omitempty"`
+
+	// Values overrides the default values of the chart, expressions are resolved with the bindings.
+	// +optional
+	Values *Projection `json:"values,omitempty"`
+
+	// Templates restricts the assertion to the resources rendered by the given templates (like `templates/deployment.yaml`).
+	// All templates are considered when not set.
+	// +optional
+	Templates []string `json:"templates,omitempty"`
+}
+
+// Collect runs a custom collector registered in the chainsaw build.
+type Collect struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Type is the name of the registered collector to run.
+	Type string `json:"type"`
+
+	// Config is passed to the collector as is, its structure depends on the collector type.
+	// +optional
+	Config *Projection `json:"config,omitempty"`
 }
 
 // Command describes a command to run as a part of a test step.
 type Command struct {
 	ActionBindings `json:",inline"`
+	ActionCapture  `json:",inline"`
 	ActionCheck    `json:",inline"`
 	ActionClusters `json:",inline"`
 	ActionEnv      `json:",inline"`
@@ -176,8 +392,29 @@ type Command struct {
 	Args []string `json:"args,omitempty"`
 
 	// WorkDir is the working directory for command.
+	// Relative paths are resolved against the test folder.
 	// +optional
-	WorkDir *string `json:"workDir,omitempty"`
+	WorkDir *Expression `json:"workDir,omitempty"`
+}
+
+// Compare evaluates two expressions and asserts they produce equal values.
+// It doesn't involve any cluster object.
+type Compare struct {
+	ActionBindings `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Actual is the expression evaluating to the actual value.
+	Actual Expression `json:"actual"`
+
+	// Expected is the expression evaluating to the expected value.
+	Expected Expression `json:"expected"`
+}
+
+// Cordon marks nodes as unschedulable.
+type Cordon struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ActionNode     `json:",inline"`
 }
 
 // Create represents a set of resources that should be created.
@@ -188,6 +425,7 @@ type Create struct {
 	ActionDryRun       `json:",inline"`
 	ActionExpectations `json:",inline"`
 	ActionOutputs      `json:",inline"`
+	ActionRendered     `json:",inline"`
 	ActionResourceRef  `json:",inline"`
 	ActionTimeout      `json:",inline"`
 }
@@ -207,9 +445,15 @@ type Delete struct {
 	// File is the path to the referenced file. This can be a direct path to a file
 	// or an expression that matches multiple files, such as "manifest/*.yaml" for all YAML
 	// files within the "manifest" directory.
+	// Parts of the path can be expressions resolved with the bindings, such as "manifests/($kind).yaml".
 	// +optional
 	File Expression `json:"file,omitempty"`
 
+	// AllowEmpty determines whether a file expression matching no files is allowed.
+	// When true, the operation is skipped instead of failing. Defaults to false.
+	// +optional
+	AllowEmpty *bool `json:"allowEmpty,omitempty"`
+
 	// Ref determines objects to be deleted.
 	// +optional
 	Ref *ObjectReference `json:"ref,omitempty"`
@@ -220,19 +464,89 @@ type Delete struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=Orphan;Background;Foreground
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// Wait determines whether the operation should wait for the resources to be actually deleted.
+	// When false, the operation doesn't block on finalizers or propagation. Defaults to true.
+	// +optional
+	Wait *bool `json:"wait,omitempty"`
+
+	// AllNamespaces determines whether objects are deleted across all namespaces (for namespaced kinds).
+	// It requires a ref with a label selector and no name or namespace.
+	// +optional
+	AllNamespaces *bool `json:"allNamespaces,omitempty"`
 }
 
-// Describe defines how to describe resources.
-type Describe struct {
+// DeltaOperator is the operator used to compare a value with an expected value.
+// Unchanged asserts the field is still equal to the snapshot, it supports any type of value and ignores the expected value.
+type DeltaOperator string
+
+const (
+	DeltaOperatorEqual              DeltaOperator = "Equal"
+	DeltaOperatorNotEqual           DeltaOperator = "NotEqual"
+	DeltaOperatorGreaterThan        DeltaOperator = "GreaterThan"
+	DeltaOperatorGreaterThanOrEqual DeltaOperator = "GreaterThanOrEqual"
+	DeltaOperatorLessThan           DeltaOperator = "LessThan"
+	DeltaOperatorLessThanOrEqual    DeltaOperator = "LessThanOrEqual"
+	DeltaOperatorUnchanged          DeltaOperator = "Unchanged"
+)
+
+// Delta asserts the change of a resource field, compared to a snapshot taken earlier in the step, satisfies an operator.
+type Delta struct {
 	ActionClusters `json:",inline"`
-	ActionObject   `json:",inline"`
 	ActionTimeout  `json:",inline"`
+	ObjectType     `json:",inline"`
+	ObjectName     `json:",inline"`
+
+	// Snapshot is the name of the snapshot to compare with.
+	Snapshot string `json:"snapshot"`
+
+	// Field is the expression evaluated against the resource to compute the current value.
+	Field Expression `json:"field"`
+
+	// Operator is the operator used to compare the delta (current value minus snapshot value) with Value.
+	// +optional
+	// +kubebuilder:default:=Equal
+	// +kubebuilder:validation:Enum:=Equal;NotEqual;GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual;Unchanged
+	Operator DeltaOperator `json:"operator,omitempty"`
+
+	// Value is the value the delta is compared with.
+	// +optional
+	Value int64 `json:"value,omitempty"`
+}
+
+// Describe defines how to describe resources.
+type Describe struct {
+	ActionAnnotationSelector `json:",inline"`
+	ActionClusters           `json:",inline"`
+	ActionFieldSelector      `json:",inline"`
+	ActionObject             `json:",inline"`
+	ActionTimeout            `json:",inline"`
 
 	// Show Events indicates whether to include related events.
 	// +optional
 	ShowEvents *bool `json:"showEvents,omitempty"`
 }
 
+// Drain evicts the pods running on nodes, nodes are marked as unschedulable first.
+type Drain struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ActionNode     `json:",inline"`
+
+	// IgnoreDaemonSets ignores pods managed by DaemonSets.
+	// +optional
+	IgnoreDaemonSets bool `json:"ignoreDaemonSets,omitempty"`
+
+	// DeleteEmptyDirData continues even if there are pods using emptyDir volumes (their data is deleted).
+	// +optional
+	DeleteEmptyDirData bool `json:"deleteEmptyDirData,omitempty"`
+
+	// GracePeriod is the period of time given to each pod to terminate gracefully.
+	// If not set, the pods termination grace period is used.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
 // Error represents an anticipated error condition that may arise during testing.
 // Instead of treating such an error as a test failure, it acknowledges it as expected.
 type Error struct {
@@ -240,22 +554,110 @@ type Error struct {
 	ActionCheckRef `json:",inline"`
 	ActionClusters `json:",inline"`
 	ActionTimeout  `json:",inline"`
+
+	// Message is a regular expression the rejection message must match.
+	// When set, the resource is created (or updated if it already exists) in dry run mode and the request is expected to be rejected (by an admission webhook for example).
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // Events defines how to collect events.
 type Events struct {
 	ActionClusters       `json:",inline"`
+	ActionFieldSelector  `json:",inline"`
 	ActionFormat         `json:",inline"`
 	ActionObjectSelector `json:",inline"`
 	ActionTimeout        `json:",inline"`
 }
 
+// Exec runs a command in a container of a running pod.
+type Exec struct {
+	ActionCheck          `json:",inline"`
+	ActionClusters       `json:",inline"`
+	ActionObjectSelector `json:",inline"`
+	ActionOutputs        `json:",inline"`
+	ActionTimeout        `json:",inline"`
+
+	// Container is the name of the container to run the command in (defaults to the default container of the pod).
+	// +optional
+	Container Expression `json:"container,omitempty"`
+
+	// Command is the command to run in the container, the first element is the entry point.
+	// +kubebuilder:validation:MinItems:=1
+	Command []string `json:"command"`
+}
+
 // Get defines how to get resources.
 type Get struct {
+	ActionAnnotationSelector `json:",inline"`
+	ActionClusters           `json:",inline"`
+	ActionFieldSelector      `json:",inline"`
+	ActionFormat             `json:",inline"`
+	ActionObject             `json:",inline"`
+	ActionTimeout            `json:",inline"`
+
+	// Subresource fetches a subresource (scale or status) of the resources instead of the resources themselves.
+	// +optional
+	// +kubebuilder:validation:Enum:=scale;status
+	Subresource string `json:"subresource,omitempty"`
+}
+
+// Health checks whether an external dependency is reachable, either with an HTTP GET request or a TCP dial.
+type Health struct {
+	ActionTimeout `json:",inline"`
+
+	// URL is the url of the HTTP endpoint to check, a GET request is sent to this url.
+	// It can be an expression, resolved with the bindings available at execution time.
+	// +optional
+	URL Expression `json:"url,omitempty"`
+
+	// Address is the host:port address to check, a TCP connection is opened to this address.
+	// It can be an expression, resolved with the bindings available at execution time.
+	// +optional
+	Address Expression `json:"address,omitempty"`
+
+	// Status is the expected HTTP status code, any 2xx status code is accepted if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+	Status int `json:"status,omitempty"`
+
+	// Skip determines whether the test should be skipped instead of failed when the dependency is not reachable.
+	// +optional
+	Skip *bool `json:"skip,omitempty"`
+}
+
+// Job waits for a job to complete successfully and optionally asserts on the logs of its pods.
+type Job struct {
 	ActionClusters `json:",inline"`
-	ActionFormat   `json:",inline"`
-	ActionObject   `json:",inline"`
 	ActionTimeout  `json:",inline"`
+	ObjectName     `json:",inline"`
+
+	// CronJob determines whether the name refers to a cron job, the latest job created by the cron job is used in this case.
+	// +optional
+	CronJob bool `json:"cronJob,omitempty"`
+
+	// Container is the name of the container to fetch logs from (defaults to all containers).
+	// +optional
+	Container Expression `json:"container,omitempty"`
+
+	// Logs is a list of regular expressions matching lines that must appear in order in the logs of the job pods.
+	// +optional
+	Logs []string `json:"logs,omitempty"`
+}
+
+// NoCrashLoop asserts no container of the pods matching a selector crash loops during the operation timeout.
+type NoCrashLoop struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Namespace of the pods (defaults to the test namespace).
+	// +optional
+	Namespace Expression `json:"namespace,omitempty"`
+
+	// Selector defines the labels selector of the pods to check (defaults to all pods in the namespace).
+	// +optional
+	Selector Expression `json:"selector,omitempty"`
 }
 
 // Patch represents a set of resources that should be patched.
@@ -266,12 +668,43 @@ type Patch struct {
 	ActionDryRun       `json:",inline"`
 	ActionExpectations `json:",inline"`
 	ActionOutputs      `json:",inline"`
+	ActionRendered     `json:",inline"`
 	ActionResourceRef  `json:",inline"`
 	ActionTimeout      `json:",inline"`
 }
 
+// PodCount waits until the number of pods matching a label selector and in a given phase satisfies an operator.
+type PodCount struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+
+	// Namespace of the pods (defaults to the test namespace).
+	// +optional
+	Namespace Expression `json:"namespace,omitempty"`
+
+	// Selector defines the labels selector of the pods to count.
+	Selector Expression `json:"selector"`
+
+	// Phase is the phase of the pods to count.
+	// +optional
+	// +kubebuilder:validation:Enum:=Pending;Running;Succeeded;Failed;Unknown
+	// +kubebuilder:default:=Running
+	Phase string `json:"phase,omitempty"`
+
+	// Operator is the operator used to compare the number of pods with Count.
+	// +optional
+	// +kubebuilder:default:=Equal
+	// +kubebuilder:validation:Enum:=Equal;NotEqual;GreaterThan;GreaterThanOrEqual;LessThan;LessThanOrEqual
+	Operator DeltaOperator `json:"operator,omitempty"`
+
+	// Count is the number of pods the actual number of pods is compared with.
+	// +kubebuilder:validation:Minimum:=0
+	Count int64 `json:"count"`
+}
+
 // PodLogs defines how to collect pod logs.
 type PodLogs struct {
+	ActionCheck          `json:",inline"`
 	ActionClusters       `json:",inline"`
 	ActionObjectSelector `json:",inline"`
 	ActionTimeout        `json:",inline"`
@@ -283,8 +716,47 @@ type PodLogs struct {
 	// Tail is the number of last lines to collect from pods. If omitted or zero,
 	// then the default is 10 if you use a selector, or -1 (all) if you use a pod name.
 	// This matches default behavior of `kubectl logs`.
+	// It can be an integer or an expression evaluating to an integer.
+	// +optional
+	Tail *intstr.IntOrString `json:"tail,omitempty"`
+
+	// Timestamps includes the timestamp at the beginning of each log line.
+	// +optional
+	Timestamps *bool `json:"timestamps,omitempty"`
+
+	// JSONFields parses each log line as JSON and only keeps the given top level fields.
+	// Lines that are not valid JSON are kept as is.
 	// +optional
-	Tail *int `json:"tail,omitempty"`
+	JSONFields []string `json:"jsonFields,omitempty"`
+}
+
+// Probe sends HTTP GET requests to an url until the response matches the expected status and body.
+type Probe struct {
+	ActionTimeout `json:",inline"`
+
+	// URL is the url of the HTTP endpoint to probe.
+	// It can be an expression, resolved with the bindings available at execution time.
+	URL Expression `json:"url"`
+
+	// Address is the host[:port] address the requests are sent to instead of the host of the url (like the ingress controller IP).
+	// The host of the url is still used for the Host header and the TLS server name, the port of the url is used if not specified.
+	// It can be an expression, resolved with the bindings available at execution time.
+	// +optional
+	Address Expression `json:"address,omitempty"`
+
+	// Status is the expected HTTP status code, any 2xx status code is accepted if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum:=100
+	// +kubebuilder:validation:Maximum:=599
+	Status int `json:"status,omitempty"`
+
+	// Body is a regular expression the response body must match.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// Insecure determines whether the server certificate verification is skipped.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 // Proxy defines how to get resources.
@@ -304,9 +776,38 @@ type Proxy struct {
 	TargetPath Expression `json:"path,omitempty"`
 }
 
+// Rollout asserts a deployment rolled out since a snapshot of its observed generation was taken earlier in the step,
+// without the containers of its pods restarting more than allowed.
+type Rollout struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ObjectName     `json:",inline"`
+
+	// Snapshot is the name of the snapshot holding the observed generation of the deployment before the rollout.
+	// The snapshot must capture pod restarts too.
+	Snapshot string `json:"snapshot"`
+
+	// MaxRestarts is the maximum number of container restarts allowed across the pods of the deployment since the snapshot.
+	// +optional
+	MaxRestarts int64 `json:"maxRestarts,omitempty"`
+}
+
+// Scale changes the number of replicas of a resource through its scale subresource.
+type Scale struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ObjectType     `json:",inline"`
+	ObjectName     `json:",inline"`
+
+	// Replicas is the desired number of replicas.
+	// A string prefixed with + or - (like "+1" or "-2") scales relatively to the current number of replicas.
+	Replicas intstr.IntOrString `json:"replicas"`
+}
+
 // Script describes a script to run as a part of a test step.
 type Script struct {
 	ActionBindings `json:",inline"`
+	ActionCapture  `json:",inline"`
 	ActionCheck    `json:",inline"`
 	ActionClusters `json:",inline"`
 	ActionEnv      `json:",inline"`
@@ -317,9 +818,35 @@ type Script struct {
 	// +optional
 	Content string `json:"content,omitempty"`
 
+	// Stdin is passed to the script standard input.
+	// It can be an expression, resolved with the bindings available at execution time.
+	// +optional
+	Stdin *string `json:"stdin,omitempty"`
+
 	// WorkDir is the working directory for script.
+	// Relative paths are resolved against the test folder.
 	// +optional
-	WorkDir *string `json:"workDir,omitempty"`
+	WorkDir *Expression `json:"workDir,omitempty"`
+}
+
+// Snapshot captures the value of a resource field so that a delta operation can compare with it later in the step.
+type Snapshot struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ObjectType     `json:",inline"`
+	ObjectName     `json:",inline"`
+
+	// As is the name of the snapshot, the captured value is registered as a binding with this name.
+	// +kubebuilder:validation:Pattern:=`^\w+$`
+	As string `json:"as"`
+
+	// Field is the expression evaluated against the resource to compute the captured value.
+	Field Expression `json:"field"`
+
+	// Restarts also captures the container restart counts of the pods selected by the resource (indexed by pod uid)
+	// in a binding named after the snapshot with a _restarts suffix.
+	// +optional
+	Restarts bool `json:"restarts,omitempty"`
 }
 
 // Sleep represents a duration while nothing happens.
@@ -328,6 +855,13 @@ type Sleep struct {
 	Duration metav1.Duration `json:"duration"`
 }
 
+// Uncordon marks nodes as schedulable.
+type Uncordon struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ActionNode     `json:",inline"`
+}
+
 // Update represents a set of resources that should be updated.
 // If a resource does not exist in the cluster it will fail.
 type Update struct {
@@ -336,21 +870,37 @@ type Update struct {
 	ActionDryRun       `json:",inline"`
 	ActionExpectations `json:",inline"`
 	ActionOutputs      `json:",inline"`
+	ActionRendered     `json:",inline"`
 	ActionResourceRef  `json:",inline"`
 	ActionTimeout      `json:",inline"`
 }
 
 // Wait specifies how to perform wait operations on resources.
 type Wait struct {
-	ActionTimeout  `json:",inline"`
-	ActionFormat   `json:",inline"`
-	ActionClusters `json:",inline"`
-	ActionObject   `json:",inline"`
+	ActionTimeout       `json:",inline"`
+	ActionFieldSelector `json:",inline"`
+	ActionFormat        `json:",inline"`
+	ActionClusters      `json:",inline"`
+	ActionObject        `json:",inline"`
 
 	// WaitFor specifies the condition to wait for.
 	WaitFor `json:"for"`
 }
 
+// Watch records the state transitions of a resource and asserts they match an expected sequence.
+type Watch struct {
+	ActionClusters `json:",inline"`
+	ActionTimeout  `json:",inline"`
+	ObjectType     `json:",inline"`
+	ObjectName     `json:",inline"`
+
+	// Sequence is the ordered list of states the resource is expected to go through.
+	// Consecutive events matching the same state are allowed, the operation succeeds
+	// when the last state of the sequence is reached.
+	// +kubebuilder:validation:MinItems:=1
+	Sequence []Check `json:"sequence"`
+}
+
 // WaitFor specifies the condition to wait for.
 type WaitFor struct {
 	// Deletion specifies parameters for waiting on a resource's deletion.
@@ -1,6 +1,7 @@
 package v1alpha1
 
 // CatchFinally defines actions to be executed in catch, finally and cleanup blocks.
+// +kubebuilder:oneOf:={required:{collect}}
 // +kubebuilder:oneOf:={required:{command}}
 // +kubebuilder:oneOf:={required:{delete}}
 // +kubebuilder:oneOf:={required:{describe}}
@@ -9,6 +10,7 @@ package v1alpha1
 // +kubebuilder:oneOf:={required:{podLogs}}
 // +kubebuilder:oneOf:={required:{script}}
 // +kubebuilder:oneOf:={required:{sleep}}
+// +kubebuilder:oneOf:={required:{uncordon}}
 // +kubebuilder:oneOf:={required:{wait}}
 type CatchFinally struct {
 	// Description contains a description of the operation.
@@ -43,6 +45,10 @@ type CatchFinally struct {
 	// +optional
 	Delete *Delete `json:"delete,omitempty"`
 
+	// Collect determines the custom collector to execute.
+	// +optional
+	Collect *Collect `json:"collect,omitempty"`
+
 	// Command defines a command to run.
 	// +optional
 	Command *Command `json:"command,omitempty"`
@@ -54,10 +60,16 @@ type CatchFinally struct {
 	// Sleep defines zzzz.
 	// +optional
 	Sleep *Sleep `json:"sleep,omitempty"`
+
+	// Uncordon marks nodes as schedulable.
+	// +optional
+	Uncordon *Uncordon `json:"uncordon,omitempty"`
 }
 
 func (f *CatchFinally) Bindings() []Binding {
 	switch {
+	case f.Collect != nil:
+		return nil
 	case f.Command != nil:
 		return f.Command.Bindings
 	case f.Delete != nil:
@@ -74,6 +86,8 @@ func (f *CatchFinally) Bindings() []Binding {
 		return f.Script.Bindings
 	case f.Sleep != nil:
 		return nil
+	case f.Uncordon != nil:
+		return nil
 	case f.Wait != nil:
 		return nil
 	}
@@ -82,6 +96,8 @@ func (f *CatchFinally) Bindings() []Binding {
 
 func (f *CatchFinally) Outputs() []Output {
 	switch {
+	case f.Collect != nil:
+		return nil
 	case f.Command != nil:
 		return f.Command.Outputs
 	case f.Delete != nil:
@@ -98,6 +114,8 @@ func (f *CatchFinally) Outputs() []Output {
 		return f.Script.Outputs
 	case f.Sleep != nil:
 		return nil
+	case f.Uncordon != nil:
+		return nil
 	case f.Wait != nil:
 		return nil
 	}
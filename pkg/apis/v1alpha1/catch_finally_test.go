@@ -14,15 +14,21 @@ func TestFinally_Bindings(t *testing.T) {
 		Wait     *Wait
 		Get      *Get
 		Delete   *Delete
+		Collect  *Collect
 		Command  *Command
 		Script   *Script
 		Sleep    *Sleep
+		Uncordon *Uncordon
 	}
 	tests := []struct {
 		name   string
 		fields fields
 		want   int
 	}{{
+		fields: fields{
+			Collect: &Collect{},
+		},
+	}, {
 		fields: fields{
 			Command: &Command{
 				ActionBindings: ActionBindings{Bindings: []Binding{{Name: "foo", Value: NewProjection("bar")}}},
@@ -63,6 +69,10 @@ func TestFinally_Bindings(t *testing.T) {
 		fields: fields{
 			Sleep: &Sleep{},
 		},
+	}, {
+		fields: fields{
+			Uncordon: &Uncordon{},
+		},
 	}, {
 		fields: fields{
 			Wait: &Wait{},
@@ -77,9 +87,11 @@ func TestFinally_Bindings(t *testing.T) {
 				Wait:     tt.fields.Wait,
 				Get:      tt.fields.Get,
 				Delete:   tt.fields.Delete,
+				Collect:  tt.fields.Collect,
 				Command:  tt.fields.Command,
 				Script:   tt.fields.Script,
 				Sleep:    tt.fields.Sleep,
+				Uncordon: tt.fields.Uncordon,
 			}
 			got := c.Bindings()
 			assert.Equal(t, tt.want, len(got))
@@ -96,15 +108,21 @@ func TestFinally_Outputs(t *testing.T) {
 		Wait     *Wait
 		Get      *Get
 		Delete   *Delete
+		Collect  *Collect
 		Command  *Command
 		Script   *Script
 		Sleep    *Sleep
+		Uncordon *Uncordon
 	}
 	tests := []struct {
 		name   string
 		fields fields
 		want   int
 	}{{
+		fields: fields{
+			Collect: &Collect{},
+		},
+	}, {
 		fields: fields{
 			Command: &Command{
 				ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
@@ -142,6 +160,10 @@ func TestFinally_Outputs(t *testing.T) {
 		fields: fields{
 			Sleep: &Sleep{},
 		},
+	}, {
+		fields: fields{
+			Uncordon: &Uncordon{},
+		},
 	}, {
 		fields: fields{
 			Wait: &Wait{},
@@ -156,9 +178,11 @@ func TestFinally_Outputs(t *testing.T) {
 				Wait:     tt.fields.Wait,
 				Get:      tt.fields.Get,
 				Delete:   tt.fields.Delete,
+				Collect:  tt.fields.Collect,
 				Command:  tt.fields.Command,
 				Script:   tt.fields.Script,
 				Sleep:    tt.fields.Sleep,
+				Uncordon: tt.fields.Uncordon,
 			}
 			got := c.Outputs()
 			assert.Equal(t, tt.want, len(got))
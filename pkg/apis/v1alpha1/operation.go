@@ -14,24 +14,46 @@ type OperationBase struct {
 	// Compiler defines the default compiler to use when evaluating expressions.
 	// +optional
 	Compiler *Compiler `json:"compiler,omitempty"`
+
+	// If is a condition evaluated against the bindings before running the operation.
+	// The operation is skipped when it evaluates to false.
+	// +optional
+	If Expression `json:"if,omitempty"`
 }
 
 // Operation defines a single operation, only one action is permitted for a given operation.
 // +kubebuilder:oneOf:={required:{apply}}
 // +kubebuilder:oneOf:={required:{assert}}
+// +kubebuilder:oneOf:={required:{canI}}
+// +kubebuilder:oneOf:={required:{chart}}
 // +kubebuilder:oneOf:={required:{command}}
+// +kubebuilder:oneOf:={required:{compare}}
+// +kubebuilder:oneOf:={required:{cordon}}
 // +kubebuilder:oneOf:={required:{create}}
 // +kubebuilder:oneOf:={required:{delete}}
+// +kubebuilder:oneOf:={required:{delta}}
 // +kubebuilder:oneOf:={required:{describe}}
+// +kubebuilder:oneOf:={required:{drain}}
 // +kubebuilder:oneOf:={required:{error}}
 // +kubebuilder:oneOf:={required:{events}}
+// +kubebuilder:oneOf:={required:{exec}}
+// +kubebuilder:oneOf:={required:{health}}
+// +kubebuilder:oneOf:={required:{job}}
+// +kubebuilder:oneOf:={required:{noCrashLoop}}
 // +kubebuilder:oneOf:={required:{patch}}
+// +kubebuilder:oneOf:={required:{podCount}}
 // +kubebuilder:oneOf:={required:{podLogs}}
+// +kubebuilder:oneOf:={required:{probe}}
 // +kubebuilder:oneOf:={required:{proxy}}
+// +kubebuilder:oneOf:={required:{rollout}}
+// +kubebuilder:oneOf:={required:{scale}}
 // +kubebuilder:oneOf:={required:{script}}
 // +kubebuilder:oneOf:={required:{sleep}}
+// +kubebuilder:oneOf:={required:{snapshot}}
+// +kubebuilder:oneOf:={required:{uncordon}}
 // +kubebuilder:oneOf:={required:{update}}
 // +kubebuilder:oneOf:={required:{wait}}
+// +kubebuilder:oneOf:={required:{watch}}
 type Operation struct {
 	// OperationBase defines common elements to all operations.
 	// +optional
@@ -46,10 +68,26 @@ type Operation struct {
 	// +optional
 	Assert *Assert `json:"assert,omitempty"`
 
+	// CanI checks access to the cluster, failing or skipping the test when denied.
+	// +optional
+	CanI *CanI `json:"canI,omitempty"`
+
+	// Chart asserts the resources in the cluster match the templates rendered from a Helm chart.
+	// +optional
+	Chart *Chart `json:"chart,omitempty"`
+
 	// Command defines a command to run.
 	// +optional
 	Command *Command `json:"command,omitempty"`
 
+	// Compare asserts two expressions evaluate to equal values.
+	// +optional
+	Compare *Compare `json:"compare,omitempty"`
+
+	// Cordon marks nodes as unschedulable.
+	// +optional
+	Cordon *Cordon `json:"cordon,omitempty"`
+
 	// Create represents a creation operation.
 	// +optional
 	Create *Create `json:"create,omitempty"`
@@ -58,10 +96,18 @@ type Operation struct {
 	// +optional
 	Delete *Delete `json:"delete,omitempty"`
 
+	// Delta asserts the change of a resource field compared to a snapshot.
+	// +optional
+	Delta *Delta `json:"delta,omitempty"`
+
 	// Describe determines the resource describe collector to execute.
 	// +optional
 	Describe *Describe `json:"describe,omitempty"`
 
+	// Drain evicts the pods running on nodes.
+	// +optional
+	Drain *Drain `json:"drain,omitempty"`
+
 	// Error represents the expected errors for this test step. If any of these errors occur, the test
 	// will consider them as expected; otherwise, they will be treated as test failures.
 	// +optional
@@ -71,22 +117,54 @@ type Operation struct {
 	// +optional
 	Events *Events `json:"events,omitempty"`
 
+	// Exec runs a command in a container of a running pod.
+	// +optional
+	Exec *Exec `json:"exec,omitempty"`
+
 	// Get determines the resource get collector to execute.
 	// +optional
 	Get *Get `json:"get,omitempty"`
 
+	// Health checks an external dependency is reachable, failing or skipping the test otherwise.
+	// +optional
+	Health *Health `json:"health,omitempty"`
+
+	// Job waits for a job to complete and optionally asserts on the logs of its pods.
+	// +optional
+	Job *Job `json:"job,omitempty"`
+
+	// NoCrashLoop asserts no pod matching a selector is crash looping.
+	// +optional
+	NoCrashLoop *NoCrashLoop `json:"noCrashLoop,omitempty"`
+
 	// Patch represents a patch operation.
 	// +optional
 	Patch *Patch `json:"patch,omitempty"`
 
+	// PodCount waits until the number of pods matching a selector reaches a target.
+	// +optional
+	PodCount *PodCount `json:"podCount,omitempty"`
+
 	// PodLogs determines the pod logs collector to execute.
 	// +optional
 	PodLogs *PodLogs `json:"podLogs,omitempty"`
 
+	// Probe sends HTTP requests until the response matches the expected status and body.
+	// +optional
+	Probe *Probe `json:"probe,omitempty"`
+
 	// Proxy runs a proxy request.
 	// +optional
 	Proxy *Proxy `json:"proxy,omitempty"`
 
+	// Rollout asserts a deployment rolled out without pods restarting more than allowed.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+
+	// Scale changes the number of replicas of a resource.
+	// +optional
+	Scale *Scale `json:"scale,omitempty"`
+
 	// Script defines a script to run.
 	// +optional
 	Script *Script `json:"script,omitempty"`
@@ -95,6 +173,14 @@ type Operation struct {
 	// +optional
 	Sleep *Sleep `json:"sleep,omitempty"`
 
+	// Snapshot captures the value of a resource field.
+	// +optional
+	Snapshot *Snapshot `json:"snapshot,omitempty"`
+
+	// Uncordon marks nodes as schedulable.
+	// +optional
+	Uncordon *Uncordon `json:"uncordon,omitempty"`
+
 	// Update represents an update operation.
 	// +optional
 	Update *Update `json:"update,omitempty"`
@@ -102,6 +188,10 @@ type Operation struct {
 	// Wait determines the resource wait collector to execute.
 	// +optional
 	Wait *Wait `json:"wait,omitempty"`
+
+	// Watch asserts a resource goes through an expected sequence of states.
+	// +optional
+	Watch *Watch `json:"watch,omitempty"`
 }
 
 func (o *Operation) Bindings() []Binding {
@@ -110,34 +200,68 @@ func (o *Operation) Bindings() []Binding {
 		return o.Apply.Bindings
 	case o.Assert != nil:
 		return o.Assert.Bindings
+	case o.CanI != nil:
+		return nil
+	case o.Chart != nil:
+		return nil
 	case o.Command != nil:
 		return o.Command.Bindings
+	case o.Compare != nil:
+		return o.Compare.Bindings
+	case o.Cordon != nil:
+		return nil
 	case o.Create != nil:
 		return o.Create.Bindings
 	case o.Delete != nil:
 		return o.Delete.Bindings
+	case o.Delta != nil:
+		return nil
 	case o.Describe != nil:
 		return nil
+	case o.Drain != nil:
+		return nil
 	case o.Error != nil:
 		return o.Error.Bindings
 	case o.Events != nil:
 		return nil
+	case o.Exec != nil:
+		return nil
 	case o.Get != nil:
 		return nil
+	case o.Health != nil:
+		return nil
+	case o.Job != nil:
+		return nil
+	case o.NoCrashLoop != nil:
+		return nil
 	case o.Patch != nil:
 		return o.Patch.Bindings
+	case o.PodCount != nil:
+		return nil
 	case o.PodLogs != nil:
 		return nil
+	case o.Probe != nil:
+		return nil
 	case o.Proxy != nil:
 		return nil
+	case o.Rollout != nil:
+		return nil
+	case o.Scale != nil:
+		return nil
 	case o.Script != nil:
 		return o.Script.Bindings
 	case o.Sleep != nil:
 		return nil
+	case o.Snapshot != nil:
+		return nil
+	case o.Uncordon != nil:
+		return nil
 	case o.Update != nil:
 		return o.Update.Bindings
 	case o.Wait != nil:
 		return nil
+	case o.Watch != nil:
+		return nil
 	}
 	panic("missing binding operation type handler")
 }
@@ -148,34 +272,68 @@ func (o *Operation) Outputs() []Output {
 		return o.Apply.Outputs
 	case o.Assert != nil:
 		return nil
+	case o.CanI != nil:
+		return nil
+	case o.Chart != nil:
+		return nil
 	case o.Command != nil:
 		return o.Command.Outputs
+	case o.Compare != nil:
+		return nil
+	case o.Cordon != nil:
+		return nil
 	case o.Create != nil:
 		return o.Create.Outputs
 	case o.Delete != nil:
 		return nil
+	case o.Delta != nil:
+		return nil
 	case o.Describe != nil:
 		return nil
+	case o.Drain != nil:
+		return nil
 	case o.Error != nil:
 		return nil
 	case o.Events != nil:
 		return nil
+	case o.Exec != nil:
+		return o.Exec.Outputs
 	case o.Get != nil:
 		return nil
+	case o.Health != nil:
+		return nil
+	case o.Job != nil:
+		return nil
+	case o.NoCrashLoop != nil:
+		return nil
 	case o.Patch != nil:
 		return o.Patch.Outputs
+	case o.PodCount != nil:
+		return nil
 	case o.PodLogs != nil:
 		return nil
+	case o.Probe != nil:
+		return nil
 	case o.Proxy != nil:
 		return o.Proxy.Outputs
+	case o.Rollout != nil:
+		return nil
+	case o.Scale != nil:
+		return nil
 	case o.Script != nil:
 		return o.Script.Outputs
 	case o.Sleep != nil:
 		return nil
+	case o.Snapshot != nil:
+		return nil
+	case o.Uncordon != nil:
+		return nil
 	case o.Update != nil:
 		return o.Update.Outputs
 	case o.Wait != nil:
 		return nil
+	case o.Watch != nil:
+		return nil
 	}
 	panic("missing output operation type handler")
 }
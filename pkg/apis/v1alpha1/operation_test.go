@@ -25,6 +25,16 @@ func TestOperation_Bindings(t *testing.T) {
 			},
 		},
 		want: 1,
+	}, {
+		operation: Operation{
+			CanI: &CanI{},
+		},
+		want: 0,
+	}, {
+		operation: Operation{
+			Chart: &Chart{},
+		},
+		want: 0,
 	}, {
 		operation: Operation{
 			Command: &Command{
@@ -63,11 +73,26 @@ func TestOperation_Bindings(t *testing.T) {
 			Events: &Events{},
 		},
 		want: 0,
+	}, {
+		operation: Operation{
+			Exec: &Exec{},
+		},
+		want: 0,
 	}, {
 		operation: Operation{
 			Get: &Get{},
 		},
 		want: 0,
+	}, {
+		operation: Operation{
+			Health: &Health{},
+		},
+		want: 0,
+	}, {
+		operation: Operation{
+			NoCrashLoop: &NoCrashLoop{},
+		},
+		want: 0,
 	}, {
 		operation: Operation{
 			Patch: &Patch{
@@ -75,11 +100,21 @@ func TestOperation_Bindings(t *testing.T) {
 			},
 		},
 		want: 1,
+	}, {
+		operation: Operation{
+			PodCount: &PodCount{},
+		},
+		want: 0,
 	}, {
 		operation: Operation{
 			PodLogs: &PodLogs{},
 		},
 		want: 0,
+	}, {
+		operation: Operation{
+			Probe: &Probe{},
+		},
+		want: 0,
 	}, {
 		operation: Operation{
 			Proxy: &Proxy{},
@@ -107,6 +142,10 @@ func TestOperation_Bindings(t *testing.T) {
 		operation: Operation{
 			Wait: &Wait{},
 		},
+	}, {
+		operation: Operation{
+			Watch: &Watch{},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -133,6 +172,14 @@ func TestOperation_Outputs(t *testing.T) {
 		operation: Operation{
 			Assert: &Assert{},
 		},
+	}, {
+		operation: Operation{
+			CanI: &CanI{},
+		},
+	}, {
+		operation: Operation{
+			Chart: &Chart{},
+		},
 	}, {
 		operation: Operation{
 			Command: &Command{
@@ -163,10 +210,25 @@ func TestOperation_Outputs(t *testing.T) {
 		operation: Operation{
 			Events: &Events{},
 		},
+	}, {
+		operation: Operation{
+			Exec: &Exec{
+				ActionOutputs: ActionOutputs{Outputs: []Output{{Binding: Binding{Name: "foo", Value: NewProjection("bar")}}}},
+			},
+		},
+		want: 1,
 	}, {
 		operation: Operation{
 			Get: &Get{},
 		},
+	}, {
+		operation: Operation{
+			Health: &Health{},
+		},
+	}, {
+		operation: Operation{
+			NoCrashLoop: &NoCrashLoop{},
+		},
 	}, {
 		operation: Operation{
 			Patch: &Patch{
@@ -174,10 +236,18 @@ func TestOperation_Outputs(t *testing.T) {
 			},
 		},
 		want: 1,
+	}, {
+		operation: Operation{
+			PodCount: &PodCount{},
+		},
 	}, {
 		operation: Operation{
 			PodLogs: &PodLogs{},
 		},
+	}, {
+		operation: Operation{
+			Probe: &Probe{},
+		},
 	}, {
 		operation: Operation{
 			Proxy: &Proxy{},
@@ -204,6 +274,10 @@ func TestOperation_Outputs(t *testing.T) {
 		operation: Operation{
 			Wait: &Wait{},
 		},
+	}, {
+		operation: Operation{
+			Watch: &Watch{},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
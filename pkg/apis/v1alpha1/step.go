@@ -95,6 +95,7 @@ type TestStepSpec struct {
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
 
 	// Cluster defines the target cluster (will be inherited if not specified).
+	// It can be an expression, resolved with the bindings available at execution time.
 	// +optional
 	Cluster *string `json:"cluster,omitempty"`
 
@@ -102,6 +103,11 @@ type TestStepSpec struct {
 	// +optional
 	Clusters Clusters `json:"clusters,omitempty"`
 
+	// ParallelOperations determines whether the operations of the try block run concurrently.
+	// Outputs of an operation are not available to the other operations of the step in this case.
+	// +optional
+	ParallelOperations *bool `json:"parallelOperations,omitempty"`
+
 	// SkipDelete determines whether the resources created by the step should be deleted after the test step is executed.
 	// +optional
 	SkipDelete *bool `json:"skipDelete,omitempty"`
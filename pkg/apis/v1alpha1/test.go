@@ -32,11 +32,17 @@ type TestSpec struct {
 	// +optional
 	FailFast *bool `json:"failFast,omitempty"`
 
+	// StopOnFirstFailure determines whether the remaining steps of the test are skipped once a step failed.
+	// Overrides the value set in the Configuration.
+	// +optional
+	StopOnFirstFailure *bool `json:"stopOnFirstFailure,omitempty"`
+
 	// Timeouts for the test. Overrides the global timeouts set in the Configuration on a per operation basis.
 	// +optional
 	Timeouts *Timeouts `json:"timeouts,omitempty"`
 
 	// Cluster defines the target cluster (will be inherited if not specified).
+	// It can be an expression, resolved with the bindings available at execution time.
 	// +optional
 	Cluster *string `json:"cluster,omitempty"`
 
@@ -106,6 +112,26 @@ type TestSpec struct {
 	// +optional
 	// +kubebuilder:validation:Enum:=Orphan;Background;Foreground
 	DeletionPropagationPolicy *metav1.DeletionPropagation `json:"deletionPropagationPolicy,omitempty"`
+
+	// ServiceAccount configures a dedicated service account created for the test.
+	// When set, the test operations impersonate this service account.
+	// +optional
+	ServiceAccount *TestServiceAccount `json:"serviceAccount,omitempty"`
+}
+
+// TestServiceAccount defines a service account created in the test namespace and impersonated by the test operations.
+type TestServiceAccount struct {
+	// Name of the service account, defaults to the test name.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Roles are the names of the roles bound to the service account in the test namespace.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// ClusterRoles are the names of the cluster roles bound to the service account in the test namespace.
+	// +optional
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
 }
 
 // Scenario defines per scenario bindings.
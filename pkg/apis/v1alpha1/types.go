@@ -3,8 +3,11 @@ package v1alpha1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/jmespath-community/go-jmespath/pkg/parsing"
 	"github.com/kyverno/chainsaw/pkg/apis"
@@ -12,6 +15,7 @@ import (
 	"github.com/kyverno/kyverno-json/pkg/apis/policy/v1alpha1"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var (
@@ -51,7 +55,13 @@ type Check = v1alpha1.AssertionTree
 // Cluster defines cluster config and context.
 type Cluster struct {
 	// Kubeconfig is the path to the referenced file.
-	Kubeconfig string `json:"kubeconfig"`
+	// +optional
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// KubeconfigData is an inline kubeconfig, raw or base64 encoded.
+	// It can be an expression to load the kubeconfig from a binding.
+	// +optional
+	KubeconfigData Expression `json:"kubeconfigData,omitempty"`
 
 	// Context is the name of the context to use.
 	// +optional
@@ -141,15 +151,55 @@ type ObjectReference struct {
 }
 
 // ObjectType represents a specific apiVersion and kind.
+// Group and version can be used instead of apiVersion to reference the type explicitly.
 type ObjectType struct {
 	// API version of the referent.
-	APIVersion Expression `json:"apiVersion"`
+	// Required unless version is set.
+	// +optional
+	APIVersion Expression `json:"apiVersion,omitempty"`
+
+	// Group of the referent, empty for the core group.
+	// Cannot be used with apiVersion.
+	// +optional
+	Group Expression `json:"group,omitempty"`
+
+	// Version of the referent.
+	// Cannot be used with apiVersion.
+	// +optional
+	Version Expression `json:"version,omitempty"`
 
 	// Kind of the referent.
 	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
 	Kind Expression `json:"kind"`
 }
 
+// GroupVersion evaluates the group and version of the referent, either from apiVersion
+// or from the explicit group and version.
+func (t ObjectType) GroupVersion(ctx context.Context, compilers compilers.Compilers, bindings apis.Bindings) (schema.GroupVersion, error) {
+	if t.Version != "" {
+		if t.APIVersion != "" {
+			return schema.GroupVersion{}, errors.New("apiVersion cannot be used with group and version")
+		}
+		group, err := t.Group.Value(ctx, compilers, bindings)
+		if err != nil {
+			return schema.GroupVersion{}, err
+		}
+		version, err := t.Version.Value(ctx, compilers, bindings)
+		if err != nil {
+			return schema.GroupVersion{}, err
+		}
+		return schema.GroupVersion{Group: group, Version: version}, nil
+	}
+	if t.Group != "" {
+		return schema.GroupVersion{}, errors.New("version is required when group is set")
+	}
+	apiVersion, err := t.APIVersion.Value(ctx, compilers, bindings)
+	if err != nil {
+		return schema.GroupVersion{}, err
+	}
+	return schema.ParseGroupVersion(apiVersion)
+}
+
 // Output represents an output binding with a match to determine if the binding must be considered or not.
 type Output struct {
 	// Binding determines the binding to create when the match succeeds.
@@ -191,6 +241,10 @@ type DefaultTimeouts struct {
 	// +optional
 	// +kubebuilder:default:="5s"
 	Exec metav1.Duration `json:"exec"`
+
+	// Named defines named timeouts, operations can reference them by name (prefixed with @, like `@slowApply`).
+	// +optional
+	Named map[string]metav1.Duration `json:"named,omitempty"`
 }
 
 func (t DefaultTimeouts) Combine(override *Timeouts) DefaultTimeouts {
@@ -238,3 +292,54 @@ type Timeouts struct {
 	// Exec defines the timeout for exec operations
 	Exec *metav1.Duration `json:"exec,omitempty"`
 }
+
+// Timeout is either a duration or a reference to a named timeout declared in the configuration (prefixed with @, like `@slowApply`).
+// +k8s:deepcopy-gen=false
+// +kubebuilder:validation:Type:=string
+type Timeout struct {
+	// Duration is the timeout duration, it is ignored when the timeout references a named timeout.
+	Duration time.Duration `json:"-"`
+
+	// Name is the name of the referenced timeout.
+	Name string `json:"-"`
+}
+
+func (t Timeout) MarshalJSON() ([]byte, error) {
+	if t.Name != "" {
+		return json.Marshal("@" + t.Name)
+	}
+	return json.Marshal(t.Duration.String())
+}
+
+func (t *Timeout) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if name, ok := strings.CutPrefix(str, "@"); ok {
+		if name == "" {
+			return errors.New("timeout reference must not be empty")
+		}
+		*t = Timeout{Name: name}
+		return nil
+	}
+	duration, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+	*t = Timeout{Duration: duration}
+	return nil
+}
+
+func (in *Timeout) DeepCopyInto(out *Timeout) {
+	*out = *in
+}
+
+func (in *Timeout) DeepCopy() *Timeout {
+	if in == nil {
+		return nil
+	}
+	out := new(Timeout)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,11 +1,14 @@
 package v1alpha1
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestBinding_CheckName(t *testing.T) {
@@ -105,3 +108,99 @@ func TestTimeouts_Combine(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeout_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Timeout
+		wantErr bool
+	}{{
+		name: "duration",
+		data: `"1m30s"`,
+		want: Timeout{Duration: 90 * time.Second},
+	}, {
+		name: "named",
+		data: `"@slowApply"`,
+		want: Timeout{Name: "slowApply"},
+	}, {
+		name:    "empty name",
+		data:    `"@"`,
+		wantErr: true,
+	}, {
+		name:    "invalid duration",
+		data:    `"foo"`,
+		wantErr: true,
+	}, {
+		name:    "not a string",
+		data:    `42`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Timeout
+			err := got.UnmarshalJSON([]byte(tt.data))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+				data, err := got.MarshalJSON()
+				assert.NoError(t, err)
+				assert.JSONEq(t, tt.data, string(data))
+			}
+		})
+	}
+}
+
+func TestObjectType_GroupVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectType ObjectType
+		want       schema.GroupVersion
+		wantErr    bool
+	}{{
+		name:       "api version",
+		objectType: ObjectType{APIVersion: "apps/v1", Kind: "Deployment"},
+		want:       schema.GroupVersion{Group: "apps", Version: "v1"},
+	}, {
+		name:       "core api version",
+		objectType: ObjectType{APIVersion: "v1", Kind: "Pod"},
+		want:       schema.GroupVersion{Version: "v1"},
+	}, {
+		name:       "group and version",
+		objectType: ObjectType{Group: "apps", Version: "v1", Kind: "Deployment"},
+		want:       schema.GroupVersion{Group: "apps", Version: "v1"},
+	}, {
+		name:       "core version",
+		objectType: ObjectType{Version: "v1", Kind: "Pod"},
+		want:       schema.GroupVersion{Version: "v1"},
+	}, {
+		name:       "expressions",
+		objectType: ObjectType{Group: "('apps')", Version: "('v1')", Kind: "Deployment"},
+		want:       schema.GroupVersion{Group: "apps", Version: "v1"},
+	}, {
+		name:       "api version and version",
+		objectType: ObjectType{APIVersion: "apps/v1", Version: "v1", Kind: "Deployment"},
+		wantErr:    true,
+	}, {
+		name:       "group without version",
+		objectType: ObjectType{Group: "apps", Kind: "Deployment"},
+		wantErr:    true,
+	}, {
+		name:       "invalid api version",
+		objectType: ObjectType{APIVersion: "apps/v1/foo", Kind: "Deployment"},
+		wantErr:    true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.objectType.GroupVersion(context.TODO(), apis.DefaultCompilers, apis.NewBindings())
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -25,8 +25,25 @@ import (
 	policyv1alpha1 "github.com/kyverno/kyverno-json/pkg/apis/policy/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionAnnotationSelector) DeepCopyInto(out *ActionAnnotationSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionAnnotationSelector.
+func (in *ActionAnnotationSelector) DeepCopy() *ActionAnnotationSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionAnnotationSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionBindings) DeepCopyInto(out *ActionBindings) {
 	*out = *in
@@ -50,6 +67,27 @@ func (in *ActionBindings) DeepCopy() *ActionBindings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionCapture) DeepCopyInto(out *ActionCapture) {
+	*out = *in
+	if in.Capture != nil {
+		in, out := &in.Capture, &out.Capture
+		*out = new(Capture)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionCapture.
+func (in *ActionCapture) DeepCopy() *ActionCapture {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionCapture)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionCheck) DeepCopyInto(out *ActionCheck) {
 	*out = *in
@@ -73,7 +111,7 @@ func (in *ActionCheck) DeepCopy() *ActionCheck {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionCheckRef) DeepCopyInto(out *ActionCheckRef) {
 	*out = *in
-	out.FileRef = in.FileRef
+	in.FileRef.DeepCopyInto(&out.FileRef)
 	if in.Check != nil {
 		in, out := &in.Check, &out.Check
 		*out = (*in).DeepCopy()
@@ -191,6 +229,22 @@ func (in *ActionExpectations) DeepCopy() *ActionExpectations {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionFieldSelector) DeepCopyInto(out *ActionFieldSelector) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionFieldSelector.
+func (in *ActionFieldSelector) DeepCopy() *ActionFieldSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionFieldSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionFormat) DeepCopyInto(out *ActionFormat) {
 	*out = *in
@@ -227,6 +281,22 @@ func (in *ActionInlineResource) DeepCopy() *ActionInlineResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionNode) DeepCopyInto(out *ActionNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionNode.
+func (in *ActionNode) DeepCopy() *ActionNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionObject) DeepCopyInto(out *ActionObject) {
 	*out = *in
@@ -285,10 +355,30 @@ func (in *ActionOutputs) DeepCopy() *ActionOutputs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActionRendered) DeepCopyInto(out *ActionRendered) {
+	*out = *in
+	if in.Rendered != nil {
+		in, out := &in.Rendered, &out.Rendered
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionRendered.
+func (in *ActionRendered) DeepCopy() *ActionRendered {
+	if in == nil {
+		return nil
+	}
+	out := new(ActionRendered)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionResourceRef) DeepCopyInto(out *ActionResourceRef) {
 	*out = *in
-	out.FileRef = in.FileRef
+	in.FileRef.DeepCopyInto(&out.FileRef)
 	if in.Resource != nil {
 		in, out := &in.Resource, &out.Resource
 		*out = (*in).DeepCopy()
@@ -316,8 +406,7 @@ func (in *ActionTimeout) DeepCopyInto(out *ActionTimeout) {
 	*out = *in
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
-		**out = **in
+		*out = (*in).DeepCopy()
 	}
 	return
 }
@@ -340,8 +429,19 @@ func (in *Apply) DeepCopyInto(out *Apply) {
 	in.ActionDryRun.DeepCopyInto(&out.ActionDryRun)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionRendered.DeepCopyInto(&out.ActionRendered)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.ServerSide != nil {
+		in, out := &in.ServerSide, &out.ServerSide
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ForceConflicts != nil {
+		in, out := &in.ForceConflicts, &out.ForceConflicts
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -358,10 +458,43 @@ func (in *Apply) DeepCopy() *Apply {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Assert) DeepCopyInto(out *Assert) {
 	*out = *in
+	out.ActionAnnotationSelector = in.ActionAnnotationSelector
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
 	in.ActionCheckRef.DeepCopyInto(&out.ActionCheckRef)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]Expression, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tolerances != nil {
+		in, out := &in.Tolerances, &out.Tolerances
+		*out = make([]Tolerance, len(*in))
+		copy(*out, *in)
+	}
+	if in.StableFor != nil {
+		in, out := &in.StableFor, &out.StableFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AnyOf != nil {
+		in, out := &in.AnyOf, &out.AnyOf
+		*out = make([]policyv1alpha1.AssertionTree, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -397,6 +530,45 @@ func (in *Binding) DeepCopy() *Binding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanI) DeepCopyInto(out *CanI) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Skip != nil {
+		in, out := &in.Skip, &out.Skip
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanI.
+func (in *CanI) DeepCopy() *CanI {
+	if in == nil {
+		return nil
+	}
+	out := new(CanI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Capture) DeepCopyInto(out *Capture) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Capture.
+func (in *Capture) DeepCopy() *Capture {
+	if in == nil {
+		return nil
+	}
+	out := new(Capture)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CatchFinally) DeepCopyInto(out *CatchFinally) {
 	*out = *in
@@ -435,6 +607,11 @@ func (in *CatchFinally) DeepCopyInto(out *CatchFinally) {
 		*out = new(Delete)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Collect != nil {
+		in, out := &in.Collect, &out.Collect
+		*out = new(Collect)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
 		*out = new(Command)
@@ -450,6 +627,11 @@ func (in *CatchFinally) DeepCopyInto(out *CatchFinally) {
 		*out = new(Sleep)
 		**out = **in
 	}
+	if in.Uncordon != nil {
+		in, out := &in.Uncordon, &out.Uncordon
+		*out = new(Uncordon)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -463,6 +645,33 @@ func (in *CatchFinally) DeepCopy() *CatchFinally {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Chart) DeepCopyInto(out *Chart) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = (*in).DeepCopy()
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Chart.
+func (in *Chart) DeepCopy() *Chart {
+	if in == nil {
+		return nil
+	}
+	out := new(Chart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -501,10 +710,33 @@ func (in Clusters) DeepCopy() Clusters {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Collect) DeepCopyInto(out *Collect) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Collect.
+func (in *Collect) DeepCopy() *Collect {
+	if in == nil {
+		return nil
+	}
+	out := new(Collect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Command) DeepCopyInto(out *Command) {
 	*out = *in
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionCapture.DeepCopyInto(&out.ActionCapture)
 	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	in.ActionEnv.DeepCopyInto(&out.ActionEnv)
@@ -517,7 +749,7 @@ func (in *Command) DeepCopyInto(out *Command) {
 	}
 	if in.WorkDir != nil {
 		in, out := &in.WorkDir, &out.WorkDir
-		*out = new(string)
+		*out = new(Expression)
 		**out = **in
 	}
 	return
@@ -533,6 +765,24 @@ func (in *Command) DeepCopy() *Command {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Compare) DeepCopyInto(out *Compare) {
+	*out = *in
+	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Compare.
+func (in *Compare) DeepCopy() *Compare {
+	if in == nil {
+		return nil
+	}
+	out := new(Compare)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -563,7 +813,7 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	*out = *in
-	out.Timeouts = in.Timeouts
+	in.Timeouts.DeepCopyInto(&out.Timeouts)
 	if in.Compiler != nil {
 		in, out := &in.Compiler, &out.Compiler
 		*out = new(policyv1alpha1.Compiler)
@@ -625,6 +875,25 @@ func (in *ConfigurationSpec) DeepCopy() *ConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cordon) DeepCopyInto(out *Cordon) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ActionNode = in.ActionNode
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cordon.
+func (in *Cordon) DeepCopy() *Cordon {
+	if in == nil {
+		return nil
+	}
+	out := new(Cordon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Create) DeepCopyInto(out *Create) {
 	*out = *in
@@ -633,6 +902,7 @@ func (in *Create) DeepCopyInto(out *Create) {
 	in.ActionDryRun.DeepCopyInto(&out.ActionDryRun)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionRendered.DeepCopyInto(&out.ActionRendered)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
@@ -657,6 +927,13 @@ func (in *DefaultTimeouts) DeepCopyInto(out *DefaultTimeouts) {
 	out.Delete = in.Delete
 	out.Error = in.Error
 	out.Exec = in.Exec
+	if in.Named != nil {
+		in, out := &in.Named, &out.Named
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -682,6 +959,11 @@ func (in *Delete) DeepCopyInto(out *Delete) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AllowEmpty != nil {
+		in, out := &in.AllowEmpty, &out.AllowEmpty
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Ref != nil {
 		in, out := &in.Ref, &out.Ref
 		*out = new(ObjectReference)
@@ -692,6 +974,16 @@ func (in *Delete) DeepCopyInto(out *Delete) {
 		*out = new(v1.DeletionPropagation)
 		**out = **in
 	}
+	if in.Wait != nil {
+		in, out := &in.Wait, &out.Wait
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllNamespaces != nil {
+		in, out := &in.AllNamespaces, &out.AllNamespaces
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -705,10 +997,32 @@ func (in *Delete) DeepCopy() *Delete {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Delta) DeepCopyInto(out *Delta) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectType = in.ObjectType
+	out.ObjectName = in.ObjectName
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Delta.
+func (in *Delta) DeepCopy() *Delta {
+	if in == nil {
+		return nil
+	}
+	out := new(Delta)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Describe) DeepCopyInto(out *Describe) {
 	*out = *in
+	out.ActionAnnotationSelector = in.ActionAnnotationSelector
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	out.ActionFieldSelector = in.ActionFieldSelector
 	out.ActionObject = in.ActionObject
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	if in.ShowEvents != nil {
@@ -729,6 +1043,30 @@ func (in *Describe) DeepCopy() *Describe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drain) DeepCopyInto(out *Drain) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ActionNode = in.ActionNode
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drain.
+func (in *Drain) DeepCopy() *Drain {
+	if in == nil {
+		return nil
+	}
+	out := new(Drain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Error) DeepCopyInto(out *Error) {
 	*out = *in
@@ -753,6 +1091,7 @@ func (in *Error) DeepCopy() *Error {
 func (in *Events) DeepCopyInto(out *Events) {
 	*out = *in
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	out.ActionFieldSelector = in.ActionFieldSelector
 	out.ActionFormat = in.ActionFormat
 	out.ActionObjectSelector = in.ActionObjectSelector
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
@@ -769,6 +1108,32 @@ func (in *Events) DeepCopy() *Events {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Exec) DeepCopyInto(out *Exec) {
+	*out = *in
+	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	out.ActionObjectSelector = in.ActionObjectSelector
+	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exec.
+func (in *Exec) DeepCopy() *Exec {
+	if in == nil {
+		return nil
+	}
+	out := new(Exec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Expectation) DeepCopyInto(out *Expectation) {
 	*out = *in
@@ -793,6 +1158,11 @@ func (in *Expectation) DeepCopy() *Expectation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileRef) DeepCopyInto(out *FileRef) {
 	*out = *in
+	if in.AllowEmpty != nil {
+		in, out := &in.AllowEmpty, &out.AllowEmpty
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -809,7 +1179,9 @@ func (in *FileRef) DeepCopy() *FileRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Get) DeepCopyInto(out *Get) {
 	*out = *in
+	out.ActionAnnotationSelector = in.ActionAnnotationSelector
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	out.ActionFieldSelector = in.ActionFieldSelector
 	out.ActionFormat = in.ActionFormat
 	out.ActionObject = in.ActionObject
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
@@ -826,6 +1198,70 @@ func (in *Get) DeepCopy() *Get {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Health) DeepCopyInto(out *Health) {
+	*out = *in
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Skip != nil {
+		in, out := &in.Skip, &out.Skip
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Health.
+func (in *Health) DeepCopy() *Health {
+	if in == nil {
+		return nil
+	}
+	out := new(Health)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Job) DeepCopyInto(out *Job) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectName = in.ObjectName
+	if in.Logs != nil {
+		in, out := &in.Logs, &out.Logs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Job.
+func (in *Job) DeepCopy() *Job {
+	if in == nil {
+		return nil
+	}
+	out := new(Job)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoCrashLoop) DeepCopyInto(out *NoCrashLoop) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoCrashLoop.
+func (in *NoCrashLoop) DeepCopy() *NoCrashLoop {
+	if in == nil {
+		return nil
+	}
+	out := new(NoCrashLoop)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectName) DeepCopyInto(out *ObjectName) {
 	*out = *in
@@ -897,11 +1333,31 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(Assert)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CanI != nil {
+		in, out := &in.CanI, &out.CanI
+		*out = new(CanI)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Chart != nil {
+		in, out := &in.Chart, &out.Chart
+		*out = new(Chart)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
 		*out = new(Command)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = new(Compare)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cordon != nil {
+		in, out := &in.Cordon, &out.Cordon
+		*out = new(Cordon)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Create != nil {
 		in, out := &in.Create, &out.Create
 		*out = new(Create)
@@ -912,11 +1368,21 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(Delete)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Delta != nil {
+		in, out := &in.Delta, &out.Delta
+		*out = new(Delta)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Describe != nil {
 		in, out := &in.Describe, &out.Describe
 		*out = new(Describe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(Drain)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Error != nil {
 		in, out := &in.Error, &out.Error
 		*out = new(Error)
@@ -927,26 +1393,66 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(Events)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(Exec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Get != nil {
 		in, out := &in.Get, &out.Get
 		*out = new(Get)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(Health)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(Job)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NoCrashLoop != nil {
+		in, out := &in.NoCrashLoop, &out.NoCrashLoop
+		*out = new(NoCrashLoop)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Patch != nil {
 		in, out := &in.Patch, &out.Patch
 		*out = new(Patch)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodCount != nil {
+		in, out := &in.PodCount, &out.PodCount
+		*out = new(PodCount)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodLogs != nil {
 		in, out := &in.PodLogs, &out.PodLogs
 		*out = new(PodLogs)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(Probe)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Proxy != nil {
 		in, out := &in.Proxy, &out.Proxy
 		*out = new(Proxy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(Rollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(Scale)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Script != nil {
 		in, out := &in.Script, &out.Script
 		*out = new(Script)
@@ -957,6 +1463,16 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(Sleep)
 		**out = **in
 	}
+	if in.Snapshot != nil {
+		in, out := &in.Snapshot, &out.Snapshot
+		*out = new(Snapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Uncordon != nil {
+		in, out := &in.Uncordon, &out.Uncordon
+		*out = new(Uncordon)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Update != nil {
 		in, out := &in.Update, &out.Update
 		*out = new(Update)
@@ -967,6 +1483,11 @@ func (in *Operation) DeepCopyInto(out *Operation) {
 		*out = new(Wait)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Watch != nil {
+		in, out := &in.Watch, &out.Watch
+		*out = new(Watch)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1035,6 +1556,7 @@ func (in *Patch) DeepCopyInto(out *Patch) {
 	in.ActionDryRun.DeepCopyInto(&out.ActionDryRun)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionRendered.DeepCopyInto(&out.ActionRendered)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
@@ -1050,17 +1572,46 @@ func (in *Patch) DeepCopy() *Patch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCount) DeepCopyInto(out *PodCount) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCount.
+func (in *PodCount) DeepCopy() *PodCount {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodLogs) DeepCopyInto(out *PodLogs) {
 	*out = *in
+	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	out.ActionObjectSelector = in.ActionObjectSelector
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	if in.Tail != nil {
 		in, out := &in.Tail, &out.Tail
-		*out = new(int)
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Timestamps != nil {
+		in, out := &in.Timestamps, &out.Timestamps
+		*out = new(bool)
+		**out = **in
+	}
+	if in.JSONFields != nil {
+		in, out := &in.JSONFields, &out.JSONFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1074,6 +1625,23 @@ func (in *PodLogs) DeepCopy() *PodLogs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Probe) DeepCopyInto(out *Probe) {
+	*out = *in
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Probe.
+func (in *Probe) DeepCopy() *Probe {
+	if in == nil {
+		return nil
+	}
+	out := new(Probe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Proxy) DeepCopyInto(out *Proxy) {
 	*out = *in
@@ -1095,6 +1663,46 @@ func (in *Proxy) DeepCopy() *Proxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rollout) DeepCopyInto(out *Rollout) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectName = in.ObjectName
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rollout.
+func (in *Rollout) DeepCopy() *Rollout {
+	if in == nil {
+		return nil
+	}
+	out := new(Rollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scale) DeepCopyInto(out *Scale) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectType = in.ObjectType
+	out.ObjectName = in.ObjectName
+	out.Replicas = in.Replicas
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scale.
+func (in *Scale) DeepCopy() *Scale {
+	if in == nil {
+		return nil
+	}
+	out := new(Scale)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scenario) DeepCopyInto(out *Scenario) {
 	*out = *in
@@ -1122,14 +1730,20 @@ func (in *Scenario) DeepCopy() *Scenario {
 func (in *Script) DeepCopyInto(out *Script) {
 	*out = *in
 	in.ActionBindings.DeepCopyInto(&out.ActionBindings)
+	in.ActionCapture.DeepCopyInto(&out.ActionCapture)
 	in.ActionCheck.DeepCopyInto(&out.ActionCheck)
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	in.ActionEnv.DeepCopyInto(&out.ActionEnv)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	if in.Stdin != nil {
+		in, out := &in.Stdin, &out.Stdin
+		*out = new(string)
+		**out = **in
+	}
 	if in.WorkDir != nil {
 		in, out := &in.WorkDir, &out.WorkDir
-		*out = new(string)
+		*out = new(Expression)
 		**out = **in
 	}
 	return
@@ -1162,6 +1776,26 @@ func (in *Sleep) DeepCopy() *Sleep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Snapshot) DeepCopyInto(out *Snapshot) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectType = in.ObjectType
+	out.ObjectName = in.ObjectName
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Snapshot.
+func (in *Snapshot) DeepCopy() *Snapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(Snapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepTemplate) DeepCopyInto(out *StepTemplate) {
 	*out = *in
@@ -1267,6 +1901,32 @@ func (in *Test) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestServiceAccount) DeepCopyInto(out *TestServiceAccount) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestServiceAccount.
+func (in *TestServiceAccount) DeepCopy() *TestServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(TestServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 	*out = *in
@@ -1275,6 +1935,11 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.StopOnFirstFailure != nil {
+		in, out := &in.StopOnFirstFailure, &out.StopOnFirstFailure
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Timeouts != nil {
 		in, out := &in.Timeouts, &out.Timeouts
 		*out = new(Timeouts)
@@ -1369,6 +2034,11 @@ func (in *TestSpec) DeepCopyInto(out *TestSpec) {
 		*out = new(v1.DeletionPropagation)
 		**out = **in
 	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(TestServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1429,6 +2099,11 @@ func (in *TestStepSpec) DeepCopyInto(out *TestStepSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ParallelOperations != nil {
+		in, out := &in.ParallelOperations, &out.ParallelOperations
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SkipDelete != nil {
 		in, out := &in.SkipDelete, &out.SkipDelete
 		*out = new(bool)
@@ -1538,6 +2213,41 @@ func (in *Timeouts) DeepCopy() *Timeouts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tolerance) DeepCopyInto(out *Tolerance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tolerance.
+func (in *Tolerance) DeepCopy() *Tolerance {
+	if in == nil {
+		return nil
+	}
+	out := new(Tolerance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Uncordon) DeepCopyInto(out *Uncordon) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ActionNode = in.ActionNode
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Uncordon.
+func (in *Uncordon) DeepCopy() *Uncordon {
+	if in == nil {
+		return nil
+	}
+	out := new(Uncordon)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Update) DeepCopyInto(out *Update) {
 	*out = *in
@@ -1546,6 +2256,7 @@ func (in *Update) DeepCopyInto(out *Update) {
 	in.ActionDryRun.DeepCopyInto(&out.ActionDryRun)
 	in.ActionExpectations.DeepCopyInto(&out.ActionExpectations)
 	in.ActionOutputs.DeepCopyInto(&out.ActionOutputs)
+	in.ActionRendered.DeepCopyInto(&out.ActionRendered)
 	in.ActionResourceRef.DeepCopyInto(&out.ActionResourceRef)
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
 	return
@@ -1582,6 +2293,7 @@ func (in *Use) DeepCopy() *Use {
 func (in *Wait) DeepCopyInto(out *Wait) {
 	*out = *in
 	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ActionFieldSelector = in.ActionFieldSelector
 	out.ActionFormat = in.ActionFormat
 	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
 	out.ActionObject = in.ActionObject
@@ -1688,6 +2400,33 @@ func (in *WaitForJsonPath) DeepCopy() *WaitForJsonPath {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Watch) DeepCopyInto(out *Watch) {
+	*out = *in
+	in.ActionClusters.DeepCopyInto(&out.ActionClusters)
+	in.ActionTimeout.DeepCopyInto(&out.ActionTimeout)
+	out.ObjectType = in.ObjectType
+	out.ObjectName = in.ObjectName
+	if in.Sequence != nil {
+		in, out := &in.Sequence, &out.Sequence
+		*out = make([]policyv1alpha1.AssertionTree, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Watch.
+func (in *Watch) DeepCopy() *Watch {
+	if in == nil {
+		return nil
+	}
+	out := new(Watch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *With) DeepCopyInto(out *With) {
 	*out = *in
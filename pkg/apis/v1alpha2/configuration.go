@@ -25,11 +25,20 @@ type Configuration struct {
 // ConfigurationSpec contains the configuration used to run tests.
 // +k8s:conversion-gen=false
 type ConfigurationSpec struct {
+	// BindingsFrom lists Secrets and ConfigMaps loaded at startup, their keys are registered as bindings.
+	// +optional
+	BindingsFrom []BindingSource `json:"bindingsFrom,omitempty"`
+
 	// Cleanup contains cleanup configuration.
 	// +optional
 	// +kubebuilder:default:={}
 	Cleanup CleanupOptions `json:"cleanup"`
 
+	// Client contains the Kubernetes clients configuration.
+	// +optional
+	// +kubebuilder:default:={}
+	Client ClientOptions `json:"client"`
+
 	// Clusters holds a registry to clusters to support multi-cluster tests.
 	// +optional
 	Clusters Clusters `json:"clusters"`
@@ -54,11 +63,27 @@ type ConfigurationSpec struct {
 	// +kubebuilder:default:={}
 	Execution ExecutionOptions `json:"execution"`
 
+	// ExitCodes contains the process exit codes used when the run fails.
+	// +optional
+	ExitCodes *ExitCodeOptions `json:"exitCodes,omitempty"`
+
 	// Namespace contains properties for the namespace to use for tests.
 	// +optional
 	// +kubebuilder:default:={}
 	Namespace NamespaceOptions `json:"namespace"`
 
+	// Notification contains properties for the post-run notification.
+	// +optional
+	Notification *NotificationOptions `json:"notification,omitempty"`
+
+	// Output contains the configuration of the run artifacts directory.
+	// +optional
+	Output *OutputOptions `json:"output,omitempty"`
+
+	// Proxy contains the HTTP proxy configuration.
+	// +optional
+	Proxy *ProxyOptions `json:"proxy,omitempty"`
+
 	// Report contains properties for the report.
 	// +optional
 	Report *ReportOptions `json:"report,omitempty"`
@@ -72,4 +97,8 @@ type ConfigurationSpec struct {
 	// +optional
 	// +kubebuilder:default:={}
 	Timeouts DefaultTimeouts `json:"timeouts"`
+
+	// Tracing contains the OpenTelemetry tracing configuration.
+	// +optional
+	Tracing *TracingOptions `json:"tracing,omitempty"`
 }
@@ -2,20 +2,69 @@ package v1alpha2
 
 import (
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// BindingSource references a Secret or ConfigMap whose keys are registered as bindings.
+type BindingSource struct {
+	// Kind of the source (Secret or ConfigMap).
+	// +kubebuilder:validation:Enum:=Secret;ConfigMap
+	Kind string `json:"kind"`
+
+	// Namespace of the source.
+	Namespace string `json:"namespace"`
+
+	// Name of the source.
+	Name string `json:"name"`
+
+	// Prefix is prepended to the name of the bindings created from the source keys.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
 // CleanupOptions contains the configuration used for cleaning up resources.
 type CleanupOptions struct {
 	// If set, do not delete the resources after running a test.
 	// +optional
 	SkipDelete bool `json:"skipDelete,omitempty"`
 
+	// SkipDeleteIf is a condition evaluated against the bindings before deleting the resources created by a test or a step.
+	// The resources are not deleted when it evaluates to true.
+	// +optional
+	SkipDeleteIf v1alpha1.Expression `json:"skipDeleteIf,omitempty"`
+
 	// DelayBeforeCleanup adds a delay between the time a test ends and the time cleanup starts.
 	// +optional
 	DelayBeforeCleanup *metav1.Duration `json:"delayBeforeCleanup,omitempty"`
 }
 
+// ClientOptions contains the configuration used by the Kubernetes clients.
+type ClientOptions struct {
+	// QPS defines the maximum number of queries per second sent to the api server by a client.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=300
+	QPS int `json:"qps,omitempty"`
+
+	// Burst defines the maximum burst of queries sent to the api server by a client.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:default:=300
+	Burst int `json:"burst,omitempty"`
+
+	// ConnectionAttempts defines how many times the initial connection to the cluster is attempted before running the tests.
+	// When not set, the connection is not checked upfront.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	ConnectionAttempts int `json:"connectionAttempts,omitempty"`
+
+	// ConnectionInterval defines the delay before retrying the initial connection to the cluster (defaults to 1s).
+	// It doubles after every failed attempt.
+	// +optional
+	ConnectionInterval *metav1.Duration `json:"connectionInterval,omitempty"`
+}
+
 // DeletionOptions contains the configuration used for deleting resources.
 type DeletionOptions struct {
 	// Propagation decides if a deletion will propagate to the dependents of
@@ -36,6 +85,15 @@ type DiscoveryOptions struct {
 	// +optional
 	IncludeTestRegex string `json:"includeTestRegex,omitempty"`
 
+	// Grep selects the tests whose name matches a regular expression, other tests are not run nor reported.
+	// The name is the full test name when FullName is set.
+	// +optional
+	Grep string `json:"grep,omitempty"`
+
+	// GrepInvert excludes the tests whose name matches a regular expression, it can be combined with Grep.
+	// +optional
+	GrepInvert string `json:"grepInvert,omitempty"`
+
 	// TestFile is the name of the file containing the test to run.
 	// If no extension is provided, chainsaw will try with .yaml first and .yml if needed.
 	// +optional
@@ -56,17 +114,40 @@ type ErrorOptions struct {
 }
 
 // ExecutionOptions determines how tests are run.
+// +kubebuilder:not:={required:{forceSerial,forceParallel},properties:{forceSerial:{enum:{true}},forceParallel:{enum:{true}}}}
 type ExecutionOptions struct {
 	// FailFast determines whether the test should stop upon encountering the first failure.
 	// +optional
 	FailFast bool `json:"failFast,omitempty"`
 
+	// StopOnFirstFailure determines whether the remaining steps of a test are skipped once a step failed.
+	// Other tests continue to run.
+	// +optional
+	StopOnFirstFailure bool `json:"stopOnFirstFailure,omitempty"`
+
 	// The maximum number of tests to run at once.
 	// +kubebuilder:validation:Format:=int
 	// +kubebuilder:validation:Minimum:=1
 	// +optional
 	Parallel *int `json:"parallel,omitempty"`
 
+	// ParallelCommands is the maximum number of external commands (commands, scripts and collectors) to run at once across all tests.
+	// Commands are not limited by default.
+	// +kubebuilder:validation:Format:=int
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	ParallelCommands *int `json:"parallelCommands,omitempty"`
+
+	// ForceSerial runs all tests one after the other, regardless of their concurrent setting.
+	// It takes precedence over the concurrent setting of the tests and can't be enabled together with ForceParallel.
+	// +optional
+	ForceSerial bool `json:"forceSerial,omitempty"`
+
+	// ForceParallel runs all tests in parallel, regardless of their concurrent setting.
+	// It takes precedence over the concurrent setting of the tests and can't be enabled together with ForceSerial.
+	// +optional
+	ForceParallel bool `json:"forceParallel,omitempty"`
+
 	// RepeatCount indicates how many times the tests should be executed.
 	// +kubebuilder:validation:Format:=int
 	// +kubebuilder:validation:Minimum:=1
@@ -76,6 +157,62 @@ type ExecutionOptions struct {
 	// ForceTerminationGracePeriod forces the termination grace period on pods, statefulsets, daemonsets and deployments.
 	// +optional
 	ForceTerminationGracePeriod *metav1.Duration `json:"forceTerminationGracePeriod,omitempty"`
+
+	// InjectLabels defines labels added to every resource created or applied by tests.
+	// Values support expressions, labels declared in the resource take precedence.
+	// +optional
+	InjectLabels map[string]v1alpha1.Expression `json:"injectLabels,omitempty"`
+
+	// InjectAnnotations defines annotations added to every resource created or applied by tests.
+	// Values support expressions, annotations declared in the resource take precedence.
+	// +optional
+	InjectAnnotations map[string]v1alpha1.Expression `json:"injectAnnotations,omitempty"`
+
+	// InjectHostAliases defines host aliases added to the pod spec of pods, deployments, statefulsets, daemonsets,
+	// jobs and cronjobs created or applied by tests. Hostnames are merged into the host aliases declared with the same IP.
+	// +optional
+	InjectHostAliases []corev1.HostAlias `json:"injectHostAliases,omitempty"`
+
+	// PodLogsDefaultContainer makes pod logs collected without a container honor the `kubectl.kubernetes.io/default-container`
+	// annotation of the pods instead of collecting the logs of all containers.
+	// +optional
+	PodLogsDefaultContainer bool `json:"podLogsDefaultContainer,omitempty"`
+
+	// Seed initializes the random generator used across the run (to generate namespace names for example).
+	// Running tests with the same seed makes random behaviors reproducible.
+	// +optional
+	Seed *int64 `json:"seed,omitempty"`
+
+	// Timeout defines the maximum duration of the whole run.
+	// When exceeded, running tests are failed and remaining tests are not started, cleanup still runs.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ExitCodeOptions contains the process exit codes used when a run fails, depending on the dominant failure category.
+type ExitCodeOptions struct {
+	// TestFailure is the exit code used when some tests failed.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=125
+	// +kubebuilder:default:=1
+	TestFailure int `json:"testFailure,omitempty"`
+
+	// SetupFailure is the exit code used when setting up the run or the tests failed (namespace, quota, service account, etc).
+	// It takes precedence over the other categories.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=125
+	// +kubebuilder:default:=2
+	SetupFailure int `json:"setupFailure,omitempty"`
+
+	// Timeout is the exit code used when the run timeout was exceeded.
+	// It takes precedence over test failures.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=125
+	// +kubebuilder:default:=3
+	Timeout int `json:"timeout,omitempty"`
 }
 
 // NamespaceOptions contains the configuration used to allocate a namespace for each test.
@@ -86,6 +223,13 @@ type NamespaceOptions struct {
 	// +optional
 	Name string `json:"name,omitempty"`
 
+	// Strategy determines how namespaces are allocated when Name is not specified.
+	// PerTest creates a random ephemeral namespace for every test, Shared creates a single
+	// random namespace used by all tests (tests then run serially). Defaults to PerTest.
+	// +optional
+	// +kubebuilder:validation:Enum:=PerTest;Shared
+	Strategy NamespaceStrategy `json:"strategy,omitempty"`
+
 	// Compiler defines the default compiler to use when evaluating expressions.
 	// +optional
 	Compiler *Compiler `json:"compiler,omitempty"`
@@ -93,8 +237,59 @@ type NamespaceOptions struct {
 	// Template defines a template to create the test namespace.
 	// +optional
 	Template *Projection `json:"template,omitempty"`
+
+	// Cleanup determines whether the namespace is deleted once tests complete.
+	// Always deletes the namespace, Never keeps it, OnFailure keeps it only if tests failed
+	// and OnSuccess keeps it only if tests succeeded. Defaults to Always.
+	// +optional
+	// +kubebuilder:validation:Enum:=Always;Never;OnSuccess;OnFailure
+	Cleanup NamespaceCleanupPolicy `json:"cleanup,omitempty"`
+
+	// Existing determines what happens when the namespace defined by Name already exists.
+	// Reuse uses the existing namespace, Fail stops the tests and Recreate deletes the namespace
+	// and creates it again (it requires ConfirmRecreate and system namespaces are never recreated). Defaults to Reuse.
+	// +optional
+	// +kubebuilder:validation:Enum:=Reuse;Fail;Recreate
+	Existing NamespaceExistingPolicy `json:"existing,omitempty"`
+
+	// ConfirmRecreate acknowledges that the Recreate policy deletes the existing namespace with everything it contains.
+	// Recreate is refused unless it is set.
+	// +optional
+	ConfirmRecreate bool `json:"confirmRecreate,omitempty"`
+
+	// Quota defines a template to create a ResourceQuota in every ephemeral test namespace.
+	// The quota is deleted together with the namespace.
+	// +optional
+	Quota *Projection `json:"quota,omitempty"`
 }
 
+// NamespaceCleanupPolicy determines whether a namespace is deleted once tests complete.
+type NamespaceCleanupPolicy string
+
+const (
+	NamespaceCleanupAlways    NamespaceCleanupPolicy = "Always"
+	NamespaceCleanupNever     NamespaceCleanupPolicy = "Never"
+	NamespaceCleanupOnSuccess NamespaceCleanupPolicy = "OnSuccess"
+	NamespaceCleanupOnFailure NamespaceCleanupPolicy = "OnFailure"
+)
+
+// NamespaceExistingPolicy determines what happens when the namespace used for tests already exists.
+type NamespaceExistingPolicy string
+
+const (
+	NamespaceExistingReuse    NamespaceExistingPolicy = "Reuse"
+	NamespaceExistingFail     NamespaceExistingPolicy = "Fail"
+	NamespaceExistingRecreate NamespaceExistingPolicy = "Recreate"
+)
+
+// NamespaceStrategy determines how namespaces are allocated to tests.
+type NamespaceStrategy string
+
+const (
+	NamespaceStrategyPerTest NamespaceStrategy = "PerTest"
+	NamespaceStrategyShared  NamespaceStrategy = "Shared"
+)
+
 type ReportFormatType string
 
 const (
@@ -105,6 +300,36 @@ const (
 	JUnitOperationFormat ReportFormatType = "JUNIT-OPERATION"
 )
 
+// NotificationOptions contains the configuration used to notify the results of a run.
+type NotificationOptions struct {
+	// URL defines the webhook endpoint the notification is posted to.
+	URL string `json:"url"`
+
+	// Template defines a go template used to render the notification body.
+	// It defaults to a Slack compatible JSON payload.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// OutputOptions contains the configuration of the run artifacts directory.
+type OutputOptions struct {
+	// Dir defines the directory where a new artifacts directory is created for every run.
+	// The report of the run is written in the run artifacts directory.
+	Dir string `json:"dir"`
+
+	// Retention determines which artifacts directories of previous runs are pruned when a run starts.
+	// Only directories created by chainsaw are pruned, when not set nothing is pruned.
+	// +optional
+	Retention *RetentionOptions `json:"retention,omitempty"`
+}
+
+// ProxyOptions contains the HTTP proxy configuration.
+type ProxyOptions struct {
+	// URL defines an explicit proxy used for remote fetches and Kubernetes clients.
+	// It takes precedence over HTTP_PROXY and HTTPS_PROXY, NO_PROXY is still honored.
+	URL string `json:"url"`
+}
+
 // ReportOptions contains the configuration used for reporting.
 type ReportOptions struct {
 	// ReportFormat determines test report format (JSON|XML|JUNIT-TEST|JUNIT-STEP|JUNIT-OPERATION).
@@ -121,6 +346,22 @@ type ReportOptions struct {
 	// +optional
 	// +kubebuilder:default:="chainsaw-report"
 	Name string `json:"name,omitempty"`
+
+	// Properties are key/value pairs embedded in JUnit reports, at the test suite and test case level.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// RetentionOptions contains the retention policy of run artifacts directories.
+type RetentionOptions struct {
+	// MaxRuns defines the maximum number of run artifacts directories to keep, including the one of the current run.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	MaxRuns *int `json:"maxRuns,omitempty"`
+
+	// MaxAge defines the maximum age of the run artifacts directories to keep.
+	// +optional
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
 }
 
 // TemplatingOptions contains the templating configuration.
@@ -134,3 +375,10 @@ type TemplatingOptions struct {
 	// +optional
 	Compiler *Compiler `json:"compiler,omitempty"`
 }
+
+// TracingOptions contains the OpenTelemetry tracing configuration.
+type TracingOptions struct {
+	// Endpoint defines the OTLP (gRPC) endpoint spans are exported to.
+	// It is either a host and port (using TLS) or a URL, an http:// URL disables TLS.
+	Endpoint string `json:"endpoint"`
+}
@@ -24,10 +24,27 @@ package v1alpha2
 import (
 	v1alpha1 "github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	policyv1alpha1 "github.com/kyverno/kyverno-json/pkg/apis/policy/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindingSource) DeepCopyInto(out *BindingSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingSource.
+func (in *BindingSource) DeepCopy() *BindingSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BindingSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CleanupOptions) DeepCopyInto(out *CleanupOptions) {
 	*out = *in
@@ -49,6 +66,27 @@ func (in *CleanupOptions) DeepCopy() *CleanupOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientOptions) DeepCopyInto(out *ClientOptions) {
+	*out = *in
+	if in.ConnectionInterval != nil {
+		in, out := &in.ConnectionInterval, &out.ConnectionInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientOptions.
+func (in *ClientOptions) DeepCopy() *ClientOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -79,7 +117,13 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	*out = *in
+	if in.BindingsFrom != nil {
+		in, out := &in.BindingsFrom, &out.BindingsFrom
+		*out = make([]BindingSource, len(*in))
+		copy(*out, *in)
+	}
 	in.Cleanup.DeepCopyInto(&out.Cleanup)
+	in.Client.DeepCopyInto(&out.Client)
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make(v1alpha1.Clusters, len(*in))
@@ -91,14 +135,39 @@ func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	out.Discovery = in.Discovery
 	in.Error.DeepCopyInto(&out.Error)
 	in.Execution.DeepCopyInto(&out.Execution)
+	if in.ExitCodes != nil {
+		in, out := &in.ExitCodes, &out.ExitCodes
+		*out = new(ExitCodeOptions)
+		**out = **in
+	}
 	in.Namespace.DeepCopyInto(&out.Namespace)
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(NotificationOptions)
+		**out = **in
+	}
+	if in.Output != nil {
+		in, out := &in.Output, &out.Output
+		*out = new(OutputOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyOptions)
+		**out = **in
+	}
 	if in.Report != nil {
 		in, out := &in.Report, &out.Report
 		*out = new(ReportOptions)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.Templating.DeepCopyInto(&out.Templating)
-	out.Timeouts = in.Timeouts
+	in.Timeouts.DeepCopyInto(&out.Timeouts)
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingOptions)
+		**out = **in
+	}
 	return
 }
 
@@ -175,6 +244,11 @@ func (in *ExecutionOptions) DeepCopyInto(out *ExecutionOptions) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.ParallelCommands != nil {
+		in, out := &in.ParallelCommands, &out.ParallelCommands
+		*out = new(int)
+		**out = **in
+	}
 	if in.RepeatCount != nil {
 		in, out := &in.RepeatCount, &out.RepeatCount
 		*out = new(int)
@@ -185,6 +259,37 @@ func (in *ExecutionOptions) DeepCopyInto(out *ExecutionOptions) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.InjectLabels != nil {
+		in, out := &in.InjectLabels, &out.InjectLabels
+		*out = make(map[string]v1alpha1.Expression, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InjectAnnotations != nil {
+		in, out := &in.InjectAnnotations, &out.InjectAnnotations
+		*out = make(map[string]v1alpha1.Expression, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InjectHostAliases != nil {
+		in, out := &in.InjectHostAliases, &out.InjectHostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -198,6 +303,22 @@ func (in *ExecutionOptions) DeepCopy() *ExecutionOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExitCodeOptions) DeepCopyInto(out *ExitCodeOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExitCodeOptions.
+func (in *ExitCodeOptions) DeepCopy() *ExitCodeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExitCodeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceOptions) DeepCopyInto(out *NamespaceOptions) {
 	*out = *in
@@ -210,6 +331,10 @@ func (in *NamespaceOptions) DeepCopyInto(out *NamespaceOptions) {
 		in, out := &in.Template, &out.Template
 		*out = (*in).DeepCopy()
 	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -223,9 +348,69 @@ func (in *NamespaceOptions) DeepCopy() *NamespaceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationOptions) DeepCopyInto(out *NotificationOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationOptions.
+func (in *NotificationOptions) DeepCopy() *NotificationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutputOptions) DeepCopyInto(out *OutputOptions) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(RetentionOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutputOptions.
+func (in *OutputOptions) DeepCopy() *OutputOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(OutputOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyOptions) DeepCopyInto(out *ProxyOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyOptions.
+func (in *ProxyOptions) DeepCopy() *ProxyOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReportOptions) DeepCopyInto(out *ReportOptions) {
 	*out = *in
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -239,6 +424,32 @@ func (in *ReportOptions) DeepCopy() *ReportOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionOptions) DeepCopyInto(out *RetentionOptions) {
+	*out = *in
+	if in.MaxRuns != nil {
+		in, out := &in.MaxRuns, &out.MaxRuns
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionOptions.
+func (in *RetentionOptions) DeepCopy() *RetentionOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplatingOptions) DeepCopyInto(out *TemplatingOptions) {
 	*out = *in
@@ -259,3 +470,19 @@ func (in *TemplatingOptions) DeepCopy() *TemplatingOptions {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingOptions) DeepCopyInto(out *TracingOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingOptions.
+func (in *TracingOptions) DeepCopy() *TracingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -2,6 +2,7 @@ package cleaner
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/client"
@@ -38,9 +39,12 @@ type cleaner struct {
 	timeout     time.Duration
 	propagation metav1.DeletionPropagation
 	entries     []cleanupEntry
+	lock        sync.Mutex
 }
 
 func (c *cleaner) Add(client client.Client, object client.Object) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	c.entries = append(c.entries, cleanupEntry{
 		client: client,
 		object: object,
@@ -48,10 +52,14 @@ func (c *cleaner) Add(client client.Client, object client.Object) {
 }
 
 func (c *cleaner) Empty() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
 	return len(c.entries) == 0
 }
 
 func (c *cleaner) Run(ctx context.Context, stepReport *model.StepReport) []error {
+	// cleanup must run to completion even if the run was interrupted
+	ctx = context.WithoutCancel(ctx)
 	if c.delay != nil {
 		time.Sleep(*c.delay)
 	}
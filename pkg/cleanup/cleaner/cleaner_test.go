@@ -201,3 +201,38 @@ func Test_cleaner_Run(t *testing.T) {
 		})
 	}
 }
+
+func Test_cleaner_Run_Cancelled(t *testing.T) {
+	obj := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+	var deleted []string
+	c := &cleaner{
+		timeout: 1 * time.Second,
+		entries: []cleanupEntry{{
+			object: obj,
+			client: &tclient.FakeClient{
+				DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					deleted = append(deleted, obj.GetName())
+					return nil
+				},
+				GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+					return kerror.NewNotFound(corev1.Resource("namespace"), "foo")
+				},
+			},
+		}},
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	got := c.Run(ctx, nil)
+	assert.Nil(t, got)
+	assert.Equal(t, []string{"foo"}, deleted)
+}
@@ -0,0 +1,229 @@
+package cassette
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Cassette stores the client interactions of a run so that they can be replayed without a cluster.
+type Cassette struct {
+	// Seed is the seed of the random generator used by the recorded run.
+	Seed int64 `json:"seed"`
+	// Version is the version of the cluster the run was recorded against.
+	Version *version.Info `json:"version,omitempty"`
+	// Scopes records whether kinds are namespaced or not.
+	Scopes map[string]bool `json:"scopes,omitempty"`
+	// Interactions are the recorded requests and their responses, in order.
+	Interactions []Interaction `json:"interactions,omitempty"`
+
+	lock    sync.Mutex
+	index   map[string][]int
+	cursors map[string]int
+}
+
+// Interaction is a request and the response (or error) returned by the cluster.
+type Interaction struct {
+	Request  string         `json:"request"`
+	Response map[string]any `json:"response,omitempty"`
+	Error    *metav1.Status `json:"error,omitempty"`
+}
+
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to load cassette %s (%w)", path, err)
+	}
+	return &cassette, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (c *Cassette) record(request string, response runtime.Object, err error) {
+	interaction := Interaction{
+		Request: request,
+	}
+	if err != nil {
+		interaction.Error = toStatus(err)
+	} else if response != nil {
+		if content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(response); err == nil {
+			interaction.Response = redact(content)
+		}
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+	c.index = nil
+}
+
+// redact hides the values of secrets so that cassettes don't leak credentials, keys are kept.
+// the last applied configuration annotation is dropped as it contains the values too.
+func redact(content map[string]any) map[string]any {
+	if content["kind"] == "Secret" {
+		return redactSecret(content)
+	}
+	items, ok := content["items"].([]any)
+	if !ok {
+		return content
+	}
+	list := content["kind"] == "SecretList"
+	var redacted []any
+	for _, item := range items {
+		if object, ok := item.(map[string]any); ok && (list || object["kind"] == "Secret") {
+			item = redactSecret(object)
+		}
+		redacted = append(redacted, item)
+	}
+	out := maps.Clone(content)
+	out["items"] = redacted
+	return out
+}
+
+func redactSecret(secret map[string]any) map[string]any {
+	out := maps.Clone(secret)
+	for _, field := range []string{"data", "stringData"} {
+		if values, ok := secret[field].(map[string]any); ok {
+			hidden := map[string]any{}
+			for key := range values {
+				// an empty value is valid base64, the secret can still be decoded
+				hidden[key] = ""
+			}
+			out[field] = hidden
+		}
+	}
+	if metadata, ok := secret["metadata"].(map[string]any); ok {
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+				annotations = maps.Clone(annotations)
+				delete(annotations, corev1.LastAppliedConfigAnnotation)
+				metadata = maps.Clone(metadata)
+				metadata["annotations"] = annotations
+				out["metadata"] = metadata
+			}
+		}
+	}
+	return out
+}
+
+func (c *Cassette) recordScope(kind string, namespaced bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.Scopes == nil {
+		c.Scopes = map[string]bool{}
+	}
+	c.Scopes[kind] = namespaced
+}
+
+// replay returns the next interaction recorded for the request, the last one is returned again once they have all been replayed.
+func (c *Cassette) replay(request string) (Interaction, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.index == nil {
+		c.index = map[string][]int{}
+		for i, interaction := range c.Interactions {
+			c.index[interaction.Request] = append(c.index[interaction.Request], i)
+		}
+	}
+	if c.cursors == nil {
+		c.cursors = map[string]int{}
+	}
+	interactions := c.index[request]
+	if len(interactions) == 0 {
+		return Interaction{}, fmt.Errorf("no recorded interaction for %s", request)
+	}
+	cursor := c.cursors[request]
+	c.cursors[request] = cursor + 1
+	return c.Interactions[interactions[min(cursor, len(interactions)-1)]], nil
+}
+
+func (c *Cassette) scope(kind string) (bool, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	namespaced, ok := c.Scopes[kind]
+	return namespaced, ok
+}
+
+// apply writes the interaction response into the target, or returns the recorded error.
+func (i Interaction) apply(target runtime.Object) error {
+	if i.Error != nil {
+		return &kerrors.StatusError{ErrStatus: *i.Error}
+	}
+	if i.Response == nil || target == nil {
+		return nil
+	}
+	content := runtime.DeepCopyJSON(i.Response)
+	if obj, ok := target.(runtime.Unstructured); ok {
+		obj.SetUnstructuredContent(content)
+		return nil
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, target)
+}
+
+func toStatus(err error) *metav1.Status {
+	if status, ok := err.(kerrors.APIStatus); ok {
+		status := status.Status()
+		return &status
+	}
+	return &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+}
+
+func kind(obj runtime.Object) string {
+	if gvk := obj.GetObjectKind().GroupVersionKind(); !gvk.Empty() {
+		return gvk.String()
+	}
+	return fmt.Sprintf("%T", obj)
+}
+
+// request identifies a request by its verb, the kind of the object and its namespaced name.
+func request(verb string, obj runtime.Object, key client.ObjectKey, extra ...string) string {
+	parts := []string{verb, kind(obj), client.Name(key)}
+	for _, part := range extra {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func objectRequest(verb string, obj client.Object, extra ...string) string {
+	return request(verb, obj, client.Key(obj), extra...)
+}
+
+func listRequest(list client.ObjectList, opts ...client.ListOption) string {
+	var options ctrlclient.ListOptions
+	options.ApplyOptions(opts)
+	var selectors []string
+	if options.LabelSelector != nil && !options.LabelSelector.Empty() {
+		selectors = append(selectors, "labels="+options.LabelSelector.String())
+	}
+	if options.FieldSelector != nil && !options.FieldSelector.Empty() {
+		selectors = append(selectors, "fields="+options.FieldSelector.String())
+	}
+	return request("list", list, client.ObjectKey{Namespace: options.Namespace}, selectors...)
+}
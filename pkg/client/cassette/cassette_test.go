@@ -0,0 +1,182 @@
+package cassette
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func configMap(data map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name":      "foo",
+				"namespace": "chainsaw",
+			},
+		},
+	}
+	if data != nil {
+		obj.Object["data"] = data
+	}
+	return obj
+}
+
+// requests runs the same sequence of requests against a recorder and a replayer.
+func requests(t *testing.T, c client.Client) []any {
+	t.Helper()
+	ctx := context.TODO()
+	var results []any
+	// not found first, then found
+	for range 2 {
+		obj := configMap(nil)
+		err := c.Get(ctx, client.Key(obj), obj)
+		if err != nil {
+			results = append(results, kerrors.IsNotFound(err))
+		} else {
+			results = append(results, obj.Object)
+		}
+	}
+	obj := configMap(map[string]any{"foo": "bar"})
+	assert.NoError(t, c.Create(ctx, obj))
+	results = append(results, string(obj.GetUID()))
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("ConfigMapList")
+	assert.NoError(t, c.List(ctx, &list, client.InNamespace("chainsaw")))
+	results = append(results, len(list.Items))
+	namespaced, err := c.IsObjectNamespaced(obj)
+	assert.NoError(t, err)
+	results = append(results, namespaced)
+	return results
+}
+
+func TestRecordReplay(t *testing.T) {
+	inner := &tclient.FakeClient{
+		GetFn: func(_ context.Context, call int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			if call == 0 {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			obj.(*unstructured.Unstructured).Object = configMap(map[string]any{"foo": "bar"}).Object
+			return nil
+		},
+		CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+			obj.SetUID("1234")
+			return nil
+		},
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{*configMap(nil), *configMap(nil)}
+			return nil
+		},
+		IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+			return true, nil
+		},
+	}
+	recording := &Cassette{Seed: 42}
+	recorded := requests(t, NewRecorder(inner, recording))
+	assert.Equal(t, []any{
+		true,
+		configMap(map[string]any{"foo": "bar"}).Object,
+		"1234",
+		2,
+		true,
+	}, recorded)
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	assert.NoError(t, recording.Save(path))
+	loaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), loaded.Seed)
+	replayer := NewReplayer(loaded)
+	assert.Equal(t, recorded, requests(t, replayer))
+	// once replayed, the last interaction is returned again
+	obj := configMap(nil)
+	assert.NoError(t, replayer.Get(context.TODO(), client.Key(obj), obj))
+	assert.Equal(t, configMap(map[string]any{"foo": "bar"}).Object, obj.Object)
+	// requests that were not recorded fail
+	obj.SetName("bar")
+	assert.EqualError(t, replayer.Get(context.TODO(), client.Key(obj), obj), "no recorded interaction for get /v1, Kind=ConfigMap chainsaw/bar")
+	// watches can't be replayed
+	_, err = replayer.Watch(context.TODO(), &unstructured.UnstructuredList{})
+	assert.Error(t, err)
+	// the rest mapper is built from recorded scopes
+	mapping, err := replayer.RESTMapper().RESTMapping(schema.GroupKind{Kind: "ConfigMap"}, "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, meta.RESTScopeNameNamespace, mapping.Scope.Name())
+}
+
+func TestRecord_redactsSecrets(t *testing.T) {
+	secret := func() map[string]any {
+		return map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]any{
+				"name":      "foo",
+				"namespace": "chainsaw",
+				"annotations": map[string]any{
+					"kubectl.kubernetes.io/last-applied-configuration": `{"data":{"password":"c2VjcmV0"}}`,
+					"team": "a",
+				},
+			},
+			"data": map[string]any{
+				"password": "c2VjcmV0",
+			},
+			"stringData": map[string]any{
+				"user": "admin",
+			},
+		}
+	}
+	redacted := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]any{
+			"name":      "foo",
+			"namespace": "chainsaw",
+			"annotations": map[string]any{
+				"team": "a",
+			},
+		},
+		"data": map[string]any{
+			"password": "",
+		},
+		"stringData": map[string]any{
+			"user": "",
+		},
+	}
+	inner := &tclient.FakeClient{
+		GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			obj.(*unstructured.Unstructured).Object = secret()
+			return nil
+		},
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{{Object: secret()}}
+			return nil
+		},
+	}
+	recording := &Cassette{}
+	recorder := NewRecorder(inner, recording)
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("Secret")
+	assert.NoError(t, recorder.Get(context.TODO(), client.ObjectKey{Namespace: "chainsaw", Name: "foo"}, obj))
+	// the caller still receives the secret values
+	assert.Equal(t, secret(), obj.Object)
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("SecretList")
+	assert.NoError(t, recorder.List(context.TODO(), &list))
+	assert.Equal(t, secret(), list.Items[0].Object)
+	// the cassette doesn't
+	assert.Len(t, recording.Interactions, 2)
+	assert.Equal(t, redacted, recording.Interactions[0].Response)
+	assert.Equal(t, []any{redacted}, recording.Interactions[1].Response["items"])
+}
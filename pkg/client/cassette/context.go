@@ -0,0 +1,33 @@
+package cassette
+
+import (
+	"context"
+)
+
+// Mode determines whether a cassette is being recorded or replayed.
+type Mode string
+
+const (
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+type contextKey struct{}
+
+type session struct {
+	cassette *Cassette
+	mode     Mode
+}
+
+func FromContext(ctx context.Context) (*Cassette, Mode) {
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKey{}).(session); ok {
+			return v.cassette, v.mode
+		}
+	}
+	return nil, ""
+}
+
+func IntoContext(ctx context.Context, cassette *Cassette, mode Mode) context.Context {
+	return context.WithValue(ctx, contextKey{}, session{cassette: cassette, mode: mode})
+}
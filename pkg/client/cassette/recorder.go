@@ -0,0 +1,126 @@
+package cassette
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type recorder struct {
+	inner    client.Client
+	cassette *Cassette
+}
+
+// NewRecorder returns a client recording the interactions with the inner client in the cassette.
+// Watches are not recorded.
+func NewRecorder(inner client.Client, cassette *Cassette) client.Client {
+	return &recorder{
+		inner:    inner,
+		cassette: cassette,
+	}
+}
+
+func (c *recorder) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	request := objectRequest("create", obj)
+	err := c.inner.Create(ctx, obj, opts...)
+	c.cassette.record(request, obj, err)
+	return err
+}
+
+func (c *recorder) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	request := objectRequest("update", obj)
+	err := c.inner.Update(ctx, obj, opts...)
+	c.cassette.record(request, obj, err)
+	return err
+}
+
+func (c *recorder) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	request := objectRequest("delete", obj)
+	err := c.inner.Delete(ctx, obj, opts...)
+	c.cassette.record(request, nil, err)
+	return err
+}
+
+func (c *recorder) Get(ctx context.Context, key types.NamespacedName, obj client.Object, opts ...client.GetOption) error {
+	request := request("get", obj, key)
+	err := c.inner.Get(ctx, key, obj, opts...)
+	c.cassette.record(request, obj, err)
+	return err
+}
+
+func (c *recorder) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	namespaced, err := c.inner.IsObjectNamespaced(obj)
+	if err == nil {
+		c.cassette.recordScope(kind(obj), namespaced)
+	}
+	return namespaced, err
+}
+
+func (c *recorder) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	request := listRequest(list, opts...)
+	err := c.inner.List(ctx, list, opts...)
+	c.cassette.record(request, list, err)
+	return err
+}
+
+func (c *recorder) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	return c.inner.Watch(ctx, list, opts...)
+}
+
+func (c *recorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	request := objectRequest("patch", obj)
+	err := c.inner.Patch(ctx, obj, patch, opts...)
+	c.cassette.record(request, obj, err)
+	return err
+}
+
+func (c *recorder) SubResource(subResource string) client.SubResourceClient {
+	return &subResourceRecorder{
+		inner:       c.inner.SubResource(subResource),
+		subResource: subResource,
+		cassette:    c.cassette,
+	}
+}
+
+func (c *recorder) RESTMapper() meta.RESTMapper {
+	return c.inner.RESTMapper()
+}
+
+type subResourceRecorder struct {
+	inner       client.SubResourceClient
+	subResource string
+	cassette    *Cassette
+}
+
+func (c *subResourceRecorder) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceGetOption) error {
+	request := objectRequest("get", obj, c.subResource)
+	err := c.inner.Get(ctx, obj, subResource, opts...)
+	c.cassette.record(request, subResource, err)
+	return err
+}
+
+func (c *subResourceRecorder) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceCreateOption) error {
+	request := objectRequest("create", obj, c.subResource)
+	err := c.inner.Create(ctx, obj, subResource, opts...)
+	c.cassette.record(request, subResource, err)
+	return err
+}
+
+func (c *subResourceRecorder) Update(ctx context.Context, obj client.Object, opts ...ctrlclient.SubResourceUpdateOption) error {
+	request := objectRequest("update", obj, c.subResource)
+	err := c.inner.Update(ctx, obj, opts...)
+	c.cassette.record(request, obj, err)
+	return err
+}
+
+func (c *subResourceRecorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+	request := objectRequest("patch", obj, c.subResource)
+	err := c.inner.Patch(ctx, obj, patch, opts...)
+	c.cassette.record(request, obj, err)
+	return err
+}
@@ -0,0 +1,132 @@
+package cassette
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type replayer struct {
+	cassette *Cassette
+}
+
+// NewReplayer returns a client answering requests with the interactions recorded in the cassette, no cluster is needed.
+// Requests are matched by verb, kind and namespaced name, repeated requests are answered in the order they were recorded.
+func NewReplayer(cassette *Cassette) client.Client {
+	return &replayer{
+		cassette: cassette,
+	}
+}
+
+func (c *replayer) replay(request string, target runtime.Object) error {
+	interaction, err := c.cassette.replay(request)
+	if err != nil {
+		return err
+	}
+	return interaction.apply(target)
+}
+
+func (c *replayer) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	return c.replay(objectRequest("create", obj), obj)
+}
+
+func (c *replayer) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return c.replay(objectRequest("update", obj), obj)
+}
+
+func (c *replayer) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	return c.replay(objectRequest("delete", obj), nil)
+}
+
+func (c *replayer) Get(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+	return c.replay(request("get", obj, key), obj)
+}
+
+func (c *replayer) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	namespaced, ok := c.cassette.scope(kind(obj))
+	if !ok {
+		return false, fmt.Errorf("no recorded scope for %s", kind(obj))
+	}
+	return namespaced, nil
+}
+
+func (c *replayer) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.replay(listRequest(list, opts...), list)
+}
+
+func (c *replayer) Watch(context.Context, client.ObjectList, ...client.ListOption) (watch.Interface, error) {
+	return nil, errors.New("watches can't be replayed")
+}
+
+func (c *replayer) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return c.replay(objectRequest("patch", obj), obj)
+}
+
+func (c *replayer) SubResource(subResource string) client.SubResourceClient {
+	return &subResourceReplayer{
+		replayer:    c,
+		subResource: subResource,
+	}
+}
+
+// RESTMapper returns a mapper built from the recorded scopes, resources are guessed from the kinds.
+func (c *replayer) RESTMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	c.cassette.lock.Lock()
+	defer c.cassette.lock.Unlock()
+	for kind, namespaced := range c.cassette.Scopes {
+		gvk, ok := parseKind(kind)
+		if !ok {
+			continue
+		}
+		scope := meta.RESTScopeRoot
+		if namespaced {
+			scope = meta.RESTScopeNamespace
+		}
+		mapper.Add(gvk, scope)
+	}
+	return mapper
+}
+
+// parseKind parses a kind formatted by schema.GroupVersionKind.String.
+func parseKind(kind string) (schema.GroupVersionKind, bool) {
+	groupVersion, kind, ok := strings.Cut(kind, ", Kind=")
+	if !ok {
+		return schema.GroupVersionKind{}, false
+	}
+	gv, err := schema.ParseGroupVersion(groupVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, false
+	}
+	return gv.WithKind(kind), true
+}
+
+type subResourceReplayer struct {
+	replayer    *replayer
+	subResource string
+}
+
+func (c *subResourceReplayer) Get(_ context.Context, obj client.Object, subResource client.Object, _ ...ctrlclient.SubResourceGetOption) error {
+	return c.replayer.replay(objectRequest("get", obj, c.subResource), subResource)
+}
+
+func (c *subResourceReplayer) Create(_ context.Context, obj client.Object, subResource client.Object, _ ...ctrlclient.SubResourceCreateOption) error {
+	return c.replayer.replay(objectRequest("create", obj, c.subResource), subResource)
+}
+
+func (c *subResourceReplayer) Update(_ context.Context, obj client.Object, _ ...ctrlclient.SubResourceUpdateOption) error {
+	return c.replayer.replay(objectRequest("update", obj, c.subResource), obj)
+}
+
+func (c *subResourceReplayer) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...ctrlclient.SubResourcePatchOption) error {
+	return c.replayer.replay(objectRequest("patch", obj, c.subResource), obj)
+}
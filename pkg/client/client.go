@@ -5,6 +5,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type Client interface {
@@ -18,6 +19,9 @@ type Client interface {
 	// result returned from the server.
 	List(ctx context.Context, list ObjectList, opts ...ListOption) error
 
+	// Watch watches objects of type list for a given namespace and list options.
+	Watch(ctx context.Context, list ObjectList, opts ...ListOption) (watch.Interface, error)
+
 	// Create saves the object obj in the Kubernetes cluster. obj must be a
 	// struct pointer so that obj can be updated with the content returned by the Server.
 	Create(ctx context.Context, obj Object, opts ...CreateOption) error
@@ -33,6 +37,9 @@ type Client interface {
 	// struct pointer so that obj can be updated with the content returned by the Server.
 	Patch(ctx context.Context, obj Object, patch Patch, opts ...PatchOption) error
 
+	// SubResource returns a client for the given subresource (scale, status, ...).
+	SubResource(subResource string) SubResourceClient
+
 	// IsObjectNamespaced returns true if the GroupVersionKind of the object is namespaced.
 	IsObjectNamespaced(obj runtime.Object) (bool, error)
 
@@ -7,6 +7,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -40,14 +41,42 @@ func (c *dryRunClient) List(ctx context.Context, list client.ObjectList, opts ..
 	return c.inner.List(ctx, list, opts...)
 }
 
+func (c *dryRunClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	return c.inner.Watch(ctx, list, opts...)
+}
+
 func (c *dryRunClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
 	return c.inner.Patch(ctx, obj, patch, append(opts, ctrlclient.DryRunAll)...)
 }
 
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{inner: c.inner.SubResource(subResource)}
+}
+
 func (c *dryRunClient) RESTMapper() meta.RESTMapper {
 	return c.inner.RESTMapper()
 }
 
+type dryRunSubResourceClient struct {
+	inner client.SubResourceClient
+}
+
+func (c *dryRunSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceGetOption) error {
+	return c.inner.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *dryRunSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceCreateOption) error {
+	return c.inner.Create(ctx, obj, subResource, append(opts, ctrlclient.DryRunAll)...)
+}
+
+func (c *dryRunSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...ctrlclient.SubResourceUpdateOption) error {
+	return c.inner.Update(ctx, obj, append(opts, ctrlclient.DryRunAll)...)
+}
+
+func (c *dryRunSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+	return c.inner.Patch(ctx, obj, patch, append(opts, ctrlclient.DryRunAll)...)
+}
+
 func New(inner Client) Client {
 	return &dryRunClient{inner: inner}
 }
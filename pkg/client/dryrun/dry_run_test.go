@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -379,6 +380,50 @@ func Test_dryRunClient_List(t *testing.T) {
 	}
 }
 
+func Test_dryRunClient_Watch(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     client.ObjectList
+		opts    []client.ListOption
+		wantErr bool
+	}{{
+		name:    "no error",
+		obj:     nil,
+		opts:    nil,
+		wantErr: false,
+	}, {
+		name:    "error",
+		obj:     nil,
+		opts:    nil,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &tclient.FakeClient{
+				WatchFn: func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+					assert.NotContains(t, opts, ctrlclient.DryRunAll)
+					if tt.wantErr {
+						return nil, errors.New("dummy error")
+					}
+					return watch.NewEmptyWatch(), nil
+				},
+			}
+			c := &dryRunClient{
+				inner: inner,
+			}
+			watcher, err := c.Watch(context.TODO(), tt.obj, tt.opts...)
+			assert.Equal(t, 1, inner.NumCalls())
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, watcher)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, watcher)
+			}
+		})
+	}
+}
+
 func Test_dryRunClient_Patch(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -448,6 +493,72 @@ func Test_dryRunClient_Patch(t *testing.T) {
 	}
 }
 
+func Test_dryRunClient_SubResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{{
+		name:    "no error",
+		wantErr: false,
+	}, {
+		name:    "error",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wantErr := func() error {
+				if tt.wantErr {
+					return errors.New("dummy error")
+				}
+				return nil
+			}
+			subResource := &tclient.FakeSubResourceClient{
+				GetFn: func(ctx context.Context, call int, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceGetOption) error {
+					assert.Empty(t, opts)
+					return wantErr()
+				},
+				CreateFn: func(ctx context.Context, call int, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceCreateOption) error {
+					assert.Contains(t, opts, ctrlclient.DryRunAll)
+					return wantErr()
+				},
+				UpdateFn: func(ctx context.Context, call int, obj client.Object, opts ...ctrlclient.SubResourceUpdateOption) error {
+					assert.Contains(t, opts, ctrlclient.DryRunAll)
+					return wantErr()
+				},
+				PatchFn: func(ctx context.Context, call int, obj client.Object, patch client.Patch, opts ...ctrlclient.SubResourcePatchOption) error {
+					assert.Contains(t, opts, ctrlclient.DryRunAll)
+					return wantErr()
+				},
+			}
+			inner := &tclient.FakeClient{
+				SubResourceFn: func(call int, name string) client.SubResourceClient {
+					assert.Equal(t, "scale", name)
+					return subResource
+				},
+			}
+			c := &dryRunClient{
+				inner: inner,
+			}
+			sc := c.SubResource("scale")
+			errs := []error{
+				sc.Get(context.TODO(), nil, nil),
+				sc.Create(context.TODO(), nil, nil),
+				sc.Update(context.TODO(), nil),
+				sc.Patch(context.TODO(), nil, nil),
+			}
+			assert.Equal(t, 1, inner.NumCalls())
+			assert.Equal(t, 4, subResource.NumCalls())
+			for _, err := range errs {
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		})
+	}
+}
+
 func Test_dryRunClient_IsObjectNamespaced(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1,17 +1,32 @@
 package simple
 
 import (
+	"context"
+
 	"github.com/kyverno/chainsaw/pkg/client"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/rest"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+type withWatch struct {
+	ctrlclient.Client
+	watcher ctrlclient.WithWatch
+}
+
+func (c *withWatch) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	return c.watcher.Watch(ctx, list, opts...)
+}
+
 func New(cfg *rest.Config) (client.Client, error) {
 	var opts ctrlclient.Options
-	client, err := ctrlclient.New(cfg, opts)
+	client, err := ctrlclient.NewWithWatch(cfg, opts)
 	if err != nil {
 		return nil, err
 	}
-	return ctrlclient.WithFieldValidation(client, metav1.FieldValidationStrict), nil
+	return &withWatch{
+		Client:  ctrlclient.WithFieldValidation(client, metav1.FieldValidationStrict),
+		watcher: client,
+	}, nil
 }
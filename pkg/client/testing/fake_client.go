@@ -2,65 +2,130 @@ package testing
 
 import (
 	"context"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// TODO: not thread safe
 type FakeClient struct {
 	GetFn                func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
 	CreateFn             func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error
 	UpdateFn             func(ctx context.Context, call int, obj client.Object, opts ...client.UpdateOption) error
 	DeleteFn             func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error
 	ListFn               func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) error
+	WatchFn              func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error)
 	PatchFn              func(ctx context.Context, call int, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+	SubResourceFn        func(call int, subResource string) client.SubResourceClient
 	IsObjectNamespacedFn func(call int, obj runtime.Object) (bool, error)
 	RESTMapperFn         func(call int) meta.RESTMapper
 	numCalls             int
+	lock                 sync.Mutex
 }
 
 func (c *FakeClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
-	defer func() { c.numCalls++ }()
-	return c.GetFn(ctx, c.numCalls, key, obj, opts...)
+	call := c.nextCall()
+	return c.GetFn(ctx, call, key, obj, opts...)
 }
 
 func (c *FakeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-	defer func() { c.numCalls++ }()
-	return c.ListFn(ctx, c.numCalls, list, opts...)
+	call := c.nextCall()
+	return c.ListFn(ctx, call, list, opts...)
+}
+
+func (c *FakeClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	call := c.nextCall()
+	return c.WatchFn(ctx, call, list, opts...)
 }
 
 func (c *FakeClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
-	defer func() { c.numCalls++ }()
-	return c.CreateFn(ctx, c.numCalls, obj, opts...)
+	call := c.nextCall()
+	return c.CreateFn(ctx, call, obj, opts...)
 }
 
 func (c *FakeClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
-	defer func() { c.numCalls++ }()
-	return c.UpdateFn(ctx, c.numCalls, obj, opts...)
+	call := c.nextCall()
+	return c.UpdateFn(ctx, call, obj, opts...)
 }
 
 func (c *FakeClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
-	defer func() { c.numCalls++ }()
-	return c.DeleteFn(ctx, c.numCalls, obj, opts...)
+	call := c.nextCall()
+	return c.DeleteFn(ctx, call, obj, opts...)
 }
 
 func (c *FakeClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
-	defer func() { c.numCalls++ }()
-	return c.PatchFn(ctx, c.numCalls, obj, patch, opts...)
+	call := c.nextCall()
+	return c.PatchFn(ctx, call, obj, patch, opts...)
+}
+
+func (c *FakeClient) SubResource(subResource string) client.SubResourceClient {
+	call := c.nextCall()
+	return c.SubResourceFn(call, subResource)
 }
 
 func (c *FakeClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
-	defer func() { c.numCalls++ }()
-	return c.IsObjectNamespacedFn(c.numCalls, obj)
+	call := c.nextCall()
+	return c.IsObjectNamespacedFn(call, obj)
 }
 
 func (c *FakeClient) RESTMapper() meta.RESTMapper {
-	defer func() { c.numCalls++ }()
-	return c.RESTMapperFn(c.numCalls)
+	call := c.nextCall()
+	return c.RESTMapperFn(call)
 }
 
 func (c *FakeClient) NumCalls() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.numCalls
+}
+
+func (c *FakeClient) nextCall() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	defer func() { c.numCalls++ }()
+	return c.numCalls
+}
+
+type FakeSubResourceClient struct {
+	GetFn    func(ctx context.Context, call int, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error
+	CreateFn func(ctx context.Context, call int, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error
+	UpdateFn func(ctx context.Context, call int, obj client.Object, opts ...client.SubResourceUpdateOption) error
+	PatchFn  func(ctx context.Context, call int, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error
+	numCalls int
+	lock     sync.Mutex
+}
+
+func (c *FakeSubResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	call := c.nextCall()
+	return c.GetFn(ctx, call, obj, subResource, opts...)
+}
+
+func (c *FakeSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	call := c.nextCall()
+	return c.CreateFn(ctx, call, obj, subResource, opts...)
+}
+
+func (c *FakeSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	call := c.nextCall()
+	return c.UpdateFn(ctx, call, obj, opts...)
+}
+
+func (c *FakeSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	call := c.nextCall()
+	return c.PatchFn(ctx, call, obj, patch, opts...)
+}
+
+func (c *FakeSubResourceClient) NumCalls() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.numCalls
+}
+
+func (c *FakeSubResourceClient) nextCall() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	defer func() { c.numCalls++ }()
 	return c.numCalls
 }
@@ -5,19 +5,29 @@ import (
 )
 
 type (
-	Object            = ctrlclient.Object
-	ObjectKey         = ctrlclient.ObjectKey
-	ObjectList        = ctrlclient.ObjectList
-	Patch             = ctrlclient.Patch
-	GetOption         = ctrlclient.GetOption
-	ListOption        = ctrlclient.ListOption
-	CreateOption      = ctrlclient.CreateOption
-	UpdateOption      = ctrlclient.UpdateOption
-	DeleteOption      = ctrlclient.DeleteOption
-	PatchOption       = ctrlclient.PatchOption
-	InNamespace       = ctrlclient.InNamespace
-	PropagationPolicy = ctrlclient.PropagationPolicy
-	MatchingLabels    = ctrlclient.MatchingLabels
+	Object                 = ctrlclient.Object
+	ObjectKey              = ctrlclient.ObjectKey
+	ObjectList             = ctrlclient.ObjectList
+	Patch                  = ctrlclient.Patch
+	GetOption              = ctrlclient.GetOption
+	ListOption             = ctrlclient.ListOption
+	CreateOption           = ctrlclient.CreateOption
+	UpdateOption           = ctrlclient.UpdateOption
+	DeleteOption           = ctrlclient.DeleteOption
+	PatchOption            = ctrlclient.PatchOption
+	FieldOwner             = ctrlclient.FieldOwner
+	InNamespace            = ctrlclient.InNamespace
+	PropagationPolicy      = ctrlclient.PropagationPolicy
+	MatchingLabels         = ctrlclient.MatchingLabels
+	MatchingFields         = ctrlclient.MatchingFields
+	MatchingSelector       = ctrlclient.MatchingLabelsSelector
+	MatchingFieldsSelector = ctrlclient.MatchingFieldsSelector
+	SubResourceClient      = ctrlclient.SubResourceClient
 )
 
-var RawPatch = ctrlclient.RawPatch
+var (
+	Apply               = ctrlclient.Apply
+	ForceOwnership      = ctrlclient.ForceOwnership
+	RawPatch            = ctrlclient.RawPatch
+	WithSubResourceBody = ctrlclient.WithSubResourceBody
+)
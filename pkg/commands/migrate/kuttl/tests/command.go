@@ -21,6 +21,7 @@ import (
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 )
@@ -374,9 +375,9 @@ func testStep(to *v1alpha1.TestStepSpec, in unstructured.Unstructured) error {
 	}
 	var operations []v1alpha1.Operation
 	for _, operation := range from.Commands {
-		var timeout *metav1.Duration
+		var timeout *v1alpha1.Timeout
 		if operation.Timeout != 0 {
-			timeout = &metav1.Duration{Duration: time.Second * time.Duration(operation.Timeout)}
+			timeout = &v1alpha1.Timeout{Duration: time.Second * time.Duration(operation.Timeout)}
 		}
 		if operation.Background {
 			return errors.New("found a command with background=true, this is not supported in chainsaw")
@@ -512,7 +513,7 @@ func testAssert(to *v1alpha1.TestStepSpec, in unstructured.Unstructured) error {
 				Container: v1alpha1.Expression(collector.Container),
 			}
 			if collector.Tail != 0 {
-				op.Tail = ptr.To(collector.Tail)
+				op.Tail = ptr.To(intstr.FromInt(collector.Tail))
 			}
 			to.Catch = append(to.Catch, v1alpha1.CatchFinally{PodLogs: op})
 		case "command":
@@ -11,6 +11,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/commands/renovate"
 	"github.com/kyverno/chainsaw/pkg/commands/root"
 	"github.com/kyverno/chainsaw/pkg/commands/test"
+	"github.com/kyverno/chainsaw/pkg/commands/validate"
 	"github.com/kyverno/chainsaw/pkg/commands/version"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +28,7 @@ func RootCommand() *cobra.Command {
 		migrate.Command(),
 		renovate.Command(),
 		test.Command(),
+		validate.Command(),
 		version.Command(),
 	)
 	return cmd
@@ -5,18 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client/cassette"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/loaders/config"
 	"github.com/kyverno/chainsaw/pkg/loaders/values"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/chainsaw/pkg/report"
 	"github.com/kyverno/chainsaw/pkg/runner"
+	"github.com/kyverno/chainsaw/pkg/runner/events"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
+	"github.com/kyverno/chainsaw/pkg/runner/output"
+	"github.com/kyverno/chainsaw/pkg/tui"
 	flagutils "github.com/kyverno/chainsaw/pkg/utils/flag"
 	fsutils "github.com/kyverno/chainsaw/pkg/utils/fs"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
+	signalutils "github.com/kyverno/chainsaw/pkg/utils/signal"
 	"github.com/kyverno/chainsaw/pkg/version"
 	"github.com/kyverno/pkg/ext/output/color"
 	"github.com/spf13/cobra"
@@ -39,22 +49,30 @@ type options struct {
 	execTimeout                 metav1.Duration
 	testDirs                    []string
 	skipDelete                  bool
+	skipDeleteIf                string
 	template                    bool
 	defaultCompiler             string
 	failFast                    bool
+	failFastWithinTest          bool
 	parallel                    int
+	parallelCommands            int
+	parallelTests               string
 	repeatCount                 int
 	reportFormat                string
 	reportPath                  string
 	reportName                  string
+	junitProperties             map[string]string
 	namespace                   string
 	deletionPropagationPolicy   string
 	fullName                    bool
 	excludeTestRegex            string
 	includeTestRegex            string
+	grep                        string
+	grepInvert                  string
 	noColor                     bool
 	kubeConfigOverrides         clientcmd.ConfigOverrides
 	forceTerminationGracePeriod metav1.Duration
+	podLogsDefaultContainer     bool
 	delayBeforeCleanup          metav1.Duration
 	selector                    []string
 	noCluster                   bool
@@ -64,6 +82,30 @@ type options struct {
 	remarshal                   bool
 	shardIndex                  int
 	shardCount                  int
+	seed                        int64
+	notifyURL                   string
+	notifyTemplate              string
+	proxyURL                    string
+	outputDir                   string
+	outputMaxRuns               int
+	outputMaxAge                metav1.Duration
+	otlpEndpoint                string
+	kubeAPIQPS                  int
+	onlyFailed                  string
+	kubeAPIBurst                int
+	kubeAPIConnectionAttempts   int
+	kubeAPIConnectionInterval   metav1.Duration
+	shutdownGracePeriod         metav1.Duration
+	timeoutAll                  metav1.Duration
+	tui                         bool
+	dumpConfig                  string
+	record                      string
+	replay                      string
+}
+
+// newSeed returns the seed used when none was configured
+var newSeed = func() int64 {
+	return time.Now().UnixNano()
 }
 
 func Command() *cobra.Command {
@@ -72,12 +114,19 @@ func Command() *cobra.Command {
 		Use:          "test [flags]... [test directories]...",
 		Short:        "Run tests",
 		SilenceUsage: true,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (_err error) {
 			color.Init(options.noColor, true)
 			clock := clock.RealClock{}
 			out := cmd.OutOrStdout()
 			fmt.Fprintf(out, "Version: %s\n", version.Version())
 			var configuration v1alpha2.Configuration
+			// errors happening outside of the tests (configuration, cluster connection, etc) are setup failures
+			category := model.FailureCategorySetup
+			defer func() {
+				if _err != nil {
+					_err = exitError{err: _err, code: exitCode(configuration.Spec.ExitCodes, category)}
+				}
+			}()
 			// if no config file was provided, give a chance to the default config name
 			if options.config == "" {
 				if _, err := os.Stat(config.DefaultFileName); err == nil {
@@ -124,9 +173,15 @@ func Command() *cobra.Command {
 			if flagutils.IsSet(flags, "exec-timeout") {
 				configuration.Spec.Timeouts.Exec = options.execTimeout
 			}
+			if flagutils.IsSet(flags, "timeout-all") {
+				configuration.Spec.Execution.Timeout = &options.timeoutAll
+			}
 			if flagutils.IsSet(flags, "skip-delete") {
 				configuration.Spec.Cleanup.SkipDelete = options.skipDelete
 			}
+			if flagutils.IsSet(flags, "skip-delete-if") {
+				configuration.Spec.Cleanup.SkipDeleteIf = v1alpha1.Expression(options.skipDeleteIf)
+			}
 			if flagutils.IsSet(flags, "template") {
 				configuration.Spec.Templating.Enabled = options.template
 			}
@@ -136,9 +191,30 @@ func Command() *cobra.Command {
 			if flagutils.IsSet(flags, "fail-fast") {
 				configuration.Spec.Execution.FailFast = options.failFast
 			}
+			if flagutils.IsSet(flags, "fail-fast-within-test") {
+				configuration.Spec.Execution.StopOnFirstFailure = options.failFastWithinTest
+			}
+			if flagutils.IsSet(flags, "pod-logs-default-container") {
+				configuration.Spec.Execution.PodLogsDefaultContainer = options.podLogsDefaultContainer
+			}
 			if flagutils.IsSet(flags, "parallel") {
 				configuration.Spec.Execution.Parallel = &options.parallel
 			}
+			if flagutils.IsSet(flags, "parallel-commands") {
+				configuration.Spec.Execution.ParallelCommands = &options.parallelCommands
+			}
+			if flagutils.IsSet(flags, "parallel-tests") {
+				switch options.parallelTests {
+				case "serial":
+					configuration.Spec.Execution.ForceSerial = true
+					configuration.Spec.Execution.ForceParallel = false
+				case "parallel":
+					configuration.Spec.Execution.ForceSerial = false
+					configuration.Spec.Execution.ForceParallel = true
+				default:
+					return fmt.Errorf("invalid parallel tests value %s (expected serial or parallel)", options.parallelTests)
+				}
+			}
 			if flagutils.IsSet(flags, "repeat-count") {
 				configuration.Spec.Execution.RepeatCount = &options.repeatCount
 			}
@@ -169,6 +245,79 @@ func Command() *cobra.Command {
 				}
 				configuration.Spec.Report.Name = options.reportName
 			}
+			if flagutils.IsSet(flags, "junit-properties") {
+				if configuration.Spec.Report == nil {
+					configuration.Spec.Report = &v1alpha2.ReportOptions{
+						Format: v1alpha2.JSONFormat,
+						Name:   "chainsaw-report",
+					}
+				}
+				configuration.Spec.Report.Properties = options.junitProperties
+			}
+			if flagutils.IsSet(flags, "notify-url") {
+				if configuration.Spec.Notification == nil {
+					configuration.Spec.Notification = &v1alpha2.NotificationOptions{}
+				}
+				configuration.Spec.Notification.URL = options.notifyURL
+			}
+			if flagutils.IsSet(flags, "notify-template") {
+				if configuration.Spec.Notification == nil {
+					configuration.Spec.Notification = &v1alpha2.NotificationOptions{}
+				}
+				configuration.Spec.Notification.Template = options.notifyTemplate
+			}
+			if flagutils.IsSet(flags, "proxy-url") {
+				if configuration.Spec.Proxy == nil {
+					configuration.Spec.Proxy = &v1alpha2.ProxyOptions{}
+				}
+				configuration.Spec.Proxy.URL = options.proxyURL
+			}
+			if flagutils.IsSet(flags, "output-dir") {
+				if configuration.Spec.Output == nil {
+					configuration.Spec.Output = &v1alpha2.OutputOptions{}
+				}
+				configuration.Spec.Output.Dir = options.outputDir
+			}
+			if flagutils.IsSet(flags, "output-max-runs") {
+				// pruning would otherwise delete every previous run
+				if options.outputMaxRuns < 1 {
+					return fmt.Errorf("invalid output max runs value %d (must be at least 1)", options.outputMaxRuns)
+				}
+				if configuration.Spec.Output == nil {
+					configuration.Spec.Output = &v1alpha2.OutputOptions{}
+				}
+				if configuration.Spec.Output.Retention == nil {
+					configuration.Spec.Output.Retention = &v1alpha2.RetentionOptions{}
+				}
+				configuration.Spec.Output.Retention.MaxRuns = &options.outputMaxRuns
+			}
+			if flagutils.IsSet(flags, "output-max-age") {
+				if configuration.Spec.Output == nil {
+					configuration.Spec.Output = &v1alpha2.OutputOptions{}
+				}
+				if configuration.Spec.Output.Retention == nil {
+					configuration.Spec.Output.Retention = &v1alpha2.RetentionOptions{}
+				}
+				configuration.Spec.Output.Retention.MaxAge = &options.outputMaxAge
+			}
+			if flagutils.IsSet(flags, "otlp-endpoint") {
+				if configuration.Spec.Tracing == nil {
+					configuration.Spec.Tracing = &v1alpha2.TracingOptions{}
+				}
+				configuration.Spec.Tracing.Endpoint = options.otlpEndpoint
+			}
+			if flagutils.IsSet(flags, "kube-api-qps") {
+				configuration.Spec.Client.QPS = options.kubeAPIQPS
+			}
+			if flagutils.IsSet(flags, "kube-api-burst") {
+				configuration.Spec.Client.Burst = options.kubeAPIBurst
+			}
+			if flagutils.IsSet(flags, "kube-api-connection-attempts") {
+				configuration.Spec.Client.ConnectionAttempts = options.kubeAPIConnectionAttempts
+			}
+			if flagutils.IsSet(flags, "kube-api-connection-interval") {
+				configuration.Spec.Client.ConnectionInterval = &options.kubeAPIConnectionInterval
+			}
 			if flagutils.IsSet(flags, "namespace") {
 				configuration.Spec.Namespace.Name = options.namespace
 			}
@@ -184,9 +333,28 @@ func Command() *cobra.Command {
 			if flagutils.IsSet(flags, "exclude-test-regex") {
 				configuration.Spec.Discovery.ExcludeTestRegex = options.excludeTestRegex
 			}
+			if flagutils.IsSet(flags, "grep") {
+				configuration.Spec.Discovery.Grep = options.grep
+			}
+			if flagutils.IsSet(flags, "grep-invert") {
+				configuration.Spec.Discovery.GrepInvert = options.grepInvert
+			}
 			if flagutils.IsSet(flags, "force-termination-grace-period") {
 				configuration.Spec.Execution.ForceTerminationGracePeriod = &options.forceTerminationGracePeriod
 			}
+			if flagutils.IsSet(flags, "seed") {
+				configuration.Spec.Execution.Seed = &options.seed
+			}
+			// a recorded run is replayed with the same seed so that random names match the recorded requests
+			var recording *cassette.Cassette
+			if options.replay != "" {
+				loaded, err := cassette.Load(options.replay)
+				if err != nil {
+					return err
+				}
+				recording = loaded
+				configuration.Spec.Execution.Seed = &recording.Seed
+			}
 			if flagutils.IsSet(flags, "cleanup-delay") {
 				configuration.Spec.Cleanup.DelayBeforeCleanup = &options.delayBeforeCleanup
 			}
@@ -225,6 +393,25 @@ func Command() *cobra.Command {
 			if options.pauseOnFailure {
 				configuration.Spec.Execution.Parallel = ptr.To(1)
 			}
+			// dump the effective configuration and exit without running tests
+			if options.dumpConfig != "" {
+				if err := dumpConfig(options.dumpConfig, configuration); err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "Configuration written to %s\n", options.dumpConfig)
+				fmt.Fprintln(out, "Done.")
+				return nil
+			}
+			// the generated seed is printed below so that the run can be reproduced
+			if configuration.Spec.Execution.Seed == nil {
+				seed := newSeed()
+				configuration.Spec.Execution.Seed = &seed
+			}
+			if options.replay == "" && options.record != "" {
+				recording = &cassette.Cassette{
+					Seed: *configuration.Spec.Execution.Seed,
+				}
+			}
 			fmt.Fprintf(out, "- Using test file: %s\n", configuration.Spec.Discovery.TestFile)
 			fmt.Fprintf(out, "- TestDirs %v\n", options.testDirs)
 			fmt.Fprintf(out, "- SkipDelete %v\n", configuration.Spec.Cleanup.SkipDelete)
@@ -236,26 +423,72 @@ func Command() *cobra.Command {
 					fmt.Fprintf(out, "- ReportPath '%v'\n", configuration.Spec.Report.Path)
 				}
 			}
+			if configuration.Spec.Notification != nil && configuration.Spec.Notification.URL != "" {
+				// webhook urls often embed a secret token, only the scheme and host are printed
+				fmt.Fprintf(out, "- NotifyURL '%v'\n", redactNotifyURL(configuration.Spec.Notification.URL))
+			}
+			if configuration.Spec.Proxy != nil && configuration.Spec.Proxy.URL != "" {
+				fmt.Fprintf(out, "- ProxyURL '%v'\n", redactProxyURL(configuration.Spec.Proxy.URL))
+			}
+			if configuration.Spec.Output != nil && configuration.Spec.Output.Dir != "" {
+				fmt.Fprintf(out, "- OutputDir '%v'\n", configuration.Spec.Output.Dir)
+				if retention := configuration.Spec.Output.Retention; retention != nil {
+					if retention.MaxRuns != nil {
+						fmt.Fprintf(out, "- OutputMaxRuns %v\n", *retention.MaxRuns)
+					}
+					if retention.MaxAge != nil {
+						fmt.Fprintf(out, "- OutputMaxAge %v\n", retention.MaxAge.Duration)
+					}
+				}
+			}
+			if configuration.Spec.Tracing != nil && configuration.Spec.Tracing.Endpoint != "" {
+				fmt.Fprintf(out, "- OTLPEndpoint '%v'\n", configuration.Spec.Tracing.Endpoint)
+			}
 			fmt.Fprintf(out, "- Namespace '%v'\n", configuration.Spec.Namespace.Name)
 			fmt.Fprintf(out, "- FullName %v\n", configuration.Spec.Discovery.FullName)
 			fmt.Fprintf(out, "- IncludeTestRegex '%v'\n", configuration.Spec.Discovery.IncludeTestRegex)
 			fmt.Fprintf(out, "- ExcludeTestRegex '%v'\n", configuration.Spec.Discovery.ExcludeTestRegex)
+			if configuration.Spec.Discovery.Grep != "" {
+				fmt.Fprintf(out, "- Grep '%v'\n", configuration.Spec.Discovery.Grep)
+			}
+			if configuration.Spec.Discovery.GrepInvert != "" {
+				fmt.Fprintf(out, "- GrepInvert '%v'\n", configuration.Spec.Discovery.GrepInvert)
+			}
 			fmt.Fprintf(out, "- ApplyTimeout %v\n", configuration.Spec.Timeouts.Apply.Duration)
 			fmt.Fprintf(out, "- AssertTimeout %v\n", configuration.Spec.Timeouts.Assert.Duration)
 			fmt.Fprintf(out, "- CleanupTimeout %v\n", configuration.Spec.Timeouts.Cleanup.Duration)
 			fmt.Fprintf(out, "- DeleteTimeout %v\n", configuration.Spec.Timeouts.Delete.Duration)
 			fmt.Fprintf(out, "- ErrorTimeout %v\n", configuration.Spec.Timeouts.Error.Duration)
 			fmt.Fprintf(out, "- ExecTimeout %v\n", configuration.Spec.Timeouts.Exec.Duration)
+			if configuration.Spec.Execution.Timeout != nil {
+				fmt.Fprintf(out, "- Timeout %v\n", configuration.Spec.Execution.Timeout.Duration)
+			}
 			fmt.Fprintf(out, "- DeletionPropagationPolicy %v\n", configuration.Spec.Deletion.Propagation)
 			if configuration.Spec.Execution.Parallel != nil && *configuration.Spec.Execution.Parallel > 0 {
 				fmt.Fprintf(out, "- Parallel %d\n", *configuration.Spec.Execution.Parallel)
 			}
+			if configuration.Spec.Execution.ParallelCommands != nil {
+				fmt.Fprintf(out, "- ParallelCommands %d\n", *configuration.Spec.Execution.ParallelCommands)
+			}
+			if configuration.Spec.Execution.ForceSerial {
+				fmt.Fprintf(out, "- ForceSerial %v\n", configuration.Spec.Execution.ForceSerial)
+			}
+			if configuration.Spec.Execution.ForceParallel {
+				fmt.Fprintf(out, "- ForceParallel %v\n", configuration.Spec.Execution.ForceParallel)
+			}
 			if configuration.Spec.Execution.RepeatCount != nil {
 				fmt.Fprintf(out, "- RepeatCount %v\n", *configuration.Spec.Execution.RepeatCount)
 			}
 			if configuration.Spec.Execution.ForceTerminationGracePeriod != nil {
 				fmt.Fprintf(out, "- ForceTerminationGracePeriod %v\n", configuration.Spec.Execution.ForceTerminationGracePeriod.Duration)
 			}
+			fmt.Fprintf(out, "- Seed %d\n", *configuration.Spec.Execution.Seed)
+			if configuration.Spec.Execution.StopOnFirstFailure {
+				fmt.Fprintf(out, "- StopOnFirstFailure %v\n", configuration.Spec.Execution.StopOnFirstFailure)
+			}
+			if configuration.Spec.Execution.PodLogsDefaultContainer {
+				fmt.Fprintf(out, "- PodLogsDefaultContainer %v\n", configuration.Spec.Execution.PodLogsDefaultContainer)
+			}
 			if configuration.Spec.Cleanup.DelayBeforeCleanup != nil {
 				fmt.Fprintf(out, "- DelayBeforeCleanup %v\n", configuration.Spec.Cleanup.DelayBeforeCleanup.Duration)
 			}
@@ -270,19 +503,44 @@ func Command() *cobra.Command {
 				fmt.Fprintf(out, "- Default compiler %v\n", *configuration.Spec.Templating.Compiler)
 			}
 			if len(configuration.Spec.Clusters) != 0 {
-				fmt.Fprintf(out, "- Clusters %v\n", configuration.Spec.Clusters)
+				fmt.Fprintf(out, "- Clusters %v\n", redactClusters(configuration.Spec.Clusters))
 			}
 			if options.remarshal {
 				fmt.Fprintf(out, "- Remarshal %v\n", options.remarshal)
 			}
 			fmt.Fprintf(out, "- NoCluster %v\n", options.noCluster)
+			if options.record != "" {
+				fmt.Fprintf(out, "- Record '%v'\n", options.record)
+			}
+			if options.replay != "" {
+				fmt.Fprintf(out, "- Replay '%v'\n", options.replay)
+			}
 			fmt.Fprintf(out, "- PauseOnFailure %v\n", options.pauseOnFailure)
 			if options.shardCount > 0 {
 				fmt.Fprintf(out, "- Shard %v / %v\n", options.shardIndex, options.shardCount)
 			}
+			if options.onlyFailed != "" {
+				fmt.Fprintf(out, "- OnlyFailed '%v'\n", options.onlyFailed)
+			}
 			// load tests
 			fmt.Fprintln(out, "Loading tests...")
-			if err := fsutils.CheckFolders(options.testDirs...); err != nil {
+			if configuration.Spec.Proxy != nil {
+				httputils.SetProxy(configuration.Spec.Proxy.URL)
+			}
+			testDirs := make([]string, 0, len(options.testDirs))
+			for _, testDir := range options.testDirs {
+				if !discovery.IsRemote(testDir) {
+					testDirs = append(testDirs, testDir)
+					continue
+				}
+				dir, cleanup, err := discovery.Fetch(context.Background(), testDir)
+				if err != nil {
+					return err
+				}
+				defer cleanup()
+				testDirs = append(testDirs, dir)
+			}
+			if err := fsutils.CheckFolders(testDirs...); err != nil {
 				return err
 			}
 			var selector labels.Selector
@@ -293,10 +551,22 @@ func Command() *cobra.Command {
 				}
 				selector = parsed
 			}
-			tests, err := discovery.DiscoverTests(configuration.Spec.Discovery.TestFile, selector, options.remarshal, options.testDirs...)
+			tests, err := discovery.DiscoverTests(configuration.Spec.Discovery.TestFile, selector, options.remarshal, testDirs...)
 			if err != nil {
 				return err
 			}
+			if options.onlyFailed != "" {
+				failed, err := report.LoadFailed(options.onlyFailed)
+				if err != nil {
+					return fmt.Errorf("failed to load report %s: %w", options.onlyFailed, err)
+				}
+				tests = onlyFailed(tests, failed)
+				if len(tests) == 0 {
+					fmt.Fprintln(out, "No failed tests to run.")
+					fmt.Fprintln(out, "Done.")
+					return nil
+				}
+			}
 			// TODO: we may want to find a sort key here ?
 			if options.shardCount > 0 && options.shardIndex < options.shardCount {
 				shardLen := float64(len(tests)) / float64(options.shardCount)
@@ -322,28 +592,85 @@ func Command() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			// create the run artifacts directory, the report is written into it
+			if configuration.Spec.Output != nil && configuration.Spec.Output.Dir != "" {
+				dir, err := output.Prepare(configuration.Spec.Output.Dir, clock.Now(), configuration.Spec.Output.Retention)
+				if err != nil {
+					return err
+				}
+				if configuration.Spec.Report == nil {
+					configuration.Spec.Report = &v1alpha2.ReportOptions{
+						Format: v1alpha2.JSONFormat,
+						Name:   "chainsaw-report",
+					}
+				}
+				if !filepath.IsAbs(configuration.Spec.Report.Path) {
+					configuration.Spec.Report.Path = filepath.Join(dir, configuration.Spec.Report.Path)
+				}
+				fmt.Fprintf(out, "Writing run artifacts to %s\n", dir)
+			}
 			// run tests
 			fmt.Fprintln(out, "Running tests...")
 			var restConfig *rest.Config
-			if !options.noCluster {
+			if options.replay != "" {
+				// no cluster is needed, client interactions are answered by the cassette
+				restConfig = &rest.Config{}
+			} else if !options.noCluster {
 				cfg, err := restutils.DefaultConfig(options.kubeConfigOverrides)
 				if err != nil {
 					return err
 				}
 				restConfig = cfg
 			}
-			ctx := failer.IntoContext(context.Background(), failer.New(options.pauseOnFailure))
+			ctx, cancel := signalutils.Context(context.Background(), options.shutdownGracePeriod.Duration, out)
+			defer cancel()
+			if options.replay != "" {
+				ctx = cassette.IntoContext(ctx, recording, cassette.Replay)
+			} else if options.record != "" {
+				ctx = cassette.IntoContext(ctx, recording, cassette.Record)
+			}
+			ctx = failer.IntoContext(ctx, failer.New(options.pauseOnFailure))
+			// the progress view degrades to plain logging when not attached to a terminal
+			var program *tui.Program
+			if options.tui {
+				if tui.IsTerminal(out) {
+					program = tui.New(out, clock)
+					if err := program.Start(); err != nil {
+						return err
+					}
+					defer program.Stop()
+					ctx = events.IntoContext(ctx, program)
+				} else {
+					fmt.Fprintln(out, "Not attached to a terminal, progress view disabled.")
+				}
+			}
 			summary, err := runner.Run(ctx, restConfig, clock, configuration.Spec, values, testToRun...)
+			// the view is stopped before printing the summary, the deferred call covers early returns
+			if program != nil {
+				program.Stop()
+			}
+			if options.record != "" {
+				if err := recording.Save(options.record); err != nil {
+					return err
+				}
+			}
 			if summary != nil {
 				fmt.Fprintln(out, "Tests Summary...")
 				fmt.Fprintln(out, "- Passed  tests", summary.Passed())
 				fmt.Fprintln(out, "- Failed  tests", summary.Failed())
 				fmt.Fprintln(out, "- Skipped tests", summary.Skipped())
+				if flaky := summary.Flaky(); len(flaky) != 0 {
+					fmt.Fprintln(out, "- Flaky   tests", len(flaky))
+					for _, test := range flaky {
+						fmt.Fprintln(out, "  -", test)
+					}
+				}
 			}
 			if err != nil {
 				fmt.Fprintln(out, "Done with error.")
 			} else if summary != nil && summary.Failed() > 0 {
 				fmt.Fprintln(out, "Done with failures.")
+				category = summary.Failure()
 				err = errors.New("some tests failed")
 			} else {
 				fmt.Fprintln(out, "Done.")
@@ -357,7 +684,8 @@ func Command() *cobra.Command {
 	}
 	// config
 	cmd.Flags().StringVar(&options.config, "config", "", "Chainsaw configuration file")
-	cmd.Flags().StringSliceVar(&options.testDirs, "test-dir", nil, "Directories containing test cases to run")
+	cmd.Flags().StringVar(&options.dumpConfig, "dump-config", "", "Writes the effective configuration (after defaults and flags are applied) to the given file (JSON if the file has a .json extension, YAML otherwise) and exits without running tests")
+	cmd.Flags().StringSliceVar(&options.testDirs, "test-dir", nil, "Directories, archives or OCI artifacts containing test cases to run")
 	clientcmd.BindOverrideFlags(&options.kubeConfigOverrides, cmd.Flags(), clientcmd.RecommendedConfigOverrideFlags("kube-"))
 	// timeouts options
 	cmd.Flags().DurationVar(&options.applyTimeout.Duration, "apply-timeout", config.Spec.Timeouts.Apply.Duration, "The apply timeout to use as default for configuration")
@@ -366,16 +694,25 @@ func Command() *cobra.Command {
 	cmd.Flags().DurationVar(&options.deleteTimeout.Duration, "delete-timeout", config.Spec.Timeouts.Delete.Duration, "The delete timeout to use as default for configuration")
 	cmd.Flags().DurationVar(&options.errorTimeout.Duration, "error-timeout", config.Spec.Timeouts.Error.Duration, "The error timeout to use as default for configuration")
 	cmd.Flags().DurationVar(&options.execTimeout.Duration, "exec-timeout", config.Spec.Timeouts.Exec.Duration, "The exec timeout to use as default for configuration")
+	cmd.Flags().DurationVar(&options.timeoutAll.Duration, "timeout-all", 0, "The maximum duration of the whole run (running tests are failed when exceeded)")
 	// discovery options
 	cmd.Flags().StringVar(&options.testFile, "test-file", "chainsaw-test", "Name of the test file")
 	cmd.Flags().BoolVar(&options.fullName, "full-name", false, "Use full test case folder path instead of folder name")
 	cmd.Flags().StringVar(&options.includeTestRegex, "include-test-regex", "", "Regular expression to include tests")
 	cmd.Flags().StringVar(&options.excludeTestRegex, "exclude-test-regex", "", "Regular expression to exclude tests")
+	cmd.Flags().StringVar(&options.grep, "grep", "", "Regular expression matched against test names to select the tests to run")
+	cmd.Flags().StringVar(&options.grepInvert, "grep-invert", "", "Regular expression matched against test names to exclude tests from the run")
 	// execution options
 	cmd.Flags().BoolVar(&options.failFast, "fail-fast", false, "Stop the test upon encountering the first failure")
+	cmd.Flags().BoolVar(&options.failFastWithinTest, "fail-fast-within-test", false, "Skip the remaining steps of a test once a step failed")
 	cmd.Flags().IntVar(&options.parallel, "parallel", 0, "The maximum number of tests to run at once")
+	cmd.Flags().IntVar(&options.parallelCommands, "parallel-commands", 0, "The maximum number of external commands (commands, scripts and collectors) to run at once")
+	cmd.Flags().StringVar(&options.parallelTests, "parallel-tests", "", "Force all tests to run in parallel or serially regardless of their concurrent setting (parallel or serial)")
 	cmd.Flags().IntVar(&options.repeatCount, "repeat-count", 1, "Number of times to repeat each test")
 	cmd.Flags().DurationVar(&options.forceTerminationGracePeriod.Duration, "force-termination-grace-period", 0, "If specified, overrides termination grace periods in applicable resources")
+	cmd.Flags().BoolVar(&options.podLogsDefaultContainer, "pod-logs-default-container", false, "Collect pod logs from the container selected by the kubectl.kubernetes.io/default-container annotation when no container is specified")
+	cmd.Flags().Int64Var(&options.seed, "seed", 0, "Seed used to initialize the random generator (makes random behaviors reproducible)")
+	cmd.Flags().DurationVar(&options.shutdownGracePeriod.Duration, "shutdown-grace-period", time.Minute, "Time given to cleanup to complete after the run was interrupted (a second interrupt exits immediately)")
 	// namespace options
 	cmd.Flags().StringVar(&options.namespace, "namespace", "", "Namespace to use for tests")
 	// templating options
@@ -383,6 +720,7 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVar(&options.defaultCompiler, "default-compiler", "", "If set, configures the default compiler (jp or cel)")
 	// cleanup options
 	cmd.Flags().BoolVar(&options.skipDelete, "skip-delete", false, "If set, do not delete the resources after running the tests")
+	cmd.Flags().StringVar(&options.skipDeleteIf, "skip-delete-if", "", "Condition evaluated against the bindings, if it evaluates to true the resources are not deleted after running the tests")
 	cmd.Flags().DurationVar(&options.delayBeforeCleanup.Duration, "cleanup-delay", 0, "Adds a delay between the time a test ends and the time cleanup starts")
 	// deletion options
 	cmd.Flags().StringVar(&options.deletionPropagationPolicy, "deletion-propagation-policy", "Background", "The deletion propagation policy (Foreground|Background|Orphan)")
@@ -391,12 +729,32 @@ func Command() *cobra.Command {
 	cmd.Flags().StringVar(&options.reportFormat, "report-format", "", "Test report format (JSON|XML|JUNIT-TEST|JUNIT-STEP|JUNIT-OPERATION)")
 	cmd.Flags().StringVar(&options.reportName, "report-name", "chainsaw-report", "The name of the report to create")
 	cmd.Flags().StringVar(&options.reportPath, "report-path", "", "The path of the report to create")
+	cmd.Flags().StringToStringVar(&options.junitProperties, "junit-properties", nil, "Properties embedded in JUnit reports (format <name>=<value>)")
+	// notification options
+	cmd.Flags().StringVar(&options.notifyURL, "notify-url", "", "If set, posts a summary of the run to the given webhook URL")
+	cmd.Flags().StringVar(&options.notifyTemplate, "notify-template", "", "Go template used to render the notification body (defaults to a Slack compatible payload)")
+	// proxy options
+	cmd.Flags().StringVar(&options.proxyURL, "proxy-url", "", "If set, uses the given proxy for remote fetches and Kubernetes clients (takes precedence over HTTP_PROXY and HTTPS_PROXY)")
+	// output options
+	cmd.Flags().StringVar(&options.outputDir, "output-dir", "", "If set, creates a directory for the artifacts (report) of every run in the given directory")
+	cmd.Flags().IntVar(&options.outputMaxRuns, "output-max-runs", 0, "Maximum number of run artifacts directories kept in the output directory (older ones are pruned when a run starts)")
+	cmd.Flags().DurationVar(&options.outputMaxAge.Duration, "output-max-age", 0, "Maximum age of run artifacts directories kept in the output directory (older ones are pruned when a run starts)")
+	// tracing options
+	cmd.Flags().StringVar(&options.otlpEndpoint, "otlp-endpoint", "", "If set, exports OpenTelemetry traces of the run to the given OTLP (gRPC) endpoint")
+	// client options
+	cmd.Flags().IntVar(&options.kubeAPIQPS, "kube-api-qps", config.Spec.Client.QPS, "Maximum number of queries per second sent to the api server by a client")
+	cmd.Flags().IntVar(&options.kubeAPIBurst, "kube-api-burst", config.Spec.Client.Burst, "Maximum burst of queries sent to the api server by a client")
+	cmd.Flags().IntVar(&options.kubeAPIConnectionAttempts, "kube-api-connection-attempts", 0, "If set, checks the api server is reachable before running tests, retrying up to the given number of attempts")
+	cmd.Flags().DurationVar(&options.kubeAPIConnectionInterval.Duration, "kube-api-connection-interval", 0, "The delay before retrying the initial connection to the api server, doubled after every failed attempt (defaults to 1s)")
 	// multi-cluster options
 	cmd.Flags().StringSliceVar(&options.clusters, "cluster", nil, "Register cluster (format <cluster name>=<kubeconfig path>:[context name])")
 	// pause options
 	cmd.Flags().BoolVar(&options.pauseOnFailure, "pause-on-failure", false, "Pause test execution failure (implies no concurrency)")
 	// no cluster options
 	cmd.Flags().BoolVar(&options.noCluster, "no-cluster", false, "Runs without cluster")
+	// record and replay options
+	cmd.Flags().StringVar(&options.record, "record", "", "If set, records the cluster client interactions of the run in the given cassette file (secret values are not recorded)")
+	cmd.Flags().StringVar(&options.replay, "replay", "", "If set, replays the cluster client interactions recorded in the given cassette file instead of connecting to a cluster")
 	// label selectors
 	cmd.Flags().StringSliceVar(&options.selector, "selector", nil, "Selector (label query) to filter on")
 	// external values
@@ -404,11 +762,34 @@ func Command() *cobra.Command {
 	// sharding
 	cmd.Flags().IntVar(&options.shardIndex, "shard-index", 0, "Current shard index (if `--shard-count` > 0)")
 	cmd.Flags().IntVar(&options.shardCount, "shard-count", 0, "Number of shards")
+	// rerun options
+	cmd.Flags().StringVar(&options.onlyFailed, "only-failed", "", "Path to a previous JSON report, only the tests that failed in this report are run")
 	// others
 	cmd.Flags().BoolVar(&options.noColor, "no-color", false, "Removes output colors")
 	cmd.Flags().BoolVar(&options.remarshal, "remarshal", false, "Remarshals tests yaml to apply anchors before parsing")
+	cmd.Flags().BoolVar(&options.tui, "tui", false, "Shows a live progress view of the running tests (requires a terminal)")
 	if err := cmd.MarkFlagFilename("config"); err != nil {
 		panic(err)
 	}
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
+	cmd.MarkFlagsMutuallyExclusive("replay", "no-cluster")
 	return cmd
 }
+
+// onlyFailed filters tests to keep only the ones reported as failed.
+func onlyFailed(tests []discovery.Test, failed []report.TestRef) []discovery.Test {
+	refs := map[report.TestRef]struct{}{}
+	for _, ref := range failed {
+		refs[ref] = struct{}{}
+	}
+	var filtered []discovery.Test
+	for _, test := range tests {
+		if test.Test == nil {
+			continue
+		}
+		if _, ok := refs[report.TestRef{BasePath: test.BasePath, Name: test.Test.Name}]; ok {
+			filtered = append(filtered, test)
+		}
+	}
+	return filtered
+}
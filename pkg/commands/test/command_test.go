@@ -7,10 +7,17 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/chainsaw/pkg/report"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestChainsawCommand(t *testing.T) {
+	defaultSeed := newSeed
+	newSeed = func() int64 { return 1 }
+	t.Cleanup(func() { newSeed = defaultSeed })
 	basePath := "../../../testdata/commands/test"
 	tests := []struct {
 		name    string
@@ -63,6 +70,15 @@ func TestChainsawCommand(t *testing.T) {
 		},
 		wantErr: false,
 		out:     filepath.Join(basePath, "with_test_dirs.txt"),
+	}, {
+		name: "invalid output max runs",
+		args: []string{
+			"--output-dir",
+			"artifacts",
+			"--output-max-runs",
+			"0",
+		},
+		wantErr: true,
 	}, {
 		name: "nonexistent config file",
 		args: []string{
@@ -119,6 +135,21 @@ func TestChainsawCommand(t *testing.T) {
 		},
 		wantErr: false,
 		out:     filepath.Join(basePath, "config_all_fields.txt"),
+	}, {
+		name: "only failed without failures",
+		args: []string{
+			"--only-failed",
+			"../../../testdata/report/passed.json",
+		},
+		wantErr: false,
+		out:     filepath.Join(basePath, "with_only_failed.txt"),
+	}, {
+		name: "only failed with missing report",
+		args: []string{
+			"--only-failed",
+			"../../../testdata/report/not-found.json",
+		},
+		wantErr: true,
 	}, {
 		name: "all flags",
 		args: []string{
@@ -141,6 +172,7 @@ func TestChainsawCommand(t *testing.T) {
 			"--include-test-regex=^.*$",
 			"--exclude-test-regex=^.*$",
 			"--force-termination-grace-period=5s",
+			"--seed=42",
 		},
 		wantErr: false,
 		out:     filepath.Join(basePath, "all_flags.txt"),
@@ -177,3 +209,25 @@ func TestChainsawCommand(t *testing.T) {
 		})
 	}
 }
+
+func Test_onlyFailed(t *testing.T) {
+	test := func(basePath, name string) discovery.Test {
+		return discovery.Test{
+			BasePath: basePath,
+			Test:     &model.Test{ObjectMeta: metav1.ObjectMeta{Name: name}},
+		}
+	}
+	tests := []discovery.Test{
+		test("tests/foo", "foo"),
+		test("tests/bar", "bar"),
+		test("tests/baz", "baz"),
+		test("tests/qux", "qux"),
+		test("other/foo", "foo"),
+	}
+	failed, err := report.LoadFailed("../../../testdata/report/failed.json")
+	assert.NoError(t, err)
+	assert.Equal(t, []discovery.Test{test("tests/foo", "foo"), test("tests/baz", "baz")}, onlyFailed(tests, failed))
+	failed, err = report.LoadFailed("../../../testdata/report/passed.json")
+	assert.NoError(t, err)
+	assert.Empty(t, onlyFailed(tests, failed))
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+)
+
+// dumpConfig writes the effective configuration to a file, as JSON if the file has a .json extension or as YAML otherwise.
+// The dumped file can be passed back with the --config flag to reproduce a run.
+func dumpConfig(path string, configuration v1alpha2.Configuration) error {
+	configuration.SetGroupVersionKind(v1alpha2.SchemeGroupVersion.WithKind("Configuration"))
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = json.MarshalIndent(configuration, "", "  ")
+	} else {
+		data, err = yaml.Marshal(configuration)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	return nil
+}
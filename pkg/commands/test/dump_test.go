@@ -0,0 +1,50 @@
+package test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dumpConfig(t *testing.T) {
+	defaults, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	tests := []struct {
+		name string
+		file string
+		args []string
+	}{{
+		name: "yaml",
+		file: "config.yaml",
+	}, {
+		name: "json",
+		file: "config.json",
+	}, {
+		name: "with flags",
+		file: "config.yaml",
+		args: []string{"--apply-timeout", "10s"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			cmd := Command()
+			cmd.SetArgs(append([]string{"--dump-config", path}, tt.args...))
+			stdout := bytes.NewBufferString("")
+			cmd.SetOut(stdout)
+			assert.NoError(t, cmd.Execute())
+			assert.Contains(t, stdout.String(), "Configuration written to "+path)
+			assert.NotContains(t, stdout.String(), "Running tests...")
+			dumped, err := config.Load(path)
+			assert.NoError(t, err)
+			expected := defaults.Spec.DeepCopy()
+			if len(tt.args) != 0 {
+				expected.Timeouts.Apply.Duration = 10 * time.Second
+			}
+			assert.Equal(t, *expected, dumped.Spec)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package test
+
+import (
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/model"
+)
+
+const (
+	defaultTestFailureExitCode  = 1
+	defaultSetupFailureExitCode = 2
+	defaultTimeoutExitCode      = 3
+)
+
+// exitError carries the process exit code corresponding to the failure category of a run.
+type exitError struct {
+	err  error
+	code int
+}
+
+func (e exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e exitError) Unwrap() error {
+	return e.err
+}
+
+func (e exitError) ExitCode() int {
+	return e.code
+}
+
+// exitCode returns the exit code configured for the given failure category, unset codes fall back to the defaults.
+func exitCode(options *v1alpha2.ExitCodeOptions, category model.FailureCategory) int {
+	if options == nil {
+		options = &v1alpha2.ExitCodeOptions{}
+	}
+	pick := func(code int, def int) int {
+		if code == 0 {
+			return def
+		}
+		return code
+	}
+	switch category {
+	case model.FailureCategorySetup:
+		return pick(options.SetupFailure, defaultSetupFailureExitCode)
+	case model.FailureCategoryTimeout:
+		return pick(options.Timeout, defaultTimeoutExitCode)
+	default:
+		return pick(options.TestFailure, defaultTestFailureExitCode)
+	}
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_exitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *v1alpha2.ExitCodeOptions
+		category model.FailureCategory
+		want     int
+	}{{
+		name:     "assertion failure",
+		category: model.FailureCategoryTest,
+		want:     1,
+	}, {
+		name:     "setup failure",
+		category: model.FailureCategorySetup,
+		want:     2,
+	}, {
+		name:     "timeout",
+		category: model.FailureCategoryTimeout,
+		want:     3,
+	}, {
+		name:     "partially configured",
+		options:  &v1alpha2.ExitCodeOptions{TestFailure: 10},
+		category: model.FailureCategoryTimeout,
+		want:     3,
+	}, {
+		name: "configured assertion failure",
+		options: &v1alpha2.ExitCodeOptions{
+			TestFailure:  10,
+			SetupFailure: 20,
+			Timeout:      30,
+		},
+		category: model.FailureCategoryTest,
+		want:     10,
+	}, {
+		name: "configured setup failure",
+		options: &v1alpha2.ExitCodeOptions{
+			TestFailure:  10,
+			SetupFailure: 20,
+			Timeout:      30,
+		},
+		category: model.FailureCategorySetup,
+		want:     20,
+	}, {
+		name: "configured timeout",
+		options: &v1alpha2.ExitCodeOptions{
+			TestFailure:  10,
+			SetupFailure: 20,
+			Timeout:      30,
+		},
+		category: model.FailureCategoryTimeout,
+		want:     30,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCode(tt.options, tt.category))
+		})
+	}
+}
+
+func Test_exitError(t *testing.T) {
+	cause := errors.New("some tests failed")
+	var err error = exitError{err: cause, code: 2}
+	assert.Equal(t, "some tests failed", err.Error())
+	assert.ErrorIs(t, err, cause)
+	var coder interface{ ExitCode() int }
+	assert.True(t, errors.As(err, &coder))
+	assert.Equal(t, 2, coder.ExitCode())
+}
@@ -0,0 +1,43 @@
+package test
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+)
+
+// redactNotifyURL keeps only the scheme and host of a webhook url, webhook paths and queries usually carry a secret token.
+func redactNotifyURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return "<redacted>"
+	}
+	return (&url.URL{Scheme: parsed.Scheme, Host: parsed.Host}).String()
+}
+
+// redactProxyURL masks the password of a proxy url.
+func redactProxyURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "<redacted>"
+	}
+	return parsed.Redacted()
+}
+
+// redactClusters lists the cluster names with their kubeconfig paths, inline kubeconfigs carry credentials and are masked.
+func redactClusters(clusters v1alpha1.Clusters) string {
+	var entries []string
+	for _, name := range slices.Sorted(maps.Keys(clusters)) {
+		cluster := clusters[name]
+		kubeconfig := cluster.Kubeconfig
+		if cluster.KubeconfigData != "" {
+			kubeconfig = "<redacted>"
+		}
+		entries = append(entries, fmt.Sprintf("%s=%s", name, kubeconfig))
+	}
+	return "[" + strings.Join(entries, " ") + "]"
+}
@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/discovery"
+	fsutils "github.com/kyverno/chainsaw/pkg/utils/fs"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	testFile  string
+	testDirs  []string
+	remarshal bool
+}
+
+func Command() *cobra.Command {
+	var options options
+	cmd := &cobra.Command{
+		Use:          "validate [flags]... [test directories]...",
+		Short:        "Validate tests without running them",
+		Long:         "Use chainsaw validate to load tests and report errors that can be detected without a cluster (invalid documents, missing files, malformed selectors and durations).",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			testDirs := append(options.testDirs, args...)
+			if len(testDirs) == 0 {
+				testDirs = append(testDirs, ".")
+			}
+			return validate(cmd.OutOrStdout(), options.testFile, options.remarshal, testDirs...)
+		},
+	}
+	cmd.Flags().StringVar(&options.testFile, "test-file", "chainsaw-test", "Name of the test file")
+	cmd.Flags().StringSliceVar(&options.testDirs, "test-dir", nil, "Directories containing test cases to validate")
+	cmd.Flags().BoolVar(&options.remarshal, "remarshal", false, "Remarshals tests yaml to apply anchors before parsing")
+	return cmd
+}
+
+func validate(out io.Writer, testFile string, remarshal bool, paths ...string) error {
+	if err := fsutils.CheckFolders(paths...); err != nil {
+		return err
+	}
+	folders, err := fsutils.DiscoverFolders(paths...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Validating tests...")
+	count := 0
+	for _, folder := range folders {
+		file := testFilePath(folder, testFile)
+		tests, err := discovery.LoadTest(testFile, folder, remarshal)
+		if err != nil {
+			fmt.Fprintf(out, "- %s\n", file)
+			fmt.Fprintf(out, "  * %s\n", err)
+			count++
+			continue
+		}
+		for _, test := range tests {
+			fmt.Fprintf(out, "- %s (%s)\n", test.Test.Name, file)
+			for _, err := range validateTest(test.BasePath, test.Test) {
+				fmt.Fprintf(out, "  * %s\n", err)
+				count++
+			}
+		}
+	}
+	if count != 0 {
+		fmt.Fprintln(out, "Done with errors.")
+		return fmt.Errorf("found %d validation error(s)", count)
+	}
+	fmt.Fprintln(out, "Done.")
+	return nil
+}
+
+// testFilePath returns the path of the test file in a folder, or the folder itself if there's no test file.
+func testFilePath(folder string, testFile string) string {
+	candidates := []string{testFile}
+	if filepath.Ext(testFile) == "" {
+		candidates = []string{testFile + ".yaml", testFile + ".yml"}
+	}
+	for _, candidate := range candidates {
+		if _, err := os.Stat(filepath.Join(folder, candidate)); err == nil {
+			return filepath.Join(folder, candidate)
+		}
+	}
+	return folder
+}
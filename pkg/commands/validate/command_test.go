@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/commands/root"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Execute(t *testing.T) {
+	basePath := "../../../testdata/commands/validate"
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+		out     string
+	}{{
+		name: "help",
+		args: []string{
+			"validate",
+			"--help",
+		},
+		out:     filepath.Join(basePath, "help.txt"),
+		wantErr: false,
+	}, {
+		name: "valid",
+		args: []string{
+			"validate",
+			"--test-dir",
+			filepath.Join(basePath, "valid"),
+		},
+		out:     filepath.Join(basePath, "valid.txt"),
+		wantErr: false,
+	}, {
+		name: "invalid",
+		args: []string{
+			"validate",
+			"--test-dir",
+			filepath.Join(basePath, "invalid"),
+		},
+		out:     filepath.Join(basePath, "invalid.txt"),
+		wantErr: true,
+	}, {
+		name: "malformed",
+		args: []string{
+			"validate",
+			filepath.Join(basePath, "malformed"),
+		},
+		out:     filepath.Join(basePath, "malformed.txt"),
+		wantErr: true,
+	}, {
+		name: "not found",
+		args: []string{
+			"validate",
+			filepath.Join(basePath, "not-found"),
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := root.Command()
+			cmd.AddCommand(Command())
+			assert.NotNil(t, cmd)
+			cmd.SetArgs(tt.args)
+			out := bytes.NewBufferString("")
+			cmd.SetOut(out)
+			err := cmd.Execute()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			actual, err := io.ReadAll(out)
+			assert.NoError(t, err)
+			if tt.out != "" {
+				expected, err := os.ReadFile(tt.out)
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(actual))
+			}
+		})
+	}
+}
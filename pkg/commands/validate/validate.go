@@ -0,0 +1,355 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/annotations"
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/chainsaw/pkg/loaders/resource"
+	"github.com/kyverno/chainsaw/pkg/loaders/schema"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validateTest checks a loaded test for errors that can be detected without a cluster.
+// Values using expressions can't be resolved statically and are skipped.
+func validateTest(basePath string, test *v1alpha1.Test) field.ErrorList {
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	errs = append(errs, validateClusters(basePath, path.Child("clusters"), test.Spec.Clusters)...)
+	for i, step := range test.Spec.Steps {
+		path := path.Child("steps").Index(i)
+		errs = append(errs, validateClusters(basePath, path.Child("clusters"), step.Clusters)...)
+		for j, operation := range step.Try {
+			errs = append(errs, validateOperation(basePath, path.Child("try").Index(j), operation)...)
+		}
+		for j, operation := range step.Catch {
+			errs = append(errs, validateCatchFinally(basePath, path.Child("catch").Index(j), operation)...)
+		}
+		for j, operation := range step.Finally {
+			errs = append(errs, validateCatchFinally(basePath, path.Child("finally").Index(j), operation)...)
+		}
+		for j, operation := range step.Cleanup {
+			errs = append(errs, validateCatchFinally(basePath, path.Child("cleanup").Index(j), operation)...)
+		}
+	}
+	return errs
+}
+
+func validateClusters(basePath string, path *field.Path, clusters v1alpha1.Clusters) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(clusters)) {
+		path, cluster := path.Key(name), clusters[name]
+		switch {
+		case cluster.Kubeconfig == "" && cluster.KubeconfigData == "":
+			errs = append(errs, field.Required(path, "one of kubeconfig or kubeconfigData is required"))
+		case cluster.Kubeconfig != "" && cluster.KubeconfigData != "":
+			errs = append(errs, field.Forbidden(path.Child("kubeconfigData"), "kubeconfig and kubeconfigData are mutually exclusive"))
+		default:
+			errs = append(errs, validateFile(basePath, path.Child("kubeconfig"), cluster.Kubeconfig)...)
+		}
+	}
+	return errs
+}
+
+func validateOperation(basePath string, path *field.Path, operation v1alpha1.Operation) field.ErrorList {
+	var errs field.ErrorList
+	if operation.Apply != nil {
+		path := path.Child("apply")
+		errs = append(errs, validateTimeout(path, operation.Apply.ActionTimeout)...)
+		if operation.Apply.Kustomize != "" {
+			if operation.Apply.File != "" || operation.Apply.Resource != nil {
+				errs = append(errs, field.Forbidden(path.Child("kustomize"), "kustomize cannot be used with file or resource"))
+			}
+			errs = append(errs, validateFile(basePath, path.Child("kustomize"), string(operation.Apply.Kustomize))...)
+		} else {
+			errs = append(errs, validateResourceRef(basePath, path, operation.Apply.ActionResourceRef)...)
+		}
+	}
+	if operation.Assert != nil {
+		path := path.Child("assert")
+		errs = append(errs, validateTimeout(path, operation.Assert.ActionTimeout)...)
+		errs = append(errs, validateCheckRef(basePath, path, operation.Assert.ActionCheckRef)...)
+		errs = append(errs, validateAnnotationSelector(path, operation.Assert.ActionAnnotationSelector)...)
+		if file := string(operation.Assert.Schema); file != "" && !isExpression(file) {
+			path := path.Child("schema")
+			if _, err := schema.Load(resolve(basePath, file)); errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, field.NotFound(path, file))
+			} else if err != nil {
+				errs = append(errs, field.Invalid(path, file, err.Error()))
+			}
+		}
+	}
+	if operation.Chart != nil {
+		path := path.Child("chart")
+		errs = append(errs, validateTimeout(path, operation.Chart.ActionTimeout)...)
+		errs = append(errs, validateFile(basePath, path.Child("path"), operation.Chart.Path)...)
+	}
+	if operation.Command != nil {
+		errs = append(errs, validateTimeout(path.Child("command"), operation.Command.ActionTimeout)...)
+	}
+	if operation.Create != nil {
+		path := path.Child("create")
+		errs = append(errs, validateTimeout(path, operation.Create.ActionTimeout)...)
+		errs = append(errs, validateResourceRef(basePath, path, operation.Create.ActionResourceRef)...)
+	}
+	if operation.Delete != nil {
+		errs = append(errs, validateDelete(basePath, path.Child("delete"), *operation.Delete)...)
+	}
+	if operation.Describe != nil {
+		path := path.Child("describe")
+		errs = append(errs, validateTimeout(path, operation.Describe.ActionTimeout)...)
+		errs = append(errs, validateObjectSelector(path, operation.Describe.ActionObjectSelector)...)
+		errs = append(errs, validateAnnotationSelector(path, operation.Describe.ActionAnnotationSelector)...)
+	}
+	if operation.Error != nil {
+		path := path.Child("error")
+		errs = append(errs, validateTimeout(path, operation.Error.ActionTimeout)...)
+		errs = append(errs, validateCheckRef(basePath, path, operation.Error.ActionCheckRef)...)
+	}
+	if operation.Events != nil {
+		errs = append(errs, validateEvents(path.Child("events"), *operation.Events)...)
+	}
+	if operation.Exec != nil {
+		path := path.Child("exec")
+		errs = append(errs, validateTimeout(path, operation.Exec.ActionTimeout)...)
+		errs = append(errs, validateObjectSelector(path, operation.Exec.ActionObjectSelector)...)
+	}
+	if operation.Get != nil {
+		errs = append(errs, validateGet(path.Child("get"), *operation.Get)...)
+	}
+	if operation.Patch != nil {
+		path := path.Child("patch")
+		errs = append(errs, validateTimeout(path, operation.Patch.ActionTimeout)...)
+		errs = append(errs, validateResourceRef(basePath, path, operation.Patch.ActionResourceRef)...)
+	}
+	if operation.PodLogs != nil {
+		errs = append(errs, validatePodLogs(path.Child("podLogs"), *operation.PodLogs)...)
+	}
+	if operation.Proxy != nil {
+		errs = append(errs, validateTimeout(path.Child("proxy"), operation.Proxy.ActionTimeout)...)
+	}
+	if operation.Script != nil {
+		errs = append(errs, validateTimeout(path.Child("script"), operation.Script.ActionTimeout)...)
+	}
+	if operation.Sleep != nil {
+		errs = append(errs, validateSleep(path.Child("sleep"), *operation.Sleep)...)
+	}
+	if operation.Update != nil {
+		path := path.Child("update")
+		errs = append(errs, validateTimeout(path, operation.Update.ActionTimeout)...)
+		errs = append(errs, validateResourceRef(basePath, path, operation.Update.ActionResourceRef)...)
+	}
+	if operation.Wait != nil {
+		errs = append(errs, validateWait(path.Child("wait"), *operation.Wait)...)
+	}
+	return errs
+}
+
+func validateCatchFinally(basePath string, path *field.Path, operation v1alpha1.CatchFinally) field.ErrorList {
+	var errs field.ErrorList
+	if operation.Command != nil {
+		errs = append(errs, validateTimeout(path.Child("command"), operation.Command.ActionTimeout)...)
+	}
+	if operation.Delete != nil {
+		errs = append(errs, validateDelete(basePath, path.Child("delete"), *operation.Delete)...)
+	}
+	if operation.Describe != nil {
+		path := path.Child("describe")
+		errs = append(errs, validateTimeout(path, operation.Describe.ActionTimeout)...)
+		errs = append(errs, validateObjectSelector(path, operation.Describe.ActionObjectSelector)...)
+		errs = append(errs, validateAnnotationSelector(path, operation.Describe.ActionAnnotationSelector)...)
+	}
+	if operation.Events != nil {
+		errs = append(errs, validateEvents(path.Child("events"), *operation.Events)...)
+	}
+	if operation.Get != nil {
+		errs = append(errs, validateGet(path.Child("get"), *operation.Get)...)
+	}
+	if operation.PodLogs != nil {
+		errs = append(errs, validatePodLogs(path.Child("podLogs"), *operation.PodLogs)...)
+	}
+	if operation.Script != nil {
+		errs = append(errs, validateTimeout(path.Child("script"), operation.Script.ActionTimeout)...)
+	}
+	if operation.Sleep != nil {
+		errs = append(errs, validateSleep(path.Child("sleep"), *operation.Sleep)...)
+	}
+	if operation.Wait != nil {
+		errs = append(errs, validateWait(path.Child("wait"), *operation.Wait)...)
+	}
+	return errs
+}
+
+func validateDelete(basePath string, path *field.Path, operation v1alpha1.Delete) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateTimeout(path, operation.ActionTimeout)...)
+	if operation.File != "" {
+		errs = append(errs, validateFileRef(basePath, path, v1alpha1.FileRef{File: operation.File, AllowEmpty: operation.AllowEmpty}, true)...)
+	}
+	if operation.Ref != nil && len(operation.Ref.Labels) != 0 {
+		// label values coming from expressions are only known at execution time
+		literals := map[string]string{}
+		for key, value := range operation.Ref.Labels {
+			if !isExpression(value) {
+				literals[key] = value
+			}
+		}
+		if _, err := labels.ValidatedSelectorFromSet(literals); err != nil {
+			errs = append(errs, field.Invalid(path.Child("ref", "labels"), operation.Ref.Labels, err.Error()))
+		}
+	}
+	if operation.AllNamespaces != nil && *operation.AllNamespaces {
+		if operation.Ref == nil || len(operation.Ref.Labels) == 0 {
+			errs = append(errs, field.Required(path.Child("ref", "labels"), "a label selector is required when deleting across all namespaces"))
+		} else if operation.Ref.Name != "" || operation.Ref.Namespace != "" {
+			errs = append(errs, field.Forbidden(path.Child("ref"), "name and namespace are not allowed when deleting across all namespaces"))
+		}
+	}
+	return errs
+}
+
+func validateEvents(path *field.Path, operation v1alpha1.Events) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateTimeout(path, operation.ActionTimeout)...)
+	errs = append(errs, validateObjectSelector(path, operation.ActionObjectSelector)...)
+	errs = append(errs, validateFieldSelector(path, operation.ActionFieldSelector)...)
+	return errs
+}
+
+func validateGet(path *field.Path, operation v1alpha1.Get) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateTimeout(path, operation.ActionTimeout)...)
+	errs = append(errs, validateObjectSelector(path, operation.ActionObjectSelector)...)
+	errs = append(errs, validateFieldSelector(path, operation.ActionFieldSelector)...)
+	errs = append(errs, validateAnnotationSelector(path, operation.ActionAnnotationSelector)...)
+	return errs
+}
+
+func validatePodLogs(path *field.Path, operation v1alpha1.PodLogs) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateTimeout(path, operation.ActionTimeout)...)
+	errs = append(errs, validateObjectSelector(path, operation.ActionObjectSelector)...)
+	return errs
+}
+
+func validateSleep(path *field.Path, operation v1alpha1.Sleep) field.ErrorList {
+	if operation.Duration.Duration < 0 {
+		return field.ErrorList{field.Invalid(path.Child("duration"), operation.Duration.Duration.String(), "must not be negative")}
+	}
+	return nil
+}
+
+func validateWait(path *field.Path, operation v1alpha1.Wait) field.ErrorList {
+	var errs field.ErrorList
+	errs = append(errs, validateTimeout(path, operation.ActionTimeout)...)
+	errs = append(errs, validateObjectSelector(path, operation.ActionObjectSelector)...)
+	errs = append(errs, validateFieldSelector(path, operation.ActionFieldSelector)...)
+	return errs
+}
+
+func validateTimeout(path *field.Path, timeout v1alpha1.ActionTimeout) field.ErrorList {
+	if timeout.Timeout != nil && timeout.Timeout.Duration < 0 {
+		return field.ErrorList{field.Invalid(path.Child("timeout"), timeout.Timeout.Duration.String(), "must not be negative")}
+	}
+	return nil
+}
+
+func validateResourceRef(basePath string, path *field.Path, ref v1alpha1.ActionResourceRef) field.ErrorList {
+	if ref.File == "" {
+		return nil
+	}
+	return validateFileRef(basePath, path, ref.FileRef, true)
+}
+
+func validateCheckRef(basePath string, path *field.Path, ref v1alpha1.ActionCheckRef) field.ErrorList {
+	if ref.File == "" {
+		return nil
+	}
+	return validateFileRef(basePath, path, ref.FileRef, false)
+}
+
+func validateFileRef(basePath string, path *field.Path, ref v1alpha1.FileRef, manifest bool) field.ErrorList {
+	file := string(ref.File)
+	if expressions.IsTemplatedPath(file) {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(file); err == nil {
+		return nil
+	}
+	path = path.Child("file")
+	if _, err := resource.Load(resolve(basePath, file), manifest); errors.Is(err, resource.ErrNoMatch) {
+		if ref.AllowEmpty == nil || !*ref.AllowEmpty {
+			return field.ErrorList{field.NotFound(path, file)}
+		}
+	} else if err != nil {
+		return field.ErrorList{field.Invalid(path, file, err.Error())}
+	}
+	return nil
+}
+
+func validateFile(basePath string, path *field.Path, file string) field.ErrorList {
+	if file == "" || isExpression(file) {
+		return nil
+	}
+	if _, err := os.Stat(resolve(basePath, file)); errors.Is(err, os.ErrNotExist) {
+		return field.ErrorList{field.NotFound(path, file)}
+	} else if err != nil {
+		return field.ErrorList{field.Invalid(path, file, err.Error())}
+	}
+	return nil
+}
+
+func validateObjectSelector(path *field.Path, selector v1alpha1.ActionObjectSelector) field.ErrorList {
+	return validateLabelSelector(path.Child("selector"), selector.Selector)
+}
+
+func validateAnnotationSelector(path *field.Path, selector v1alpha1.ActionAnnotationSelector) field.ErrorList {
+	if selector.AnnotationSelector == "" || isExpression(string(selector.AnnotationSelector)) {
+		return nil
+	}
+	if _, err := annotations.Parse(string(selector.AnnotationSelector)); err != nil {
+		return field.ErrorList{field.Invalid(path.Child("annotationSelector"), string(selector.AnnotationSelector), err.Error())}
+	}
+	return nil
+}
+
+func validateLabelSelector(path *field.Path, selector v1alpha1.Expression) field.ErrorList {
+	if selector == "" || isExpression(string(selector)) {
+		return nil
+	}
+	if _, err := labels.Parse(string(selector)); err != nil {
+		return field.ErrorList{field.Invalid(path, string(selector), err.Error())}
+	}
+	return nil
+}
+
+func validateFieldSelector(path *field.Path, selector v1alpha1.ActionFieldSelector) field.ErrorList {
+	if selector.FieldSelector == "" || isExpression(string(selector.FieldSelector)) {
+		return nil
+	}
+	if _, err := fields.ParseSelector(string(selector.FieldSelector)); err != nil {
+		return field.ErrorList{field.Invalid(path.Child("fieldSelector"), string(selector.FieldSelector), err.Error())}
+	}
+	return nil
+}
+
+func isExpression(value string) bool {
+	expression := expressions.Parse(context.TODO(), value)
+	return expression != nil && expression.Engine != ""
+}
+
+func resolve(basePath string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(basePath, path)
+}
@@ -0,0 +1,148 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+const ociScheme = "oci://"
+
+var archiveExtensions = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// ociClient is the http client used to talk to OCI registries.
+var ociClient = httputils.Client()
+
+// IsRemote returns true if the path points to an OCI artifact or an archive
+// that must be fetched and unpacked before tests can be discovered.
+func IsRemote(path string) bool {
+	if strings.HasPrefix(path, ociScheme) {
+		return true
+	}
+	if strings.Contains(path, "::") {
+		return true
+	}
+	if u, err := url.Parse(path); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return true
+	}
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	for _, ext := range archiveExtensions {
+		if strings.HasSuffix(path, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch pulls the remote source and unpacks it in a temporary folder.
+// Archives can be pinned with a `?checksum=sha256:<digest>` query, OCI artifacts with an `@sha256:<digest>` reference.
+// The returned function removes the temporary folder.
+func Fetch(ctx context.Context, source string) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "chainsaw-tests-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+	if strings.HasPrefix(source, ociScheme) {
+		err = fetchOCI(ctx, strings.TrimPrefix(source, ociScheme), dir)
+	} else {
+		err = fetchArchive(ctx, source, dir)
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to fetch %s (%w)", source, err)
+	}
+	return dir, cleanup, nil
+}
+
+func fetchArchive(ctx context.Context, source string, dir string) error {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	client := &getter.Client{
+		Ctx:     ctx,
+		Src:     source,
+		Dst:     dir,
+		Pwd:     pwd,
+		Mode:    getter.ClientModeDir,
+		Getters: httputils.Getters(),
+	}
+	return client.Get()
+}
+
+func fetchOCI(ctx context.Context, source string, dir string) error {
+	repo, err := remote.NewRepository(source)
+	if err != nil {
+		return err
+	}
+	// anonymous registries still require a bearer token most of the time, the auth client handles the challenge
+	repo.Client = &auth.Client{
+		Client: ociClient,
+		Cache:  auth.NewCache(),
+	}
+	reference := repo.Reference.Reference
+	if reference == "" {
+		reference = "latest"
+	}
+	// fetched content is verified against the digests of the descriptors
+	_, data, err := oras.FetchBytes(ctx, repo, reference, oras.DefaultFetchBytesOptions)
+	if err != nil {
+		return err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		var decompressor getter.Decompressor
+		switch {
+		case strings.HasSuffix(layer.MediaType, "tar+gzip"):
+			decompressor = new(getter.TarGzipDecompressor)
+		case strings.HasSuffix(layer.MediaType, "tar"):
+			decompressor = new(getter.TarDecompressor)
+		default:
+			continue
+		}
+		blob, err := content.FetchAll(ctx, repo, layer)
+		if err != nil {
+			return err
+		}
+		if err := unpack(decompressor, blob, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unpack(decompressor getter.Decompressor, data []byte, dir string) error {
+	file, err := os.CreateTemp("", "chainsaw-layer-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return decompressor.Decompress(filepath.Clean(dir), file.Name(), true, 0)
+}
@@ -0,0 +1,185 @@
+package discovery
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	godigest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o644,
+			Size: int64(len(content)),
+		}))
+		_, err := tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func testArchive(t *testing.T) []byte {
+	t.Helper()
+	content, err := os.ReadFile("../../testdata/discovery/test/chainsaw-test.yaml")
+	assert.NoError(t, err)
+	return tarball(t, map[string]string{
+		"suite/test/chainsaw-test.yaml": string(content),
+	})
+}
+
+func TestIsRemote(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".", want: false},
+		{path: "../../testdata/discovery", want: false},
+		{path: "tests.tar.gz", want: true},
+		{path: "tests.tgz", want: true},
+		{path: "tests.zip", want: true},
+		{path: "https://example.com/tests.tar.gz", want: true},
+		{path: "git::https://github.com/kyverno/chainsaw", want: true},
+		{path: "oci://ghcr.io/kyverno/tests:v1", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRemote(tt.path))
+		})
+	}
+}
+
+func TestFetch_Tarball(t *testing.T) {
+	data := testArchive(t)
+	archive := filepath.Join(t.TempDir(), "tests.tar.gz")
+	assert.NoError(t, os.WriteFile(archive, data, 0o600))
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{{
+		name:   "local tarball",
+		source: archive,
+	}, {
+		name:   "pinned tarball",
+		source: archive + "?checksum=sha256:" + digest(data),
+	}, {
+		name:    "checksum mismatch",
+		source:  archive + "?checksum=sha256:" + digest([]byte("foo")),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup, err := Fetch(context.TODO(), tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			tests, err := DiscoverTests("chainsaw-test.yaml", nil, false, dir)
+			assert.NoError(t, err)
+			assert.Len(t, tests, 1)
+			assert.Equal(t, "test", tests[0].Test.Name)
+			assert.Equal(t, filepath.Join(dir, "suite", "test"), tests[0].BasePath)
+			cleanup()
+			assert.NoDirExists(t, dir)
+		})
+	}
+}
+
+func TestFetch_OCI(t *testing.T) {
+	layer := testArchive(t)
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    ocispec.DescriptorEmptyJSON,
+		Layers: []ocispec.Descriptor{{
+			MediaType: ocispec.MediaTypeImageLayerGzip,
+			Digest:    godigest.Digest("sha256:" + digest(layer)),
+			Size:      int64(len(layer)),
+		}},
+	})
+	assert.NoError(t, err)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			_, _ = w.Write([]byte(`{"token":"secret"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="https://`+r.Host+`/token",service="test",scope="repository:tests:pull"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/tests/manifests/v1", "/v2/tests/manifests/sha256:" + digest(manifest):
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", "sha256:"+digest(manifest))
+			_, _ = w.Write(manifest)
+		case "/v2/tests/blobs/sha256:" + digest(layer):
+			_, _ = w.Write(layer)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	defer func(client *http.Client) { ociClient = client }(ociClient)
+	ociClient = server.Client()
+	host := strings.TrimPrefix(server.URL, "https://")
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{{
+		name:   "tag",
+		source: "oci://" + host + "/tests:v1",
+	}, {
+		name:   "digest",
+		source: "oci://" + host + "/tests@sha256:" + digest(manifest),
+	}, {
+		name:    "unknown tag",
+		source:  "oci://" + host + "/tests:v2",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup, err := Fetch(context.TODO(), tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			defer cleanup()
+			tests, err := DiscoverTests("chainsaw-test.yaml", nil, false, dir)
+			assert.NoError(t, err)
+			assert.Len(t, tests, 1)
+			assert.Equal(t, "test", tests[0].Test.Name)
+		})
+	}
+}
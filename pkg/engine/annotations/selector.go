@@ -0,0 +1,135 @@
+package annotations
+
+import (
+	"fmt"
+	"strings"
+)
+
+type operator int
+
+const (
+	equals operator = iota
+	notEquals
+	exists
+	doesNotExist
+)
+
+type requirement struct {
+	key      string
+	operator operator
+	value    string
+}
+
+func (r requirement) matches(annotations map[string]string) bool {
+	value, ok := annotations[r.key]
+	switch r.operator {
+	case equals:
+		return ok && value == r.value
+	case notEquals:
+		return !ok || value != r.value
+	case exists:
+		return ok
+	default:
+		return !ok
+	}
+}
+
+// Selector matches resources by annotations.
+type Selector []requirement
+
+// Empty returns true if the selector matches everything.
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+// Matches returns true if the annotations satisfy all the requirements of the selector.
+func (s Selector) Matches(annotations map[string]string) bool {
+	for _, requirement := range s {
+		if !requirement.matches(annotations) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse parses an annotation selector, a comma separated list of requirements.
+// A requirement is either key=value (or key==value), key!=value, key (the annotation exists) or !key (the annotation doesn't exist).
+// Unlike label selectors, values are compared as raw strings and can contain any character (a comma is escaped as \, and a backslash as \\).
+func Parse(selector string) (Selector, error) {
+	var s Selector
+	for _, part := range split(selector) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, r)
+	}
+	return s, nil
+}
+
+func parseRequirement(in string) (requirement, error) {
+	// the key ends at the first operator, everything after the operator is the value
+	i := strings.IndexAny(in, "!=")
+	switch {
+	case i < 0:
+		return newRequirement(in, in, exists, "")
+	case i == 0 && in[0] == '!':
+		return newRequirement(in, in[1:], doesNotExist, "")
+	case in[i] == '!':
+		if !strings.HasPrefix(in[i:], "!=") {
+			return requirement{}, fmt.Errorf("invalid annotation selector requirement %q: expected != operator", in)
+		}
+		return newRequirement(in, in[:i], notEquals, in[i+2:])
+	case strings.HasPrefix(in[i:], "=="):
+		return newRequirement(in, in[:i], equals, in[i+2:])
+	default:
+		return newRequirement(in, in[:i], equals, in[i+1:])
+	}
+}
+
+func newRequirement(in string, key string, operator operator, value string) (requirement, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return requirement{}, fmt.Errorf("invalid annotation selector requirement %q: missing key", in)
+	}
+	if strings.ContainsAny(key, " !=") {
+		return requirement{}, fmt.Errorf("invalid annotation selector requirement %q: invalid key %q", in, key)
+	}
+	return requirement{
+		key:      key,
+		operator: operator,
+		value:    value,
+	}, nil
+}
+
+// split splits the selector on unescaped commas and unescapes the parts.
+func split(in string) []string {
+	var parts []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range in {
+		switch {
+		case escaped:
+			if r != ',' && r != '\\' {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	return append(parts, current.String())
+}
@@ -0,0 +1,88 @@
+package annotations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	annotations := map[string]string{
+		"url":  "https://x/y",
+		"note": "a b",
+		"list": "a,b",
+		"team": "platform",
+	}
+	tests := []struct {
+		name     string
+		selector string
+		wantErr  string
+		want     bool
+	}{{
+		name: "empty",
+		want: true,
+	}, {
+		name:     "url value",
+		selector: "url=https://x/y",
+		want:     true,
+	}, {
+		name:     "value with spaces",
+		selector: "note=a b",
+		want:     true,
+	}, {
+		name:     "double equals",
+		selector: "url==https://x/y",
+		want:     true,
+	}, {
+		name:     "escaped comma",
+		selector: `list=a\,b`,
+		want:     true,
+	}, {
+		name:     "multiple requirements",
+		selector: "team=platform, note=a b",
+		want:     true,
+	}, {
+		name:     "value mismatch",
+		selector: "team=apps",
+		want:     false,
+	}, {
+		name:     "not equals",
+		selector: "team!=apps",
+		want:     true,
+	}, {
+		name:     "not equals missing",
+		selector: "owner!=apps",
+		want:     true,
+	}, {
+		name:     "exists",
+		selector: "team",
+		want:     true,
+	}, {
+		name:     "does not exist",
+		selector: "!team",
+		want:     false,
+	}, {
+		name:     "value with operators",
+		selector: "url!=https://x/y?a!=b",
+		want:     true,
+	}, {
+		name:     "missing key",
+		selector: "=value",
+		wantErr:  `invalid annotation selector requirement "=value": missing key`,
+	}, {
+		name:     "invalid operator",
+		selector: "team!platform",
+		wantErr:  `invalid annotation selector requirement "team!platform": expected != operator`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, selector.Matches(annotations))
+		})
+	}
+}
@@ -13,6 +13,28 @@ import (
 
 var identifier = regexp.MustCompile(`^\w+$`)
 
+// reserved lists the names of the built-in bindings.
+var reserved = map[string]struct{}{
+	"client":    {},
+	"clock":     {},
+	"cluster":   {},
+	"config":    {},
+	"error":     {},
+	"namespace": {},
+	"operation": {},
+	"stderr":    {},
+	"stdout":    {},
+	"step":      {},
+	"test":      {},
+	"values":    {},
+}
+
+// IsReserved returns true if the name is the name of a built-in binding.
+func IsReserved(name string) bool {
+	_, ok := reserved[name]
+	return ok
+}
+
 func checkBindingName(name string) error {
 	if !identifier.MatchString(name) {
 		return fmt.Errorf("invalid binding name %s", name)
@@ -0,0 +1,375 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/jmespath-community/go-jmespath/pkg/parsing"
+	"github.com/kyverno/chainsaw/pkg/engine/functions"
+	"github.com/kyverno/kyverno-json/pkg/core/assertion"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/kyverno-json/pkg/core/expression"
+	"github.com/kyverno/kyverno-json/pkg/core/matching"
+	"github.com/kyverno/kyverno-json/pkg/core/projection"
+	reflectutils "github.com/kyverno/kyverno-json/pkg/utils/reflect"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// markerCall returns the name of the function called by a value when the value is an expression calling a function,
+// markers are recognised on the parsed expression so that keys and literal values are never mistaken for them.
+func markerCall(in any) string {
+	typed, ok := in.(string)
+	if !ok {
+		return ""
+	}
+	expr := expression.Parse(typed)
+	if expr.Foreach || expr.Binding != "" || (expr.Compiler != expression.CompilerDefault && expr.Compiler != expression.CompilerJP) {
+		return ""
+	}
+	node, err := parsing.NewParser().Parse(expr.Statement)
+	// markers don't take arguments, calls with arguments are evaluated (and rejected) like any other expression
+	if err != nil || node.NodeType != parsing.ASTFunctionExpression || len(node.Children) != 0 {
+		return ""
+	}
+	name, _ := node.Value.(string)
+	return name
+}
+
+func parse(path *field.Path, in any, compilers compilers.Compilers, strict bool, tolerances map[string]tolerance) (assertion.Assertion, *field.Error) {
+	switch reflectutils.GetKind(in) {
+	case reflect.Slice:
+		return parseSlice(path, in, compilers, strict, tolerances)
+	case reflect.Map:
+		return parseMap(path, in, compilers, strict, tolerances)
+	default:
+		switch markerCall(in) {
+		case functions.MarkerAbsent, functions.MarkerPresent:
+			return nil, field.Invalid(path, in, "can only be used as the value of a map key")
+		case functions.MarkerStrict:
+			return nil, field.Invalid(path, in, "can only be used as a map key")
+		}
+		return parseScalar(path, in, compilers, tolerances)
+	}
+}
+
+// sliceNode is the assertion represented by a slice.
+// it first compares the length of the analysed resource with the length of the descendants.
+// if lengths match all descendants are evaluated with their corresponding items.
+type sliceNode []assertion.Assertion
+
+func (node sliceNode) Assert(path *field.Path, value any, bindings binding.Bindings) (field.ErrorList, error) {
+	var errs field.ErrorList
+	if value == nil {
+		errs = append(errs, field.Invalid(path, value, "value is null"))
+	} else if reflectutils.GetKind(value) != reflect.Slice {
+		return nil, field.TypeInvalid(path, value, "expected a slice")
+	} else {
+		valueOf := reflect.ValueOf(value)
+		if valueOf.Len() != len(node) {
+			errs = append(errs, field.Invalid(path, value, "lengths of slices don't match"))
+		} else {
+			for i := range node {
+				if _errs, err := node[i].Assert(path.Index(i), valueOf.Index(i).Interface(), bindings); err != nil {
+					return nil, err
+				} else {
+					errs = append(errs, _errs...)
+				}
+			}
+		}
+	}
+	return errs, nil
+}
+
+func parseSlice(path *field.Path, in any, compilers compilers.Compilers, strict bool, tolerances map[string]tolerance) (sliceNode, *field.Error) {
+	var assertions sliceNode
+	valueOf := reflect.ValueOf(in)
+	for i := 0; i < valueOf.Len(); i++ {
+		sub, err := parse(path.Index(i), valueOf.Index(i).Interface(), compilers, strict, tolerances)
+		if err != nil {
+			return nil, err
+		}
+		assertions = append(assertions, sub)
+	}
+	return assertions, nil
+}
+
+type mapEntry struct {
+	*projection.Projection
+	assertion.Assertion
+	key    string
+	absent bool
+	// inline entries are asserted against the analysed resource itself instead of one of its fields
+	inline bool
+}
+
+// mapNode is the assertion represented by a map.
+// it is responsible for projecting the analysed resource and passing the result to the descendants.
+// when strict, the analysed resource must not contain fields other than the ones declared in the node.
+type mapNode struct {
+	entries  []mapEntry
+	declared map[string]struct{}
+	strict   bool
+}
+
+func (node mapNode) Assert(path *field.Path, value any, bindings binding.Bindings) (field.ErrorList, error) {
+	var errs field.ErrorList
+	// if we assert against an empty object, value is expected to be not nil
+	if len(node.entries) == 0 {
+		if value == nil {
+			errs = append(errs, field.Invalid(path, value, "invalid value, must not be null"))
+			return errs, nil
+		}
+	}
+	for _, entry := range node.entries {
+		path := path
+		if !entry.inline {
+			path = path.Child(entry.key)
+		}
+		projected, found, err := entry.Projection.Handler(value, bindings)
+		if err != nil {
+			return nil, field.InternalError(path, err)
+		} else if entry.absent {
+			// the field must not exist, a field with a null value is still present
+			if found {
+				errs = append(errs, field.Forbidden(path, "field must not exist in the input object"))
+			}
+		} else if !found {
+			errs = append(errs, field.Required(path, "field not found in the input object"))
+		} else {
+			if entry.Projection.Binding != "" {
+				bindings = bindings.Register("$"+entry.Projection.Binding, binding.NewBinding(projected))
+			}
+			if entry.Projection.Foreach {
+				projectedKind := reflectutils.GetKind(projected)
+				if projectedKind == reflect.Slice {
+					valueOf := reflect.ValueOf(projected)
+					for i := 0; i < valueOf.Len(); i++ {
+						bindings := bindings
+						if entry.Projection.ForeachName != "" {
+							bindings = bindings.Register("$"+entry.Projection.ForeachName, binding.NewBinding(i))
+						}
+						if _errs, err := entry.Assert(path.Index(i), valueOf.Index(i).Interface(), bindings); err != nil {
+							return nil, err
+						} else {
+							errs = append(errs, _errs...)
+						}
+					}
+				} else if projectedKind == reflect.Map {
+					iter := reflect.ValueOf(projected).MapRange()
+					for iter.Next() {
+						key := iter.Key().Interface()
+						bindings := bindings
+						if entry.Projection.ForeachName != "" {
+							bindings = bindings.Register("$"+entry.Projection.ForeachName, binding.NewBinding(key))
+						}
+						if _errs, err := entry.Assert(path.Key(fmt.Sprint(key)), iter.Value().Interface(), bindings); err != nil {
+							return nil, err
+						} else {
+							errs = append(errs, _errs...)
+						}
+					}
+				} else {
+					return nil, field.TypeInvalid(path, projected, "expected a slice or a map")
+				}
+			} else {
+				if _errs, err := entry.Assert(path, projected, bindings); err != nil {
+					return nil, err
+				} else {
+					errs = append(errs, _errs...)
+				}
+			}
+		}
+	}
+	if node.strict {
+		if reflectutils.GetKind(value) != reflect.Map {
+			errs = append(errs, field.TypeInvalid(path, value, "expected a map"))
+		} else {
+			var keys []string
+			for _, key := range reflect.ValueOf(value).MapKeys() {
+				keys = append(keys, fmt.Sprint(key.Interface()))
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				if _, ok := node.declared[key]; !ok {
+					errs = append(errs, field.Forbidden(path.Child(key), "field not expected in the input object (strict)"))
+				}
+			}
+		}
+	}
+	return errs, nil
+}
+
+func parseMap(path *field.Path, in any, compilers compilers.Compilers, strict bool, tolerances map[string]tolerance) (mapNode, *field.Error) {
+	assertions := mapNode{
+		declared: map[string]struct{}{},
+		strict:   strict,
+	}
+	iter := reflect.ValueOf(in).MapRange()
+	for iter.Next() {
+		key := iter.Key().Interface()
+		value := iter.Value().Interface()
+		// the value of a strict marker key is asserted strictly against the analysed resource itself
+		if markerCall(key) == functions.MarkerStrict {
+			assertion, err := parse(path, value, compilers, true, tolerances)
+			if err != nil {
+				return mapNode{}, err
+			}
+			if node, ok := assertion.(mapNode); ok {
+				for declared := range node.declared {
+					assertions.declared[declared] = struct{}{}
+				}
+			}
+			assertions.entries = append(assertions.entries, mapEntry{
+				Projection: &projection.Projection{Handler: identity},
+				Assertion:  assertion,
+				key:        fmt.Sprint(key),
+				inline:     true,
+			})
+			continue
+		}
+		path := path.Child(fmt.Sprint(key))
+		projection, err := projection.ParseMapKey(path, key, compilers)
+		if err != nil {
+			return mapNode{}, err
+		}
+		var assertion assertion.Assertion
+		marker := markerCall(value)
+		absent := marker == functions.MarkerAbsent
+		switch marker {
+		case functions.MarkerAbsent:
+		case functions.MarkerPresent:
+			assertion = presentNode{}
+		default:
+			assertion, err = parse(path, value, compilers, strict, tolerances)
+			if err != nil {
+				return mapNode{}, err
+			}
+		}
+		// only plain keys declare fields, expressions can project arbitrary data
+		if typed, ok := key.(string); !ok {
+			assertions.declared[fmt.Sprint(key)] = struct{}{}
+		} else if expr := expression.Parse(typed); expr.Compiler == "" && !expr.Foreach {
+			assertions.declared[expr.Statement] = struct{}{}
+		}
+		assertions.entries = append(assertions.entries, mapEntry{
+			Projection: projection,
+			Assertion:  assertion,
+			key:        fmt.Sprint(key),
+			absent:     absent,
+		})
+	}
+	return assertions, nil
+}
+
+// identity projects the analysed resource itself.
+func identity(value any, _ binding.Bindings) (any, bool, error) {
+	return value, true, nil
+}
+
+// presentNode is the assertion represented by a key that must exist, whatever its value.
+// the parent node already reports missing fields so it always passes.
+type presentNode struct{}
+
+func (presentNode) Assert(*field.Path, any, binding.Bindings) (field.ErrorList, error) {
+	return nil, nil
+}
+
+// assertMarker asserts a value against a marker computed by an expression.
+// only patterns can be computed, other markers are resolved when the tree is parsed.
+func assertMarker(path *field.Path, value any, marker functions.Marker) (field.ErrorList, error) {
+	var errs field.ErrorList
+	if marker.Name == functions.MarkerStrict {
+		return nil, field.Invalid(path, value, fmt.Sprintf("%s can only be used as a map key", marker.Name))
+	}
+	if marker.Pattern == nil {
+		return nil, field.Invalid(path, value, fmt.Sprintf("%s can only be used as the value of a map key", marker.Name))
+	}
+	if typed, ok := value.(string); !ok {
+		errs = append(errs, field.Invalid(path, value, fmt.Sprintf("Expected a string matching pattern: %s", marker.Pattern)))
+	} else if !marker.Pattern.MatchString(typed) {
+		errs = append(errs, field.Invalid(path, value, fmt.Sprintf("Expected value to match pattern: %s", marker.Pattern)))
+	}
+	return errs, nil
+}
+
+// scalarNode is the assertion represented by a leaf.
+// it receives a value and compares it with an expected value.
+// the expected value can be the result of an expression.
+// when an expression evaluates to a boolean and the analysed value is not a boolean,
+// the expression is considered an operator and its result tells if the value matches.
+type scalarNode struct {
+	handler   projection.ScalarHandler
+	statement string
+}
+
+func (node scalarNode) Assert(path *field.Path, value any, bindings binding.Bindings) (field.ErrorList, error) {
+	var errs field.ErrorList
+	projected, err := node.handler(value, bindings)
+	if err != nil {
+		return nil, field.InternalError(path, err)
+	}
+	if marker, ok := projected.(functions.Marker); ok {
+		return assertMarker(path, value, marker)
+	}
+	// a result is compared through its pass field and reports its own message
+	if result, ok := value.(functions.Result); ok {
+		if match, err := matching.Match(projected, result.Pass); err != nil {
+			return nil, field.InternalError(path, err)
+		} else if !match {
+			errs = append(errs, resultError(path, result, projected))
+		}
+		return errs, nil
+	}
+	if pass, ok := projected.(bool); ok && node.statement != "" && reflectutils.GetKind(value) != reflect.Bool {
+		if !pass {
+			errs = append(errs, field.Invalid(path, value, fmt.Sprintf("Expected value to satisfy: %s", node.statement)))
+		}
+		return errs, nil
+	}
+	if match, err := matching.Match(projected, value); err != nil {
+		return nil, field.InternalError(path, err)
+	} else if !match {
+		errs = append(errs, field.Invalid(path, value, expectValueMessage(projected)))
+	}
+	return errs, nil
+}
+
+func parseScalar(path *field.Path, in any, compilers compilers.Compilers, tolerances map[string]tolerance) (assertion.Assertion, *field.Error) {
+	proj, err := projection.ParseScalar(path, in, compilers)
+	if err != nil {
+		return nil, err
+	}
+	if tolerance, ok := tolerances[path.String()]; ok {
+		return toleranceNode{
+			handler:   proj,
+			tolerance: tolerance,
+		}, nil
+	}
+	node := scalarNode{
+		handler: proj,
+	}
+	if typed, ok := in.(string); ok {
+		if expr := expression.Parse(typed); expr.Compiler != "" {
+			node.statement = expr.Statement
+		}
+	}
+	return node, nil
+}
+
+func expectValueMessage(value any) string {
+	switch t := value.(type) {
+	case int64, int32, float64, float32, bool:
+		// use simple printer for simple types
+		return fmt.Sprintf("Expected value: %v", value)
+	case string:
+		return fmt.Sprintf("Expected value: %q", t)
+	case fmt.Stringer:
+		// anything that defines String() is better than raw struct
+		return fmt.Sprintf("Expected value: %s", t.String())
+	default:
+		// fallback to raw struct
+		return fmt.Sprintf("Expected value: %#v", value)
+	}
+}
@@ -7,17 +7,31 @@ import (
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
-func Check(ctx context.Context, compilers compilers.Compilers, obj any, bindings apis.Bindings, check *v1alpha1.Check) (field.ErrorList, error) {
+func Check(ctx context.Context, compilers compilers.Compilers, obj any, bindings apis.Bindings, check *v1alpha1.Check, tolerances ...v1alpha1.Tolerance) (field.ErrorList, error) {
 	if check == nil {
 		return nil, errors.New("check is null")
 	}
 	if check.IsNil() {
 		return nil, errors.New("check value is null")
 	}
-	if assertion, err := check.Compile(nil, compilers); err != nil {
+	// the check is converted back to its raw tree to support chainsaw specific syntax
+	data, err := json.Marshal(check)
+	if err != nil {
+		return nil, err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	parsedTolerances, err := parseTolerances(tolerances...)
+	if err != nil {
+		return nil, err
+	}
+	if assertion, err := parse(nil, tree, compilers, false, parsedTolerances); err != nil {
 		return nil, err
 	} else {
 		if bindings == nil {
@@ -2,23 +2,37 @@ package checks
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	tclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 )
 
 func TestCheck(t *testing.T) {
+	clock := tclock.NewFakePassiveClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	nodeClient := &tclient.FakeClient{
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			obj.(*unstructured.Unstructured).SetLabels(map[string]string{"zone": strings.TrimPrefix(key.Name, "node-")})
+			return nil
+		},
+	}
 	tests := []struct {
-		name     string
-		obj      any
-		bindings apis.Bindings
-		check    *v1alpha1.Check
-		want     field.ErrorList
-		wantErr  bool
+		name       string
+		obj        any
+		bindings   apis.Bindings
+		check      *v1alpha1.Check
+		tolerances []v1alpha1.Tolerance
+		want       field.ErrorList
+		wantErr    bool
 	}{{
 		name:     "nil check",
 		obj:      nil,
@@ -64,10 +78,1049 @@ func TestCheck(t *testing.T) {
 			Detail:   "Expected value: \"baz\"",
 		}},
 		wantErr: false,
+	}, {
+		name: "quantity passing",
+		obj: map[string]any{
+			"memory": "1Gi",
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_quantity_compare(memory, '512Mi') >= `0`)": true,
+				"(x_quantity(memory) == x_quantity('1024Mi'))": true,
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "quantity not passing",
+		obj: map[string]any{
+			"memory": "256Mi",
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_quantity_compare(memory, '0.5Gi') >= `0`)": true,
+			},
+		)),
+		want: []*field.Error{{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "(x_quantity_compare(memory, '0.5Gi') >= `0`)",
+			BadValue: false,
+			Detail:   "Expected value: true",
+		}},
+		wantErr: false,
+	}, {
+		name: "subset with extra key",
+		obj: map[string]any{
+			"data": map[string]any{
+				"foo": "bar",
+				"baz": "qux",
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"data": map[string]any{
+					"foo": "bar",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "strict passing",
+		obj: map[string]any{
+			"data": map[string]any{
+				"foo": "bar",
+				"baz": "qux",
+			},
+			"other": "value",
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"data": map[string]any{
+					"(x_strict())": map[string]any{
+						"foo": "bar",
+						"baz": "qux",
+					},
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "strict with extra key",
+		obj: map[string]any{
+			"data": map[string]any{
+				"foo": "bar",
+				"baz": "qux",
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"data": map[string]any{
+					"(x_strict())": map[string]any{
+						"foo": "bar",
+					},
+				},
+			},
+		)),
+		want: []*field.Error{{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "data.baz",
+			BadValue: "",
+			Detail:   "field not expected in the input object (strict)",
+		}},
+		wantErr: false,
+	}, {
+		name: "strict with nested extra key",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"template": map[string]any{
+					"foo": "bar",
+					"baz": "qux",
+				},
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"(x_strict())": map[string]any{
+						"template": map[string]any{
+							"foo": "bar",
+						},
+					},
+				},
+			},
+		)),
+		want: []*field.Error{{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "spec.template.baz",
+			BadValue: "",
+			Detail:   "field not expected in the input object (strict)",
+		}},
+		wantErr: false,
+	}, {
+		name: "strict with empty map",
+		obj: map[string]any{
+			"data": map[string]any{
+				"foo": "bar",
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"data": map[string]any{
+					"(x_strict())": map[string]any{},
+				},
+			},
+		)),
+		want: []*field.Error{{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "data.foo",
+			BadValue: "",
+			Detail:   "field not expected in the input object (strict)",
+		}},
+		wantErr: false,
+	}, {
+		name: "recent timestamp",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"creationTimestamp": "2024-01-01T11:59:50Z",
+			},
+		},
+		bindings: apis.NewBindings().Register("$clock", apis.NewBinding(clock)),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"(x_time_within($clock, creationTimestamp, '30s'))": true,
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "stale timestamp",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"creationTimestamp": "2024-01-01T11:50:00Z",
+			},
+		},
+		bindings: apis.NewBindings().Register("$clock", apis.NewBinding(clock)),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"(x_time_within($clock, creationTimestamp, '30s'))": true,
+				},
+			},
+		)),
+		want: []*field.Error{{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "metadata.(x_time_within($clock, creationTimestamp, '30s'))",
+			BadValue: false,
+			Detail:   "Expected value: true",
+		}},
+		wantErr: false,
+	}, {
+		name:     "log sequence in order",
+		obj:      nil,
+		bindings: apis.NewBindings().Register("$stdout", apis.NewBinding("[pod/server] listening\n[pod/client] connected\n[pod/server] done")),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_match_sequence($stdout, ['listening', 'connected', 'done']))": true,
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name:     "log sequence out of order",
+		obj:      nil,
+		bindings: apis.NewBindings().Register("$stdout", apis.NewBinding("[pod/server] listening\n[pod/client] connected\n[pod/server] done")),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_match_sequence($stdout, ['connected', 'listening']))": true,
+			},
+		)),
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "pod restarts below max",
+		obj: map[string]any{
+			"status": map[string]any{
+				"containerStatuses": []any{
+					map[string]any{"name": "foo", "restartCount": int64(0)},
+					map[string]any{"name": "bar", "restartCount": int64(0)},
+				},
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_pod_restarts(@) <= `2`)": true,
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "pod restarts above max",
+		obj: map[string]any{
+			"status": map[string]any{
+				"containerStatuses": []any{
+					map[string]any{"name": "foo", "restartCount": int64(2)},
+					map[string]any{"name": "bar", "restartCount": int64(3)},
+				},
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_pod_restarts(@) <= `2`)": true,
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("(x_pod_restarts(@) <= `2`)"), false, "Expected value: true"),
+		},
+		wantErr: false,
+	}, {
+		name: "pod scheduled on matching node",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"nodeName": "node-a",
+			},
+		},
+		bindings: apis.NewBindings().Register("$client", apis.NewBinding(nodeClient)),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_pod_scheduled_on($client, @, 'zone=a'))": true,
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "pod scheduled on wrong node",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"nodeName": "node-b",
+			},
+		},
+		bindings: apis.NewBindings().Register("$client", apis.NewBinding(nodeClient)),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_pod_scheduled_on($client, @, 'zone=a'))": true,
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("(x_pod_scheduled_on($client, @, 'zone=a'))"), "node-b", "pod is scheduled on node node-b which does not match zone=a"),
+		},
+		wantErr: false,
+	}, {
+		name: "result passed",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": int64(3),
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_assert_result(spec.replicas >= `3`, 'not enough replicas', spec.replicas))": true,
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "result failed with message and value",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": int64(1),
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_assert_result(spec.replicas >= `3`, 'not enough replicas', spec.replicas))": true,
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("(x_assert_result(spec.replicas >= `3`, 'not enough replicas', spec.replicas))"), int64(1), "not enough replicas"),
+		},
+		wantErr: false,
+	}, {
+		name: "result failed without value",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": int64(1),
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_assert_result(spec.replicas >= `3`, 'not enough replicas'))": true,
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("(x_assert_result(spec.replicas >= `3`, 'not enough replicas'))"), false, "not enough replicas"),
+		},
+		wantErr: false,
+	}, {
+		name: "result failed without message",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": int64(1),
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"(x_assert_result(spec.replicas >= `3`, ''))": true,
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("(x_assert_result(spec.replicas >= `3`, ''))"), false, "Expected value: true"),
+		},
+		wantErr: false,
+	}, {
+		name: "object shaped like a result is matched",
+		obj: map[string]any{
+			"status": map[string]any{
+				"pass":    false,
+				"message": "foo",
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"status": map[string]any{
+					"pass":    false,
+					"message": "foo",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "key starting with equal",
+		obj: map[string]any{
+			"=data": "foo",
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"=data": "foo",
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "strict next to other keys",
+		obj: map[string]any{
+			"data": map[string]any{
+				"foo": "bar",
+				"baz": "qux",
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"data": map[string]any{
+					"baz": "qux",
+					"(x_strict())": map[string]any{
+						"foo": "bar",
+					},
+				},
+			},
+		)),
+		want: []*field.Error{{
+			Type:     field.ErrorTypeForbidden,
+			Field:    "data.baz",
+			BadValue: "",
+			Detail:   "field not expected in the input object (strict)",
+		}},
+		wantErr: false,
+	}, {
+		name: "strict as a value",
+		obj: map[string]any{
+			"data": map[string]any{
+				"foo": "bar",
+			},
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"data": "(x_strict())",
+			},
+		)),
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "absent field",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"name": "foo",
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"name":              "foo",
+					"deletionTimestamp": "(x_absent())",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "present field",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"nodeName": "node-1",
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"nodeName": "(x_absent())",
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "nodeName"), "field must not exist in the input object"),
+		},
+		wantErr: false,
+	}, {
+		name: "present field with null value",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"nodeName": nil,
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"nodeName": "(x_absent())",
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "nodeName"), "field must not exist in the input object"),
+		},
+		wantErr: false,
+	}, {
+		name: "literal key starting with -",
+		obj: map[string]any{
+			"-data": "foo",
+		},
+		bindings: nil,
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"-data": "foo",
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "present label",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"pod-template-hash": "(x_present())",
+					},
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "missing present label",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"controller-revision-hash": "(x_present())",
+					},
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Required(field.NewPath("metadata", "labels", "controller-revision-hash"), "field not found in the input object"),
+		},
+		wantErr: false,
+	}, {
+		name: "label matching pattern",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"pod-template-hash": "(x_pattern('^[a-z0-9]{10}$'))",
+					},
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "label not matching pattern",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"app": "(x_pattern('^redis-'))",
+					},
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("metadata", "labels", "app"), "nginx", "Expected value to match pattern: ^redis-"),
+		},
+		wantErr: false,
+	}, {
+		name: "missing label with pattern",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"tier": "(x_pattern('.*'))",
+					},
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Required(field.NewPath("metadata", "labels", "tier"), "field not found in the input object"),
+		},
+		wantErr: false,
+	}, {
+		name: "pattern on non string value",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": int64(3),
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"replicas": "(x_pattern('^3$'))",
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "replicas"), int64(3), "Expected a string matching pattern: ^3$"),
+		},
+		wantErr: false,
+	}, {
+		name: "invalid pattern",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"app": "(x_pattern('('))",
+					},
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "absent label",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"controller-revision-hash": "(x_absent())",
+					},
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "unexpected label",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":               "nginx",
+					"pod-template-hash": "5d59d67564",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": map[string]any{
+						"pod-template-hash": "(x_absent())",
+					},
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Forbidden(field.NewPath("metadata", "labels", "pod-template-hash"), "field must not exist in the input object"),
+		},
+		wantErr: false,
+	}, {
+		name: "literal keys starting with + and ^",
+		obj: map[string]any{
+			"+data": "foo",
+			"^data": "bar",
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"+data": "foo",
+				"^data": "bar",
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "literal key starting with - not matching",
+		obj: map[string]any{
+			"-config": "foo",
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"-config": "bar",
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("-config"), "foo", `Expected value: "bar"`),
+		},
+		wantErr: false,
+	}, {
+		name: "pattern in a list",
+		obj: map[string]any{
+			"args": []any{"--v=2", "--port=8080"},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"args": []any{"(x_pattern('^--v='))", "(x_pattern('^--port=\\d+$'))"},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "pattern with escaped quote",
+		obj: map[string]any{
+			"message": "it's ready",
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"message": `(x_pattern('^it\'s'))`,
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "pattern from bindings",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"name": "team-a-settings",
+			},
+		},
+		bindings: apis.NewBindings().Register("$team", apis.NewBinding("team-a")),
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"name": "(x_pattern(join('', ['^', $team, '-'])))",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "markers with spaces",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"name": "foo",
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"name":              "( x_present( ) )",
+					"deletionTimestamp": "(x_absent( ))",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "marker with arguments",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"name": "foo",
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"name": "(x_absent('foo'))",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "absent outside of a map",
+		obj: map[string]any{
+			"args": []any{"foo"},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"args": []any{"(x_absent())"},
+			},
+		)),
+		want:    nil,
+		wantErr: true,
+	}, {
+		name: "within absolute tolerance",
+		obj: map[string]any{
+			"status": map[string]any{
+				"ratio": 0.3333,
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"status": map[string]any{
+					"ratio": 0.33,
+				},
+			},
+		)),
+		tolerances: []v1alpha1.Tolerance{{Path: "status.ratio", Absolute: "0.01"}},
+		want:       nil,
+		wantErr:    false,
+	}, {
+		name: "outside absolute tolerance",
+		obj: map[string]any{
+			"status": map[string]any{
+				"ratio": 0.35,
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"status": map[string]any{
+					"ratio": 0.33,
+				},
+			},
+		)),
+		tolerances: []v1alpha1.Tolerance{{Path: "status.ratio", Absolute: "0.01"}},
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("status", "ratio"), 0.35, "Expected value: 0.33 (absolute tolerance: 0.01, relative tolerance: 0)"),
+		},
+		wantErr: false,
+	}, {
+		name: "within relative tolerance",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"items": []any{
+					map[string]any{
+						"weight": int64(1040),
+					},
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"items": []any{
+						map[string]any{
+							"weight": 1000,
+						},
+					},
+				},
+			},
+		)),
+		tolerances: []v1alpha1.Tolerance{{Path: "spec.items[0].weight", Relative: "0.05"}},
+		want:       nil,
+		wantErr:    false,
+	}, {
+		name: "outside relative tolerance",
+		obj: map[string]any{
+			"ratio": 1.1,
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"ratio": 1,
+			},
+		)),
+		tolerances: []v1alpha1.Tolerance{{Path: "ratio", Relative: "0.05"}},
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("ratio"), 1.1, "Expected value: 1 (absolute tolerance: 0, relative tolerance: 0.05)"),
+		},
+		wantErr: false,
+	}, {
+		name: "tolerance on another path",
+		obj: map[string]any{
+			"ratio": 0.3333,
+			"other": 0.3333,
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"ratio": 0.33,
+				"other": 0.33,
+			},
+		)),
+		tolerances: []v1alpha1.Tolerance{{Path: "ratio", Absolute: "0.01"}},
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("other"), 0.3333, "Expected value: 0.33"),
+		},
+		wantErr: false,
+	}, {
+		name: "greater than operator passing",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": 3,
+				"selector": "app",
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"replicas": "(@ > `2`)",
+					"selector": "app",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "greater than operator not passing",
+		obj: map[string]any{
+			"spec": map[string]any{
+				"replicas": 2,
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"spec": map[string]any{
+					"replicas": "(@ > `2`)",
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("spec", "replicas"), 2, "Expected value to satisfy: @ > `2`"),
+		},
+		wantErr: false,
+	}, {
+		name: "less than operator on float",
+		obj: map[string]any{
+			"ratio": 0.25,
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"ratio": "(@ < `0.5`)",
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "less than operator not passing",
+		obj: map[string]any{
+			"ratio": 0.75,
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"ratio": "(@ < `0.5`)",
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("ratio"), 0.75, "Expected value to satisfy: @ < `0.5`"),
+		},
+		wantErr: false,
+	}, {
+		name: "contains operator on string",
+		obj: map[string]any{
+			"image": "nginx:1.27",
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"image": "(contains(@, 'nginx'))",
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "contains operator on array",
+		obj: map[string]any{
+			"finalizers": []any{"foo", "bar"},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"finalizers": "(contains(@, 'baz'))",
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("finalizers"), []any{"foo", "bar"}, "Expected value to satisfy: contains(@, 'baz')"),
+		},
+		wantErr: false,
+	}, {
+		name: "matches operator in array items",
+		obj: map[string]any{
+			"containers": []any{
+				map[string]any{"name": "app", "image": "nginx:1.27"},
+				map[string]any{"name": "sidecar", "image": "envoy:1.31"},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"containers": []any{
+					map[string]any{"name": "app", "image": "(regex_match('^nginx:', @))"},
+					map[string]any{"name": "sidecar", "image": "(regex_match('^nginx:', @))"},
+				},
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("containers").Index(1).Child("image"), "envoy:1.31", "Expected value to satisfy: regex_match('^nginx:', @)"),
+		},
+		wantErr: false,
+	}, {
+		name: "operator on nested map",
+		obj: map[string]any{
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app":  "foo",
+					"team": "bar",
+				},
+			},
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"metadata": map[string]any{
+					"labels": "(length(@) > `1`)",
+				},
+			},
+		)),
+		want:    nil,
+		wantErr: false,
+	}, {
+		name: "boolean expression on boolean field",
+		obj: map[string]any{
+			"enabled": true,
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"enabled": "(`false`)",
+			},
+		)),
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("enabled"), true, "Expected value: false"),
+		},
+		wantErr: false,
+	}, {
+		name: "invalid tolerance",
+		obj: map[string]any{
+			"ratio": 0.3333,
+		},
+		check: ptr.To(v1alpha1.NewCheck(
+			map[string]any{
+				"ratio": 0.33,
+			},
+		)),
+		tolerances: []v1alpha1.Tolerance{{Path: "ratio", Absolute: "foo"}},
+		want:       nil,
+		wantErr:    true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Check(context.TODO(), apis.DefaultCompilers, tt.obj, tt.bindings, tt.check)
+			got, err := Check(context.TODO(), apis.DefaultCompilers, tt.obj, tt.bindings, tt.check, tt.tolerances...)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -0,0 +1,26 @@
+package checks
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ObservedGeneration verifies the controller owning an object has observed its latest spec,
+// that is status.observedGeneration equals metadata.generation.
+func ObservedGeneration(obj map[string]any) (field.ErrorList, error) {
+	path := field.NewPath("status", "observedGeneration")
+	observed, found, err := unstructured.NestedFieldNoCopy(obj, "status", "observedGeneration")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return field.ErrorList{field.Required(path, "observed generation not reported")}, nil
+	}
+	generation := (&unstructured.Unstructured{Object: obj}).GetGeneration()
+	if fmt.Sprint(observed) != fmt.Sprint(generation) {
+		return field.ErrorList{field.Invalid(path, observed, fmt.Sprintf("Expected generation: %d", generation))}, nil
+	}
+	return nil, nil
+}
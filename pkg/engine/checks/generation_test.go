@@ -0,0 +1,58 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestObservedGeneration(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  map[string]any
+		want field.ErrorList
+	}{{
+		name: "matched",
+		obj: map[string]any{
+			"metadata": map[string]any{"generation": int64(3)},
+			"status":   map[string]any{"observedGeneration": int64(3)},
+		},
+	}, {
+		name: "matched float",
+		obj: map[string]any{
+			"metadata": map[string]any{"generation": int64(3)},
+			"status":   map[string]any{"observedGeneration": float64(3)},
+		},
+	}, {
+		name: "lagging",
+		obj: map[string]any{
+			"metadata": map[string]any{"generation": int64(3)},
+			"status":   map[string]any{"observedGeneration": int64(2)},
+		},
+		want: field.ErrorList{{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "status.observedGeneration",
+			BadValue: int64(2),
+			Detail:   "Expected generation: 3",
+		}},
+	}, {
+		name: "not reported",
+		obj: map[string]any{
+			"metadata": map[string]any{"generation": int64(1)},
+		},
+		want: field.ErrorList{{
+			Type:     field.ErrorTypeRequired,
+			Field:    "status.observedGeneration",
+			BadValue: "",
+			Detail:   "observed generation not reported",
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ObservedGeneration(tt.obj)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
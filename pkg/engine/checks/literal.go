@@ -0,0 +1,70 @@
+package checks
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kyverno/kyverno-json/pkg/core/matching"
+	reflectutils "github.com/kyverno/kyverno-json/pkg/utils/reflect"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Literal compares an object with an expected object without interpreting it as an assertion tree,
+// keys and values are compared as is (expressions, markers and key prefixes have no special meaning).
+// Like an assertion tree, the object must contain every field of the expected object but can contain more.
+func Literal(obj any, expected any) field.ErrorList {
+	return literal(nil, obj, expected)
+}
+
+func literal(path *field.Path, value any, expected any) field.ErrorList {
+	switch reflectutils.GetKind(expected) {
+	case reflect.Map:
+		if value == nil {
+			return field.ErrorList{field.Invalid(path, value, "invalid value, must not be null")}
+		}
+		if reflectutils.GetKind(value) != reflect.Map {
+			return field.ErrorList{field.TypeInvalid(path, value, "expected a map")}
+		}
+		expectedOf := reflect.ValueOf(expected)
+		valueOf := reflect.ValueOf(value)
+		keys := expectedOf.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		var errs field.ErrorList
+		for _, key := range keys {
+			path := path.Child(fmt.Sprint(key.Interface()))
+			actual := valueOf.MapIndex(key)
+			if !actual.IsValid() {
+				errs = append(errs, field.Required(path, "field not found in the input object"))
+				continue
+			}
+			errs = append(errs, literal(path, actual.Interface(), expectedOf.MapIndex(key).Interface())...)
+		}
+		return errs
+	case reflect.Slice:
+		if value == nil {
+			return field.ErrorList{field.Invalid(path, value, "value is null")}
+		}
+		if reflectutils.GetKind(value) != reflect.Slice {
+			return field.ErrorList{field.TypeInvalid(path, value, "expected a slice")}
+		}
+		expectedOf := reflect.ValueOf(expected)
+		valueOf := reflect.ValueOf(value)
+		if valueOf.Len() != expectedOf.Len() {
+			return field.ErrorList{field.Invalid(path, value, "lengths of slices don't match")}
+		}
+		var errs field.ErrorList
+		for i := 0; i < expectedOf.Len(); i++ {
+			errs = append(errs, literal(path.Index(i), valueOf.Index(i).Interface(), expectedOf.Index(i).Interface())...)
+		}
+		return errs
+	default:
+		// values of different types are reported as a mismatch, not as an error
+		if match, err := matching.Match(expected, value); err != nil || !match {
+			return field.ErrorList{field.Invalid(path, value, expectValueMessage(expected))}
+		}
+		return nil
+	}
+}
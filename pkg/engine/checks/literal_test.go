@@ -0,0 +1,86 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      any
+		expected any
+		want     field.ErrorList
+	}{{
+		name: "subset",
+		obj: map[string]any{
+			"metadata": map[string]any{"name": "foo", "uid": "1234"},
+			"spec":     map[string]any{"replicas": int64(3)},
+		},
+		expected: map[string]any{
+			"metadata": map[string]any{"name": "foo"},
+			"spec":     map[string]any{"replicas": int64(3)},
+		},
+	}, {
+		name: "expressions are not evaluated",
+		obj: map[string]any{
+			"data": map[string]any{
+				"query":   "(length(items))",
+				"-config": "foo",
+				"=strict": "bar",
+				"~.items": "baz",
+				"status":  "(x_absent())",
+			},
+		},
+		expected: map[string]any{
+			"data": map[string]any{
+				"query":   "(length(items))",
+				"-config": "foo",
+				"=strict": "bar",
+				"~.items": "baz",
+				"status":  "(x_absent())",
+			},
+		},
+	}, {
+		name: "expression compared as a string",
+		obj: map[string]any{
+			"items": []any{"a", "b"},
+			"count": int64(2),
+		},
+		expected: map[string]any{
+			"count": "(length(items))",
+		},
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("count"), int64(2), `Expected value: "(length(items))"`),
+		},
+	}, {
+		name: "missing field",
+		obj: map[string]any{
+			"data": map[string]any{},
+		},
+		expected: map[string]any{
+			"data": map[string]any{"-config": "foo"},
+		},
+		want: field.ErrorList{
+			field.Required(field.NewPath("data", "-config"), "field not found in the input object"),
+		},
+	}, {
+		name: "slices",
+		obj: map[string]any{
+			"args": []any{"--v=2"},
+		},
+		expected: map[string]any{
+			"args": []any{"--v=2", "--port=80"},
+		},
+		want: field.ErrorList{
+			field.Invalid(field.NewPath("args"), []any{"--v=2"}, "lengths of slices don't match"),
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Literal(tt.obj, tt.expected))
+		})
+	}
+}
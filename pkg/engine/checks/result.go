@@ -0,0 +1,18 @@
+package checks
+
+import (
+	"github.com/kyverno/chainsaw/pkg/engine/functions"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// resultError reports the result message and value, it falls back to the expected value message and the pass field.
+func resultError(path *field.Path, result functions.Result, expected any) *field.Error {
+	message := result.Message
+	if message == "" {
+		message = expectValueMessage(expected)
+	}
+	if result.Value == nil {
+		return field.Invalid(path, result.Pass, message)
+	}
+	return field.Invalid(path, result.Value, message)
+}
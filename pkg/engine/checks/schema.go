@@ -0,0 +1,25 @@
+package checks
+
+import (
+	"sort"
+
+	"github.com/xeipuuv/gojsonschema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Schema validates an object against a JSON schema and reports all violations.
+func Schema(schema *gojsonschema.Schema, obj any) (field.ErrorList, error) {
+	result, err := schema.Validate(gojsonschema.NewGoLoader(obj))
+	if err != nil {
+		return nil, err
+	}
+	var errs field.ErrorList
+	for _, violation := range result.Errors() {
+		errs = append(errs, field.Invalid(field.NewPath(violation.Field()), violation.Value(), violation.Description()))
+	}
+	// violations are not reported in a stable order
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+	return errs, nil
+}
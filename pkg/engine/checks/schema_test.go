@@ -0,0 +1,82 @@
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xeipuuv/gojsonschema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestSchema(t *testing.T) {
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(map[string]any{
+		"type":     "object",
+		"required": []any{"data"},
+		"properties": map[string]any{
+			"data": map[string]any{
+				"type":     "object",
+				"required": []any{"replicas"},
+				"properties": map[string]any{
+					"replicas": map[string]any{
+						"type":    "string",
+						"pattern": "^[0-9]+$",
+					},
+					"mode": map[string]any{
+						"type": "string",
+						"enum": []any{"active", "passive"},
+					},
+				},
+			},
+		},
+	}))
+	assert.NoError(t, err)
+	tests := []struct {
+		name string
+		obj  any
+		want field.ErrorList
+	}{{
+		name: "conforming",
+		obj: map[string]any{
+			"data": map[string]any{
+				"replicas": "3",
+				"mode":     "active",
+			},
+		},
+		want: nil,
+	}, {
+		name: "missing field",
+		obj:  map[string]any{},
+		want: field.ErrorList{{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "(root)",
+			BadValue: map[string]any{},
+			Detail:   "data is required",
+		}},
+	}, {
+		name: "non conforming",
+		obj: map[string]any{
+			"data": map[string]any{
+				"replicas": "three",
+				"mode":     "unknown",
+			},
+		},
+		want: field.ErrorList{{
+			Type:     field.ErrorTypeInvalid,
+			Field:    "data.mode",
+			BadValue: "unknown",
+			Detail:   `data.mode must be one of the following: "active", "passive"`,
+		}, {
+			Type:     field.ErrorTypeInvalid,
+			Field:    "data.replicas",
+			BadValue: "three",
+			Detail:   `Does not match pattern '^[0-9]+$'`,
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Schema(schema, tt.obj)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
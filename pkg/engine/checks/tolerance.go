@@ -0,0 +1,90 @@
+package checks
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/jmespath-community/go-jmespath/pkg/binding"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/kyverno-json/pkg/core/matching"
+	"github.com/kyverno/kyverno-json/pkg/core/projection"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type tolerance struct {
+	absolute float64
+	relative float64
+}
+
+func parseTolerances(in ...v1alpha1.Tolerance) (map[string]tolerance, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := map[string]tolerance{}
+	for _, t := range in {
+		var parsed tolerance
+		if t.Absolute != "" {
+			value, err := strconv.ParseFloat(t.Absolute, 64)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("invalid absolute tolerance for path %s (%s)", t.Path, t.Absolute)
+			}
+			parsed.absolute = value
+		}
+		if t.Relative != "" {
+			value, err := strconv.ParseFloat(t.Relative, 64)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("invalid relative tolerance for path %s (%s)", t.Path, t.Relative)
+			}
+			parsed.relative = value
+		}
+		out[t.Path] = parsed
+	}
+	return out, nil
+}
+
+// toleranceNode is the assertion represented by a numeric leaf with a tolerance.
+// numbers match when they are within the absolute or relative epsilon,
+// other values fall back to an exact comparison.
+type toleranceNode struct {
+	handler   projection.ScalarHandler
+	tolerance tolerance
+}
+
+func (node toleranceNode) Assert(path *field.Path, value any, bindings binding.Bindings) (field.ErrorList, error) {
+	var errs field.ErrorList
+	projected, err := node.handler(value, bindings)
+	if err != nil {
+		return nil, field.InternalError(path, err)
+	}
+	expected, expectedOk := toFloat(projected)
+	actual, actualOk := toFloat(value)
+	if expectedOk && actualOk {
+		delta := math.Abs(actual - expected)
+		if delta > node.tolerance.absolute && delta > node.tolerance.relative*math.Abs(expected) {
+			errs = append(errs, field.Invalid(path, value, fmt.Sprintf("%s (absolute tolerance: %v, relative tolerance: %v)", expectValueMessage(projected), node.tolerance.absolute, node.tolerance.relative)))
+		}
+	} else if match, err := matching.Match(projected, value); err != nil {
+		return nil, field.InternalError(path, err)
+	} else if !match {
+		errs = append(errs, field.Invalid(path, value, expectValueMessage(projected)))
+	}
+	return errs, nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case int:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case float32:
+		return float64(typed), true
+	case float64:
+		return typed, true
+	default:
+		return 0, false
+	}
+}
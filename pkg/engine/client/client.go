@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func New(inner client.Client) client.Client {
@@ -72,6 +73,10 @@ func (c *runnerClient) List(ctx context.Context, list client.ObjectList, opts ..
 	return c.inner.List(ctx, list, opts...)
 }
 
+func (c *runnerClient) Watch(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	return c.inner.Watch(ctx, list, opts...)
+}
+
 func (c *runnerClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (_err error) {
 	gvk := obj.GetObjectKind().GroupVersionKind()
 	defer func() {
@@ -85,6 +90,10 @@ func (c *runnerClient) Patch(ctx context.Context, obj client.Object, patch clien
 	return c.inner.Patch(ctx, obj, patch, opts...)
 }
 
+func (c *runnerClient) SubResource(subResource string) client.SubResourceClient {
+	return c.inner.SubResource(subResource)
+}
+
 func (c *runnerClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
 	return c.inner.IsObjectNamespaced(obj)
 }
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/version"
+)
+
+type ClusterInfo struct {
+	// Version is nil when the cluster version could not be retrieved.
+	Version *ClusterVersion
+}
+
+type ClusterVersion struct {
+	Major      int
+	Minor      int
+	GitVersion string
+	Platform   string
+}
+
+func NewClusterVersion(info *version.Info) *ClusterVersion {
+	if info == nil {
+		return nil
+	}
+	return &ClusterVersion{
+		Major:      parseVersionNumber(info.Major),
+		Minor:      parseVersionNumber(info.Minor),
+		GitVersion: info.GitVersion,
+		Platform:   info.Platform,
+	}
+}
+
+func WithClusterInfo(ctx context.Context, tc Context, info *version.Info) Context {
+	return tc.WithBinding(ctx, "cluster", ClusterInfo{
+		Version: NewClusterVersion(info),
+	})
+}
+
+// parseVersionNumber parses the leading digits of a version number,
+// some providers add a suffix to the minor version (1.29+ for example).
+func parseVersionNumber(in string) int {
+	digits := strings.TrimRightFunc(in, func(r rune) bool { return !unicode.IsDigit(r) })
+	value, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/utils/ptr"
+)
+
+func TestNewClusterVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		info *version.Info
+		want *ClusterVersion
+	}{{
+		name: "nil",
+		info: nil,
+		want: nil,
+	}, {
+		name: "version",
+		info: &version.Info{Major: "1", Minor: "29", GitVersion: "v1.29.2", Platform: "linux/amd64"},
+		want: &ClusterVersion{Major: 1, Minor: 29, GitVersion: "v1.29.2", Platform: "linux/amd64"},
+	}, {
+		name: "with suffix",
+		info: &version.Info{Major: "1", Minor: "28+", GitVersion: "v1.28.5-gke.1217000"},
+		want: &ClusterVersion{Major: 1, Minor: 28, GitVersion: "v1.28.5-gke.1217000"},
+	}, {
+		name: "invalid",
+		info: &version.Info{Major: "", Minor: "foo"},
+		want: &ClusterVersion{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewClusterVersion(tt.info)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithClusterInfo(t *testing.T) {
+	// skip on clusters older than 1.29
+	check := v1alpha1.NewCheck(map[string]any{
+		"(($cluster.version.major > `1`) || ($cluster.version.minor >= `29`))": true,
+	})
+	tests := []struct {
+		name     string
+		info     *version.Info
+		wantSkip bool
+	}{{
+		name:     "recent",
+		info:     &version.Info{Major: "1", Minor: "30"},
+		wantSkip: false,
+	}, {
+		name:     "old",
+		info:     &version.Info{Major: "1", Minor: "28+"},
+		wantSkip: true,
+	}, {
+		name:     "unknown",
+		info:     nil,
+		wantSkip: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := WithClusterInfo(context.TODO(), enginecontext.EmptyContext(), tt.info)
+			errs, err := checks.Check(context.TODO(), apis.DefaultCompilers, nil, tc.Bindings(), ptr.To(check))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSkip, len(errs) != 0)
+		})
+	}
+}
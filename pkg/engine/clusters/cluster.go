@@ -1,6 +1,8 @@
 package clusters
 
 import (
+	"encoding/base64"
+	"strings"
 	"sync"
 
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
@@ -44,3 +46,71 @@ func NewClusterFromKubeconfig(kubeconfig string, context string) Cluster {
 func (c *fromKubeconfig) Config() (*rest.Config, error) {
 	return c.resolver()
 }
+
+// NewClusterFromKubeconfigData creates a cluster from an inline kubeconfig,
+// the kubeconfig can be provided raw or base64 encoded.
+func NewClusterFromKubeconfigData(kubeconfig string, context string) Cluster {
+	resolver := sync.OnceValues(func() (*rest.Config, error) {
+		data := []byte(kubeconfig)
+		if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(kubeconfig)); err == nil {
+			data = decoded
+		}
+		return restutils.ConfigFromBytes(data, clientcmd.ConfigOverrides{
+			CurrentContext: context,
+		})
+	})
+	return &fromKubeconfig{
+		resolver: resolver,
+	}
+}
+
+type impersonated struct {
+	inner       Cluster
+	impersonate rest.ImpersonationConfig
+}
+
+func NewImpersonatedCluster(inner Cluster, impersonate rest.ImpersonationConfig) Cluster {
+	return &impersonated{
+		inner:       inner,
+		impersonate: impersonate,
+	}
+}
+
+func (c *impersonated) Config() (*rest.Config, error) {
+	config, err := c.inner.Config()
+	if err != nil || config == nil {
+		return config, err
+	}
+	config = rest.CopyConfig(config)
+	config.Impersonate = c.impersonate
+	return config, nil
+}
+
+type rateLimited struct {
+	inner Cluster
+	qps   float32
+	burst int
+}
+
+func NewRateLimitedCluster(inner Cluster, qps float32, burst int) Cluster {
+	return &rateLimited{
+		inner: inner,
+		qps:   qps,
+		burst: burst,
+	}
+}
+
+func (c *rateLimited) Config() (*rest.Config, error) {
+	config, err := c.inner.Config()
+	if err != nil || config == nil {
+		return config, err
+	}
+	config = rest.CopyConfig(config)
+	if c.qps > 0 {
+		config.QPS = c.qps
+	}
+	if c.burst > 0 {
+		config.Burst = c.burst
+	}
+	return config, nil
+}
@@ -1,6 +1,7 @@
 package clusters
 
 import (
+	"encoding/base64"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -64,3 +65,146 @@ func TestNewClusterFromKubeconfig(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClusterFromKubeconfigData(t *testing.T) {
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:53742
+  name: kind-kind
+- cluster:
+    server: https://127.0.0.1:1234
+  name: foo
+contexts:
+- context:
+    cluster: kind-kind
+    user: kind-kind
+  name: kind-kind
+- context:
+    cluster: foo
+    user: kind-kind
+  name: foo
+current-context: kind-kind
+users:
+- name: kind-kind
+  user:
+    token: secret
+`
+	tests := []struct {
+		name       string
+		kubeconfig string
+		context    string
+		wantHost   string
+		wantErr    bool
+	}{{
+		name:    "none",
+		wantErr: true,
+	}, {
+		name:       "invalid",
+		kubeconfig: "foo: [",
+		wantErr:    true,
+	}, {
+		name:       "raw",
+		kubeconfig: kubeconfig,
+		wantHost:   "https://127.0.0.1:53742",
+	}, {
+		name:       "raw with context",
+		kubeconfig: kubeconfig,
+		context:    "foo",
+		wantHost:   "https://127.0.0.1:1234",
+	}, {
+		name:       "base64",
+		kubeconfig: base64.StdEncoding.EncodeToString([]byte(kubeconfig)),
+		wantHost:   "https://127.0.0.1:53742",
+	}, {
+		name:       "base64 with context",
+		kubeconfig: base64.StdEncoding.EncodeToString([]byte(kubeconfig)) + "\n",
+		context:    "foo",
+		wantHost:   "https://127.0.0.1:1234",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewClusterFromKubeconfigData(tt.kubeconfig, tt.context)
+			assert.NotNil(t, got)
+			config, err := got.Config()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, config)
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, config) {
+					assert.Equal(t, tt.wantHost, config.Host)
+					assert.Equal(t, "secret", config.BearerToken)
+				}
+			}
+		})
+	}
+}
+
+func TestNewImpersonatedCluster(t *testing.T) {
+	impersonate := rest.ImpersonationConfig{
+		UserName: "system:serviceaccount:foo:bar",
+		Groups:   []string{"system:serviceaccounts"},
+	}
+	tests := []struct {
+		name   string
+		config *rest.Config
+		want   *rest.Config
+	}{{
+		name: "nil",
+	}, {
+		name:   "not nil",
+		config: &rest.Config{Host: "https://localhost"},
+		want: &rest.Config{
+			Host:        "https://localhost",
+			Impersonate: impersonate,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := NewClusterFromConfig(tt.config)
+			assert.NoError(t, err)
+			got, err := NewImpersonatedCluster(inner, impersonate).Config()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.config != nil {
+				// the inner config must not be modified
+				assert.Empty(t, tt.config.Impersonate.UserName)
+			}
+		})
+	}
+}
+
+func TestNewRateLimitedCluster(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *rest.Config
+		qps    float32
+		burst  int
+		want   *rest.Config
+	}{{
+		name: "nil",
+		qps:  100,
+	}, {
+		name:   "qps and burst",
+		config: &rest.Config{Host: "https://localhost", QPS: 5, Burst: 10},
+		qps:    100,
+		burst:  200,
+		want:   &rest.Config{Host: "https://localhost", QPS: 100, Burst: 200},
+	}, {
+		name:   "zero values",
+		config: &rest.Config{Host: "https://localhost", QPS: 5, Burst: 10},
+		want:   &rest.Config{Host: "https://localhost", QPS: 5, Burst: 10},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := NewClusterFromConfig(tt.config)
+			assert.NoError(t, err)
+			got, err := NewRateLimitedCluster(inner, tt.qps, tt.burst).Config()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package clusters
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// DefaultConnectionInterval is the delay before the first connection retry when none is configured.
+const DefaultConnectionInterval = time.Second
+
+// Connect calls connect until it succeeds or the number of attempts is exhausted.
+// The delay between two attempts starts at interval and doubles after every failed attempt,
+// it is measured with the given clock so that tests don't have to wait.
+func Connect(ctx context.Context, clock clock.Clock, attempts int, interval time.Duration, connect func() error) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if interval <= 0 {
+		interval = DefaultConnectionInterval
+	}
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = connect(); err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("failed to connect to cluster after %d attempt(s): %w", attempts, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to connect to cluster: %w", err)
+		case <-clock.After(interval):
+		}
+		interval *= 2
+	}
+}
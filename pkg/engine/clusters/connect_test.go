@@ -0,0 +1,98 @@
+package clusters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	tclock "k8s.io/utils/clock/testing"
+)
+
+func TestConnect(t *testing.T) {
+	tests := []struct {
+		name      string
+		attempts  int
+		failures  int
+		wantCalls int
+		wantDelay []time.Duration
+		wantErr   string
+	}{{
+		name:      "no failure",
+		attempts:  3,
+		wantCalls: 1,
+	}, {
+		name:      "no retry",
+		failures:  1,
+		wantCalls: 1,
+		wantErr:   "failed to connect to cluster after 1 attempt(s): connection refused",
+	}, {
+		name:      "first attempts fail then succeed",
+		attempts:  5,
+		failures:  3,
+		wantCalls: 4,
+		wantDelay: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+	}, {
+		name:      "all attempts fail",
+		attempts:  3,
+		failures:  5,
+		wantCalls: 3,
+		wantDelay: []time.Duration{time.Second, 2 * time.Second},
+		wantErr:   "failed to connect to cluster after 3 attempt(s): connection refused",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			clock := tclock.NewFakeClock(start)
+			var calls []time.Time
+			connect := func() error {
+				calls = append(calls, clock.Now())
+				if len(calls) <= tt.failures {
+					return errors.New("connection refused")
+				}
+				return nil
+			}
+			done := make(chan error)
+			go func() {
+				done <- Connect(context.TODO(), clock, tt.attempts, 0, connect)
+			}()
+			var err error
+		loop:
+			for {
+				select {
+				case err = <-done:
+					break loop
+				default:
+					if clock.HasWaiters() {
+						clock.Step(time.Second)
+					}
+				}
+			}
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, calls, tt.wantCalls)
+			var delays []time.Duration
+			for i := 1; i < len(calls); i++ {
+				delays = append(delays, calls[i].Sub(calls[i-1]))
+			}
+			assert.Equal(t, tt.wantDelay, delays)
+		})
+	}
+}
+
+func TestConnect_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	clock := tclock.NewFakeClock(time.Now())
+	calls := 0
+	err := Connect(ctx, clock, 3, time.Second, func() error {
+		calls++
+		return errors.New("connection refused")
+	})
+	assert.EqualError(t, err, "failed to connect to cluster: connection refused")
+	assert.Equal(t, 1, calls)
+}
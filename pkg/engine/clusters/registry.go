@@ -17,25 +17,33 @@ type Registry interface {
 
 type clientFactory = func(Cluster) (*rest.Config, client.Client, error)
 
-func defaultClientFactory(cluster Cluster) (*rest.Config, client.Client, error) {
-	if cluster == nil {
-		return nil, nil, nil
-	}
-	config, err := cluster.Config()
-	if err != nil {
-		return nil, nil, err
-	}
-	client, err := simple.New(config)
-	if err != nil {
-		return nil, nil, err
+var defaultClientFactory = NewClientFactory(simple.New)
+
+// NewClientFactory creates a client factory building clients with the given function,
+// built clients are wrapped in an engine client.
+func NewClientFactory(build func(*rest.Config) (client.Client, error)) clientFactory {
+	return func(cluster Cluster) (*rest.Config, client.Client, error) {
+		if cluster == nil {
+			return nil, nil, nil
+		}
+		config, err := cluster.Config()
+		if err != nil {
+			return nil, nil, err
+		}
+		client, err := build(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		client = engineclient.New(client)
+		return config, client, nil
 	}
-	client = engineclient.New(client)
-	return config, client, nil
 }
 
 type registry struct {
 	clientFactory clientFactory
 	clusters      map[string]Cluster
+	qps           float32
+	burst         int
 }
 
 func NewRegistry(f clientFactory) Registry {
@@ -45,6 +53,17 @@ func NewRegistry(f clientFactory) Registry {
 	}
 }
 
+// NewRateLimitedRegistry creates a registry setting the client side rate limits
+// of the configs it builds, zero values leave the cluster config untouched.
+func NewRateLimitedRegistry(f clientFactory, qps float32, burst int) Registry {
+	return registry{
+		clientFactory: f,
+		clusters:      map[string]Cluster{},
+		qps:           qps,
+		burst:         burst,
+	}
+}
+
 func (c registry) Register(name string, cluster Cluster) Registry {
 	values := map[string]Cluster{}
 	for k, v := range c.clusters {
@@ -54,6 +73,8 @@ func (c registry) Register(name string, cluster Cluster) Registry {
 	return registry{
 		clientFactory: c.clientFactory,
 		clusters:      values,
+		qps:           c.qps,
+		burst:         c.burst,
 	}
 }
 
@@ -66,5 +87,8 @@ func (c registry) Build(cluster Cluster) (*rest.Config, client.Client, error) {
 	if f == nil {
 		f = defaultClientFactory
 	}
+	if cluster != nil && (c.qps > 0 || c.burst > 0) {
+		cluster = NewRateLimitedCluster(cluster, c.qps, c.burst)
+	}
 	return f(cluster)
 }
@@ -178,6 +178,48 @@ func Test_registry_Build(t *testing.T) {
 	}
 }
 
+func Test_registry_Build_RateLimits(t *testing.T) {
+	factory := func(cluster Cluster) (*rest.Config, client.Client, error) {
+		config, err := cluster.Config()
+		return config, &tclient.FakeClient{}, err
+	}
+	cluster, err := NewClusterFromConfig(&rest.Config{Host: "https://localhost"})
+	assert.NoError(t, err)
+	c := NewRateLimitedRegistry(factory, 100, 200).Register(DefaultClient, cluster)
+	config, _, err := c.Build(c.Lookup(DefaultClient))
+	assert.NoError(t, err)
+	assert.Equal(t, float32(100), config.QPS)
+	assert.Equal(t, 200, config.Burst)
+}
+
+func Test_registry_Build_KubeconfigData(t *testing.T) {
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:53742
+  name: kind
+contexts:
+- context:
+    cluster: kind
+    user: kind
+  name: kind
+current-context: kind
+users:
+- name: kind
+  user:
+    token: secret
+`
+	c := NewRegistry(nil).Register("inline", NewClusterFromKubeconfigData(kubeconfig, ""))
+	config, client, err := c.Build(c.Lookup("inline"))
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+	if assert.NotNil(t, config) {
+		assert.Equal(t, "https://127.0.0.1:53742", config.Host)
+	}
+}
+
 func Test_defaultClientFactory(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,73 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+// Result is produced by a collector.
+// It either defines a command to run (Entrypoint and Args) or a structured Output to log.
+type Result struct {
+	Entrypoint string
+	Args       []string
+	Output     any
+}
+
+// Collector produces diagnostics for a collector type.
+// A custom build of chainsaw can register collectors with Register, they can then be used in catch and finally blocks.
+type Collector interface {
+	Collect(ctx context.Context, compilers compilers.Compilers, client client.Client, bindings apis.Bindings, config any) (*Result, error)
+}
+
+// CollectorFunc is an adapter to use an ordinary function as a Collector.
+type CollectorFunc func(context.Context, compilers.Compilers, client.Client, apis.Bindings, any) (*Result, error)
+
+func (f CollectorFunc) Collect(ctx context.Context, compilers compilers.Compilers, client client.Client, bindings apis.Bindings, config any) (*Result, error) {
+	return f(ctx, compilers, client, bindings, config)
+}
+
+var (
+	lock     sync.RWMutex
+	registry = map[string]Collector{}
+)
+
+// Register registers a collector for the given type, a type can only be registered once.
+func Register(name string, collector Collector) error {
+	if name == "" {
+		return errors.New("collector type must not be empty")
+	}
+	if collector == nil {
+		return fmt.Errorf("collector %q is null", name)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if _, ok := registry[name]; ok {
+		return fmt.Errorf("collector %q is already registered", name)
+	}
+	registry[name] = collector
+	return nil
+}
+
+// Get returns the collector registered for the given type.
+func Get(name string) (Collector, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+	collector, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown collector type %q", name)
+	}
+	return collector, nil
+}
+
+// Unregister removes the collector registered for the given type, it is mostly useful in tests.
+func Unregister(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(registry, name)
+}
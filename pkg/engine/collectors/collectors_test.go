@@ -0,0 +1,33 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	collector := CollectorFunc(func(_ context.Context, _ compilers.Compilers, _ client.Client, _ apis.Bindings, config any) (*Result, error) {
+		return &Result{Output: config}, nil
+	})
+	defer Unregister("test")
+	assert.EqualError(t, Register("", collector), "collector type must not be empty")
+	assert.EqualError(t, Register("test", nil), `collector "test" is null`)
+	assert.NoError(t, Register("test", collector))
+	assert.EqualError(t, Register("test", collector), `collector "test" is already registered`)
+	got, err := Get("test")
+	assert.NoError(t, err)
+	result, err := got.Collect(context.TODO(), apis.DefaultCompilers, nil, nil, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{Output: "foo"}, result)
+}
+
+func TestGet(t *testing.T) {
+	got, err := Get("unknown")
+	assert.Nil(t, got)
+	assert.EqualError(t, err, `unknown collector type "unknown"`)
+}
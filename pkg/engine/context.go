@@ -2,11 +2,14 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/engine/bindings"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/clock"
 )
 
 func WithBindings(ctx context.Context, tc Context, variables ...v1alpha1.Binding) (Context, error) {
@@ -20,16 +23,29 @@ func WithBindings(ctx context.Context, tc Context, variables ...v1alpha1.Binding
 	return tc, nil
 }
 
-func WithClusters(ctx context.Context, tc Context, basePath string, c map[string]v1alpha1.Cluster) Context {
+func WithClusters(ctx context.Context, tc Context, basePath string, c map[string]v1alpha1.Cluster) (Context, error) {
 	for name, cluster := range c {
-		kubeconfig := filepath.Join(basePath, cluster.Kubeconfig)
-		cluster := clusters.NewClusterFromKubeconfig(kubeconfig, cluster.Context)
-		tc = tc.WithCluster(ctx, name, cluster)
+		switch {
+		case cluster.Kubeconfig != "" && cluster.KubeconfigData != "":
+			return tc, fmt.Errorf("cluster %s: kubeconfig and kubeconfigData are mutually exclusive", name)
+		case cluster.KubeconfigData != "":
+			kubeconfig, err := cluster.KubeconfigData.Value(ctx, tc.Compilers(), tc.Bindings())
+			if err != nil {
+				return tc, fmt.Errorf("cluster %s: failed to resolve kubeconfigData: %w", name, err)
+			}
+			tc = tc.WithCluster(ctx, name, clusters.NewClusterFromKubeconfigData(kubeconfig, cluster.Context))
+		default:
+			kubeconfig := filepath.Join(basePath, cluster.Kubeconfig)
+			tc = tc.WithCluster(ctx, name, clusters.NewClusterFromKubeconfig(kubeconfig, cluster.Context))
+		}
 	}
-	return tc
+	return tc, nil
 }
 
 func WithCurrentCluster(ctx context.Context, tc Context, name string) (Context, error) {
+	if name != clusters.DefaultClient && tc.Cluster(name) == nil {
+		return tc, fmt.Errorf("cluster not found: %s", name)
+	}
 	tc = tc.WithCurrentCluster(ctx, name)
 	config, client, err := tc.CurrentClusterClient()
 	if err != nil {
@@ -40,6 +56,21 @@ func WithCurrentCluster(ctx context.Context, tc Context, name string) (Context,
 	return tc, nil
 }
 
+func WithImpersonation(ctx context.Context, tc Context, impersonate rest.ImpersonationConfig) (Context, error) {
+	tc = tc.WithImpersonation(ctx, impersonate)
+	config, client, err := tc.CurrentClusterClient()
+	if err != nil {
+		return tc, err
+	}
+	tc = tc.WithBinding(ctx, "client", client)
+	tc = tc.WithBinding(ctx, "config", config)
+	return tc, nil
+}
+
+func WithClock(ctx context.Context, tc Context, clock clock.PassiveClock) Context {
+	return tc.WithBinding(ctx, "clock", clock)
+}
+
 func WithNamespace(ctx context.Context, tc Context, namespace string) Context {
 	return tc.WithBinding(ctx, "namespace", namespace)
 }
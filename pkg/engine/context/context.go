@@ -94,6 +94,13 @@ func (tc TestContext) WithCurrentCluster(ctx context.Context, name string) TestC
 	return tc
 }
 
+func (tc TestContext) WithImpersonation(ctx context.Context, impersonate rest.ImpersonationConfig) TestContext {
+	if tc.cluster != nil {
+		tc.cluster = clusters.NewImpersonatedCluster(tc.cluster, impersonate)
+	}
+	return tc
+}
+
 func (tc TestContext) WithDryRun(ctx context.Context, dryRun bool) TestContext {
 	tc.dryRun = dryRun
 	return tc
@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithClusters(t *testing.T) {
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:53742
+  name: kind
+contexts:
+- context:
+    cluster: kind
+    user: kind
+  name: kind
+current-context: kind
+users:
+- name: kind
+  user:
+    token: secret
+`
+	tests := []struct {
+		name     string
+		clusters map[string]v1alpha1.Cluster
+		wantHost string
+		wantErr  bool
+	}{{
+		name: "from binding",
+		clusters: map[string]v1alpha1.Cluster{
+			"inline": {KubeconfigData: "($kubeconfig)"},
+		},
+		wantHost: "https://127.0.0.1:53742",
+	}, {
+		name: "raw",
+		clusters: map[string]v1alpha1.Cluster{
+			"inline": {KubeconfigData: v1alpha1.Expression(kubeconfig)},
+		},
+		wantHost: "https://127.0.0.1:53742",
+	}, {
+		name: "unknown binding",
+		clusters: map[string]v1alpha1.Cluster{
+			"inline": {KubeconfigData: "($unknown)"},
+		},
+		wantErr: true,
+	}, {
+		name: "mutually exclusive",
+		clusters: map[string]v1alpha1.Cluster{
+			"inline": {Kubeconfig: "kubeconfig", KubeconfigData: "($kubeconfig)"},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := enginecontext.EmptyContext().WithBinding(context.TODO(), "kubeconfig", kubeconfig)
+			got, err := WithClusters(context.TODO(), tc, "", tt.clusters)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			config, client, err := got.Clusters().Build(got.Cluster("inline"))
+			assert.NoError(t, err)
+			assert.NotNil(t, client)
+			if assert.NotNil(t, config) {
+				assert.Equal(t, tt.wantHost, config.Host)
+			}
+		})
+	}
+}
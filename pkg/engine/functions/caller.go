@@ -2,6 +2,8 @@ package functions
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 
 	jpfunctions "github.com/jmespath-community/go-jmespath/pkg/functions"
@@ -9,9 +11,79 @@ import (
 	"github.com/kyverno/kyverno-json/pkg/jp"
 )
 
-var Caller = sync.OnceValue(func() interpreter.FunctionCaller {
+var defaultRegistry = NewRegistry()
+
+// Caller returns the function caller used to evaluate JMESPath expressions.
+// It includes built-in functions and the functions added with Register.
+func Caller() interpreter.FunctionCaller {
+	return defaultRegistry
+}
+
+// Register adds custom functions to the functions available in expressions and templates.
+// It is meant to be called by custom builds (usually from an init function) before any expression is evaluated.
+// A function can't shadow a built-in or previously registered function unless override is true.
+func Register(override bool, funcs ...jpfunctions.FunctionEntry) error {
+	return defaultRegistry.Register(override, funcs...)
+}
+
+// Registry is a function caller combining built-in functions with custom functions.
+// The set of functions is frozen on the first function call.
+type Registry struct {
+	lock   sync.Mutex
+	once   sync.Once
+	custom []jpfunctions.FunctionEntry
+	caller interpreter.FunctionCaller
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) Register(override bool, funcs ...jpfunctions.FunctionEntry) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.caller != nil {
+		return errors.New("functions must be registered before any expression is evaluated")
+	}
+	known := map[string]bool{}
+	for _, function := range builtins() {
+		known[function.Name] = true
+	}
+	for _, function := range r.custom {
+		known[function.Name] = true
+	}
+	for _, function := range funcs {
+		if function.Name == "" {
+			return errors.New("function name must not be empty")
+		}
+		if function.Handler == nil {
+			return fmt.Errorf("function %s has no handler", function.Name)
+		}
+		if known[function.Name] && !override {
+			return fmt.Errorf("function %s is already defined, override must be enabled to replace it", function.Name)
+		}
+		known[function.Name] = true
+	}
+	r.custom = append(r.custom, funcs...)
+	return nil
+}
+
+func (r *Registry) CallFunction(name string, arguments []any) (any, error) {
+	r.once.Do(func() {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		// later entries take precedence, custom functions come after built-ins
+		var funcs []jpfunctions.FunctionEntry
+		funcs = append(funcs, builtins()...)
+		funcs = append(funcs, r.custom...)
+		r.caller = interpreter.NewFunctionCaller(funcs...)
+	})
+	return r.caller.CallFunction(name, arguments)
+}
+
+func builtins() []jpfunctions.FunctionEntry {
 	var funcs []jpfunctions.FunctionEntry
 	funcs = append(funcs, jp.GetFunctions(context.Background())...)
 	funcs = append(funcs, GetFunctions()...)
-	return interpreter.NewFunctionCaller(funcs...)
-})
+	return funcs
+}
@@ -0,0 +1,95 @@
+package functions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmespath-community/go-jmespath/pkg/functions"
+	"github.com/stretchr/testify/assert"
+)
+
+func upper(name string) functions.FunctionEntry {
+	return functions.FunctionEntry{
+		Name: name,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpString}},
+		},
+		Handler: func(arguments []any) (any, error) {
+			return strings.ToUpper(arguments[0].(string)), nil
+		},
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []functions.FunctionEntry
+		override bool
+		funcs    []functions.FunctionEntry
+		wantErr  string
+	}{{
+		name:  "custom function",
+		funcs: []functions.FunctionEntry{upper("shout")},
+	}, {
+		name:    "shadow built-in",
+		funcs:   []functions.FunctionEntry{upper("trim_space")},
+		wantErr: "function trim_space is already defined, override must be enabled to replace it",
+	}, {
+		name:    "shadow jmespath built-in",
+		funcs:   []functions.FunctionEntry{upper("length")},
+		wantErr: "function length is already defined, override must be enabled to replace it",
+	}, {
+		name:     "override built-in",
+		override: true,
+		funcs:    []functions.FunctionEntry{upper("trim_space")},
+	}, {
+		name:     "shadow custom function",
+		existing: []functions.FunctionEntry{upper("shout")},
+		funcs:    []functions.FunctionEntry{upper("shout")},
+		wantErr:  "function shout is already defined, override must be enabled to replace it",
+	}, {
+		name:    "duplicate",
+		funcs:   []functions.FunctionEntry{upper("shout"), upper("shout")},
+		wantErr: "function shout is already defined, override must be enabled to replace it",
+	}, {
+		name:    "empty name",
+		funcs:   []functions.FunctionEntry{upper("")},
+		wantErr: "function name must not be empty",
+	}, {
+		name:    "no handler",
+		funcs:   []functions.FunctionEntry{{Name: "shout"}},
+		wantErr: "function shout has no handler",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			assert.NoError(t, registry.Register(false, tt.existing...))
+			err := registry.Register(tt.override, tt.funcs...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_CallFunction(t *testing.T) {
+	registry := NewRegistry()
+	assert.NoError(t, registry.Register(false, upper("shout")))
+	assert.NoError(t, registry.Register(true, upper("trim_space")))
+	// custom function
+	got, err := registry.CallFunction("shout", []any{"foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, "FOO", got)
+	// overridden built-in
+	got, err = registry.CallFunction("trim_space", []any{" foo "})
+	assert.NoError(t, err)
+	assert.Equal(t, " FOO ", got)
+	// built-in
+	got, err = registry.CallFunction("length", []any{"foo"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, got)
+	// registering after the first call is not allowed
+	assert.EqualError(t, registry.Register(false, upper("whisper")), "functions must be registered before any expression is evaluated")
+}
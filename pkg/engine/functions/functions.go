@@ -13,12 +13,25 @@ var (
 	trimSpace = stable("trim_space")
 	asString  = stable("as_string")
 	// experimental functions
+	assertResult      = experimental("assert_result")
+	imagesEqual       = experimental("images_equal")
+	imagesMatch       = experimental("images_match")
 	k8sGet            = experimental("k8s_get")
 	k8sList           = experimental("k8s_list")
 	k8sExists         = experimental("k8s_exists")
+	k8sLookup         = experimental("k8s_lookup")
 	k8sResourceExists = experimental("k8s_resource_exists")
 	k8sServerVersion  = experimental("k8s_server_version")
+	matchSequence     = experimental("match_sequence")
 	metricsDecode     = experimental("metrics_decode")
+	podRestarts       = experimental("pod_restarts")
+	podScheduledOn    = experimental("pod_scheduled_on")
+	pvcBound          = experimental("pvc_bound")
+	quantity          = experimental("quantity")
+	quantityCompare   = experimental("quantity_compare")
+	replicasReady     = experimental("replicas_ready")
+	timeWithin        = experimental("time_within")
+	uniq              = experimental("uniq")
 )
 
 func GetFunctions() []functions.FunctionEntry {
@@ -29,6 +42,35 @@ func GetFunctions() []functions.FunctionEntry {
 		},
 		Handler:     jpEnv,
 		Description: "Returns the value of the environment variable passed in argument.",
+	}, {
+		Name:        MarkerAbsent,
+		Handler:     jpAbsent,
+		Description: "Returns a marker telling the assertion engine that the field it is the value of must not exist, a field set to null exists.",
+	}, {
+		Name: assertResult,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpAny}, Optional: true},
+		},
+		Handler:     jpAssertResult,
+		Description: "Returns a structured assertion result, when the assertion fails the message and the value (optional third argument) are reported instead of the generic expected value message.",
+	}, {
+		Name: imagesEqual,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpObject}},
+			{Types: []functions.JpType{functions.JpArrayString}},
+		},
+		Handler:     jpImagesEqual,
+		Description: "Checks that the images of the containers of a pod, a workload with a pod template or a cron job are exactly the given list, in the same order (init containers are ignored).",
+	}, {
+		Name: imagesMatch,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpObject}},
+			{Types: []functions.JpType{functions.JpString}},
+		},
+		Handler:     jpImagesMatch,
+		Description: "Checks that the images of all (init and regular) containers of a pod, a workload with a pod template or a cron job match a pattern, either a glob (like `*@sha256:*` to require a digest) or a regular expression prefixed with `regex:`.",
 	}, {
 		Name: k8sGet,
 		Arguments: []functions.ArgSpec{
@@ -61,6 +103,17 @@ func GetFunctions() []functions.FunctionEntry {
 		},
 		Handler:     jpKubernetesExists,
 		Description: "Checks if a given resource exists in a Kubernetes cluster.",
+	}, {
+		Name: k8sLookup,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpAny}, Optional: true},
+		},
+		Handler:     jpKubernetesLookup,
+		Description: "Looks up a resource from a Kubernetes cluster by kind (optionally suffixed with its group, like Deployment.apps), returns the default value (last argument) if the resource is not found.",
 	}, {
 		Name: k8sResourceExists,
 		Arguments: []functions.ArgSpec{
@@ -77,6 +130,14 @@ func GetFunctions() []functions.FunctionEntry {
 		},
 		Handler:     jpKubernetesServerVersion,
 		Description: "Returns the version of a Kubernetes cluster.",
+	}, {
+		Name: matchSequence,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpArrayString}},
+		},
+		Handler:     jpMatchSequence,
+		Description: "Checks that lines matching the given patterns (regular expressions) appear in order in a multiline string (not necessarily contiguous), fails on the first pattern not found in sequence.",
 	}, {
 		Name: metricsDecode,
 		Arguments: []functions.ArgSpec{
@@ -84,6 +145,76 @@ func GetFunctions() []functions.FunctionEntry {
 		},
 		Handler:     jpMetricsDecode,
 		Description: "Decodes metrics in the Prometheus text format.",
+	}, {
+		Name: MarkerPattern,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpString}},
+		},
+		Handler:     jpPattern,
+		Description: "Returns a marker telling the assertion engine that the field it is the value of must be a string matching the regular expression.",
+	}, {
+		Name: podRestarts,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpObject}},
+		},
+		Handler:     jpPodRestarts,
+		Description: "Returns the total number of restarts of a pod, summed across all its (init, regular and ephemeral) containers.",
+	}, {
+		Name: podScheduledOn,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpObject}},
+			{Types: []functions.JpType{functions.JpString}},
+		},
+		Handler:     jpPodScheduledOn,
+		Description: "Returns a structured assertion result checking that a pod is scheduled on a node matching a label selector, the node is looked up in the cluster from the pod `spec.nodeName`.",
+	}, {
+		Name:        MarkerPresent,
+		Handler:     jpPresent,
+		Description: "Returns a marker telling the assertion engine that the field it is the value of must exist, whatever its value.",
+	}, {
+		Name: pvcBound,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpObject}},
+			{Types: []functions.JpType{functions.JpString, functions.JpNumber}, Optional: true},
+		},
+		Handler:     jpPvcBound,
+		Description: "Returns a structured assertion result checking that a persistent volume claim is bound with at least a given capacity (second argument, defaults to the requested storage).",
+	}, {
+		Name: quantity,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpString, functions.JpNumber}},
+		},
+		Handler:     jpQuantity,
+		Description: "Parses a Kubernetes resource quantity and returns its (approximate) numeric value.",
+	}, {
+		Name: quantityCompare,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpString, functions.JpNumber}},
+			{Types: []functions.JpType{functions.JpString, functions.JpNumber}},
+		},
+		Handler:     jpQuantityCompare,
+		Description: "Compares two Kubernetes resource quantities, returns -1 if the first is lower, 0 if they are equal and 1 if the first is greater.",
+	}, {
+		Name: replicasReady,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpObject}},
+		},
+		Handler:     jpReplicasReady,
+		Description: "Returns a structured assertion result checking that the ready replicas of a workload (like a deployment or a stateful set) match its desired replicas, the failure message reports the ready and desired replicas.",
+	}, {
+		Name:        MarkerStrict,
+		Handler:     jpStrict,
+		Description: "Returns a marker telling the assertion engine that the object using it as a key must match its value strictly, fields not declared in the value fail the assertion.",
+	}, {
+		Name: timeWithin,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpAny}},
+			{Types: []functions.JpType{functions.JpString}},
+			{Types: []functions.JpType{functions.JpString}},
+		},
+		Handler:     jpTimeWithin,
+		Description: "Checks if a time (RFC 3339) is not older than a duration (second string) relative to the current time of a clock.",
 	}, {
 		Name: trimSpace,
 		Arguments: []functions.ArgSpec{
@@ -91,6 +222,13 @@ func GetFunctions() []functions.FunctionEntry {
 		},
 		Handler:     jpTrimSpace,
 		Description: "Trims leading and trailing spaces from the string passed in argument.",
+	}, {
+		Name: uniq,
+		Arguments: []functions.ArgSpec{
+			{Types: []functions.JpType{functions.JpArray}},
+		},
+		Handler:     jpUniq,
+		Description: "Returns the distinct elements of an array, preserving the order of their first occurrence.",
 	}, {
 		Name: asString,
 		Arguments: []functions.ArgSpec{
@@ -0,0 +1,136 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// podSpecPaths are the locations of a pod spec in the supported objects (pods, workloads with a pod template and cron jobs).
+var podSpecPaths = [][]string{
+	{"spec"},
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+var podSpecContainers = []string{"initContainers", "containers"}
+
+func jpImagesMatch(arguments []any) (any, error) {
+	var obj map[string]any
+	if err := getArg(arguments, 0, &obj); err != nil {
+		return nil, err
+	}
+	var pattern string
+	if err := getArg(arguments, 1, &pattern); err != nil {
+		return nil, err
+	}
+	matcher, err := imageMatcher(pattern)
+	if err != nil {
+		return nil, err
+	}
+	images, err := containerImages(obj, podSpecContainers...)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return false, nil
+	}
+	for _, image := range images {
+		if !matcher.MatchString(image) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func jpImagesEqual(arguments []any) (any, error) {
+	var obj map[string]any
+	if err := getArg(arguments, 0, &obj); err != nil {
+		return nil, err
+	}
+	var expected []any
+	if err := getArg(arguments, 1, &expected); err != nil {
+		return nil, err
+	}
+	images, err := containerImages(obj, "containers")
+	if err != nil {
+		return nil, err
+	}
+	if len(images) != len(expected) {
+		return false, nil
+	}
+	for i, item := range expected {
+		image, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid image: %v", item)
+		}
+		if images[i] != image {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// imageMatcher compiles a glob pattern ('*' matches any sequence of characters, '?' matches a single character),
+// or a regular expression when the pattern is prefixed with 'regex:'.
+func imageMatcher(pattern string) (*regexp.Regexp, error) {
+	if expression, ok := strings.CutPrefix(pattern, "regex:"); ok {
+		matcher, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image regular expression: %w", err)
+		}
+		return matcher, nil
+	}
+	var expression strings.Builder
+	expression.WriteString("^")
+	for _, char := range pattern {
+		switch char {
+		case '*':
+			expression.WriteString(".*")
+		case '?':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.Compile(expression.String())
+}
+
+// containerImages returns the images of the containers listed in the given pod spec fields, in declaration order.
+func containerImages(obj map[string]any, fields ...string) ([]string, error) {
+	var images []string
+	for _, path := range podSpecPaths {
+		spec := obj
+		for _, field := range path {
+			next, ok := spec[field].(map[string]any)
+			if !ok {
+				spec = nil
+				break
+			}
+			spec = next
+		}
+		if spec == nil {
+			continue
+		}
+		for _, field := range fields {
+			containers, ok := spec[field].([]any)
+			if !ok {
+				continue
+			}
+			for _, container := range containers {
+				container, ok := container.(map[string]any)
+				if !ok {
+					return nil, errors.New("invalid container")
+				}
+				image, ok := container["image"].(string)
+				if !ok {
+					return nil, errors.New("invalid container image")
+				}
+				images = append(images, image)
+			}
+		}
+	}
+	return images, nil
+}
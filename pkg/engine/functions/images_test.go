@@ -0,0 +1,203 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pod(images ...string) map[string]any {
+	var containers []any
+	for _, image := range images {
+		containers = append(containers, map[string]any{"name": "app", "image": image})
+	}
+	return map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"spec": map[string]any{
+			"containers": containers,
+		},
+	}
+}
+
+func Test_jpImagesMatch(t *testing.T) {
+	deployment := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"initContainers": []any{
+						map[string]any{"name": "init", "image": "ghcr.io/kyverno/init:v1.2.0"},
+					},
+					"containers": []any{
+						map[string]any{"name": "app", "image": "ghcr.io/kyverno/app:v1.2.0"},
+					},
+				},
+			},
+		},
+	}
+	cronJob := map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"spec": map[string]any{
+			"jobTemplate": map[string]any{
+				"spec": map[string]any{
+					"template": map[string]any{
+						"spec": map[string]any{
+							"containers": []any{
+								map[string]any{"name": "job", "image": "busybox@sha256:5acba83a746c7608ed544dc1533b87c737a0b0fb730301639a0179f9344b1678"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "not an object",
+		arguments: []any{"foo", "*"},
+		wantErr:   true,
+	}, {
+		name:      "no pattern",
+		arguments: []any{pod("nginx:1.27")},
+		wantErr:   true,
+	}, {
+		name:      "no containers",
+		arguments: []any{map[string]any{}, "*"},
+		want:      false,
+	}, {
+		name:      "invalid container",
+		arguments: []any{map[string]any{"spec": map[string]any{"containers": []any{"foo"}}}, "*"},
+		wantErr:   true,
+	}, {
+		name:      "tag",
+		arguments: []any{pod("nginx:1.27"), "nginx:1.27"},
+		want:      true,
+	}, {
+		name:      "tag glob",
+		arguments: []any{deployment, "ghcr.io/kyverno/*:v1.2.?"},
+		want:      true,
+	}, {
+		name:      "tag mismatch",
+		arguments: []any{pod("nginx:1.27", "nginx:1.26"), "nginx:1.27"},
+		want:      false,
+	}, {
+		name:      "init container mismatch",
+		arguments: []any{deployment, "ghcr.io/kyverno/app:*"},
+		want:      false,
+	}, {
+		name:      "digest",
+		arguments: []any{cronJob, "*@sha256:*"},
+		want:      true,
+	}, {
+		name:      "digest missing",
+		arguments: []any{pod("busybox@sha256:5acba83a746c7608ed544dc1533b87c737a0b0fb730301639a0179f9344b1678", "nginx:1.27"), "*@sha256:*"},
+		want:      false,
+	}, {
+		name:      "regex",
+		arguments: []any{pod("nginx:1.27", "nginx:1.26"), `regex:^nginx:1\.2[67]$`},
+		want:      true,
+	}, {
+		name:      "regex mismatch",
+		arguments: []any{pod("nginx:latest"), `regex:^nginx:\d+\.\d+$`},
+		want:      false,
+	}, {
+		name:      "invalid regex",
+		arguments: []any{pod("nginx:1.27"), "regex:("},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpImagesMatch(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_jpImagesEqual(t *testing.T) {
+	deployment := map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"initContainers": []any{
+						map[string]any{"name": "init", "image": "busybox:1.36"},
+					},
+					"containers": []any{
+						map[string]any{"name": "app", "image": "ghcr.io/kyverno/app:v1.2.0"},
+						map[string]any{"name": "sidecar", "image": "envoyproxy/envoy:v1.30.0"},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "no expected images",
+		arguments: []any{pod("nginx:1.27")},
+		wantErr:   true,
+	}, {
+		name:      "invalid expected image",
+		arguments: []any{pod("nginx:1.27"), []any{1}},
+		wantErr:   true,
+	}, {
+		name:      "no containers",
+		arguments: []any{map[string]any{}, []any{}},
+		want:      true,
+	}, {
+		name:      "exact order",
+		arguments: []any{pod("nginx:1.27", "busybox:1.36"), []any{"nginx:1.27", "busybox:1.36"}},
+		want:      true,
+	}, {
+		name:      "reordered",
+		arguments: []any{pod("nginx:1.27", "busybox:1.36"), []any{"busybox:1.36", "nginx:1.27"}},
+		want:      false,
+	}, {
+		name:      "missing image",
+		arguments: []any{pod("nginx:1.27", "busybox:1.36"), []any{"nginx:1.27"}},
+		want:      false,
+	}, {
+		name:      "extra image",
+		arguments: []any{pod("nginx:1.27"), []any{"nginx:1.27", "nginx:1.27"}},
+		want:      false,
+	}, {
+		name:      "workload ignores init containers",
+		arguments: []any{deployment, []any{"ghcr.io/kyverno/app:v1.2.0", "envoyproxy/envoy:v1.30.0"}},
+		want:      true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpImagesEqual(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
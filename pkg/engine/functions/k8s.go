@@ -3,6 +3,7 @@ package functions
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/kyverno/chainsaw/pkg/client"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -100,6 +101,41 @@ func jpKubernetesGet(arguments []any) (any, error) {
 	return obj.UnstructuredContent(), nil
 }
 
+func jpKubernetesLookup(arguments []any) (any, error) {
+	var kind string
+	var key client.ObjectKey
+	var c client.Client
+	if err := getArg(arguments, 0, &c); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 1, &kind); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 2, &key.Namespace); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 3, &key.Name); err != nil {
+		return nil, err
+	}
+	gk := schema.ParseGroupKind(kind)
+	mapping, err := c.RESTMapper().RESTMapping(gk)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err := c.Get(context.TODO(), key, &obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			if len(arguments) == 5 {
+				return arguments[4], nil
+			}
+			return nil, fmt.Errorf("%s %s not found", kind, client.Name(key))
+		}
+		return nil, err
+	}
+	return obj.UnstructuredContent(), nil
+}
+
 func jpKubernetesList(arguments []any) (any, error) {
 	var c client.Client
 	var apiVersion, kind, namespace string
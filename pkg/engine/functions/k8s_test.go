@@ -1,12 +1,18 @@
 package functions
 
 import (
+	"context"
 	"testing"
 
+	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/client/simple"
 	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -227,6 +233,70 @@ func Test_jpKubernetesGet(t *testing.T) {
 	}
 }
 
+func Test_jpKubernetesLookup(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	fakeClient := &tclient.FakeClient{
+		RESTMapperFn: func(int) meta.RESTMapper {
+			return mapper
+		},
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			if key.Namespace != "default" || key.Name != "settings" {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, obj.GetObjectKind().GroupVersionKind())
+			return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, "bar", "data", "foo")
+		},
+	}
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "not enough args",
+		arguments: []any{fakeClient, "ConfigMap", "default"},
+		wantErr:   true,
+	}, {
+		name:      "found",
+		arguments: []any{fakeClient, "ConfigMap", "default", "settings"},
+		want: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data": map[string]any{
+				"foo": "bar",
+			},
+		},
+	}, {
+		name:      "not found",
+		arguments: []any{fakeClient, "ConfigMap", "default", "missing"},
+		wantErr:   true,
+	}, {
+		name:      "not found with default",
+		arguments: []any{fakeClient, "ConfigMap", "default", "missing", "fallback"},
+		want:      "fallback",
+	}, {
+		name:      "unknown kind",
+		arguments: []any{fakeClient, "Deployment.apps", "default", "settings"},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpKubernetesLookup(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
 func Test_jpKubernetesList(t *testing.T) {
 	config, err := restutils.DefaultConfig(clientcmd.ConfigOverrides{})
 	assert.NoError(t, err)
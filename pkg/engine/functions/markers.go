@@ -0,0 +1,45 @@
+package functions
+
+import (
+	"regexp"
+)
+
+// marker functions, their results are interpreted by the assertion engine
+var (
+	MarkerAbsent  = experimental("absent")
+	MarkerPresent = experimental("present")
+	MarkerPattern = experimental("pattern")
+	MarkerStrict  = experimental("strict")
+)
+
+// Marker is returned by the marker functions, it changes how the assertion engine asserts the field it is used with.
+type Marker struct {
+	// Name is the name of the function that returned the marker.
+	Name string
+	// Pattern is the regular expression the field must match, set by the pattern marker.
+	Pattern *regexp.Regexp
+}
+
+func jpAbsent([]any) (any, error) {
+	return Marker{Name: MarkerAbsent}, nil
+}
+
+func jpPresent([]any) (any, error) {
+	return Marker{Name: MarkerPresent}, nil
+}
+
+func jpStrict([]any) (any, error) {
+	return Marker{Name: MarkerStrict}, nil
+}
+
+func jpPattern(arguments []any) (any, error) {
+	var pattern string
+	if err := getArg(arguments, 0, &pattern); err != nil {
+		return nil, err
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return Marker{Name: MarkerPattern, Pattern: regex}, nil
+}
@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpAbsent(t *testing.T) {
+	got, err := jpAbsent(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Marker{Name: "x_absent"}, got)
+}
+
+func Test_jpPresent(t *testing.T) {
+	got, err := jpPresent(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Marker{Name: "x_present"}, got)
+}
+
+func Test_jpStrict(t *testing.T) {
+	got, err := jpStrict(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Marker{Name: "x_strict"}, got)
+}
+
+func Test_jpPattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   string
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   "index out of range (0 / 0)",
+	}, {
+		name:      "not a string",
+		arguments: []any{1.0},
+		wantErr:   "invalid type",
+	}, {
+		name:      "invalid pattern",
+		arguments: []any{"("},
+		wantErr:   "error parsing regexp: missing closing ): `(`",
+	}, {
+		name:      "pattern",
+		arguments: []any{"^[a-z0-9]{10}$"},
+		want:      Marker{Name: "x_pattern", Pattern: regexp.MustCompile("^[a-z0-9]{10}$")},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpPattern(tt.arguments)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
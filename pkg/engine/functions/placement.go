@@ -0,0 +1,62 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func jpPodScheduledOn(arguments []any) (any, error) {
+	var c client.Client
+	var pod map[string]any
+	var selector string
+	if err := getArg(arguments, 0, &c); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 1, &pod); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 2, &selector); err != nil {
+		return nil, err
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	nodeName, _, _ := unstructured.NestedString(pod, "spec", "nodeName")
+	if nodeName == "" {
+		return Result{
+			Pass:    false,
+			Message: "pod is not scheduled",
+		}, nil
+	}
+	var node unstructured.Unstructured
+	node.SetAPIVersion("v1")
+	node.SetKind("Node")
+	if err := c.Get(context.TODO(), client.ObjectKey{Name: nodeName}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return Result{
+				Pass:    false,
+				Message: fmt.Sprintf("pod is scheduled on node %s which was not found", nodeName),
+				Value:   nodeName,
+			}, nil
+		}
+		return nil, err
+	}
+	if !parsed.Matches(labels.Set(node.GetLabels())) {
+		return Result{
+			Pass:    false,
+			Message: fmt.Sprintf("pod is scheduled on node %s which does not match %s", nodeName, selector),
+			Value:   nodeName,
+		}, nil
+	}
+	return Result{
+		Pass:    true,
+		Message: fmt.Sprintf("pod is scheduled on node %s", nodeName),
+		Value:   nodeName,
+	}, nil
+}
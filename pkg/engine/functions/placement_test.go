@@ -0,0 +1,104 @@
+package functions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_jpPodScheduledOn(t *testing.T) {
+	fakeClient := &tclient.FakeClient{
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "Node"}, obj.GetObjectKind().GroupVersionKind())
+			switch key.Name {
+			case "node-a":
+				obj.(*unstructured.Unstructured).SetLabels(map[string]string{"zone": "a", "disktype": "ssd"})
+				return nil
+			case "node-b":
+				obj.(*unstructured.Unstructured).SetLabels(map[string]string{"zone": "b"})
+				return nil
+			case "broken":
+				return errors.New("boom")
+			}
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, key.Name)
+		},
+	}
+	pod := func(nodeName string) map[string]any {
+		return map[string]any{
+			"spec": map[string]any{
+				"nodeName": nodeName,
+			},
+		}
+	}
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "not enough args",
+		arguments: []any{fakeClient, pod("node-a")},
+		wantErr:   true,
+	}, {
+		name:      "invalid selector",
+		arguments: []any{fakeClient, pod("node-a"), "zone in a"},
+		wantErr:   true,
+	}, {
+		name:      "matching node",
+		arguments: []any{fakeClient, pod("node-a"), "zone=a,disktype=ssd"},
+		want: Result{
+			Pass:    true,
+			Message: "pod is scheduled on node node-a",
+			Value:   "node-a",
+		},
+	}, {
+		name:      "wrong node",
+		arguments: []any{fakeClient, pod("node-b"), "zone=a"},
+		want: Result{
+			Pass:    false,
+			Message: "pod is scheduled on node node-b which does not match zone=a",
+			Value:   "node-b",
+		},
+	}, {
+		name:      "not scheduled",
+		arguments: []any{fakeClient, map[string]any{}, "zone=a"},
+		want: Result{
+			Pass:    false,
+			Message: "pod is not scheduled",
+		},
+	}, {
+		name:      "node not found",
+		arguments: []any{fakeClient, pod("missing"), "zone=a"},
+		want: Result{
+			Pass:    false,
+			Message: "pod is scheduled on node missing which was not found",
+			Value:   "missing",
+		},
+	}, {
+		name:      "client error",
+		arguments: []any{fakeClient, pod("broken"), "zone=a"},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpPodScheduledOn(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
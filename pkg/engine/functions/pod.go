@@ -0,0 +1,44 @@
+package functions
+
+import (
+	"errors"
+	"fmt"
+)
+
+var podContainerStatuses = []string{"initContainerStatuses", "containerStatuses", "ephemeralContainerStatuses"}
+
+func jpPodRestarts(arguments []any) (any, error) {
+	var pod map[string]any
+	if err := getArg(arguments, 0, &pod); err != nil {
+		return nil, err
+	}
+	status, ok := pod["status"].(map[string]any)
+	if !ok {
+		return 0.0, nil
+	}
+	var restarts float64
+	for _, field := range podContainerStatuses {
+		statuses, ok := status[field].([]any)
+		if !ok {
+			continue
+		}
+		for _, status := range statuses {
+			status, ok := status.(map[string]any)
+			if !ok {
+				return nil, errors.New("invalid container status")
+			}
+			switch count := status["restartCount"].(type) {
+			case nil:
+			case float64:
+				restarts += count
+			case int64:
+				restarts += float64(count)
+			case int:
+				restarts += float64(count)
+			default:
+				return nil, fmt.Errorf("invalid restart count type (%T)", count)
+			}
+		}
+	}
+	return restarts, nil
+}
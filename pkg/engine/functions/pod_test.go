@@ -0,0 +1,85 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpPodRestarts(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "not a pod",
+		arguments: []any{"foo"},
+		wantErr:   true,
+	}, {
+		name:      "no status",
+		arguments: []any{map[string]any{}},
+		want:      0.0,
+	}, {
+		name: "no restarts",
+		arguments: []any{map[string]any{
+			"status": map[string]any{
+				"containerStatuses": []any{
+					map[string]any{"name": "foo", "restartCount": 0.0},
+					map[string]any{"name": "bar", "restartCount": int64(0)},
+				},
+			},
+		}},
+		want: 0.0,
+	}, {
+		name: "restarts",
+		arguments: []any{map[string]any{
+			"status": map[string]any{
+				"initContainerStatuses": []any{
+					map[string]any{"name": "init", "restartCount": int64(1)},
+				},
+				"containerStatuses": []any{
+					map[string]any{"name": "foo", "restartCount": 3.0},
+					map[string]any{"name": "bar", "restartCount": int64(2)},
+				},
+				"ephemeralContainerStatuses": []any{
+					map[string]any{"name": "debug"},
+				},
+			},
+		}},
+		want: 6.0,
+	}, {
+		name: "invalid container status",
+		arguments: []any{map[string]any{
+			"status": map[string]any{
+				"containerStatuses": []any{"foo"},
+			},
+		}},
+		wantErr: true,
+	}, {
+		name: "invalid restart count",
+		arguments: []any{map[string]any{
+			"status": map[string]any{
+				"containerStatuses": []any{
+					map[string]any{"name": "foo", "restartCount": "3"},
+				},
+			},
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpPodRestarts(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package functions
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func jpPvcBound(arguments []any) (any, error) {
+	var pvc map[string]any
+	if err := getArg(arguments, 0, &pvc); err != nil {
+		return nil, err
+	}
+	phase, _, _ := unstructured.NestedString(pvc, "status", "phase")
+	if phase != "Bound" {
+		return Result{
+			Pass:    false,
+			Message: fmt.Sprintf("persistent volume claim is not bound (phase: %s)", phase),
+			Value:   phase,
+		}, nil
+	}
+	// the minimum capacity defaults to the requested storage
+	var minimum resource.Quantity
+	if len(arguments) > 1 {
+		q, err := parseQuantity(arguments, 1)
+		if err != nil {
+			return nil, err
+		}
+		minimum = q
+	} else if requested, ok, err := unstructured.NestedString(pvc, "spec", "resources", "requests", "storage"); err != nil {
+		return nil, err
+	} else if ok {
+		q, err := resource.ParseQuantity(requested)
+		if err != nil {
+			return nil, err
+		}
+		minimum = q
+	}
+	capacity, ok, err := unstructured.NestedString(pvc, "status", "capacity", "storage")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return Result{
+			Pass:    false,
+			Message: "persistent volume claim has no storage capacity",
+		}, nil
+	}
+	actual, err := resource.ParseQuantity(capacity)
+	if err != nil {
+		return nil, err
+	}
+	if actual.Cmp(minimum) < 0 {
+		return Result{
+			Pass:    false,
+			Message: fmt.Sprintf("persistent volume claim capacity is lower than %s", minimum.String()),
+			Value:   capacity,
+		}, nil
+	}
+	return Result{
+		Pass:    true,
+		Message: fmt.Sprintf("persistent volume claim is bound with capacity %s", capacity),
+		Value:   capacity,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpPvcBound(t *testing.T) {
+	pvc := func(phase string, requested string, capacity string) map[string]any {
+		pvc := map[string]any{
+			"spec": map[string]any{
+				"resources": map[string]any{
+					"requests": map[string]any{"storage": requested},
+				},
+			},
+			"status": map[string]any{
+				"phase": phase,
+			},
+		}
+		if capacity != "" {
+			pvc["status"].(map[string]any)["capacity"] = map[string]any{"storage": capacity}
+		}
+		return pvc
+	}
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "not an object",
+		arguments: []any{"foo"},
+		wantErr:   true,
+	}, {
+		name:      "pending",
+		arguments: []any{pvc("Pending", "1Gi", "")},
+		want: Result{
+			Pass:    false,
+			Message: "persistent volume claim is not bound (phase: Pending)",
+			Value:   "Pending",
+		},
+	}, {
+		name:      "bound with requested capacity",
+		arguments: []any{pvc("Bound", "1Gi", "1Gi")},
+		want: Result{
+			Pass:    true,
+			Message: "persistent volume claim is bound with capacity 1Gi",
+			Value:   "1Gi",
+		},
+	}, {
+		name:      "bound with enough capacity",
+		arguments: []any{pvc("Bound", "1Gi", "10Gi"), "5Gi"},
+		want: Result{
+			Pass:    true,
+			Message: "persistent volume claim is bound with capacity 10Gi",
+			Value:   "10Gi",
+		},
+	}, {
+		name:      "bound with numeric capacity",
+		arguments: []any{pvc("Bound", "1Gi", "1Gi"), 1073741824.0},
+		want: Result{
+			Pass:    true,
+			Message: "persistent volume claim is bound with capacity 1Gi",
+			Value:   "1Gi",
+		},
+	}, {
+		name:      "bound with not enough capacity",
+		arguments: []any{pvc("Bound", "1Gi", "1Gi"), "2Gi"},
+		want: Result{
+			Pass:    false,
+			Message: "persistent volume claim capacity is lower than 2Gi",
+			Value:   "1Gi",
+		},
+	}, {
+		name:      "bound without capacity",
+		arguments: []any{pvc("Bound", "1Gi", "")},
+		want: Result{
+			Pass:    false,
+			Message: "persistent volume claim has no storage capacity",
+		},
+	}, {
+		name:      "invalid capacity",
+		arguments: []any{pvc("Bound", "1Gi", "foo")},
+		wantErr:   true,
+	}, {
+		name:      "invalid minimum capacity",
+		arguments: []any{pvc("Bound", "1Gi", "1Gi"), "foo"},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpPvcBound(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
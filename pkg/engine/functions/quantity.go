@@ -0,0 +1,43 @@
+package functions
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func parseQuantity(arguments []any, index int) (resource.Quantity, error) {
+	arg, err := getArgAt(arguments, index)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+	switch value := arg.(type) {
+	case string:
+		return resource.ParseQuantity(value)
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(value, 'f', -1, 64))
+	default:
+		return resource.Quantity{}, fmt.Errorf("invalid quantity type (%T)", arg)
+	}
+}
+
+func jpQuantity(arguments []any) (any, error) {
+	q, err := parseQuantity(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	return q.AsApproximateFloat64(), nil
+}
+
+func jpQuantityCompare(arguments []any) (any, error) {
+	left, err := parseQuantity(arguments, 0)
+	if err != nil {
+		return nil, err
+	}
+	right, err := parseQuantity(arguments, 1)
+	if err != nil {
+		return nil, err
+	}
+	return float64(left.Cmp(right)), nil
+}
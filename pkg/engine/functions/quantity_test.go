@@ -0,0 +1,111 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpQuantity(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "binary suffix",
+		arguments: []any{"1Ki"},
+		want:      1024.0,
+	}, {
+		name:      "decimal suffix",
+		arguments: []any{"500m"},
+		want:      0.5,
+	}, {
+		name:      "number",
+		arguments: []any{2.0},
+		want:      2.0,
+	}, {
+		name:      "invalid",
+		arguments: []any{"foo"},
+		wantErr:   true,
+	}, {
+		name:      "wrong type",
+		arguments: []any{true},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpQuantity(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_jpQuantityCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "missing argument",
+		arguments: []any{"1Gi"},
+		wantErr:   true,
+	}, {
+		name:      "equivalent binary",
+		arguments: []any{"512Mi", "0.5Gi"},
+		want:      0.0,
+	}, {
+		name:      "equivalent decimal",
+		arguments: []any{"1", "1000m"},
+		want:      0.0,
+	}, {
+		name:      "equivalent number",
+		arguments: []any{"1.5", 1.5},
+		want:      0.0,
+	}, {
+		name:      "greater",
+		arguments: []any{"1Gi", "512Mi"},
+		want:      1.0,
+	}, {
+		name:      "lower",
+		arguments: []any{"100m", "0.2"},
+		want:      -1.0,
+	}, {
+		name:      "binary vs decimal",
+		arguments: []any{"1Gi", "1G"},
+		want:      1.0,
+	}, {
+		name:      "invalid left",
+		arguments: []any{"foo", "1Gi"},
+		wantErr:   true,
+	}, {
+		name:      "invalid right",
+		arguments: []any{"1Gi", "foo"},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpQuantityCompare(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package functions
+
+import (
+	"fmt"
+)
+
+func jpReplicasReady(arguments []any) (any, error) {
+	var object map[string]any
+	if err := getArg(arguments, 0, &object); err != nil {
+		return nil, err
+	}
+	// desired replicas default to 1 when not set, like in workload specs
+	desired, ready := 1.0, 0.0
+	var err error
+	if spec, ok := object["spec"].(map[string]any); ok {
+		if desired, err = replicaCount(spec["replicas"], desired); err != nil {
+			return nil, err
+		}
+	}
+	if status, ok := object["status"].(map[string]any); ok {
+		if ready, err = replicaCount(status["readyReplicas"], ready); err != nil {
+			return nil, err
+		}
+	}
+	return Result{
+		Pass:    ready == desired,
+		Message: fmt.Sprintf("%d/%d replicas ready", int64(ready), int64(desired)),
+		Value:   ready,
+	}, nil
+}
+
+func replicaCount(in any, def float64) (float64, error) {
+	switch count := in.(type) {
+	case nil:
+		return def, nil
+	case float64:
+		return count, nil
+	case int64:
+		return float64(count), nil
+	case int:
+		return float64(count), nil
+	default:
+		return 0, fmt.Errorf("invalid replicas count type (%T)", count)
+	}
+}
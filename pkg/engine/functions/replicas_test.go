@@ -0,0 +1,82 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpReplicasReady(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "not an object",
+		arguments: []any{"foo"},
+		wantErr:   true,
+	}, {
+		name:      "no spec and no status",
+		arguments: []any{map[string]any{}},
+		want: Result{
+			Pass:    false,
+			Message: "0/1 replicas ready",
+			Value:   0.0,
+		},
+	}, {
+		name: "fully ready",
+		arguments: []any{map[string]any{
+			"spec":   map[string]any{"replicas": int64(3)},
+			"status": map[string]any{"replicas": int64(3), "readyReplicas": 3.0},
+		}},
+		want: Result{
+			Pass:    true,
+			Message: "3/3 replicas ready",
+			Value:   3.0,
+		},
+	}, {
+		name: "partially ready",
+		arguments: []any{map[string]any{
+			"spec":   map[string]any{"replicas": 3.0},
+			"status": map[string]any{"replicas": int64(3), "readyReplicas": int64(1)},
+		}},
+		want: Result{
+			Pass:    false,
+			Message: "1/3 replicas ready",
+			Value:   1.0,
+		},
+	}, {
+		name: "scaled to zero",
+		arguments: []any{map[string]any{
+			"spec":   map[string]any{"replicas": 0},
+			"status": map[string]any{},
+		}},
+		want: Result{
+			Pass:    true,
+			Message: "0/0 replicas ready",
+			Value:   0.0,
+		},
+	}, {
+		name: "invalid replicas",
+		arguments: []any{map[string]any{
+			"spec": map[string]any{"replicas": "3"},
+		}},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpReplicasReady(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
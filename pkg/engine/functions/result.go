@@ -0,0 +1,35 @@
+package functions
+
+import (
+	"errors"
+)
+
+// Result is returned by the result functions, the assertion engine compares Pass with the expected value
+// and reports Message and Value when they don't match.
+type Result struct {
+	// Pass tells whether the check succeeded.
+	Pass bool
+	// Message describes the outcome of the check.
+	Message string
+	// Value is the value reported when the check fails, Pass is reported when not set.
+	Value any
+}
+
+func jpAssertResult(arguments []any) (any, error) {
+	var pass bool
+	var message string
+	if err := getArg(arguments, 0, &pass); err != nil {
+		return nil, errors.New("invalid pass argument, expected a boolean")
+	}
+	if err := getArg(arguments, 1, &message); err != nil {
+		return nil, err
+	}
+	result := Result{
+		Pass:    pass,
+		Message: message,
+	}
+	if len(arguments) > 2 {
+		result.Value = arguments[2]
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpAssertResult(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   string
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   "invalid pass argument, expected a boolean",
+	}, {
+		name:      "not a boolean",
+		arguments: []any{"true", "message"},
+		wantErr:   "invalid pass argument, expected a boolean",
+	}, {
+		name:      "no message",
+		arguments: []any{true},
+		wantErr:   "index out of range (1 / 1)",
+	}, {
+		name:      "without value",
+		arguments: []any{false, "not enough replicas"},
+		want: Result{
+			Pass:    false,
+			Message: "not enough replicas",
+		},
+	}, {
+		name:      "with value",
+		arguments: []any{false, "not enough replicas", 1.0},
+		want: Result{
+			Pass:    false,
+			Message: "not enough replicas",
+			Value:   1.0,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpAssertResult(tt.arguments)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
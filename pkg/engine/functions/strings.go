@@ -1,6 +1,8 @@
 package functions
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -11,3 +13,51 @@ func jpTrimSpace(arguments []any) (any, error) {
 	}
 	return strings.TrimSpace(in), nil
 }
+
+func jpMatchSequence(arguments []any) (any, error) {
+	var in string
+	var items []any
+	if err := getArg(arguments, 0, &in); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 1, &items); err != nil {
+		return nil, err
+	}
+	patterns := make([]*regexp.Regexp, 0, len(items))
+	for _, item := range items {
+		pattern, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid pattern: %v", item)
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, regex)
+	}
+	lines := strings.Split(in, "\n")
+	// matched[i] is the line index where patterns[i] was matched
+	matched := make([]int, 0, len(patterns))
+	for i, line := range lines {
+		if len(matched) == len(patterns) {
+			break
+		}
+		if patterns[len(matched)].MatchString(line) {
+			matched = append(matched, i)
+		}
+	}
+	if len(matched) == len(patterns) {
+		return true, nil
+	}
+	missing := patterns[len(matched)]
+	if len(matched) != 0 {
+		previous := matched[len(matched)-1]
+		for i := 0; i < previous; i++ {
+			if missing.MatchString(lines[i]) {
+				return nil, fmt.Errorf("pattern %q found out of order (line %d, expected after %q at line %d)", missing, i+1, patterns[len(matched)-1], previous+1)
+			}
+		}
+		return nil, fmt.Errorf("pattern %q not found after %q (line %d)", missing, patterns[len(matched)-1], previous+1)
+	}
+	return nil, fmt.Errorf("pattern %q not found", missing)
+}
@@ -0,0 +1,64 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpMatchSequence(t *testing.T) {
+	logs := "[pod/server] listening\n[pod/client] connecting\n[pod/server] handshake started\n[pod/server] handshake done\n[pod/client] connected"
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   string
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   "index out of range (0 / 0)",
+	}, {
+		name:      "not enough args",
+		arguments: []any{logs},
+		wantErr:   "index out of range (1 / 1)",
+	}, {
+		name:      "empty sequence",
+		arguments: []any{logs, []any{}},
+		want:      true,
+	}, {
+		name:      "in order",
+		arguments: []any{logs, []any{"listening", "handshake started", "handshake done", "client.*connected$"}},
+		want:      true,
+	}, {
+		name:      "out of order",
+		arguments: []any{logs, []any{"handshake started", "listening", "handshake done"}},
+		wantErr:   `pattern "listening" found out of order (line 1, expected after "handshake started" at line 3)`,
+	}, {
+		name:      "not found after",
+		arguments: []any{logs, []any{"listening", "disconnected"}},
+		wantErr:   `pattern "disconnected" not found after "listening" (line 1)`,
+	}, {
+		name:      "not found",
+		arguments: []any{logs, []any{"disconnected"}},
+		wantErr:   `pattern "disconnected" not found`,
+	}, {
+		name:      "invalid pattern",
+		arguments: []any{logs, []any{"("}},
+		wantErr:   "error parsing regexp: missing closing ): `(`",
+	}, {
+		name:      "invalid pattern type",
+		arguments: []any{logs, []any{42}},
+		wantErr:   "invalid pattern: 42",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpMatchSequence(tt.arguments)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package functions
+
+import (
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+func jpTimeWithin(arguments []any) (any, error) {
+	var clock clock.PassiveClock
+	var value, window string
+	if err := getArg(arguments, 0, &clock); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 1, &value); err != nil {
+		return nil, err
+	}
+	if err := getArg(arguments, 2, &window); err != nil {
+		return nil, err
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(window)
+	if err != nil {
+		return nil, err
+	}
+	return !timestamp.Before(clock.Now().Add(-duration)), nil
+}
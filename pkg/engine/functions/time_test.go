@@ -0,0 +1,58 @@
+package functions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	tclock "k8s.io/utils/clock/testing"
+)
+
+func Test_jpTimeWithin(t *testing.T) {
+	clock := tclock.NewFakePassiveClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   bool
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   true,
+	}, {
+		name:      "recent",
+		arguments: []any{clock, "2024-01-01T11:59:50Z", "30s"},
+		want:      true,
+	}, {
+		name:      "at the window limit",
+		arguments: []any{clock, "2024-01-01T11:59:30Z", "30s"},
+		want:      true,
+	}, {
+		name:      "stale",
+		arguments: []any{clock, "2024-01-01T11:50:00Z", "30s"},
+		want:      false,
+	}, {
+		name:      "not a clock",
+		arguments: []any{"foo", "2024-01-01T11:59:50Z", "30s"},
+		wantErr:   true,
+	}, {
+		name:      "invalid time",
+		arguments: []any{clock, "foo", "30s"},
+		wantErr:   true,
+	}, {
+		name:      "invalid duration",
+		arguments: []any{clock, "2024-01-01T11:59:50Z", "foo"},
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpTimeWithin(tt.arguments)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
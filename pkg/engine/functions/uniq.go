@@ -0,0 +1,26 @@
+package functions
+
+import (
+	"reflect"
+)
+
+func jpUniq(arguments []any) (any, error) {
+	var items []any
+	if err := getArg(arguments, 0, &items); err != nil {
+		return nil, err
+	}
+	uniq := make([]any, 0, len(items))
+	for _, item := range items {
+		found := false
+		for _, existing := range uniq {
+			if reflect.DeepEqual(existing, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			uniq = append(uniq, item)
+		}
+	}
+	return uniq, nil
+}
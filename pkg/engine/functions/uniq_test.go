@@ -0,0 +1,53 @@
+package functions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_jpUniq(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments []any
+		want      any
+		wantErr   string
+	}{{
+		name:      "nil",
+		arguments: nil,
+		wantErr:   "index out of range (0 / 0)",
+	}, {
+		name:      "empty",
+		arguments: []any{[]any{}},
+		want:      []any{},
+	}, {
+		name:      "scalars",
+		arguments: []any{[]any{"a", "b", "a", 1.0, 1.0}},
+		want:      []any{"a", "b", 1.0},
+	}, {
+		name: "objects",
+		arguments: []any{[]any{
+			map[string]any{"disktype": "ssd"},
+			map[string]any{"disktype": "ssd"},
+			nil,
+			map[string]any{"disktype": "hdd"},
+			nil,
+		}},
+		want: []any{
+			map[string]any{"disktype": "ssd"},
+			nil,
+			map[string]any{"disktype": "hdd"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jpUniq(tt.arguments)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
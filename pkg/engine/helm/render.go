@@ -0,0 +1,121 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/resource"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Release describes the release a chart is rendered for.
+type Release struct {
+	Name      string
+	Namespace string
+}
+
+// Render renders the chart stored in the given folder like `helm template` does and returns the resulting objects.
+// The values are merged on top of the default values of the chart, when templates are given (like `templates/deployment.yaml`),
+// only the objects rendered by those templates are returned. The release is named after the chart when no name is given.
+func Render(ctx context.Context, chartPath string, release Release, values map[string]any, templates ...string) ([]unstructured.Unstructured, error) {
+	type result struct {
+		objects []unstructured.Unstructured
+		err     error
+	}
+	// rendering doesn't support cancellation, give up waiting when the context is done
+	done := make(chan result, 1)
+	go func() {
+		objects, err := render(chartPath, release, values, templates...)
+		done <- result{objects: objects, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-done:
+		return result.objects, result.err
+	}
+}
+
+func render(chartPath string, release Release, values map[string]any, templates ...string) ([]unstructured.Unstructured, error) {
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+	if release.Name == "" {
+		release.Name = chrt.Name()
+	}
+	if release.Namespace == "" {
+		release.Namespace = "default"
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, values); err != nil {
+		return nil, err
+	}
+	renderValues, err := chartutil.ToRenderValues(chrt, values, chartutil.ReleaseOptions{
+		Name:      release.Name,
+		Namespace: release.Namespace,
+		Revision:  1,
+		IsInstall: true,
+	}, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	files, err := engine.Render(chrt, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("helm template failed: %w", err)
+	}
+	// notes are not manifests
+	for name := range files {
+		if strings.HasSuffix(name, "NOTES.txt") {
+			delete(files, name)
+		}
+	}
+	hooks, manifests, err := releaseutil.SortManifests(files, chartutil.DefaultCapabilities.APIVersions, releaseutil.InstallOrder)
+	if err != nil {
+		return nil, fmt.Errorf("helm template failed: %w", err)
+	}
+	for _, hook := range hooks {
+		manifests = append(manifests, releaseutil.Manifest{Name: hook.Path, Content: hook.Manifest})
+	}
+	if len(templates) != 0 {
+		if manifests, err = showOnly(chrt, manifests, templates...); err != nil {
+			return nil, err
+		}
+	}
+	var objects []unstructured.Unstructured
+	for _, manifest := range manifests {
+		parsed, err := resource.Parse([]byte(manifest.Content), true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rendered chart: %w", err)
+		}
+		objects = append(objects, parsed...)
+	}
+	return objects, nil
+}
+
+// showOnly keeps the manifests rendered by the given templates, every template must render at least one manifest.
+func showOnly(chrt *chart.Chart, manifests []releaseutil.Manifest, templates ...string) ([]releaseutil.Manifest, error) {
+	var kept []releaseutil.Manifest
+	for _, template := range templates {
+		name := chrt.Name() + "/" + strings.TrimPrefix(template, "./")
+		found := false
+		for _, manifest := range manifests {
+			if manifest.Name == name {
+				kept = append(kept, manifest)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("helm template failed: could not find template %s in chart", template)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,142 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const chartPath = "../../../testdata/helm/chart"
+
+func deployment(release string, namespace string, replicas int64, image string) unstructured.Unstructured {
+	labels := map[string]any{
+		"app.kubernetes.io/name":     "tiny",
+		"app.kubernetes.io/instance": release,
+	}
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":      release + "-tiny",
+				"namespace": namespace,
+				"labels":    labels,
+			},
+			"spec": map[string]any{
+				"replicas": replicas,
+				"selector": map[string]any{
+					"matchLabels": labels,
+				},
+				"template": map[string]any{
+					"metadata": map[string]any{
+						"labels": labels,
+					},
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{
+								"name":  "tiny",
+								"image": image,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func service(release string, namespace string) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata": map[string]any{
+				"name":      release + "-tiny",
+				"namespace": namespace,
+			},
+			"spec": map[string]any{
+				"selector": map[string]any{
+					"app.kubernetes.io/name":     "tiny",
+					"app.kubernetes.io/instance": release,
+				},
+				"ports": []any{
+					map[string]any{
+						"port": int64(80),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		chartPath string
+		release   Release
+		values    map[string]any
+		templates []string
+		want      []unstructured.Unstructured
+		wantErr   string
+	}{{
+		name:      "defaults",
+		chartPath: chartPath,
+		want:      []unstructured.Unstructured{deployment("tiny", "default", 1, "nginx:1.25.0")},
+	}, {
+		name:      "with release and values",
+		chartPath: chartPath,
+		release:   Release{Name: "demo", Namespace: "chainsaw"},
+		values: map[string]any{
+			"replicas": 3,
+			"image": map[string]any{
+				"tag": "1.27.0",
+			},
+			"service": map[string]any{
+				"enabled": true,
+			},
+		},
+		want: []unstructured.Unstructured{service("demo", "chainsaw"), deployment("demo", "chainsaw", 3, "nginx:1.27.0")},
+	}, {
+		name:      "with templates",
+		chartPath: chartPath,
+		release:   Release{Name: "demo", Namespace: "chainsaw"},
+		values: map[string]any{
+			"service": map[string]any{
+				"enabled": true,
+			},
+		},
+		templates: []string{"templates/service.yaml"},
+		want:      []unstructured.Unstructured{service("demo", "chainsaw")},
+	}, {
+		name:      "template not rendered",
+		chartPath: chartPath,
+		templates: []string{"templates/service.yaml"},
+		wantErr:   "helm template failed: could not find template templates/service.yaml in chart",
+	}, {
+		name:      "not a chart",
+		chartPath: "../../../testdata/helm",
+		wantErr:   "Chart.yaml file is missing",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(context.TODO(), tt.chartPath, tt.release, tt.values, tt.templates...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRender_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	got, err := Render(ctx, chartPath, Release{}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,100 @@
+package kubectl
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/annotations"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// selectedNames returns the names of the resources matching the selectors.
+// It is used when kubectl can't select the resources itself (kubectl doesn't support annotation selectors
+// and kubectl describe doesn't support field selectors), resources are listed and filtered client side.
+func selectedNames(
+	ctx context.Context,
+	compilers compilers.Compilers,
+	c client.Client,
+	tc apis.Bindings,
+	resource v1alpha1.ObjectType,
+	clustered bool,
+	namespace string,
+	selector string,
+	fieldSelector string,
+	annotationSelector string,
+) ([]string, error) {
+	matcher, err := annotations.Parse(annotationSelector)
+	if err != nil {
+		return nil, err
+	}
+	gvk, err := resourceGVK(ctx, compilers, tc, resource)
+	if err != nil {
+		return nil, err
+	}
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	var listOptions []client.ListOption
+	if !clustered {
+		if namespace == "*" {
+			return nil, errors.New("resources filtered client side cannot be listed in all namespaces")
+		}
+		if namespace == "" {
+			namespace, err = currentNamespace(tc)
+			if err != nil {
+				return nil, err
+			}
+		}
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+	if selector != "" {
+		selector, err := labels.Parse(selector)
+		if err != nil {
+			return nil, err
+		}
+		listOptions = append(listOptions, client.MatchingSelector{Selector: selector})
+	}
+	if fieldSelector != "" {
+		fieldSelector, err := fields.ParseSelector(fieldSelector)
+		if err != nil {
+			return nil, err
+		}
+		listOptions = append(listOptions, client.MatchingFieldsSelector{Selector: fieldSelector})
+	}
+	if err := c.List(ctx, &list, listOptions...); err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, item := range list.Items {
+		if matcher.Matches(item.GetAnnotations()) {
+			names = append(names, item.GetName())
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no resource matched the selectors")
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func currentNamespace(tc apis.Bindings) (string, error) {
+	binding, err := tc.Get("$namespace")
+	if err != nil {
+		return "", err
+	}
+	value, err := binding.Value()
+	if err != nil {
+		return "", err
+	}
+	namespace, ok := value.(string)
+	if !ok || namespace == "" {
+		return "", errors.New("failed to resolve the current namespace")
+	}
+	return namespace, nil
+}
@@ -0,0 +1,157 @@
+package kubectl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_selectedNames(t *testing.T) {
+	pods := func(namespace *string, err error) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			ListFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+				if err != nil {
+					return err
+				}
+				for _, opt := range opts {
+					if ns, ok := opt.(client.InNamespace); ok {
+						*namespace = string(ns)
+					}
+				}
+				assert.Equal(t, "PodList", list.GetObjectKind().GroupVersionKind().Kind)
+				var items []unstructured.Unstructured
+				for _, team := range []string{"a", "b", ""} {
+					var item unstructured.Unstructured
+					item.SetAPIVersion("v1")
+					item.SetKind("Pod")
+					item.SetName("pod-" + team)
+					if team != "" {
+						item.SetAnnotations(map[string]string{"team": team, "url": "https://example.com/" + team, "note": "team " + team})
+					}
+					items = append(items, item)
+				}
+				list.(*unstructured.UnstructuredList).Items = items
+				return nil
+			},
+		}
+	}
+	tests := []struct {
+		name               string
+		namespace          string
+		annotationSelector string
+		listErr            error
+		want               []string
+		wantNamespace      string
+		wantErr            bool
+	}{{
+		name:               "single match",
+		annotationSelector: "team=b",
+		want:               []string{"pod-b"},
+		wantNamespace:      "chainsaw",
+	}, {
+		name:               "multiple matches",
+		namespace:          "foo",
+		annotationSelector: "team",
+		want:               []string{"pod-a", "pod-b"},
+		wantNamespace:      "foo",
+	}, {
+		name:               "raw values",
+		annotationSelector: "url=https://example.com/a, note=team a",
+		want:               []string{"pod-a"},
+		wantNamespace:      "chainsaw",
+	}, {
+		name:               "no match",
+		annotationSelector: "team=c",
+		wantNamespace:      "chainsaw",
+		wantErr:            true,
+	}, {
+		name:               "invalid selector",
+		annotationSelector: "=",
+		wantErr:            true,
+	}, {
+		name:               "all namespaces",
+		namespace:          "*",
+		annotationSelector: "team=b",
+		wantErr:            true,
+	}, {
+		name:               "list error",
+		annotationSelector: "team=b",
+		listErr:            errors.New("dummy"),
+		wantErr:            true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var namespace string
+			client := pods(&namespace, tt.listErr)
+			tc := apis.NewBindings().Register("$namespace", apis.NewBinding("chainsaw"))
+			got, err := selectedNames(
+				context.TODO(),
+				apis.DefaultCompilers,
+				client,
+				tc,
+				v1alpha1.ObjectType{APIVersion: "v1", Kind: "Pod"},
+				false,
+				tt.namespace,
+				"",
+				"",
+				tt.annotationSelector,
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantNamespace, namespace)
+		})
+	}
+}
+
+func TestGet_annotationSelector(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	client := &tclient.FakeClient{
+		RESTMapperFn: func(int) meta.RESTMapper {
+			return mapper
+		},
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+			var items []unstructured.Unstructured
+			for _, team := range []string{"a", "b", "c"} {
+				var item unstructured.Unstructured
+				item.SetName("pod-" + team)
+				item.SetAnnotations(map[string]string{"team": team, "url": "https://example.com/" + team, "note": "team " + team})
+				items = append(items, item)
+			}
+			list.(*unstructured.UnstructuredList).Items = items
+			return nil
+		},
+	}
+	tc := apis.NewBindings().Register("$namespace", apis.NewBinding("chainsaw"))
+	collector := v1alpha1.Get{
+		ActionAnnotationSelector: v1alpha1.ActionAnnotationSelector{
+			AnnotationSelector: "team=b",
+		},
+		ActionObject: v1alpha1.ActionObject{
+			ObjectType: v1alpha1.ObjectType{
+				APIVersion: "v1",
+				Kind:       "Pod",
+			},
+		},
+	}
+	entrypoint, args, err := Get(context.TODO(), apis.DefaultCompilers, client, tc, &collector)
+	assert.NoError(t, err)
+	assert.Equal(t, "kubectl", entrypoint)
+	assert.Equal(t, []string{"get", "pods", "pod-b", "-n", "$NAMESPACE"}, args)
+	collector.Name = "foo"
+	_, _, err = Get(context.TODO(), apis.DefaultCompilers, client, tc, &collector)
+	assert.Error(t, err)
+}
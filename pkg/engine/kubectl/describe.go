@@ -27,15 +27,36 @@ func Describe(ctx context.Context, compilers compilers.Compilers, client client.
 	if err != nil {
 		return "", nil, err
 	}
+	annotationSelector, err := collector.AnnotationSelector.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", nil, err
+	}
+	fieldSelector, err := collector.FieldSelector.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", nil, err
+	}
 	if name != "" && selector != "" {
 		return "", nil, errors.New("name cannot be provided when a selector is specified")
 	}
+	if name != "" && annotationSelector != "" {
+		return "", nil, errors.New("name cannot be provided when an annotation selector is specified")
+	}
+	if name != "" && fieldSelector != "" {
+		return "", nil, errors.New("name cannot be provided when a field selector is specified")
+	}
 	resource, clustered, err := mapResource(ctx, compilers, client, tc, collector.ObjectType)
 	if err != nil {
 		return "", nil, err
 	}
 	args := []string{"describe", resource}
-	if name != "" {
+	// kubectl describe doesn't support field selectors, resources are selected client side
+	if annotationSelector != "" || fieldSelector != "" {
+		names, err := selectedNames(ctx, compilers, client, tc, collector.ObjectType, clustered, namespace, selector, fieldSelector, annotationSelector)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, names...)
+	} else if name != "" {
 		args = append(args, name)
 	} else if selector != "" {
 		args = append(args, "-l", selector)
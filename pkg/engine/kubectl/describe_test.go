@@ -6,9 +6,15 @@ import (
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/client/simple"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
 )
@@ -284,3 +290,41 @@ func TestDescribe(t *testing.T) {
 		})
 	}
 }
+
+func TestDescribe_fieldSelector(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	var listOptions []client.ListOption
+	fakeClient := &tclient.FakeClient{
+		RESTMapperFn: func(int) meta.RESTMapper {
+			return mapper
+		},
+		ListFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			listOptions = opts
+			var item unstructured.Unstructured
+			item.SetName("pod-a")
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{item}
+			return nil
+		},
+	}
+	tc := apis.NewBindings().Register("$namespace", apis.NewBinding("chainsaw"))
+	collector := v1alpha1.Describe{
+		ActionFieldSelector: v1alpha1.ActionFieldSelector{
+			FieldSelector: "status.phase=Running",
+		},
+		ActionObject: v1alpha1.ActionObject{
+			ObjectType: v1alpha1.ObjectType{
+				APIVersion: "v1",
+				Kind:       "Pod",
+			},
+		},
+	}
+	entrypoint, args, err := Describe(context.TODO(), apis.DefaultCompilers, fakeClient, tc, &collector)
+	assert.NoError(t, err)
+	assert.Equal(t, "kubectl", entrypoint)
+	assert.Equal(t, []string{"describe", "pods", "pod-a", "-n", "$NAMESPACE"}, args)
+	assert.Contains(t, listOptions, client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("status.phase", "Running")})
+	collector.Name = "foo"
+	_, _, err = Describe(context.TODO(), apis.DefaultCompilers, fakeClient, tc, &collector)
+	assert.Error(t, err)
+}
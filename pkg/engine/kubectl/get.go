@@ -26,6 +26,14 @@ func Get(ctx context.Context, compilers compilers.Compilers, client client.Clien
 	if err != nil {
 		return "", nil, err
 	}
+	fieldSelector, err := collector.FieldSelector.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", nil, err
+	}
+	annotationSelector, err := collector.AnnotationSelector.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", nil, err
+	}
 	format, err := v1alpha1.Expression(collector.Format).Value(ctx, compilers, tc)
 	if err != nil {
 		return "", nil, err
@@ -33,15 +41,32 @@ func Get(ctx context.Context, compilers compilers.Compilers, client client.Clien
 	if name != "" && selector != "" {
 		return "", nil, errors.New("name cannot be provided when a selector is specified")
 	}
+	if name != "" && fieldSelector != "" {
+		return "", nil, errors.New("name cannot be provided when a field selector is specified")
+	}
+	if name != "" && annotationSelector != "" {
+		return "", nil, errors.New("name cannot be provided when an annotation selector is specified")
+	}
 	resource, clustered, err := mapResource(ctx, compilers, client, tc, collector.ObjectType)
 	if err != nil {
 		return "", nil, err
 	}
 	args := []string{"get", resource}
-	if name != "" {
-		args = append(args, name)
-	} else if selector != "" {
-		args = append(args, "-l", selector)
+	if annotationSelector != "" {
+		names, err := selectedNames(ctx, compilers, client, tc, collector.ObjectType, clustered, namespace, selector, fieldSelector, annotationSelector)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, names...)
+	} else {
+		if name != "" {
+			args = append(args, name)
+		} else if selector != "" {
+			args = append(args, "-l", selector)
+		}
+		if fieldSelector != "" {
+			args = append(args, "--field-selector", fieldSelector)
+		}
 	}
 	if !clustered {
 		if namespace == "*" {
@@ -53,6 +78,9 @@ func Get(ctx context.Context, compilers compilers.Compilers, client client.Clien
 			args = append(args, "-n", namespace)
 		}
 	}
+	if collector.Subresource != "" {
+		args = append(args, "--subresource", collector.Subresource)
+	}
 	if format != "" {
 		args = append(args, "-o", format)
 	}
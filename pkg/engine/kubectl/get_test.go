@@ -176,6 +176,109 @@ func TestGet(t *testing.T) {
 		wantEntrypoint: "kubectl",
 		wantArgs:       []string{"get", "pods", "-l", "foo=bar", "-n", "bar"},
 		wantErr:        false,
+	}, {
+		name: "with field selector",
+		collector: &v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "status.phase=Running",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"get", "pods", "--field-selector", "status.phase=Running", "-n", "$NAMESPACE"},
+		wantErr:        false,
+	}, {
+		name: "with selector and field selector",
+		collector: &v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					Selector: "foo=bar",
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "status.phase=Running",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"get", "pods", "-l", "foo=bar", "--field-selector", "status.phase=Running", "-n", "$NAMESPACE"},
+		wantErr:        false,
+	}, {
+		name: "with subresource",
+		collector: &v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{
+						Name: "foo",
+					},
+				},
+			},
+			Subresource: "scale",
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"get", "deployments.v1.apps", "foo", "-n", "$NAMESPACE", "--subresource", "scale"},
+		wantErr:        false,
+	}, {
+		name: "with templated field selector",
+		collector: &v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "(join('=', ['status.phase', 'Running']))",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"get", "pods", "--field-selector", "status.phase=Running", "-n", "$NAMESPACE"},
+		wantErr:        false,
+	}, {
+		name: "with name and field selector",
+		collector: &v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{
+						Name: "foo",
+					},
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "status.phase=Running",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "bad field selector",
+		collector: &v1alpha1.Get{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "($bad)",
+			},
+		},
+		wantErr: true,
 	}, {
 		name: "with all namespaces",
 		collector: &v1alpha1.Get{
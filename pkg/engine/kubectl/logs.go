@@ -2,15 +2,35 @@ package kubectl
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/expressions"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+// DefaultContainerAnnotation is the annotation kubectl uses to select the default container of a pod.
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
 func Logs(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, collector *v1alpha1.PodLogs) (string, []string, error) {
+	return logs(ctx, compilers, tc, collector, true)
+}
+
+// UnprefixedLogs is like Logs but log lines are not prefixed with the pod and container names,
+// it is used when log lines are matched against anchored patterns.
+func UnprefixedLogs(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, collector *v1alpha1.PodLogs) (string, []string, error) {
+	return logs(ctx, compilers, tc, collector, false)
+}
+
+func logs(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, collector *v1alpha1.PodLogs, prefix bool) (string, []string, error) {
 	if collector == nil {
 		return "", nil, errors.New("collector is null")
 	}
@@ -36,7 +56,11 @@ func Logs(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings,
 	if name != "" && selector != "" {
 		return "", nil, errors.New("name cannot be provided when a selector is specified")
 	}
-	args := []string{"logs", "--prefix"}
+	// field selectors are not supported, kubectl logs only accepts a single pod name or a label selector
+	args := []string{"logs"}
+	if prefix {
+		args = append(args, "--prefix")
+	}
 	if name != "" {
 		args = append(args, name)
 	} else if selector != "" {
@@ -52,7 +76,115 @@ func Logs(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings,
 		args = append(args, "-c", container)
 	}
 	if collector.Tail != nil {
-		args = append(args, "--tail", fmt.Sprint(*collector.Tail))
+		tail, err := expressions.Int(ctx, compilers, *collector.Tail, tc)
+		if err != nil {
+			return "", nil, err
+		}
+		args = append(args, "--tail", fmt.Sprint(tail))
+	}
+	if collector.Timestamps != nil && *collector.Timestamps {
+		args = append(args, "--timestamps")
 	}
 	return "kubectl", args, nil
 }
+
+// DefaultContainer returns the container selected by the default container annotation of the pods targeted by the collector.
+// When a selector is specified, the annotation is honored only if all matching pods agree on it.
+// Pods are looked up in the given namespace if the collector doesn't specify one, an empty string is returned when no default container applies.
+func DefaultContainer(ctx context.Context, compilers compilers.Compilers, c client.Client, tc apis.Bindings, namespace string, collector *v1alpha1.PodLogs) (string, error) {
+	if collector == nil {
+		return "", errors.New("collector is null")
+	}
+	name, err := collector.Name.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", err
+	}
+	ns, err := collector.Namespace.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", err
+	}
+	selector, err := collector.Selector.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", err
+	}
+	if ns != "" {
+		namespace = ns
+	}
+	if name != "" {
+		var pod unstructured.Unstructured
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &pod); err != nil {
+			return "", err
+		}
+		return pod.GetAnnotations()[DefaultContainerAnnotation], nil
+	}
+	if selector == "" {
+		return "", nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return "", err
+	}
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("PodList")
+	if err := c.List(ctx, &list, client.InNamespace(namespace), client.MatchingSelector{Selector: parsed}); err != nil {
+		return "", err
+	}
+	container := ""
+	for i, item := range list.Items {
+		value := item.GetAnnotations()[DefaultContainerAnnotation]
+		if i != 0 && value != container {
+			return "", nil
+		}
+		container = value
+	}
+	return container, nil
+}
+
+// JSONFields returns a filter parsing each log line as JSON and only keeping the given fields.
+// The line prefix (pod, container and timestamp) is preserved, lines that are not valid JSON are kept as is.
+func JSONFields(fields ...string) func(string) string {
+	return func(logs string) string {
+		lines := strings.Split(logs, "\n")
+		for i, line := range lines {
+			prefix, message := splitLogPrefix(line)
+			if !strings.HasPrefix(message, "{") {
+				continue
+			}
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(message), &entry); err != nil {
+				continue
+			}
+			selected := map[string]any{}
+			for _, field := range fields {
+				if value, ok := entry[field]; ok {
+					selected[field] = value
+				}
+			}
+			data, err := json.Marshal(selected)
+			if err != nil {
+				continue
+			}
+			lines[i] = prefix + string(data)
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+// splitLogPrefix splits a log line into the prefix added by kubectl (pod and container names, then timestamp) and the message.
+func splitLogPrefix(line string) (string, string) {
+	var prefix string
+	if strings.HasPrefix(line, "[") {
+		if end := strings.Index(line, "] "); end >= 0 {
+			prefix, line = line[:end+2], line[end+2:]
+		}
+	}
+	if timestamp, message, ok := strings.Cut(line, " "); ok {
+		if _, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			prefix, line = prefix+timestamp+" ", message
+		}
+	}
+	return prefix, line
+}
@@ -6,7 +6,11 @@ import (
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 )
 
@@ -14,6 +18,7 @@ func TestLogs(t *testing.T) {
 	tests := []struct {
 		name           string
 		collector      *v1alpha1.PodLogs
+		bindings       apis.Bindings
 		wantEntrypoint string
 		wantArgs       []string
 		wantErr        bool
@@ -97,11 +102,67 @@ func TestLogs(t *testing.T) {
 				},
 			},
 			Container: "bar",
-			Tail:      ptr.To(100),
+			Tail:      ptr.To(intstr.FromInt(100)),
 		},
 		wantEntrypoint: "kubectl",
 		wantArgs:       []string{"logs", "--prefix", "foo", "-n", "lorem", "-c", "bar", "--tail", "100"},
 		wantErr:        false,
+	}, {
+		name: "with timestamps",
+		collector: &v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+			},
+			Timestamps: ptr.To(true),
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"logs", "--prefix", "foo", "-n", "$NAMESPACE", "--all-containers", "--timestamps"},
+		wantErr:        false,
+	}, {
+		name: "without timestamps",
+		collector: &v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+			},
+			Timestamps: ptr.To(false),
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"logs", "--prefix", "foo", "-n", "$NAMESPACE", "--all-containers"},
+		wantErr:        false,
+	}, {
+		name: "with templated tail",
+		collector: &v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{
+					Name:      "foo",
+					Namespace: "lorem",
+				},
+			},
+			Container: "bar",
+			Tail:      ptr.To(intstr.FromString("($restarts * `20`)")),
+		},
+		bindings:       apis.NewBindings().Register("$restarts", apis.NewBinding(3)),
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"logs", "--prefix", "foo", "-n", "lorem", "-c", "bar", "--tail", "60"},
+		wantErr:        false,
+	}, {
+		name: "with non numeric tail",
+		collector: &v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{
+					Name:      "foo",
+					Namespace: "lorem",
+				},
+			},
+			Container: "bar",
+			Tail:      ptr.To(intstr.FromString("($tail)")),
+		},
+		bindings: apis.NewBindings().Register("$tail", apis.NewBinding("foo")),
+		wantErr:  true,
 	}, {
 		name: "with selector",
 		collector: &v1alpha1.PodLogs{
@@ -181,7 +242,7 @@ func TestLogs(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			entrypoint, args, err := Logs(context.TODO(), apis.DefaultCompilers, nil, tt.collector)
+			entrypoint, args, err := Logs(context.TODO(), apis.DefaultCompilers, tt.bindings, tt.collector)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -192,3 +253,176 @@ func TestLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestUnprefixedLogs(t *testing.T) {
+	entrypoint, args, err := UnprefixedLogs(context.TODO(), apis.DefaultCompilers, apis.NewBindings(), &v1alpha1.PodLogs{
+		ActionObjectSelector: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{
+				Namespace: "lorem",
+			},
+			Selector: "job-name=foo",
+		},
+		Tail: ptr.To(intstr.FromInt(-1)),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "kubectl", entrypoint)
+	assert.Equal(t, []string{"logs", "-l", "job-name=foo", "-n", "lorem", "--all-containers", "--tail", "-1"}, args)
+}
+
+func TestDefaultContainer(t *testing.T) {
+	pod := func(name string, container string) unstructured.Unstructured {
+		var pod unstructured.Unstructured
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetName(name)
+		if container != "" {
+			pod.SetAnnotations(map[string]string{DefaultContainerAnnotation: container})
+		}
+		return pod
+	}
+	get := func(item unstructured.Unstructured) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				assert.Equal(t, "chainsaw", key.Namespace)
+				obj.(*unstructured.Unstructured).Object = item.Object
+				return nil
+			},
+		}
+	}
+	list := func(items ...unstructured.Unstructured) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*unstructured.UnstructuredList).Items = items
+				return nil
+			},
+		}
+	}
+	byName := &v1alpha1.PodLogs{
+		ActionObjectSelector: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{
+				Name: "foo",
+			},
+		},
+	}
+	bySelector := &v1alpha1.PodLogs{
+		ActionObjectSelector: v1alpha1.ActionObjectSelector{
+			Selector: "app=foo",
+		},
+	}
+	tests := []struct {
+		name      string
+		client    *tclient.FakeClient
+		collector *v1alpha1.PodLogs
+		want      string
+		wantErr   bool
+	}{{
+		name:      "nil",
+		collector: nil,
+		wantErr:   true,
+	}, {
+		name:      "with name",
+		client:    get(pod("foo", "app")),
+		collector: byName,
+		want:      "app",
+	}, {
+		name:      "with name and no annotation",
+		client:    get(pod("foo", "")),
+		collector: byName,
+		want:      "",
+	}, {
+		name:      "with selector",
+		client:    list(pod("foo-0", "app"), pod("foo-1", "app")),
+		collector: bySelector,
+		want:      "app",
+	}, {
+		name:      "with selector and different annotations",
+		client:    list(pod("foo-0", "app"), pod("foo-1", "sidecar")),
+		collector: bySelector,
+		want:      "",
+	}, {
+		name:      "with selector and missing annotation",
+		client:    list(pod("foo-0", "app"), pod("foo-1", "")),
+		collector: bySelector,
+		want:      "",
+	}, {
+		name:   "with invalid selector",
+		client: list(),
+		collector: &v1alpha1.PodLogs{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				Selector: "app in (",
+			},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DefaultContainer(context.TODO(), apis.DefaultCompilers, tt.client, apis.NewBindings(), "chainsaw", tt.collector)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestJSONFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		logs   string
+		want   string
+	}{{
+		name:   "empty",
+		fields: []string{"msg"},
+		logs:   "",
+		want:   "",
+	}, {
+		name:   "json lines",
+		fields: []string{"level", "msg"},
+		logs: `[pod/foo/app] {"level":"info","msg":"started","ts":1704067200}
+[pod/foo/app] {"level":"error","msg":"failed","error":"boom"}
+`,
+		want: `[pod/foo/app] {"level":"info","msg":"started"}
+[pod/foo/app] {"level":"error","msg":"failed"}
+`,
+	}, {
+		name:   "with timestamps",
+		fields: []string{"msg"},
+		logs:   `[pod/foo/app] 2024-01-01T00:00:00.000000000Z {"level":"info","msg":"started"}`,
+		want:   `[pod/foo/app] 2024-01-01T00:00:00.000000000Z {"msg":"started"}`,
+	}, {
+		name:   "missing fields",
+		fields: []string{"msg", "error"},
+		logs:   `[pod/foo/app] {"level":"info","msg":"started"}`,
+		want:   `[pod/foo/app] {"msg":"started"}`,
+	}, {
+		name:   "nested values",
+		fields: []string{"request"},
+		logs:   `{"msg":"served","request":{"method":"GET","path":"/"}}`,
+		want:   `{"request":{"method":"GET","path":"/"}}`,
+	}, {
+		name:   "not json",
+		fields: []string{"msg"},
+		logs: `[pod/foo/app] starting server
+[pod/foo/app] {"msg":"started"}
+[pod/foo/app] {not json}`,
+		want: `[pod/foo/app] starting server
+[pod/foo/app] {"msg":"started"}
+[pod/foo/app] {not json}`,
+	}, {
+		name:   "text ending with json",
+		fields: []string{"msg"},
+		logs: `[pod/foo/app] request {"msg":"served","path":"/"}
+level=info payload={"msg":"served"}`,
+		want: `[pod/foo/app] request {"msg":"served","path":"/"}
+level=info payload={"msg":"served"}`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := JSONFields(tt.fields...)(tt.logs)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,24 +14,24 @@ import (
 )
 
 func mapResource(ctx context.Context, compilers compilers.Compilers, client client.Client, tc apis.Bindings, resource v1alpha1.ObjectType) (string, bool, error) {
-	if resource.APIVersion != "" && resource.Kind != "" {
-		if apiVersion, err := resource.APIVersion.Value(ctx, compilers, tc); err != nil {
-			return "", false, err
-		} else if kind, err := resource.Kind.Value(ctx, compilers, tc); err != nil {
-			return "", false, err
-		} else {
-			return mapResourceFromApiVersionAndKind(client, apiVersion, kind)
-		}
+	gvk, err := resourceGVK(ctx, compilers, tc, resource)
+	if err != nil {
+		return "", false, err
 	}
-	return "", false, errors.New("failed to map resource, either kind or resource must be specified")
+	return mapResourceFromGVK(client.RESTMapper(), gvk)
 }
 
-func mapResourceFromApiVersionAndKind(client client.Client, apiVersion string, kind string) (string, bool, error) {
-	gv, err := schema.ParseGroupVersion(apiVersion)
-	if err != nil {
-		return "", false, err
+func resourceGVK(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, resource v1alpha1.ObjectType) (schema.GroupVersionKind, error) {
+	if (resource.APIVersion != "" || resource.Version != "") && resource.Kind != "" {
+		if gv, err := resource.GroupVersion(ctx, compilers, tc); err != nil {
+			return schema.GroupVersionKind{}, err
+		} else if kind, err := resource.Kind.Value(ctx, compilers, tc); err != nil {
+			return schema.GroupVersionKind{}, err
+		} else {
+			return gv.WithKind(kind), nil
+		}
 	}
-	return mapResourceFromGVK(client.RESTMapper(), gv.WithKind(kind))
+	return schema.GroupVersionKind{}, errors.New("failed to map resource, either kind or resource must be specified")
 }
 
 func mapResourceFromGVK(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (string, bool, error) {
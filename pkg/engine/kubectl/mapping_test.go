@@ -0,0 +1,81 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_mapResource(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}, meta.RESTScopeRoot)
+	client := &tclient.FakeClient{
+		RESTMapperFn: func(int) meta.RESTMapper {
+			return mapper
+		},
+	}
+	tests := []struct {
+		name          string
+		resource      v1alpha1.ObjectType
+		want          string
+		wantClustered bool
+		wantErr       bool
+	}{{
+		name:    "empty",
+		wantErr: true,
+	}, {
+		name:     "api version and kind",
+		resource: v1alpha1.ObjectType{APIVersion: "apps/v1", Kind: "Deployment"},
+		want:     "deployments.v1.apps",
+	}, {
+		name:     "explicit core version and kind",
+		resource: v1alpha1.ObjectType{Version: "v1", Kind: "Pod"},
+		want:     "pods",
+	}, {
+		name:     "explicit group version and kind",
+		resource: v1alpha1.ObjectType{Group: "apps", Version: "v1", Kind: "Deployment"},
+		want:     "deployments.v1.apps",
+	}, {
+		name:          "explicit clustered group version and kind",
+		resource:      v1alpha1.ObjectType{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		want:          "clusterroles.v1.rbac.authorization.k8s.io",
+		wantClustered: true,
+	}, {
+		name:     "explicit group version and kind expressions",
+		resource: v1alpha1.ObjectType{Group: "($group)", Version: "v1", Kind: "Deployment"},
+		want:     "deployments.v1.apps",
+	}, {
+		name:     "explicit group and version without kind",
+		resource: v1alpha1.ObjectType{Group: "apps", Version: "v1"},
+		wantErr:  true,
+	}, {
+		name:     "api version with explicit version",
+		resource: v1alpha1.ObjectType{APIVersion: "apps/v1", Version: "v1", Kind: "Deployment"},
+		wantErr:  true,
+	}, {
+		name:     "unknown kind",
+		resource: v1alpha1.ObjectType{Group: "apps", Version: "v1", Kind: "Unknown"},
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings := apis.NewBindings().Register("$group", apis.NewBinding("apps"))
+			got, clustered, err := mapResource(context.TODO(), apis.DefaultCompilers, client, bindings, tt.resource)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantClustered, clustered)
+		})
+	}
+}
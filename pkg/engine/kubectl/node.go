@@ -0,0 +1,82 @@
+package kubectl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+func Cordon(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, collector *v1alpha1.Cordon) (string, []string, error) {
+	if collector == nil {
+		return "", nil, errors.New("collector is null")
+	}
+	nodes, err := nodeArgs(ctx, compilers, tc, collector.ActionNode)
+	if err != nil {
+		return "", nil, err
+	}
+	args := append([]string{"cordon"}, nodes...)
+	return "kubectl", args, nil
+}
+
+func Uncordon(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, collector *v1alpha1.Uncordon) (string, []string, error) {
+	if collector == nil {
+		return "", nil, errors.New("collector is null")
+	}
+	nodes, err := nodeArgs(ctx, compilers, tc, collector.ActionNode)
+	if err != nil {
+		return "", nil, err
+	}
+	args := append([]string{"uncordon"}, nodes...)
+	return "kubectl", args, nil
+}
+
+func Drain(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, collector *v1alpha1.Drain) (string, []string, error) {
+	if collector == nil {
+		return "", nil, errors.New("collector is null")
+	}
+	nodes, err := nodeArgs(ctx, compilers, tc, collector.ActionNode)
+	if err != nil {
+		return "", nil, err
+	}
+	args := append([]string{"drain"}, nodes...)
+	if collector.IgnoreDaemonSets {
+		args = append(args, "--ignore-daemonsets")
+	}
+	if collector.DeleteEmptyDirData {
+		args = append(args, "--delete-emptydir-data")
+	}
+	if collector.GracePeriod != nil {
+		args = append(args, fmt.Sprintf("--grace-period=%d", int64(collector.GracePeriod.Duration.Seconds())))
+	}
+	if collector.Timeout != nil {
+		args = append(args, "--timeout", collector.Timeout.Duration.String())
+	}
+	return "kubectl", args, nil
+}
+
+// nodeArgs returns the arguments selecting the target nodes.
+// Nodes must be selected explicitly, there's no way to target all nodes.
+func nodeArgs(ctx context.Context, compilers compilers.Compilers, tc apis.Bindings, node v1alpha1.ActionNode) ([]string, error) {
+	name, err := node.Name.Value(ctx, compilers, tc)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := node.Selector.Value(ctx, compilers, tc)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" && selector != "" {
+		return nil, errors.New("name cannot be provided when a selector is specified")
+	}
+	if name != "" {
+		return []string{name}, nil
+	}
+	if selector != "" {
+		return []string{"--selector", selector}, nil
+	}
+	return nil, errors.New("a node name or selector must be specified")
+}
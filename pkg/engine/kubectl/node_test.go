@@ -0,0 +1,183 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCordon(t *testing.T) {
+	tests := []struct {
+		name           string
+		collector      *v1alpha1.Cordon
+		wantEntrypoint string
+		wantArgs       []string
+		wantErr        bool
+	}{{
+		name:      "nil",
+		collector: nil,
+		wantErr:   true,
+	}, {
+		name:      "empty",
+		collector: &v1alpha1.Cordon{},
+		wantErr:   true,
+	}, {
+		name: "with name",
+		collector: &v1alpha1.Cordon{
+			ActionNode: v1alpha1.ActionNode{
+				Name: "worker",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"cordon", "worker"},
+	}, {
+		name: "with selector",
+		collector: &v1alpha1.Cordon{
+			ActionNode: v1alpha1.ActionNode{
+				Selector: "node-role.kubernetes.io/worker=",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"cordon", "--selector", "node-role.kubernetes.io/worker="},
+	}, {
+		name: "with name and selector",
+		collector: &v1alpha1.Cordon{
+			ActionNode: v1alpha1.ActionNode{
+				Name:     "worker",
+				Selector: "node-role.kubernetes.io/worker=",
+			},
+		},
+		wantErr: true,
+	}, {
+		name: "with name expression",
+		collector: &v1alpha1.Cordon{
+			ActionNode: v1alpha1.ActionNode{
+				Name: "($node)",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"cordon", "kind-worker"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings := apis.NewBindings().Register("$node", apis.NewBinding("kind-worker"))
+			entrypoint, args, err := Cordon(context.TODO(), apis.DefaultCompilers, bindings, tt.collector)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantEntrypoint, entrypoint)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestUncordon(t *testing.T) {
+	tests := []struct {
+		name           string
+		collector      *v1alpha1.Uncordon
+		wantEntrypoint string
+		wantArgs       []string
+		wantErr        bool
+	}{{
+		name:      "nil",
+		collector: nil,
+		wantErr:   true,
+	}, {
+		name:      "empty",
+		collector: &v1alpha1.Uncordon{},
+		wantErr:   true,
+	}, {
+		name: "with name",
+		collector: &v1alpha1.Uncordon{
+			ActionNode: v1alpha1.ActionNode{
+				Name: "worker",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"uncordon", "worker"},
+	}, {
+		name: "with selector",
+		collector: &v1alpha1.Uncordon{
+			ActionNode: v1alpha1.ActionNode{
+				Selector: "zone=a",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"uncordon", "--selector", "zone=a"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entrypoint, args, err := Uncordon(context.TODO(), apis.DefaultCompilers, apis.NewBindings(), tt.collector)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantEntrypoint, entrypoint)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	tests := []struct {
+		name           string
+		collector      *v1alpha1.Drain
+		wantEntrypoint string
+		wantArgs       []string
+		wantErr        bool
+	}{{
+		name:      "nil",
+		collector: nil,
+		wantErr:   true,
+	}, {
+		name: "no node",
+		collector: &v1alpha1.Drain{
+			IgnoreDaemonSets: true,
+		},
+		wantErr: true,
+	}, {
+		name: "with name",
+		collector: &v1alpha1.Drain{
+			ActionNode: v1alpha1.ActionNode{
+				Name: "worker",
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"drain", "worker"},
+	}, {
+		name: "with options",
+		collector: &v1alpha1.Drain{
+			ActionTimeout: v1alpha1.ActionTimeout{
+				Timeout: &v1alpha1.Timeout{Duration: 2 * time.Minute},
+			},
+			ActionNode: v1alpha1.ActionNode{
+				Selector: "zone=a",
+			},
+			IgnoreDaemonSets:   true,
+			DeleteEmptyDirData: true,
+			GracePeriod:        &metav1.Duration{Duration: 30 * time.Second},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"drain", "--selector", "zone=a", "--ignore-daemonsets", "--delete-emptydir-data", "--grace-period=30", "--timeout", "2m0s"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entrypoint, args, err := Drain(context.TODO(), apis.DefaultCompilers, apis.NewBindings(), tt.collector)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantEntrypoint, entrypoint)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -214,10 +214,10 @@ func TestProxy(t *testing.T) {
 		// 				Name: "Ready",
 		// 			},
 		// 		},
-		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 120 * time.Second}},
+		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &v1alpha1.Timeout{Duration: 120 * time.Second}},
 		// 	},
 		// 	want: &v1alpha1.Command{
-		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 120 * time.Second}},
+		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &v1alpha1.Timeout{Duration: 120 * time.Second}},
 		// 		Entrypoint:    "kubectl",
 		// 		Args:          []string{"wait", "pods", "--for=condition=Ready", "--all", "-n", "$NAMESPACE", "--timeout", "2m0s"},
 		// 	},
@@ -279,10 +279,10 @@ func TestProxy(t *testing.T) {
 		// 				Name: "Ready",
 		// 			},
 		// 		},
-		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 120 * time.Second}},
+		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &v1alpha1.Timeout{Duration: 120 * time.Second}},
 		// 	},
 		// 	want: &v1alpha1.Command{
-		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 120 * time.Second}},
+		// 		ActionTimeout: v1alpha1.ActionTimeout{Timeout: &v1alpha1.Timeout{Duration: 120 * time.Second}},
 		// 		Entrypoint:    "kubectl",
 		// 		Args:          []string{"wait", "pods", "--for=condition=Ready", "--all", "--all-namespaces", "--timeout", "2m0s"},
 		// 	},
@@ -27,6 +27,10 @@ func Wait(ctx context.Context, compilers compilers.Compilers, client client.Clie
 	if err != nil {
 		return "", nil, err
 	}
+	fieldSelector, err := collector.FieldSelector.Value(ctx, compilers, tc)
+	if err != nil {
+		return "", nil, err
+	}
 	format, err := v1alpha1.Expression(collector.Format).Value(ctx, compilers, tc)
 	if err != nil {
 		return "", nil, err
@@ -34,6 +38,9 @@ func Wait(ctx context.Context, compilers compilers.Compilers, client client.Clie
 	if name != "" && selector != "" {
 		return "", nil, errors.New("name cannot be provided when a selector is specified")
 	}
+	if name != "" && fieldSelector != "" {
+		return "", nil, errors.New("name cannot be provided when a field selector is specified")
+	}
 	resource, clustered, err := mapResource(ctx, compilers, client, tc, collector.ObjectType)
 	if err != nil {
 		return "", nil, err
@@ -83,8 +90,13 @@ func Wait(ctx context.Context, compilers compilers.Compilers, client client.Clie
 	}
 	if name != "" {
 		args = append(args, name)
-	} else if selector != "" {
-		args = append(args, "-l", selector)
+	} else if selector != "" || fieldSelector != "" {
+		if selector != "" {
+			args = append(args, "-l", selector)
+		}
+		if fieldSelector != "" {
+			args = append(args, "--field-selector", fieldSelector)
+		}
 	} else {
 		args = append(args, "--all")
 	}
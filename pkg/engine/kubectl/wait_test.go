@@ -10,7 +10,6 @@ import (
 	"github.com/kyverno/chainsaw/pkg/client/simple"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/stretchr/testify/assert"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
 )
@@ -205,6 +204,51 @@ func TestWait(t *testing.T) {
 		wantEntrypoint: "kubectl",
 		wantArgs:       []string{"wait", "pods", "--for=condition=Ready", "-l", "app=my-app", "-n", "$NAMESPACE", "--timeout=-1s"},
 		wantErr:        false,
+	}, {
+		name: "with field selector",
+		collector: &v1alpha1.Wait{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "status.phase=Running",
+			},
+			WaitFor: v1alpha1.WaitFor{
+				Condition: &v1alpha1.WaitForCondition{
+					Name: "Ready",
+				},
+			},
+		},
+		wantEntrypoint: "kubectl",
+		wantArgs:       []string{"wait", "pods", "--for=condition=Ready", "--field-selector", "status.phase=Running", "-n", "$NAMESPACE", "--timeout=-1s"},
+		wantErr:        false,
+	}, {
+		name: "name and field selector error",
+		collector: &v1alpha1.Wait{
+			ActionObject: v1alpha1.ActionObject{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+				ActionObjectSelector: v1alpha1.ActionObjectSelector{
+					ObjectName: v1alpha1.ObjectName{
+						Name: "foo",
+					},
+				},
+			},
+			ActionFieldSelector: v1alpha1.ActionFieldSelector{
+				FieldSelector: "status.phase=Running",
+			},
+			WaitFor: v1alpha1.WaitFor{
+				Condition: &v1alpha1.WaitForCondition{
+					Name: "Ready",
+				},
+			},
+		},
+		wantErr: true,
 	}, {
 		name: "with timeout",
 		collector: &v1alpha1.Wait{
@@ -219,7 +263,7 @@ func TestWait(t *testing.T) {
 					Name: "Ready",
 				},
 			},
-			ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 120 * time.Second}},
+			ActionTimeout: v1alpha1.ActionTimeout{Timeout: &v1alpha1.Timeout{Duration: 120 * time.Second}},
 		},
 		wantEntrypoint: "kubectl",
 		wantArgs:       []string{"wait", "pods", "--for=condition=Ready", "--all", "-n", "$NAMESPACE", "--timeout", "2m0s"},
@@ -281,7 +325,7 @@ func TestWait(t *testing.T) {
 					Name: "Ready",
 				},
 			},
-			ActionTimeout: v1alpha1.ActionTimeout{Timeout: &metav1.Duration{Duration: 120 * time.Second}},
+			ActionTimeout: v1alpha1.ActionTimeout{Timeout: &v1alpha1.Timeout{Duration: 120 * time.Second}},
 		},
 		wantEntrypoint: "kubectl",
 		wantArgs:       []string{"wait", "pods", "--for=condition=Ready", "--all", "--all-namespaces", "--timeout", "2m0s"},
@@ -0,0 +1,48 @@
+package kustomize
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/loaders/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// Build builds the kustomization stored in the given folder like `kustomize build` does and returns the resulting objects.
+func Build(ctx context.Context, dir string) ([]unstructured.Unstructured, error) {
+	type result struct {
+		objects []unstructured.Unstructured
+		err     error
+	}
+	// building doesn't support cancellation, give up waiting when the context is done
+	done := make(chan result, 1)
+	go func() {
+		objects, err := build(dir)
+		done <- result{objects: objects, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-done:
+		return result.objects, result.err
+	}
+}
+
+func build(dir string) ([]unstructured.Unstructured, error) {
+	kustomizer := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resources, err := kustomizer.Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build failed: %w", err)
+	}
+	content, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build failed: %w", err)
+	}
+	objects, err := resource.Parse(content, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kustomize output: %w", err)
+	}
+	return objects, nil
+}
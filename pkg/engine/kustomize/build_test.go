@@ -0,0 +1,60 @@
+package kustomize
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name    string
+		dir     string
+		want    []unstructured.Unstructured
+		wantErr string
+	}{{
+		name: "overlay",
+		dir:  "../../../testdata/kustomize/overlay",
+		want: []unstructured.Unstructured{{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name": "demo-settings",
+					"labels": map[string]any{
+						"app.kubernetes.io/name": "demo",
+					},
+				},
+				"data": map[string]any{
+					"environment": "($environment)",
+				},
+			},
+		}},
+	}, {
+		name:    "not a kustomization",
+		dir:     "../../../testdata/kustomize",
+		wantErr: "kustomize build failed: unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Build(context.TODO(), tt.dir)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuild_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	got, err := Build(ctx, "../../../testdata/kustomize/overlay")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, got)
+}
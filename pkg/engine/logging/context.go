@@ -15,6 +15,13 @@ func FromContext(ctx context.Context) Logger {
 	return nil
 }
 
+// IntoContext adds the logger to the context, values redacted in the context (see WithRedaction) are masked in its logs.
 func IntoContext(ctx context.Context, logger Logger) context.Context {
+	if redactor := redactorFromContext(ctx); redactor != nil && logger != nil {
+		if inner, ok := logger.(*redactingLogger); ok {
+			logger = inner.inner
+		}
+		logger = &redactingLogger{redactor: redactor, inner: logger}
+	}
 	return context.WithValue(ctx, contextKey{}, logger)
 }
@@ -11,24 +11,39 @@ type (
 )
 
 const (
-	Apply    Operation = "APPLY"
-	Assert   Operation = "ASSERT"
-	Catch    Operation = "CATCH"
-	Cleanup  Operation = "CLEANUP"
-	Command  Operation = "CMD"
-	Create   Operation = "CREATE"
-	Delete   Operation = "DELETE"
-	Error    Operation = "ERROR"
-	Finally  Operation = "FINALLY"
-	Get      Operation = "GET"
-	Internal Operation = "INTERNAL"
-	Patch    Operation = "PATCH"
-	Script   Operation = "SCRIPT"
-	Sleep    Operation = "SLEEP"
-	Stderr   Operation = "STDERR"
-	Stdout   Operation = "STDOUT"
-	Try      Operation = "TRY"
-	Update   Operation = "UPDATE"
+	Apply       Operation = "APPLY"
+	Assert      Operation = "ASSERT"
+	CanI        Operation = "CAN-I"
+	Catch       Operation = "CATCH"
+	Chart       Operation = "CHART"
+	Cleanup     Operation = "CLEANUP"
+	Collect     Operation = "COLLECT"
+	Command     Operation = "CMD"
+	Compare     Operation = "COMPARE"
+	Create      Operation = "CREATE"
+	Delete      Operation = "DELETE"
+	Delta       Operation = "DELTA"
+	Error       Operation = "ERROR"
+	Exec        Operation = "EXEC"
+	Finally     Operation = "FINALLY"
+	Get         Operation = "GET"
+	Health      Operation = "HEALTH"
+	Internal    Operation = "INTERNAL"
+	Job         Operation = "JOB"
+	NoCrashLoop Operation = "NO-CRASH-LOOP"
+	Patch       Operation = "PATCH"
+	PodCount    Operation = "POD-COUNT"
+	Probe       Operation = "PROBE"
+	Rollout     Operation = "ROLLOUT"
+	Scale       Operation = "SCALE"
+	Script      Operation = "SCRIPT"
+	Sleep       Operation = "SLEEP"
+	Snapshot    Operation = "SNAPSHOT"
+	Stderr      Operation = "STDERR"
+	Stdout      Operation = "STDOUT"
+	Try         Operation = "TRY"
+	Update      Operation = "UPDATE"
+	Watch       Operation = "WATCH"
 )
 
 const (
@@ -39,6 +54,7 @@ const (
 	LogStatus   Status = "LOG"
 	OkStatus    Status = "OK"
 	RunStatus   Status = "RUN"
+	SkipStatus  Status = "SKIP"
 	WarnStatus  Status = "WARN"
 	// DoneStatus  Status = "✅"
 	// ErrorStatus Status = "❌"
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+const redacted = "***"
+
+// MinRedactedLength is the minimum length of a redacted value, shorter values (like true, 1 or a namespace name)
+// would mask unrelated parts of the logs.
+const MinRedactedLength = 6
+
+type redactor struct {
+	values   []string
+	replacer *strings.Replacer
+}
+
+type redactorKey struct{}
+
+// WithRedaction returns a context where the given sensitive values are masked in the logs of the loggers added to it (see IntoContext).
+// Values registered in a parent context are masked too, values shorter than MinRedactedLength are ignored.
+func WithRedaction(ctx context.Context, values ...string) context.Context {
+	set := map[string]struct{}{}
+	if parent := redactorFromContext(ctx); parent != nil {
+		for _, value := range parent.values {
+			set[value] = struct{}{}
+		}
+	}
+	for _, value := range values {
+		if len(value) >= MinRedactedLength {
+			set[value] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return ctx
+	}
+	sorted := make([]string, 0, len(set))
+	for value := range set {
+		sorted = append(sorted, value)
+	}
+	// longer values first so that a value containing another one is fully masked
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	oldnew := make([]string, 0, 2*len(sorted))
+	for _, value := range sorted {
+		oldnew = append(oldnew, value, redacted)
+	}
+	return context.WithValue(ctx, redactorKey{}, &redactor{
+		values:   sorted,
+		replacer: strings.NewReplacer(oldnew...),
+	})
+}
+
+func redactorFromContext(ctx context.Context) *redactor {
+	if ctx != nil {
+		if v, ok := ctx.Value(redactorKey{}).(*redactor); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+type redactedString struct {
+	redactor *redactor
+	inner    fmt.Stringer
+}
+
+func (s redactedString) String() string {
+	return s.redactor.replacer.Replace(s.inner.String())
+}
+
+type redactingLogger struct {
+	redactor *redactor
+	inner    Logger
+}
+
+func (l *redactingLogger) Log(operation Operation, status Status, color *color.Color, args ...fmt.Stringer) {
+	redactedArgs := make([]fmt.Stringer, 0, len(args))
+	for _, arg := range args {
+		redactedArgs = append(redactedArgs, redactedString{redactor: l.redactor, inner: arg})
+	}
+	l.inner.Log(operation, status, color, redactedArgs...)
+}
+
+func (l *redactingLogger) WithResource(resource client.Object) Logger {
+	return &redactingLogger{
+		redactor: l.redactor,
+		inner:    l.inner.WithResource(resource),
+	}
+}
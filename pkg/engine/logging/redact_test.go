@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/stretchr/testify/assert"
+	tclock "k8s.io/utils/clock/testing"
+)
+
+func TestWithRedaction(t *testing.T) {
+	ctx := WithRedaction(context.TODO(), "", "admin", "hunter2", "hunter2-admin")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		in   string
+		want string
+	}{{
+		name: "no secret",
+		ctx:  ctx,
+		in:   "nothing to hide",
+		want: "nothing to hide",
+	}, {
+		name: "secret",
+		ctx:  ctx,
+		in:   "password is hunter2",
+		want: "password is ***",
+	}, {
+		name: "longest secret first",
+		ctx:  ctx,
+		in:   "user is hunter2-admin",
+		want: "user is ***",
+	}, {
+		name: "multiple occurrences",
+		ctx:  ctx,
+		in:   "hunter2/hunter2",
+		want: "***/***",
+	}, {
+		name: "short value",
+		ctx:  ctx,
+		in:   "user is admin",
+		want: "user is admin",
+	}, {
+		name: "parent values",
+		ctx:  WithRedaction(ctx, "t0ps3cr3t"),
+		in:   "hunter2 t0ps3cr3t",
+		want: "*** ***",
+	}, {
+		name: "not in scope",
+		ctx:  context.TODO(),
+		in:   "password is hunter2",
+		want: "password is hunter2",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockT := &tlogging.FakeTLogger{}
+			ctx := IntoContext(tt.ctx, NewLogger(mockT, tclock.NewFakePassiveClock(time.Now()), "testName", "stepName"))
+			FromContext(ctx).Log(Apply, OkStatus, nil, s(tt.in))
+			assert.Len(t, mockT.Messages, 1)
+			assert.Contains(t, mockT.Messages[0], tt.want)
+		})
+	}
+}
+
+func Test_logger_Log_Redacted(t *testing.T) {
+	ctx := WithRedaction(context.TODO(), "t0ps3cr3t")
+	mockT := &tlogging.FakeTLogger{}
+	ctx = IntoContext(ctx, NewLogger(mockT, tclock.NewFakePassiveClock(time.Now()), "testName", "stepName"))
+	// a logger replaced in a child context is still redacted
+	ctx = IntoContext(ctx, FromContext(ctx).WithResource(nil))
+	FromContext(ctx).Log(Apply, OkStatus, nil, s("token: t0ps3cr3t"))
+	assert.Len(t, mockT.Messages, 1)
+	assert.Contains(t, mockT.Messages[0], "token: ***")
+	assert.NotContains(t, mockT.Messages[0], "t0ps3cr3t")
+}
@@ -2,28 +2,35 @@ package testing
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/fatih/color"
 	"github.com/kyverno/chainsaw/pkg/client"
 )
 
-// TODO: not thread safe
 type FakeLogger struct {
 	Logs     []string
 	numCalls int
+	lock     sync.Mutex
 }
 
 func (f *FakeLogger) WithResource(resource client.Object) Logger {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 	defer func() { f.numCalls++ }()
 	return f
 }
 
 func (f *FakeLogger) Log(operation Operation, status Status, color *color.Color, args ...fmt.Stringer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 	defer func() { f.numCalls++ }()
 	message := fmt.Sprintf("%s: %s - %v", operation, status, args)
 	f.Logs = append(f.Logs, message)
 }
 
 func (f *FakeLogger) NumCalls() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
 	return f.numCalls
 }
@@ -23,15 +23,21 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+// FieldManager is the field manager used for server-side apply.
+const FieldManager = "chainsaw"
+
 type operation struct {
-	compilers  compilers.Compilers
-	client     client.Client
-	base       unstructured.Unstructured
-	namespacer namespacer.Namespacer
-	cleaner    cleaner.CleanerCollector
-	template   bool
-	expect     []v1alpha1.Expectation
-	outputs    []v1alpha1.Output
+	compilers      compilers.Compilers
+	client         client.Client
+	base           unstructured.Unstructured
+	namespacer     namespacer.Namespacer
+	cleaner        cleaner.CleanerCollector
+	template       bool
+	serverSide     bool
+	forceConflicts bool
+	rendered       *v1alpha1.Check
+	expect         []v1alpha1.Expectation
+	outputs        []v1alpha1.Output
 }
 
 func New(
@@ -41,18 +47,24 @@ func New(
 	namespacer namespacer.Namespacer,
 	cleaner cleaner.CleanerCollector,
 	template bool,
+	serverSide bool,
+	forceConflicts bool,
+	rendered *v1alpha1.Check,
 	expect []v1alpha1.Expectation,
 	outputs []v1alpha1.Output,
 ) operations.Operation {
 	return &operation{
-		compilers:  compilers,
-		client:     client,
-		base:       obj,
-		namespacer: namespacer,
-		cleaner:    cleaner,
-		template:   template,
-		expect:     expect,
-		outputs:    outputs,
+		compilers:      compilers,
+		client:         client,
+		base:           obj,
+		namespacer:     namespacer,
+		cleaner:        cleaner,
+		template:       template,
+		serverSide:     serverSide,
+		forceConflicts: forceConflicts,
+		rendered:       rendered,
+		expect:         expect,
+		outputs:        outputs,
 	}
 }
 
@@ -73,6 +85,9 @@ func (o *operation) Exec(ctx context.Context, tc apis.Bindings) (_ outputs.Outpu
 			obj = merged
 		}
 	}
+	if err := internal.CheckRendered(ctx, o.compilers, obj, tc, o.rendered); err != nil {
+		return nil, err
+	}
 	if err := internal.ApplyNamespacer(o.namespacer, o.client, &obj); err != nil {
 		return nil, err
 	}
@@ -84,7 +99,7 @@ func (o *operation) execute(ctx context.Context, tc apis.Bindings, obj unstructu
 	var lastErr error
 	var outputs outputs.Outputs
 	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
-		outputs, lastErr = o.tryApplyResource(ctx, tc, obj)
+		outputs, lastErr = o.tryApplyResource(ctx, tc, &obj)
 		// TODO: determine if the error can be retried
 		return lastErr == nil, nil
 	})
@@ -97,19 +112,50 @@ func (o *operation) execute(ctx context.Context, tc apis.Bindings, obj unstructu
 	return outputs, err
 }
 
-func (o *operation) tryApplyResource(ctx context.Context, tc apis.Bindings, obj unstructured.Unstructured) (outputs.Outputs, error) {
+func (o *operation) tryApplyResource(ctx context.Context, tc apis.Bindings, obj *unstructured.Unstructured) (outputs.Outputs, error) {
+	// the name is generated by the server, the resource can't exist yet
+	if obj.GetName() == "" && obj.GetGenerateName() != "" {
+		created := obj.DeepCopy()
+		err := o.client.Create(ctx, created)
+		if err == nil {
+			if o.cleaner != nil {
+				o.cleaner.Add(o.client, created)
+			}
+			// keep the generated name so that retries update the created resource instead of creating a new one
+			obj.SetName(created.GetName())
+		}
+		return o.handleCheck(ctx, tc, *created, *created, err)
+	}
 	var actual unstructured.Unstructured
 	actual.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
-	err := o.client.Get(ctx, client.Key(&obj), &actual)
+	err := o.client.Get(ctx, client.Key(obj), &actual)
 	if err == nil {
-		return o.updateResource(ctx, tc, &actual, obj)
+		if o.serverSide {
+			return o.serverSideApply(ctx, tc, *obj, false)
+		}
+		return o.updateResource(ctx, tc, &actual, *obj)
 	}
 	if kerrors.IsNotFound(err) {
-		return o.createResource(ctx, tc, obj)
+		if o.serverSide {
+			return o.serverSideApply(ctx, tc, *obj, true)
+		}
+		return o.createResource(ctx, tc, *obj)
 	}
 	return nil, err
 }
 
+func (o *operation) serverSideApply(ctx context.Context, tc apis.Bindings, obj unstructured.Unstructured, create bool) (outputs.Outputs, error) {
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if o.forceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	err := o.client.Patch(ctx, &obj, client.Apply, opts...)
+	if err == nil && create && o.cleaner != nil {
+		o.cleaner.Add(o.client, &obj)
+	}
+	return o.handleCheck(ctx, tc, obj, obj, err)
+}
+
 func (o *operation) updateResource(ctx context.Context, tc apis.Bindings, actual *unstructured.Unstructured, obj unstructured.Unstructured) (outputs.Outputs, error) {
 	patched, err := client.PatchObject(actual, &obj)
 	if err != nil {
@@ -119,7 +165,8 @@ func (o *operation) updateResource(ctx context.Context, tc apis.Bindings, actual
 	if err != nil {
 		return nil, err
 	}
-	return o.handleCheck(ctx, tc, obj, o.client.Patch(ctx, actual, client.RawPatch(types.MergePatchType, bytes)))
+	err = o.client.Patch(ctx, actual, client.RawPatch(types.MergePatchType, bytes))
+	return o.handleCheck(ctx, tc, obj, *actual, err)
 }
 
 func (o *operation) createResource(ctx context.Context, tc apis.Bindings, obj unstructured.Unstructured) (outputs.Outputs, error) {
@@ -127,10 +174,16 @@ func (o *operation) createResource(ctx context.Context, tc apis.Bindings, obj un
 	if err == nil && o.cleaner != nil {
 		o.cleaner.Add(o.client, &obj)
 	}
-	return o.handleCheck(ctx, tc, obj, err)
+	return o.handleCheck(ctx, tc, obj, obj, err)
 }
 
-func (o *operation) handleCheck(ctx context.Context, tc apis.Bindings, obj unstructured.Unstructured, err error) (_outputs outputs.Outputs, _err error) {
+// handleCheck evaluates expectations against the resource, applied is the object returned by the server,
+// outputs are computed from it when the resource was applied successfully.
+func (o *operation) handleCheck(ctx context.Context, tc apis.Bindings, obj unstructured.Unstructured, applied unstructured.Unstructured, err error) (_outputs outputs.Outputs, _err error) {
+	input := obj
+	if err == nil {
+		input = applied
+	}
 	if err == nil {
 		tc = bindings.RegisterBinding(ctx, tc, "error", nil)
 	} else {
@@ -138,7 +191,7 @@ func (o *operation) handleCheck(ctx context.Context, tc apis.Bindings, obj unstr
 	}
 	defer func(tc apis.Bindings) {
 		if _err == nil {
-			outputs, err := outputs.Process(ctx, o.compilers, tc, obj.UnstructuredContent(), o.outputs...)
+			outputs, err := outputs.Process(ctx, o.compilers, tc, input.UnstructuredContent(), o.outputs...)
 			if err != nil {
 				_err = err
 				return
@@ -3,18 +3,22 @@ package apply
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	"github.com/kyverno/chainsaw/pkg/client"
 	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 )
 
@@ -298,6 +302,9 @@ func Test_apply(t *testing.T) {
 				nil,
 				nil,
 				false,
+				false,
+				false,
+				nil,
 				tt.expect,
 				nil,
 			)
@@ -311,3 +318,238 @@ func Test_apply(t *testing.T) {
 		})
 	}
 }
+
+func Test_apply_rendered(t *testing.T) {
+	template := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name": "($name)",
+			},
+			"data": map[string]any{
+				"replicas": "(to_string($replicas))",
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		rendered    v1alpha1.Check
+		expectedErr string
+	}{{
+		name: "matching",
+		rendered: v1alpha1.NewCheck(map[string]any{
+			"metadata": map[string]any{
+				"name": "foo",
+			},
+			"data": map[string]any{
+				"replicas": "3",
+			},
+		}),
+	}, {
+		name: "not matching",
+		rendered: v1alpha1.NewCheck(map[string]any{
+			"data": map[string]any{
+				"replicas": "2",
+			},
+		}),
+		expectedErr: "rendered resource doesn't match: data.replicas: Invalid value: \"3\": Expected value: \"2\"",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []client.Object
+			fakeClient := &tclient.FakeClient{
+				GetFn: func(_ context.Context, _ int, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+					return kerrors.NewNotFound(corev1.Resource("configmaps"), "foo")
+				},
+				CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+					created = append(created, obj)
+					return nil
+				},
+			}
+			bindings := apis.NewBindings()
+			bindings = bindings.Register("$name", apis.NewBinding("foo"))
+			bindings = bindings.Register("$replicas", apis.NewBinding(3))
+			operation := New(apis.DefaultCompilers, fakeClient, template, nil, nil, true, false, false, &tt.rendered, nil, nil)
+			_, err := operation.Exec(context.TODO(), bindings)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				// the resource must not be submitted to the cluster
+				assert.Equal(t, 0, fakeClient.NumCalls())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, created, 1)
+			}
+		})
+	}
+}
+
+func Test_apply_serverSide(t *testing.T) {
+	configMap := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name": "foo",
+			},
+		},
+	}
+	tests := []struct {
+		name           string
+		serverSide     bool
+		forceConflicts bool
+		exists         bool
+		expectedPatch  types.PatchType
+		expectedOpts   []client.PatchOption
+		expectedCreate bool
+	}{{
+		name:          "client side",
+		exists:        true,
+		expectedPatch: types.MergePatchType,
+	}, {
+		name:           "client side, force ignored",
+		forceConflicts: true,
+		exists:         true,
+		expectedPatch:  types.MergePatchType,
+	}, {
+		name:           "client side, resource does not exist",
+		expectedCreate: true,
+	}, {
+		name:          "server side",
+		serverSide:    true,
+		exists:        true,
+		expectedPatch: types.ApplyPatchType,
+		expectedOpts:  []client.PatchOption{client.FieldOwner(FieldManager)},
+	}, {
+		name:           "server side with force",
+		serverSide:     true,
+		forceConflicts: true,
+		exists:         true,
+		expectedPatch:  types.ApplyPatchType,
+		expectedOpts:   []client.PatchOption{client.FieldOwner(FieldManager), client.ForceOwnership},
+	}, {
+		name:          "server side, resource does not exist",
+		serverSide:    true,
+		expectedPatch: types.ApplyPatchType,
+		expectedOpts:  []client.PatchOption{client.FieldOwner(FieldManager)},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patchType types.PatchType
+			var patchOpts []client.PatchOption
+			var created bool
+			fakeClient := &tclient.FakeClient{
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					if !tt.exists {
+						return kerrors.NewNotFound(corev1.Resource("configmaps"), key.Name)
+					}
+					*obj.(*unstructured.Unstructured) = configMap
+					return nil
+				},
+				CreateFn: func(_ context.Context, _ int, _ client.Object, _ ...client.CreateOption) error {
+					created = true
+					return nil
+				},
+				PatchFn: func(_ context.Context, _ int, _ client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					patchType = patch.Type()
+					patchOpts = opts
+					return nil
+				},
+			}
+			cleaner := cleaner.New(time.Second, nil, "")
+			operation := New(apis.DefaultCompilers, fakeClient, configMap, nil, cleaner, false, tt.serverSide, tt.forceConflicts, nil, nil, nil)
+			_, err := operation.Exec(context.TODO(), nil)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPatch, patchType)
+			assert.Equal(t, tt.expectedOpts, patchOpts)
+			assert.Equal(t, tt.expectedCreate, created)
+			assert.Equal(t, !tt.exists, !cleaner.Empty())
+		})
+	}
+}
+
+func Test_apply_generateName(t *testing.T) {
+	configMap := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"generateName": "foo-",
+			},
+		},
+	}
+	fakeClient := &tclient.FakeClient{
+		CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+			obj.SetName(obj.GetGenerateName() + "x7k2p")
+			obj.SetUID("3f2c9a8e-1d2b-4c5e-9f00-123456789abc")
+			return nil
+		},
+	}
+	operation := New(apis.DefaultCompilers, fakeClient, configMap, nil, nil, false, false, false, nil, nil, []v1alpha1.Output{{
+		Binding: v1alpha1.Binding{
+			Name:  "name",
+			Value: v1alpha1.NewProjection("(metadata.name)"),
+		},
+	}, {
+		Binding: v1alpha1.Binding{
+			Name:  "uid",
+			Value: v1alpha1.NewProjection("(metadata.uid)"),
+		},
+	}})
+	outputs, err := operation.Exec(context.TODO(), nil)
+	assert.NoError(t, err)
+	// the resource is created without looking it up first
+	assert.Equal(t, 1, fakeClient.NumCalls())
+	// outputs are computed from the object returned by the server
+	assert.Equal(t, "foo-x7k2p", outputs["name"])
+	assert.Equal(t, "3f2c9a8e-1d2b-4c5e-9f00-123456789abc", outputs["uid"])
+}
+
+func Test_apply_generateName_retry(t *testing.T) {
+	configMap := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"generateName": "foo-",
+			},
+		},
+	}
+	var created int
+	var patched []string
+	fakeClient := &tclient.FakeClient{
+		CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+			created++
+			obj.SetName(fmt.Sprintf("%s%d", obj.GetGenerateName(), created))
+			return nil
+		},
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			obj.SetName(key.Name)
+			return nil
+		},
+		PatchFn: func(_ context.Context, _ int, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+			patched = append(patched, obj.GetName())
+			return nil
+		},
+	}
+	cleaner := cleaner.New(time.Second, nil, "")
+	// the expectation never matches, the operation is retried until it times out
+	operation := New(apis.DefaultCompilers, fakeClient, configMap, nil, cleaner, false, false, false, nil, []v1alpha1.Expectation{{
+		Check: v1alpha1.NewCheck(
+			map[string]any{
+				"($error != null)": true,
+			},
+		),
+	}}, nil)
+	ctx, cancel := context.WithTimeout(context.TODO(), 300*time.Millisecond)
+	defer cancel()
+	_, err := operation.Exec(ctx, nil)
+	assert.Error(t, err)
+	// a single resource is created, retries update it
+	assert.Equal(t, 1, created)
+	assert.NotEmpty(t, patched)
+	for _, name := range patched {
+		assert.Equal(t, "foo-1", name)
+	}
+	assert.False(t, cleaner.Empty())
+}
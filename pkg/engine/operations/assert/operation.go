@@ -3,10 +3,15 @@ package assert
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/annotations"
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
@@ -16,19 +21,63 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/engine/templating"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/xeipuuv/gojsonschema"
 	"go.uber.org/multierr"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 )
 
 type operation struct {
-	compilers  compilers.Compilers
-	client     client.Client
-	base       unstructured.Unstructured
-	namespacer namespacer.Namespacer
-	template   bool
+	compilers   compilers.Compilers
+	client      client.Client
+	base        unstructured.Unstructured
+	namespacer  namespacer.Namespacer
+	template    bool
+	schema      *gojsonschema.Schema
+	revision    *int64
+	generation  bool
+	namespaces  []string
+	nsSelector  string
+	annotations string
+	subresource string
+	aggregate   bool
+	anyOf       []v1alpha1.Check
+	stableFor   time.Duration
+	tolerances  []v1alpha1.Tolerance
+	literal     bool
+}
+
+// Options holds the optional settings of an assert operation, the zero value asserts the expected resource only.
+type Options struct {
+	// Schema validates the actual resources against a JSON schema.
+	Schema *gojsonschema.Schema
+	// Revision asserts a previous revision of the actual resources instead of the current one.
+	Revision *int64
+	// Generation requires the actual resources to have observed their latest generation.
+	Generation bool
+	// Namespaces asserts the expected resource in every listed namespace.
+	Namespaces []string
+	// NamespaceSelector asserts the expected resource in every namespace matching the label selector.
+	NamespaceSelector string
+	// AnnotationSelector filters the actual resources by annotations.
+	AnnotationSelector string
+	// Subresource asserts a subresource of the actual resources.
+	Subresource string
+	// Aggregate asserts all the actual resources at once.
+	Aggregate bool
+	// AnyOf holds alternative checks, at least one must match.
+	AnyOf []v1alpha1.Check
+	// StableFor requires the assertion to keep passing for the given duration.
+	StableFor time.Duration
+	// Tolerances allows numeric fields to differ from the expected values.
+	Tolerances []v1alpha1.Tolerance
+	// Literal compares the expected resource as is instead of parsing it as an assertion tree.
+	Literal bool
 }
 
 func New(
@@ -37,13 +86,26 @@ func New(
 	expected unstructured.Unstructured,
 	namespacer namespacer.Namespacer,
 	template bool,
+	options Options,
 ) operations.Operation {
 	return &operation{
-		compilers:  compilers,
-		client:     client,
-		base:       expected,
-		namespacer: namespacer,
-		template:   template,
+		compilers:   compilers,
+		client:      client,
+		base:        expected,
+		namespacer:  namespacer,
+		template:    template,
+		schema:      options.Schema,
+		revision:    options.Revision,
+		generation:  options.Generation,
+		namespaces:  options.Namespaces,
+		nsSelector:  options.NamespaceSelector,
+		annotations: options.AnnotationSelector,
+		subresource: options.Subresource,
+		aggregate:   options.Aggregate,
+		anyOf:       options.AnyOf,
+		stableFor:   options.StableFor,
+		tolerances:  options.Tolerances,
+		literal:     options.Literal,
 	}
 }
 
@@ -67,12 +129,104 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 		}
 	}
 	internal.LogStart(logger, logging.Assert)
+	if o.subresource != "" && obj.GetKind() == "" {
+		return nil, errors.New("subresource requires the expected resource apiVersion and kind")
+	}
+	if o.annotations != "" && obj.GetKind() == "" {
+		return nil, errors.New("annotation selector requires the expected resource apiVersion and kind")
+	}
+	if o.aggregate {
+		if obj.GetKind() == "" {
+			return nil, errors.New("aggregate requires the expected resource apiVersion and kind")
+		}
+		if o.revision != nil || o.subresource != "" {
+			return nil, errors.New("aggregate cannot be combined with revision or subresource")
+		}
+	}
+	if len(o.namespaces) != 0 || o.nsSelector != "" {
+		return nil, o.executeNamespaces(ctx, bindings, obj)
+	}
 	return nil, o.execute(ctx, bindings, obj)
 }
 
+func (o *operation) executeNamespaces(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) error {
+	if obj.GetKind() == "" {
+		return errors.New("namespaces require the expected resource apiVersion and kind")
+	}
+	namespaced, err := o.client.IsObjectNamespaced(&obj)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return fmt.Errorf("namespaces cannot be used with clustered resource %s", obj.GetKind())
+	}
+	namespaces, err := o.resolveNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) == 0 {
+		return errors.New("no namespace matched")
+	}
+	// namespaces are asserted concurrently so that they all share the operation timeout
+	errs := make([]error, len(namespaces))
+	var wg sync.WaitGroup
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		go func(i int, namespace string) {
+			defer wg.Done()
+			candidate := *obj.DeepCopy()
+			candidate.SetNamespace(namespace)
+			if err := o.execute(ctx, bindings, candidate); err != nil {
+				errs[i] = fmt.Errorf("namespace %s: %w", namespace, err)
+			}
+		}(i, namespace)
+	}
+	wg.Wait()
+	return multierr.Combine(errs...)
+}
+
+func (o *operation) resolveNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces []string
+	seen := map[string]struct{}{}
+	add := func(namespace string) {
+		if _, ok := seen[namespace]; !ok {
+			seen[namespace] = struct{}{}
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	for _, namespace := range o.namespaces {
+		add(namespace)
+	}
+	if o.nsSelector != "" {
+		selector, err := labels.Parse(o.nsSelector)
+		if err != nil {
+			return nil, err
+		}
+		var list unstructured.UnstructuredList
+		list.SetAPIVersion("v1")
+		list.SetKind("NamespaceList")
+		if err := o.client.List(ctx, &list, client.MatchingSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		var matched []string
+		for _, item := range list.Items {
+			matched = append(matched, item.GetName())
+		}
+		sort.Strings(matched)
+		for _, namespace := range matched {
+			add(namespace)
+		}
+	}
+	return namespaces, nil
+}
+
 func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) error {
+	matcher, err := annotations.Parse(o.annotations)
+	if err != nil {
+		return err
+	}
 	var lastErrs []error
-	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (_ bool, err error) {
+	condition := func(ctx context.Context) (_ bool, err error) {
 		var errs []error
 		defer func() {
 			// record last errors only if there was no real error
@@ -81,10 +235,15 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 			}
 		}()
 		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
-			_errs, err := checks.Check(ctx, o.compilers, nil, bindings, ptr.To(v1alpha1.NewCheck(obj.UnstructuredContent())))
+			_errs, err := o.check(ctx, bindings, nil, obj)
 			if err != nil {
 				return false, err
 			}
+			anyOfErrs, err := o.checkAnyOf(ctx, bindings, nil)
+			if err != nil {
+				return false, err
+			}
+			_errs = append(_errs, anyOfErrs...)
 			if len(_errs) != 0 {
 				for _, _err := range _errs {
 					errs = append(errs, _err)
@@ -100,17 +259,65 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 					return false, nil
 				}
 				return false, err
-			} else if len(candidates) == 0 {
+			} else if candidates = filterAnnotations(candidates, matcher); len(candidates) == 0 {
 				errs = append(errs, errors.New("no actual resource found"))
+			} else if o.aggregate {
+				_errs, err := o.checkAggregate(ctx, bindings, obj, candidates)
+				if err != nil {
+					return false, err
+				}
+				if len(_errs) == 0 {
+					return true, nil
+				}
+				errs = append(errs, _errs...)
 			} else {
 				for i := range candidates {
 					candidate := candidates[i]
-					_errs, err := checks.Check(ctx, o.compilers, candidate.UnstructuredContent(), bindings, ptr.To(v1alpha1.NewCheck(obj.UnstructuredContent())))
+					if o.revision != nil {
+						revision, err := internal.Revision(ctx, o.client, candidate, *o.revision)
+						if err != nil {
+							if errors.Is(err, internal.ErrRevisionNotFound) {
+								errs = append(errs, fmt.Errorf("%s: %w", client.Name(client.Key(&candidate)), err))
+								continue
+							}
+							return false, err
+						}
+						candidate = *revision
+					}
+					expected := obj
+					if o.subresource != "" {
+						subresource, err := internal.Subresource(ctx, o.client, candidate, o.subresource)
+						if err != nil {
+							return false, err
+						}
+						candidate = *subresource
+						expected = subresourceCheck(obj)
+					}
+					_errs, err := o.check(ctx, bindings, candidate.UnstructuredContent(), expected)
+					if err != nil {
+						return false, err
+					}
+					if o.schema != nil {
+						schemaErrs, err := checks.Schema(o.schema, candidate.UnstructuredContent())
+						if err != nil {
+							return false, err
+						}
+						_errs = append(_errs, schemaErrs...)
+					}
+					if o.generation {
+						generationErrs, err := checks.ObservedGeneration(candidate.UnstructuredContent())
+						if err != nil {
+							return false, err
+						}
+						_errs = append(_errs, generationErrs...)
+					}
+					anyOfErrs, err := o.checkAnyOf(ctx, bindings, candidate.UnstructuredContent())
 					if err != nil {
 						return false, err
 					}
+					_errs = append(_errs, anyOfErrs...)
 					if len(_errs) != 0 {
-						errs = append(errs, operrors.ResourceError(o.compilers, obj, candidate, o.template, bindings, _errs))
+						errs = append(errs, operrors.ResourceError(o.compilers, expected, candidate, o.template, bindings, _errs))
 					} else {
 						// at least one match found
 						return true, nil
@@ -119,11 +326,22 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 			}
 		}
 		return false, nil
-	})
+	}
+	var stability *stability
+	if o.stableFor > 0 {
+		stability = newStability(internal.Clock(bindings), o.stableFor)
+		condition = stability.condition(condition)
+	}
+	err = wait.PollUntilContextCancel(ctx, client.PollInterval, false, condition)
 	// if no error, return success
 	if err == nil {
 		return nil
 	}
+	if stability != nil {
+		if err := stability.err(); err != nil {
+			return multierr.Combine(append([]error{err}, lastErrs...)...)
+		}
+	}
 	// eventually return a combination of last errors
 	if len(lastErrs) != 0 {
 		return multierr.Combine(lastErrs...)
@@ -131,3 +349,163 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj uns
 	// return received error
 	return err
 }
+
+// check evaluates the expected resource against the actual object,
+// the expected resource is used as an assertion tree unless the operation compares literally.
+func (o *operation) check(ctx context.Context, bindings apis.Bindings, actual any, expected unstructured.Unstructured) (field.ErrorList, error) {
+	if o.literal {
+		return checks.Literal(actual, expected.UnstructuredContent()), nil
+	}
+	return checks.Check(ctx, o.compilers, actual, bindings, ptr.To(v1alpha1.NewCheck(expected.UnstructuredContent())), o.tolerances...)
+}
+
+// checkAggregate evaluates the expected resource once against all the candidates, exposed in an `items` field.
+// Schema and observed generation checks still apply to each candidate separately.
+func (o *operation) checkAggregate(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured, candidates []unstructured.Unstructured) ([]error, error) {
+	var errs []error
+	items := make([]any, 0, len(candidates))
+	for i := range candidates {
+		candidate := candidates[i]
+		items = append(items, candidate.UnstructuredContent())
+		var _errs field.ErrorList
+		if o.schema != nil {
+			schemaErrs, err := checks.Schema(o.schema, candidate.UnstructuredContent())
+			if err != nil {
+				return nil, err
+			}
+			_errs = append(_errs, schemaErrs...)
+		}
+		if o.generation {
+			generationErrs, err := checks.ObservedGeneration(candidate.UnstructuredContent())
+			if err != nil {
+				return nil, err
+			}
+			_errs = append(_errs, generationErrs...)
+		}
+		for _, _err := range _errs {
+			errs = append(errs, fmt.Errorf("%s: %w", client.Name(client.Key(&candidate)), _err))
+		}
+	}
+	expected := subresourceCheck(obj)
+	aggregated := map[string]any{"items": items}
+	_errs, err := o.check(ctx, bindings, aggregated, expected)
+	if err != nil {
+		return nil, err
+	}
+	anyOfErrs, err := o.checkAnyOf(ctx, bindings, aggregated)
+	if err != nil {
+		return nil, err
+	}
+	_errs = append(_errs, anyOfErrs...)
+	for _, _err := range _errs {
+		errs = append(errs, _err)
+	}
+	return errs, nil
+}
+
+// checkAnyOf evaluates the alternative checks against the actual object, it returns no error as soon as one of them matches.
+// When none match, the errors of every alternative are returned, prefixed with the alternative path.
+func (o *operation) checkAnyOf(ctx context.Context, bindings apis.Bindings, actual any) (field.ErrorList, error) {
+	if len(o.anyOf) == 0 {
+		return nil, nil
+	}
+	var errs field.ErrorList
+	for i := range o.anyOf {
+		_errs, err := checks.Check(ctx, o.compilers, actual, bindings, &o.anyOf[i], o.tolerances...)
+		if err != nil {
+			return nil, err
+		}
+		if len(_errs) == 0 {
+			return nil, nil
+		}
+		path := field.NewPath("anyOf").Index(i).String()
+		for _, _err := range _errs {
+			alternative := *_err
+			if alternative.Field != "" {
+				alternative.Field = path + "." + alternative.Field
+			} else {
+				alternative.Field = path
+			}
+			errs = append(errs, &alternative)
+		}
+	}
+	return errs, nil
+}
+
+// filterAnnotations keeps the candidates matching the annotation selector.
+func filterAnnotations(candidates []unstructured.Unstructured, selector annotations.Selector) []unstructured.Unstructured {
+	if selector.Empty() {
+		return candidates
+	}
+	var filtered []unstructured.Unstructured
+	for _, candidate := range candidates {
+		if selector.Matches(candidate.GetAnnotations()) {
+			filtered = append(filtered, candidate)
+		}
+	}
+	return filtered
+}
+
+// subresourceCheck drops the fields identifying the parent resource from the expected resource,
+// they don't apply to the subresource returned by the server (nor to the aggregated resources).
+func subresourceCheck(obj unstructured.Unstructured) unstructured.Unstructured {
+	expected := obj.DeepCopy()
+	delete(expected.Object, "apiVersion")
+	delete(expected.Object, "kind")
+	delete(expected.Object, "metadata")
+	return *expected
+}
+
+var errRegressed = errors.New("assertion regressed")
+
+// stability keeps track of how long an assertion has been holding.
+type stability struct {
+	clock     clock.PassiveClock
+	window    time.Duration
+	since     *time.Time
+	held      time.Duration
+	regressed bool
+}
+
+func newStability(clock clock.PassiveClock, window time.Duration) *stability {
+	return &stability{
+		clock:  clock,
+		window: window,
+	}
+}
+
+// condition wraps a condition so that it completes only once it held for the whole window,
+// it stops polling as soon as the wrapped condition regresses after it first held.
+func (s *stability) condition(condition wait.ConditionWithContextFunc) wait.ConditionWithContextFunc {
+	return func(ctx context.Context) (bool, error) {
+		done, err := condition(ctx)
+		if err != nil {
+			return false, err
+		}
+		now := s.clock.Now()
+		if !done {
+			if s.since != nil {
+				s.held = now.Sub(*s.since)
+				s.regressed = true
+				return false, errRegressed
+			}
+			return false, nil
+		}
+		if s.since == nil {
+			s.since = &now
+		}
+		s.held = now.Sub(*s.since)
+		return s.held >= s.window, nil
+	}
+}
+
+// err describes why the assertion was not stable, it returns nil if the assertion never held.
+func (s *stability) err() error {
+	if s.regressed {
+		return fmt.Errorf("assertion regressed after holding for %s (stability window: %s)", s.held, s.window)
+	}
+	if s.since != nil {
+		return fmt.Errorf("assertion held for %s, less than the %s stability window", s.held, s.window)
+	}
+	return nil
+}
@@ -3,10 +3,12 @@ package assert
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
 	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
@@ -15,18 +17,402 @@ import (
 	tnamespacer "github.com/kyverno/chainsaw/pkg/engine/namespacer/testing"
 	ttesting "github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/stretchr/testify/assert"
+	"github.com/xeipuuv/gojsonschema"
 	kerror "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	tclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func Test_operationAssert(t *testing.T) {
+	configMapSchema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"data": map[string]any{
+				"type":     "object",
+				"required": []any{"replicas"},
+				"properties": map[string]any{
+					"replicas": map[string]any{
+						"type":    "string",
+						"pattern": "^[0-9]+$",
+					},
+				},
+			},
+		},
+	}))
+	assert.NoError(t, err)
+	configMap := func(data map[string]any) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				t.Helper()
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]any{
+						"name": "test-cm",
+					},
+					"data": data,
+				}
+				return nil
+			},
+		}
+	}
+	deployment := func(image string) map[string]any {
+		return map[string]any{
+			"spec": map[string]any{
+				"containers": []any{
+					map[string]any{
+						"name":  "app",
+						"image": image,
+					},
+				},
+			},
+		}
+	}
+	// readyDeployment returns a deployment with 3 desired replicas, the ready replicas change at every poll
+	readyDeployment := func(ready ...int64) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]any{
+						"name": "test-deploy",
+					},
+					"spec": map[string]any{
+						"replicas": int64(3),
+					},
+					"status": map[string]any{
+						"readyReplicas": ready[min(call, len(ready)-1)],
+					},
+				}
+				return nil
+			},
+		}
+	}
+	expectedReady := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name": "test-deploy",
+			},
+			"(x_replicas_ready(@))": true,
+		},
+	}
+	// pvc returns a persistent volume claim, the phase changes at every poll
+	pvc := func(phases ...string) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				phase := phases[min(call, len(phases)-1)]
+				status := map[string]any{
+					"phase": phase,
+				}
+				if phase == "Bound" {
+					status["capacity"] = map[string]any{"storage": "10Gi"}
+				}
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "v1",
+					"kind":       "PersistentVolumeClaim",
+					"metadata": map[string]any{
+						"name": "test-pvc",
+					},
+					"status": status,
+				}
+				return nil
+			},
+		}
+	}
+	expectedPvc := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "PersistentVolumeClaim",
+			"metadata": map[string]any{
+				"name": "test-pvc",
+			},
+			"(x_pvc_bound(@, '5Gi'))": true,
+		},
+	}
+	replicaSet := func(revision string, image string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "ReplicaSet",
+				"metadata": map[string]any{
+					"name": "test-deploy-" + revision,
+					"annotations": map[string]any{
+						"deployment.kubernetes.io/revision": revision,
+					},
+					"ownerReferences": []any{
+						map[string]any{
+							"apiVersion": "apps/v1",
+							"kind":       "Deployment",
+							"name":       "test-deploy",
+							"uid":        "deploy-uid",
+						},
+					},
+				},
+				"spec": map[string]any{
+					"template": deployment(image),
+				},
+			},
+		}
+	}
+	deploymentWithHistory := &tclient.FakeClient{
+		GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			obj.(*unstructured.Unstructured).Object = map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]any{
+					"name": "test-deploy",
+					"uid":  "deploy-uid",
+				},
+				"spec": map[string]any{
+					"template": deployment("app:v3"),
+				},
+			}
+			return nil
+		},
+		ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{
+				replicaSet("1", "app:v1"),
+				replicaSet("3", "app:v3"),
+				replicaSet("2", "app:v2"),
+			}
+			return nil
+		},
+	}
+	expectedDeployment := func(image string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]any{
+					"name": "test-deploy",
+				},
+				"spec": map[string]any{
+					"template": deployment(image),
+				},
+			},
+		}
+	}
+	deploymentWithGenerations := func(generation, observedGeneration int64) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]any{
+						"name":       "test-deploy",
+						"generation": generation,
+					},
+					"spec": map[string]any{
+						"template": deployment("app:v3"),
+					},
+					"status": map[string]any{
+						"observedGeneration": observedGeneration,
+					},
+				}
+				return nil
+			},
+		}
+	}
+	podInNamespaces := func(namespaces ...string) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+				return true, nil
+			},
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				for _, namespace := range namespaces {
+					if key.Namespace == namespace {
+						obj.(*unstructured.Unstructured).Object = map[string]any{
+							"apiVersion": "v1",
+							"kind":       "Pod",
+							"metadata": map[string]any{
+								"name":      key.Name,
+								"namespace": key.Namespace,
+							},
+						}
+						return nil
+					}
+				}
+				return kerror.NewNotFound(schema.GroupResource{Resource: "pods"}, key.Name)
+			},
+			ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+				var items []unstructured.Unstructured
+				for _, namespace := range []string{"team-b", "team-a"} {
+					var item unstructured.Unstructured
+					item.SetAPIVersion("v1")
+					item.SetKind("Namespace")
+					item.SetName(namespace)
+					items = append(items, item)
+				}
+				list.(*unstructured.UnstructuredList).Items = items
+				return nil
+			},
+		}
+	}
+	expectedPod := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "test-pod",
+			},
+		},
+	}
+	annotatedPods := &tclient.FakeClient{
+		ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			var items []unstructured.Unstructured
+			for _, team := range []string{"a", "b", ""} {
+				var item unstructured.Unstructured
+				item.SetAPIVersion("v1")
+				item.SetKind("Pod")
+				item.SetName("pod-" + team)
+				if team != "" {
+					item.SetAnnotations(map[string]string{"team": team, "url": "https://example.com/" + team, "note": "team " + team})
+				}
+				items = append(items, item)
+			}
+			list.(*unstructured.UnstructuredList).Items = items
+			return nil
+		},
+	}
+	expectedAnyPod := func(name string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"(name)": name,
+				},
+			},
+		}
+	}
+	expectedConfigMap := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name": "test-cm",
+			},
+		},
+	}
+	modes := func(modes ...string) []v1alpha1.Check {
+		var checks []v1alpha1.Check
+		for _, mode := range modes {
+			checks = append(checks, v1alpha1.NewCheck(map[string]any{
+				"data": map[string]any{
+					"mode": mode,
+				},
+			}))
+		}
+		return checks
+	}
+	deploymentWithScale := func(replicas int64, err error) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]any{
+						"name": key.Name,
+					},
+				}
+				return nil
+			},
+			SubResourceFn: func(_ int, subResource string) client.SubResourceClient {
+				return &tclient.FakeSubResourceClient{
+					GetFn: func(ctx context.Context, _ int, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceGetOption) error {
+						if err != nil {
+							return err
+						}
+						scale := subResource.(*unstructured.Unstructured)
+						scale.SetName(obj.GetName())
+						return unstructured.SetNestedField(scale.Object, replicas, "spec", "replicas")
+					},
+				}
+			},
+		}
+	}
+	expectedScale := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name": "test-deployment",
+			},
+			"spec": map[string]any{
+				"replicas": int64(3),
+			},
+		},
+	}
+	// nodes returns a list of nodes with the given cpu capacities
+	nodes := func(capacities ...string) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+				uList := list.(*unstructured.UnstructuredList)
+				for i, capacity := range capacities {
+					uList.Items = append(uList.Items, unstructured.Unstructured{
+						Object: map[string]any{
+							"apiVersion": "v1",
+							"kind":       "Node",
+							"metadata": map[string]any{
+								"name": fmt.Sprintf("node-%d", i),
+							},
+							"status": map[string]any{
+								"capacity": map[string]any{
+									"cpu": capacity,
+								},
+							},
+						},
+					})
+				}
+				return nil
+			},
+		}
+	}
+	// pods returns a list of pods with the given node selectors
+	pods := func(nodeSelectors ...map[string]any) *tclient.FakeClient {
+		return &tclient.FakeClient{
+			ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+				uList := list.(*unstructured.UnstructuredList)
+				for i, nodeSelector := range nodeSelectors {
+					uList.Items = append(uList.Items, unstructured.Unstructured{
+						Object: map[string]any{
+							"apiVersion": "v1",
+							"kind":       "Pod",
+							"metadata": map[string]any{
+								"name": fmt.Sprintf("pod-%d", i),
+							},
+							"spec": map[string]any{
+								"nodeSelector": nodeSelector,
+							},
+						},
+					})
+				}
+				return nil
+			},
+		}
+	}
 	tests := []struct {
 		name         string
 		expected     unstructured.Unstructured
 		client       *tclient.FakeClient
 		namespacer   func(c client.Client) namespacer.Namespacer
+		schema       *gojsonschema.Schema
+		revision     *int64
+		generation   bool
+		namespaces   []string
+		nsSelector   string
+		annotations  string
+		subresource  string
+		aggregate    bool
+		anyOf        []v1alpha1.Check
+		literal      bool
 		expectedLogs []string
 		expectErr    bool
 	}{{
@@ -334,6 +720,362 @@ func Test_operationAssert(t *testing.T) {
 		},
 		expectErr:    true,
 		expectedLogs: []string{"ASSERT: ERROR - [=== ERROR\nnamespacer error]"},
+	}, {
+		name:         "Schema conforming",
+		expected:     expectedConfigMap,
+		client:       configMap(map[string]any{"replicas": "3"}),
+		schema:       configMapSchema,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Schema non conforming",
+		expected:     expectedConfigMap,
+		client:       configMap(map[string]any{"foo": "bar", "replicas": "three"}),
+		schema:       configMapSchema,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n--------------------\nv1/ConfigMap/test-cm\n--------------------\n* data.replicas: Invalid value: \"three\": Does not match pattern '^[0-9]+$']"},
+	}, {
+		name:         "Current revision",
+		expected:     expectedDeployment("app:v3"),
+		client:       deploymentWithHistory,
+		revision:     ptr.To[int64](0),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Prior revision",
+		expected:     expectedDeployment("app:v2"),
+		client:       deploymentWithHistory,
+		revision:     ptr.To[int64](-1),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Absolute revision",
+		expected:     expectedDeployment("app:v1"),
+		client:       deploymentWithHistory,
+		revision:     ptr.To[int64](1),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Prior revision not matching",
+		expected:     expectedDeployment("app:v3"),
+		client:       deploymentWithHistory,
+		revision:     ptr.To[int64](-1),
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n------------------------------\napps/v1/Deployment/test-deploy\n------------------------------\n* spec.template.spec.containers[0].image: Invalid value: \"app:v2\": Expected value: \"app:v3\"\n\n--- expected\n+++ actual\n@@ -6,6 +6,6 @@\n   template:\n     spec:\n       containers:\n-      - image: app:v3\n+      - image: app:v2\n         name: app]"},
+	}, {
+		name:         "Revision not found",
+		expected:     expectedDeployment("app:v1"),
+		client:       deploymentWithHistory,
+		revision:     ptr.To[int64](7),
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\ntest-deploy: revision not found (7)]"},
+	}, {
+		name:         "Revisions not supported",
+		expected:     expectedConfigMap,
+		client:       configMap(map[string]any{"replicas": "3"}),
+		revision:     ptr.To[int64](-1),
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nrevisions are not supported for ConfigMap]"},
+	}, {
+		name:         "Observed generation matched",
+		expected:     expectedDeployment("app:v3"),
+		client:       deploymentWithGenerations(2, 2),
+		generation:   true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Observed generation lagging",
+		expected:     expectedDeployment("app:v3"),
+		client:       deploymentWithGenerations(2, 1),
+		generation:   true,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n------------------------------\napps/v1/Deployment/test-deploy\n------------------------------\n* status.observedGeneration: Invalid value: 1: Expected generation: 2]"},
+	}, {
+		name:         "Multiple namespaces",
+		expected:     expectedPod,
+		client:       podInNamespaces("foo", "bar"),
+		namespaces:   []string{"foo", "bar"},
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Multiple namespaces with missing resource",
+		expected:     expectedPod,
+		client:       podInNamespaces("foo"),
+		namespaces:   []string{"foo", "bar"},
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nnamespace bar: actual resource not found]"},
+	}, {
+		name:         "Namespace selector",
+		expected:     expectedPod,
+		client:       podInNamespaces("team-a", "team-b"),
+		nsSelector:   "team",
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Namespace selector with missing resource",
+		expected:     expectedPod,
+		client:       podInNamespaces("team-b"),
+		nsSelector:   "team",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nnamespace team-a: actual resource not found]"},
+	}, {
+		name:         "Namespace selector invalid",
+		expected:     expectedPod,
+		client:       podInNamespaces(),
+		nsSelector:   "=",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nfound '=', expected: !, identifier, or 'end of string']"},
+	}, {
+		name:         "Annotation selector",
+		expected:     expectedAnyPod("pod-b"),
+		client:       annotatedPods,
+		annotations:  "team=b",
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Annotation selector with raw values",
+		expected:     expectedAnyPod("pod-b"),
+		client:       annotatedPods,
+		annotations:  "url=https://example.com/b, note=team b",
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Annotation selector filters out matching resource",
+		expected:     expectedAnyPod("pod-a"),
+		client:       annotatedPods,
+		annotations:  "team=b",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n------------\nv1/Pod/pod-b\n------------\n* metadata.(name): Invalid value: \"pod-b\": Expected value: \"pod-a\"\n\n--- expected\n+++ actual\n@@ -1,5 +1,5 @@\n apiVersion: v1\n kind: Pod\n metadata:\n-  (name): pod-a\n+  name: pod-b]"},
+	}, {
+		name:         "Annotation selector without match",
+		expected:     expectedAnyPod("pod-b"),
+		client:       annotatedPods,
+		annotations:  "team=c",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nno actual resource found]"},
+	}, {
+		name: "Annotation selector without kind",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"foo": "bar",
+			},
+		},
+		client:       &tclient.FakeClient{},
+		annotations:  "team=b",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nannotation selector requires the expected resource apiVersion and kind]"},
+	}, {
+		name: "Failed match with result message",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"name": "test-pod",
+				},
+				"(x_assert_result(length(spec.containers) > `1`, 'expected a sidecar container', length(spec.containers)))": true,
+			},
+		},
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "v1",
+					"kind":       "Pod",
+					"metadata": map[string]any{
+						"name": "test-pod",
+					},
+					"spec": map[string]any{
+						"containers": []any{
+							map[string]any{
+								"name": "app",
+							},
+						},
+					},
+				}
+				return nil
+			},
+		},
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n---------------\nv1/Pod/test-pod\n---------------\n* (x_assert_result(length(spec.containers) > `1`, 'expected a sidecar container', length(spec.containers))): Invalid value: 1: expected a sidecar container\n\n--- expected\n+++ actual\n@@ -1,4 +1,3 @@\n-(x_assert_result(length(spec.containers) > `1`, 'expected a sidecar container', length(spec.containers))): true\n apiVersion: v1\n kind: Pod\n metadata:]"},
+	}, {
+		name:         "Replicas fully ready",
+		expected:     expectedReady,
+		client:       readyDeployment(1, 2, 3),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Replicas partially ready",
+		expected:     expectedReady,
+		client:       readyDeployment(1, 2),
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n------------------------------\napps/v1/Deployment/test-deploy\n------------------------------\n* (x_replicas_ready(@)): Invalid value: 2: 2/3 replicas ready\n\n--- expected\n+++ actual\n@@ -1,4 +1,3 @@\n-(x_replicas_ready(@)): true\n apiVersion: apps/v1\n kind: Deployment\n metadata:]"},
+	}, {
+		name:         "Pvc bound with enough capacity",
+		expected:     expectedPvc,
+		client:       pvc("Pending", "Bound"),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Pvc pending",
+		expected:     expectedPvc,
+		client:       pvc("Pending"),
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n---------------------------------\nv1/PersistentVolumeClaim/test-pvc\n---------------------------------\n* (x_pvc_bound(@, '5Gi')): Invalid value: \"Pending\": persistent volume claim is not bound (phase: Pending)\n\n--- expected\n+++ actual\n@@ -1,4 +1,3 @@\n-(x_pvc_bound(@, '5Gi')): true\n apiVersion: v1\n kind: PersistentVolumeClaim\n metadata:]"},
+	}, {
+		name:         "Scale subresource match",
+		expected:     expectedScale,
+		client:       deploymentWithScale(3, nil),
+		subresource:  "scale",
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "Scale subresource mismatch",
+		expected:     expectedScale,
+		client:       deploymentWithScale(2, nil),
+		subresource:  "scale",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n------------------------------------\nautoscaling/v1/Scale/test-deployment\n------------------------------------\n* spec.replicas: Invalid value: 2: Expected value: 3\n\n--- expected\n+++ actual\n@@ -1,3 +1,5 @@\n+apiVersion: autoscaling/v1\n+kind: Scale\n spec:\n-  replicas: 3\n+  replicas: 2]"},
+	}, {
+		name:         "Subresource not supported by kind",
+		expected:     expectedScale,
+		client:       deploymentWithScale(0, kerror.NewNotFound(schema.GroupResource{Resource: "deployments/scale"}, "test")),
+		subresource:  "scale",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nsubresource scale is not supported by kind Deployment]"},
+	}, {
+		name: "Subresource without kind",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"spec": map[string]any{
+					"replicas": int64(3),
+				},
+			},
+		},
+		client:       &tclient.FakeClient{},
+		subresource:  "scale",
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\nsubresource requires the expected resource apiVersion and kind]"},
+	}, {
+		name: "Aggregate sum",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Node",
+				"(sum(items[].x_quantity(status.capacity.cpu)) >= `6`)": true,
+			},
+		},
+		client:       nodes("2", "4"),
+		aggregate:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name: "Aggregate sum fails",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Node",
+				"(sum(items[].x_quantity(status.capacity.cpu)) >= `8`)": true,
+			},
+		},
+		client:       nodes("2", "4"),
+		aggregate:    true,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n(sum(items[].x_quantity(status.capacity.cpu)) >= `8`): Invalid value: false: Expected value: true]"},
+	}, {
+		name: "Aggregate uniq",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"(length(x_uniq(items[].spec.nodeSelector)))": int64(1),
+			},
+		},
+		client: pods(
+			map[string]any{"disktype": "ssd"},
+			map[string]any{"disktype": "ssd"},
+		),
+		aggregate:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name: "Aggregate uniq fails",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"(length(x_uniq(items[].spec.nodeSelector)))": int64(1),
+			},
+		},
+		client: pods(
+			map[string]any{"disktype": "ssd"},
+			map[string]any{"disktype": "hdd"},
+		),
+		aggregate:    true,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n(length(x_uniq(items[].spec.nodeSelector))): Invalid value: 2: Expected value: 1]"},
+	}, {
+		name: "Aggregate without kind",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"(length(items))": int64(1),
+			},
+		},
+		client:       &tclient.FakeClient{},
+		aggregate:    true,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\naggregate requires the expected resource apiVersion and kind]"},
+	}, {
+		name: "Aggregate with subresource",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion":      "apps/v1",
+				"kind":            "Deployment",
+				"(length(items))": int64(1),
+			},
+		},
+		client:       &tclient.FakeClient{},
+		subresource:  "scale",
+		aggregate:    true,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\naggregate cannot be combined with revision or subresource]"},
+	}, {
+		name:         "Any of alternatives matches",
+		expected:     expectedConfigMap,
+		client:       configMap(map[string]any{"mode": "standby"}),
+		anyOf:        modes("active", "standby"),
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name:         "None of alternatives matches",
+		expected:     expectedConfigMap,
+		client:       configMap(map[string]any{"mode": "failed"}),
+		anyOf:        modes("active", "standby"),
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n--------------------\nv1/ConfigMap/test-cm\n--------------------\n* anyOf[0].data.mode: Invalid value: \"failed\": Expected value: \"active\"\n* anyOf[1].data.mode: Invalid value: \"failed\": Expected value: \"standby\"]"},
+	}, {
+		name: "Literal match",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name": "test-cm",
+				},
+				"data": map[string]any{
+					"query":   "(length(items))",
+					"-config": "(x_absent())",
+				},
+			},
+		},
+		client: configMap(map[string]any{
+			"query":   "(length(items))",
+			"-config": "(x_absent())",
+		}),
+		literal:      true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: DONE - []"},
+	}, {
+		name: "Literal mismatch",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name": "test-cm",
+				},
+				"data": map[string]any{
+					"mode": "(x_present())",
+				},
+			},
+		},
+		client:       configMap(map[string]any{"mode": "active"}),
+		literal:      true,
+		expectErr:    true,
+		expectedLogs: []string{"ASSERT: RUN - []", "ASSERT: ERROR - [=== ERROR\n--------------------\nv1/ConfigMap/test-cm\n--------------------\n* data.mode: Invalid value: \"active\": Expected value: \"(x_present())\"\n\n--- expected\n+++ actual\n@@ -1,6 +1,6 @@\n apiVersion: v1\n data:\n-  mode: (x_present())\n+  mode: active\n kind: ConfigMap\n metadata:\n   name: test-cm]"},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -349,6 +1091,18 @@ func Test_operationAssert(t *testing.T) {
 				tt.expected,
 				nspacer,
 				false,
+				Options{
+					Schema:             tt.schema,
+					Revision:           tt.revision,
+					Generation:         tt.generation,
+					Namespaces:         tt.namespaces,
+					NamespaceSelector:  tt.nsSelector,
+					AnnotationSelector: tt.annotations,
+					Subresource:        tt.subresource,
+					Aggregate:          tt.aggregate,
+					AnyOf:              tt.anyOf,
+					Literal:            tt.literal,
+				},
 			)
 			logger := &tlogging.FakeLogger{}
 			outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), nil)
@@ -362,3 +1116,84 @@ func Test_operationAssert(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationAssert_StableFor(t *testing.T) {
+	pod := func(phase string) map[string]any {
+		return map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "test-pod",
+			},
+			"status": map[string]any{
+				"phase": phase,
+			},
+		}
+	}
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "test-pod",
+			},
+			"status": map[string]any{
+				"phase": "Running",
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		phases      []string
+		timeout     time.Duration
+		expectedErr string
+	}{{
+		name:   "stable",
+		phases: []string{"Pending", "Running"},
+	}, {
+		name:        "flapping",
+		phases:      []string{"Running", "Running", "Failed", "Running"},
+		expectedErr: "assertion regressed after holding for 4s (stability window: 10s)",
+	}, {
+		name:        "not stable before timeout",
+		phases:      []string{"Running"},
+		timeout:     200 * time.Millisecond,
+		expectedErr: "assertion held for",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			fakeClock := tclock.NewFakeClock(time.Unix(0, 0))
+			// the clock advances on every poll, except when the timeout case needs time to stand still
+			step := 2 * time.Second
+			if tt.timeout != 0 {
+				step = 0
+			}
+			calls := 0
+			fakeClient := &tclient.FakeClient{
+				GetFn: func(_ context.Context, _ int, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					phase := tt.phases[min(calls, len(tt.phases)-1)]
+					calls++
+					fakeClock.Step(step)
+					obj.(*unstructured.Unstructured).Object = pod(phase)
+					return nil
+				},
+			}
+			operation := New(apis.DefaultCompilers, fakeClient, expected, nil, false, Options{StableFor: 10 * time.Second})
+			bindings := apis.NewBindings().Register("$clock", apis.NewBinding(fakeClock))
+			logger := &tlogging.FakeLogger{}
+			outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
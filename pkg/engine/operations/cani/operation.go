@@ -0,0 +1,101 @@
+package cani
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/pkg/ext/output/color"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+type operation struct {
+	client     client.Client
+	namespacer namespacer.Namespacer
+	canI       v1alpha1.CanI
+}
+
+func New(
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	canI v1alpha1.CanI,
+) operations.Operation {
+	return &operation{
+		client:     client,
+		namespacer: namespacer,
+		canI:       canI,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, _ apis.Bindings) (_ outputs.Outputs, _err error) {
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		if logger != nil && errors.Is(_err, operations.ErrSkip) {
+			logger.Log(logging.CanI, logging.WarnStatus, color.BoldYellow, logging.ErrSection(_err))
+		} else {
+			internal.LogEnd(logger, logging.CanI, _err)
+		}
+	}()
+	internal.LogStart(logger, logging.CanI)
+	return nil, o.execute(ctx)
+}
+
+func (o *operation) execute(ctx context.Context) error {
+	namespace := o.canI.Namespace
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	if namespace == "*" {
+		namespace = ""
+	}
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        o.canI.Verb,
+				Group:       o.canI.Group,
+				Resource:    o.canI.Resource,
+				Subresource: o.canI.Subresource,
+			},
+		},
+	}
+	if err := o.client.Create(ctx, review); err != nil {
+		return err
+	}
+	if review.Status.Allowed {
+		return nil
+	}
+	err := deniedError(review)
+	if o.canI.Skip != nil && *o.canI.Skip {
+		return fmt.Errorf("%w (%w)", operations.ErrSkip, err)
+	}
+	return err
+}
+
+func deniedError(review *authorizationv1.SelfSubjectAccessReview) error {
+	attributes := review.Spec.ResourceAttributes
+	resource := attributes.Resource
+	if attributes.Group != "" {
+		resource = resource + "." + attributes.Group
+	}
+	if attributes.Subresource != "" {
+		resource = resource + "/" + attributes.Subresource
+	}
+	parts := []string{fmt.Sprintf("cannot %s %s", attributes.Verb, resource)}
+	if attributes.Namespace != "" {
+		parts = append(parts, fmt.Sprintf("in namespace %s", attributes.Namespace))
+	}
+	if review.Status.Reason != "" {
+		parts = append(parts, fmt.Sprintf("(%s)", review.Status.Reason))
+	}
+	return errors.New(strings.Join(parts, " "))
+}
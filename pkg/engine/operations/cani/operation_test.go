@@ -0,0 +1,107 @@
+package cani
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/utils/ptr"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name              string
+		canI              v1alpha1.CanI
+		namespacer        namespacer.Namespacer
+		allowed           bool
+		createErr         error
+		expectedNamespace string
+		expectedLogs      []string
+		expectedErr       string
+		expectedSkip      bool
+	}{{
+		name: "allowed",
+		canI: v1alpha1.CanI{
+			Verb:     "create",
+			Resource: "pods",
+		},
+		namespacer:        namespacer.New("chainsaw"),
+		allowed:           true,
+		expectedNamespace: "chainsaw",
+		expectedLogs:      []string{"CAN-I: RUN - []", "CAN-I: DONE - []"},
+	}, {
+		name: "denied",
+		canI: v1alpha1.CanI{
+			Verb:        "get",
+			Group:       "apps",
+			Resource:    "deployments",
+			Subresource: "scale",
+			Namespace:   "foo",
+		},
+		namespacer:        namespacer.New("chainsaw"),
+		expectedNamespace: "foo",
+		expectedLogs:      []string{"CAN-I: RUN - []", "CAN-I: ERROR - [=== ERROR\ncannot get deployments.apps/scale in namespace foo (denied)]"},
+		expectedErr:       "cannot get deployments.apps/scale in namespace foo (denied)",
+	}, {
+		name: "denied with skip",
+		canI: v1alpha1.CanI{
+			Verb:      "list",
+			Resource:  "nodes",
+			Namespace: "*",
+			Skip:      ptr.To(true),
+		},
+		expectedNamespace: "",
+		expectedLogs:      []string{"CAN-I: RUN - []", "CAN-I: WARN - [=== ERROR\ncannot list nodes (denied)\ntest skipped]"},
+		expectedErr:       "test skipped (cannot list nodes (denied))",
+		expectedSkip:      true,
+	}, {
+		name: "client error",
+		canI: v1alpha1.CanI{
+			Verb:     "create",
+			Resource: "pods",
+		},
+		createErr:    errors.New("failed to create review"),
+		expectedLogs: []string{"CAN-I: RUN - []", "CAN-I: ERROR - [=== ERROR\nfailed to create review]"},
+		expectedErr:  "failed to create review",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+					if tt.createErr != nil {
+						return tt.createErr
+					}
+					review := obj.(*authorizationv1.SelfSubjectAccessReview)
+					assert.Equal(t, tt.expectedNamespace, review.Spec.ResourceAttributes.Namespace)
+					review.Status.Allowed = tt.allowed
+					if !tt.allowed {
+						review.Status.Reason = "denied"
+					}
+					return nil
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			operation := New(fakeClient, tt.namespacer, tt.canI)
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedSkip, errors.Is(err, operations.ErrSkip))
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package chart
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/helm"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	opassert "github.com/kyverno/chainsaw/pkg/engine/operations/assert"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/engine/templating"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	chart      v1alpha1.Chart
+	basePath   string
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	chart v1alpha1.Chart,
+	basePath string,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		chart:      chart,
+		basePath:   basePath,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Chart, _err)
+	}()
+	internal.LogStart(logger, logging.Chart)
+	resources, err := o.render(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	// rendered resources are asserted literally, expressions in the rendered content are not evaluated
+	for _, resource := range resources {
+		if _, err := opassert.New(o.compilers, o.client, resource, o.namespacer, false, opassert.Options{Literal: true}).Exec(ctx, bindings); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// render renders the chart with the values resolved from the bindings.
+func (o *operation) render(ctx context.Context, bindings apis.Bindings) ([]unstructured.Unstructured, error) {
+	release, err := o.chart.Release.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.chart.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	var values map[string]any
+	if o.chart.Values != nil && o.chart.Values.Value() != nil {
+		values, err = templating.TemplateObject(ctx, o.compilers, *o.chart.Values, bindings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	path := o.chart.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(o.basePath, path)
+	}
+	return helm.Render(ctx, path, helm.Release{Name: release, Namespace: namespace}, values, o.chart.Templates...)
+}
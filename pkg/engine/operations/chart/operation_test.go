@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name     string
+		chart    v1alpha1.Chart
+		bindings apis.Bindings
+		replicas int64
+		wantErr  string
+		wantGets []string
+	}{{
+		name: "matches",
+		chart: v1alpha1.Chart{
+			Path:    "helm/chart",
+			Release: "($release)",
+			Values: ptr.To(v1alpha1.NewProjection(map[string]any{
+				"replicas": "($replicas)",
+			})),
+		},
+		bindings: apis.NewBindings().Register("$release", apis.NewBinding("demo")).Register("$replicas", apis.NewBinding(3)),
+		replicas: 3,
+		wantGets: []string{"chainsaw/demo-tiny"},
+	}, {
+		name: "doesn't match",
+		chart: v1alpha1.Chart{
+			Path:    "helm/chart",
+			Release: "demo",
+		},
+		replicas: 3,
+		wantErr:  "spec.replicas: Invalid value: 3: Expected value: 1",
+	}, {
+		name: "not a chart",
+		chart: v1alpha1.Chart{
+			Path: "helm",
+		},
+		wantErr: "Chart.yaml file is missing",
+	}, {
+		name: "template not rendered",
+		chart: v1alpha1.Chart{
+			Path:      "helm/chart",
+			Templates: []string{"templates/service.yaml"},
+		},
+		wantErr: "helm template failed: could not find template templates/service.yaml in chart",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets []string
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					gets = append(gets, key.String())
+					if key.Name != "demo-tiny" {
+						return kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name)
+					}
+					u := obj.(*unstructured.Unstructured)
+					u.SetName(key.Name)
+					u.SetNamespace(key.Namespace)
+					u.SetLabels(map[string]string{
+						"app.kubernetes.io/name":     "tiny",
+						"app.kubernetes.io/instance": "demo",
+					})
+					if err := unstructured.SetNestedStringMap(u.Object, u.GetLabels(), "spec", "selector", "matchLabels"); err != nil {
+						return err
+					}
+					if err := unstructured.SetNestedStringMap(u.Object, u.GetLabels(), "spec", "template", "metadata", "labels"); err != nil {
+						return err
+					}
+					if err := unstructured.SetNestedSlice(u.Object, []any{map[string]any{"name": "tiny", "image": "nginx:1.25.0"}}, "spec", "template", "spec", "containers"); err != nil {
+						return err
+					}
+					return unstructured.SetNestedField(u.Object, tt.replicas, "spec", "replicas")
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), tt.chart, "../../../../testdata")
+			outputs, err := operation.Exec(ctx, tt.bindings)
+			assert.Nil(t, outputs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantGets, gets)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package collect
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/pkg/ext/output/color"
+	"sigs.k8s.io/yaml"
+)
+
+type operation struct {
+	output any
+}
+
+// New returns an operation logging the structured output produced by a custom collector.
+func New(output any) operations.Operation {
+	return &operation{
+		output: output,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, _ apis.Bindings) (_ outputs.Outputs, _err error) {
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Collect, _err)
+	}()
+	internal.LogStart(logger, logging.Collect)
+	return nil, o.execute(logger)
+}
+
+func (o *operation) execute(logger logging.Logger) error {
+	if o.output == nil {
+		return nil
+	}
+	data, err := yaml.Marshal(o.output)
+	if err != nil {
+		return err
+	}
+	if logger != nil {
+		logger.Log(logging.Collect, logging.LogStatus, color.BoldFgCyan, logging.Section("OUTPUT", string(data)))
+	}
+	return nil
+}
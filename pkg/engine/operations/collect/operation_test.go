@@ -0,0 +1,45 @@
+package collect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       any
+		expectedLogs []string
+	}{{
+		name:         "nil",
+		output:       nil,
+		expectedLogs: []string{"COLLECT: RUN - []", "COLLECT: DONE - []"},
+	}, {
+		name: "structured",
+		output: map[string]any{
+			"status": "healthy",
+			"zones":  []any{"a", "b"},
+		},
+		expectedLogs: []string{
+			"COLLECT: RUN - []",
+			"COLLECT: LOG - [=== OUTPUT\nstatus: healthy\nzones:\n- a\n- b]",
+			"COLLECT: DONE - []",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			operation := New(tt.output)
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -3,9 +3,9 @@ package command
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
-	"path/filepath"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -15,6 +15,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/engine/throttle"
 	environment "github.com/kyverno/chainsaw/pkg/utils/env"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
@@ -28,6 +29,7 @@ type operation struct {
 	basePath  string
 	namespace string
 	cfg       *rest.Config
+	filter    func(string) string
 }
 
 func New(
@@ -36,6 +38,7 @@ func New(
 	basePath string,
 	namespace string,
 	cfg *rest.Config,
+	filter func(string) string,
 ) operations.Operation {
 	return &operation{
 		compilers: compilers,
@@ -43,6 +46,7 @@ func New(
 		basePath:  basePath,
 		namespace: namespace,
 		cfg:       cfg,
+		filter:    filter,
 	}
 }
 
@@ -95,15 +99,11 @@ func (o *operation) createCommand(ctx context.Context, bindings apis.Bindings) (
 	args := environment.Expand(maps, o.command.Args...)
 	cmd := exec.CommandContext(ctx, o.command.Entrypoint, args...) //nolint:gosec
 	cmd.Env = env
-	basePath := o.basePath
-	if o.command.WorkDir != nil {
-		if filepath.IsAbs(*o.command.WorkDir) {
-			basePath = *o.command.WorkDir
-		} else {
-			basePath = filepath.Join(basePath, *o.command.WorkDir)
-		}
+	workDir, err := internal.WorkDir(ctx, o.compilers, bindings, o.basePath, o.command.WorkDir)
+	if err != nil {
+		return nil, cancel, err
 	}
-	cmd.Dir = basePath
+	cmd.Dir = workDir
 	return cmd, cancel, nil
 }
 
@@ -119,7 +119,13 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 	}
 	cmd.Stdout = &output.Stdout
 	cmd.Stderr = &output.Stderr
-	err := cmd.Run()
+	err := throttle.Run(ctx, cmd)
+	if o.filter != nil {
+		// post process stdout, both the binding and the logged output are affected
+		filtered := o.filter(output.Out())
+		output.Stdout.Reset()
+		output.Stdout.WriteString(filtered)
+	}
 	bindings = apibindings.RegisterBinding(ctx, bindings, "stdout", output.Out())
 	bindings = apibindings.RegisterBinding(ctx, bindings, "stderr", output.Err())
 	if err == nil {
@@ -127,6 +133,15 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 	} else {
 		bindings = apibindings.RegisterBinding(ctx, bindings, "error", err.Error())
 	}
+	var captured outputs.Outputs
+	if err == nil && o.command.Capture != nil {
+		value, err := internal.Capture(*o.command.Capture, output.Out())
+		if err != nil {
+			return nil, err
+		}
+		bindings = apibindings.RegisterBinding(ctx, bindings, o.command.Capture.As, value)
+		captured = outputs.Outputs{o.command.Capture.As: value}
+	}
 	defer func(bindings apis.Bindings) {
 		if _err == nil {
 			outputs, err := outputs.Process(ctx, o.compilers, bindings, nil, o.command.Outputs...)
@@ -134,6 +149,11 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 				_err = err
 				return
 			}
+			// declared outputs take precedence over the captured stdout
+			if captured != nil {
+				maps.Copy(captured, outputs)
+				outputs = captured
+			}
 			_outputs = outputs
 		}
 	}(bindings)
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
@@ -18,6 +19,7 @@ func Test_operationCommand(t *testing.T) {
 		command    v1alpha1.Command
 		basePath   string
 		namespace  string
+		filter     func(string) string
 		wantErr    bool
 		wantErrMsg string
 	}{{
@@ -62,7 +64,7 @@ func Test_operationCommand(t *testing.T) {
 			Entrypoint: "cat",
 			Args:       []string{"operation.go"},
 			ActionEnv:  v1alpha1.ActionEnv{SkipLogOutput: true},
-			WorkDir:    ptr.To("/bar"),
+			WorkDir:    ptr.To[v1alpha1.Expression]("/bar"),
 		},
 		basePath:   "..",
 		namespace:  "test-namespace",
@@ -74,12 +76,29 @@ func Test_operationCommand(t *testing.T) {
 			Entrypoint: "cat",
 			Args:       []string{"operation.go"},
 			ActionEnv:  v1alpha1.ActionEnv{SkipLogOutput: true},
-			WorkDir:    ptr.To("./foo"),
+			WorkDir:    ptr.To[v1alpha1.Expression]("./foo"),
 		},
 		basePath:   "..",
 		namespace:  "test-namespace",
 		wantErr:    true,
 		wantErrMsg: "../foo: no such file or directory",
+	}, {
+		name: "with filter",
+		command: v1alpha1.Command{
+			Entrypoint: "echo",
+			Args:       []string{"hello"},
+			ActionEnv:  v1alpha1.ActionEnv{SkipLogOutput: true},
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: ptr.To(v1alpha1.NewCheck(
+					map[string]any{
+						"($stdout)": "HELLO\n",
+					},
+				)),
+			},
+		},
+		namespace: "test-namespace",
+		filter:    strings.ToUpper,
+		wantErr:   false,
 	}, {
 		name: "with check",
 		command: v1alpha1.Command{
@@ -141,6 +160,7 @@ func Test_operationCommand(t *testing.T) {
 				tt.basePath,
 				tt.namespace,
 				nil,
+				tt.filter,
 			)
 			_, err := operation.Exec(ctx, nil)
 			if tt.wantErr {
@@ -154,3 +174,30 @@ func Test_operationCommand(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationCommandCapture(t *testing.T) {
+	command := v1alpha1.Command{
+		Entrypoint: "echo",
+		Args:       []string{`{"name":"foo","replicas":3}`},
+		ActionCapture: v1alpha1.ActionCapture{
+			Capture: &v1alpha1.Capture{As: "deployment", Parser: v1alpha1.CaptureParserJSON},
+		},
+		ActionCheck: v1alpha1.ActionCheck{
+			Check: ptr.To(v1alpha1.NewCheck(
+				map[string]any{
+					"($deployment.name)": "foo",
+				},
+			)),
+		},
+	}
+	ctx := logging.IntoContext(context.TODO(), &tlogging.FakeLogger{})
+	operation := New(apis.DefaultCompilers, command, "", "test-namespace", nil, nil)
+	outputs, err := operation.Exec(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"deployment": map[string]any{
+			"name":     "foo",
+			"replicas": 3.0,
+		},
+	}, outputs)
+}
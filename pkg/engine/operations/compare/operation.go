@@ -0,0 +1,101 @@
+package compare
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	diffutils "github.com/kyverno/chainsaw/pkg/utils/diff"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operation struct {
+	compilers compilers.Compilers
+	compare   v1alpha1.Compare
+}
+
+func New(
+	compilers compilers.Compilers,
+	compare v1alpha1.Compare,
+) operations.Operation {
+	return &operation{
+		compilers: compilers,
+		compare:   compare,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Compare, _err)
+	}()
+	internal.LogStart(logger, logging.Compare)
+	return nil, o.execute(ctx, bindings)
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings) error {
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		lastErr = o.tryCompare(ctx, bindings)
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (o *operation) tryCompare(ctx context.Context, bindings apis.Bindings) error {
+	actual, err := o.evaluate(ctx, bindings, o.compare.Actual)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate actual value: %w", err)
+	}
+	expected, err := o.evaluate(ctx, bindings, o.compare.Expected)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate expected value: %w", err)
+	}
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+	diff, err := diffutils.PrettyDiffValues(expected, actual)
+	if err != nil {
+		return err
+	}
+	return errors.New("values are not equal\n" + strings.TrimSpace(diff))
+}
+
+// evaluate computes the value of an expression and normalizes it
+// so that values of different go types can be compared.
+func (o *operation) evaluate(ctx context.Context, bindings apis.Bindings, expression v1alpha1.Expression) (any, error) {
+	value, err := expressions.Any(ctx, o.compilers, string(expression), bindings)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -0,0 +1,96 @@
+package compare
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	bindings := apis.NewBindings().
+		Register("$secret", apis.NewBinding(map[string]any{"password": "foo"})).
+		Register("$config", apis.NewBinding(map[string]any{"password": "foo", "user": "admin"})).
+		Register("$other", apis.NewBinding(map[string]any{"password": "bar", "user": "admin"})).
+		Register("$replicas", apis.NewBinding(int64(3)))
+	tests := []struct {
+		name         string
+		compare      v1alpha1.Compare
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name: "equal strings",
+		compare: v1alpha1.Compare{
+			Actual:   "($secret.password)",
+			Expected: "($config.password)",
+		},
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: DONE - []"},
+	}, {
+		name: "equal to literal",
+		compare: v1alpha1.Compare{
+			Actual:   "($secret.password)",
+			Expected: "foo",
+		},
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: DONE - []"},
+	}, {
+		name: "equal numbers",
+		compare: v1alpha1.Compare{
+			Actual:   "($replicas)",
+			Expected: "(`3`)",
+		},
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: DONE - []"},
+	}, {
+		name: "equal objects",
+		compare: v1alpha1.Compare{
+			Actual:   "($config)",
+			Expected: "({password: 'foo', user: 'admin'})",
+		},
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: DONE - []"},
+	}, {
+		name: "unequal strings",
+		compare: v1alpha1.Compare{
+			Actual:   "($secret.password)",
+			Expected: "($other.password)",
+		},
+		expectedErr:  "values are not equal\n--- expected\n+++ actual\n@@ -1,2 +1,2 @@\n-bar\n+foo",
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: ERROR - [=== ERROR\nvalues are not equal\n--- expected\n+++ actual\n@@ -1,2 +1,2 @@\n-bar\n+foo]"},
+	}, {
+		name: "unequal objects",
+		compare: v1alpha1.Compare{
+			Actual:   "($config)",
+			Expected: "($other)",
+		},
+		expectedErr:  "values are not equal\n--- expected\n+++ actual\n@@ -1,3 +1,3 @@\n-password: bar\n+password: foo\n user: admin",
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: ERROR - [=== ERROR\nvalues are not equal\n--- expected\n+++ actual\n@@ -1,3 +1,3 @@\n-password: bar\n+password: foo\n user: admin]"},
+	}, {
+		name: "evaluation error",
+		compare: v1alpha1.Compare{
+			Actual:   "($missing)",
+			Expected: "foo",
+		},
+		expectedErr:  "failed to evaluate actual value: variable not defined: $missing",
+		expectedLogs: []string{"COMPARE: RUN - []", "COMPARE: ERROR - [=== ERROR\nfailed to evaluate actual value: variable not defined: $missing]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.compare)
+			logger := &tlogging.FakeLogger{}
+			outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -29,6 +29,7 @@ type operation struct {
 	namespacer namespacer.Namespacer
 	cleaner    cleaner.CleanerCollector
 	template   bool
+	rendered   *v1alpha1.Check
 	expect     []v1alpha1.Expectation
 	outputs    []v1alpha1.Output
 }
@@ -40,6 +41,7 @@ func New(
 	namespacer namespacer.Namespacer,
 	cleaner cleaner.CleanerCollector,
 	template bool,
+	rendered *v1alpha1.Check,
 	expect []v1alpha1.Expectation,
 	outputs []v1alpha1.Output,
 ) operations.Operation {
@@ -50,6 +52,7 @@ func New(
 		namespacer: namespacer,
 		cleaner:    cleaner,
 		template:   template,
+		rendered:   rendered,
 		expect:     expect,
 		outputs:    outputs,
 	}
@@ -72,6 +75,9 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 			obj = merged
 		}
 	}
+	if err := internal.CheckRendered(ctx, o.compilers, obj, bindings, o.rendered); err != nil {
+		return nil, err
+	}
 	if err := internal.ApplyNamespacer(o.namespacer, o.client, &obj); err != nil {
 		return nil, err
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 )
 
@@ -115,6 +116,19 @@ func Test_create(t *testing.T) {
 		},
 		expect:      nil,
 		expectedErr: errors.New("some arbitrary error"),
+	}, {
+		name:   "quota exceeded",
+		object: pod,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerrors.NewNotFound(obj.GetObjectKind().GroupVersionKind().GroupVersion().WithResource("pod").GroupResource(), key.Name)
+			},
+			CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+				return kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, obj.GetName(), errors.New("exceeded quota: chainsaw-quota, requested: pods=1, used: pods=10, limited: pods=10"))
+			},
+		},
+		expect:      nil,
+		expectedErr: errors.New(`pods "test-pod" is forbidden: exceeded quota: chainsaw-quota, requested: pods=1, used: pods=10, limited: pods=10`),
 	}, {
 		name:   "failed create (expected)",
 		object: pod,
@@ -225,6 +239,7 @@ func Test_create(t *testing.T) {
 				nil,
 				tt.cleaner,
 				false,
+				nil,
 				tt.expect,
 				nil,
 			)
@@ -2,6 +2,7 @@ package delete
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -30,6 +31,8 @@ type operation struct {
 	template          bool
 	expect            []v1alpha1.Expectation
 	propagationPolicy metav1.DeletionPropagation
+	wait              bool
+	allNamespaces     bool
 }
 
 func New(
@@ -39,6 +42,8 @@ func New(
 	namespacer namespacer.Namespacer,
 	template bool,
 	propagationPolicy metav1.DeletionPropagation,
+	wait bool,
+	allNamespaces bool,
 	expect ...v1alpha1.Expectation,
 ) operations.Operation {
 	return &operation{
@@ -49,6 +54,8 @@ func New(
 		template:          template,
 		expect:            expect,
 		propagationPolicy: propagationPolicy,
+		wait:              wait,
+		allNamespaces:     allNamespaces,
 	}
 }
 
@@ -69,7 +76,12 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 			obj = merged
 		}
 	}
-	if err := internal.ApplyNamespacer(o.namespacer, o.client, &obj); err != nil {
+	if o.allNamespaces {
+		// deleting across all namespaces must be explicit, it requires a selector and no name or namespace
+		if len(obj.GetLabels()) == 0 || obj.GetName() != "" || obj.GetNamespace() != "" {
+			return nil, errors.New("deleting across all namespaces requires a label selector without name or namespace")
+		}
+	} else if err := internal.ApplyNamespacer(o.namespacer, o.client, &obj); err != nil {
 		return nil, err
 	}
 	internal.LogStart(logger, logging.Delete)
@@ -109,9 +121,11 @@ func (o *operation) deleteResources(ctx context.Context, bindings apis.Bindings,
 			errs = append(errs, err)
 		}
 	}
-	for _, resource := range deleted {
-		if err := o.waitForDeletion(ctx, resource); err != nil {
-			errs = append(errs, err)
+	if o.wait {
+		for _, resource := range deleted {
+			if err := o.waitForDeletion(ctx, resource); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	}
 	return multierr.Combine(errs...)
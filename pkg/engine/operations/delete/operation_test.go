@@ -19,6 +19,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func Test_operationDelete(t *testing.T) {
@@ -37,6 +39,8 @@ func Test_operationDelete(t *testing.T) {
 		client       *tclient.FakeClient
 		namespacer   func(c client.Client) namespacer.Namespacer
 		expect       []v1alpha1.Expectation
+		wait         *bool
+		all          bool
 		expectedErr  error
 		expectedLogs []string
 	}{{
@@ -158,6 +162,30 @@ func Test_operationDelete(t *testing.T) {
 		}},
 		expectedErr:  nil,
 		expectedLogs: []string{"DELETE: RUN - []", "DELETE: DONE - []"},
+	}, {
+		name:   "no wait",
+		object: pod,
+		client: &tclient.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				t := ttesting.FromContext(ctx)
+				// only the initial read is expected, no polling after delete
+				assert.Equal(t, 0, call)
+				return nil
+			},
+			DeleteFn: func(ctx context.Context, call int, obj client.Object, _ ...client.DeleteOption) error {
+				return nil
+			},
+		},
+		wait:         ptr.To(false),
+		expectedErr:  nil,
+		expectedLogs: []string{"DELETE: RUN - []", "DELETE: DONE - []"},
+	}, {
+		name:         "all namespaces without selector",
+		object:       pod,
+		client:       &tclient.FakeClient{},
+		all:          true,
+		expectedErr:  errors.New("deleting across all namespaces requires a label selector without name or namespace"),
+		expectedLogs: []string{"DELETE: ERROR - [=== ERROR\ndeleting across all namespaces requires a label selector without name or namespace]"},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -174,6 +202,8 @@ func Test_operationDelete(t *testing.T) {
 				nspacer,
 				false,
 				metav1.DeletePropagationForeground,
+				ptr.Deref(tt.wait, true),
+				tt.all,
 				tt.expect...,
 			)
 			logger := &tlogging.FakeLogger{}
@@ -188,3 +218,69 @@ func Test_operationDelete(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationDelete_AllNamespaces(t *testing.T) {
+	podIn := func(namespace string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]any{
+					"name":      "test-pod",
+					"namespace": namespace,
+					"labels": map[string]any{
+						"app": "foo",
+					},
+				},
+			},
+		}
+	}
+	selector := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"labels": map[string]any{
+					"app": "foo",
+				},
+			},
+		},
+	}
+	var deleted []string
+	c := &tclient.FakeClient{
+		ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			t := ttesting.FromContext(ctx)
+			var options ctrlclient.ListOptions
+			options.ApplyOptions(opts)
+			assert.Equal(t, "", options.Namespace)
+			assert.Equal(t, "app=foo", options.LabelSelector.String())
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{podIn("ns-1"), podIn("ns-2")}
+			return nil
+		},
+		DeleteFn: func(_ context.Context, _ int, obj client.Object, _ ...client.DeleteOption) error {
+			deleted = append(deleted, obj.GetNamespace()+"/"+obj.GetName())
+			return nil
+		},
+		GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			return kerrors.NewNotFound(obj.GetObjectKind().GroupVersionKind().GroupVersion().WithResource("pod").GroupResource(), key.Name)
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	operation := New(
+		apis.DefaultCompilers,
+		c,
+		selector,
+		namespacer.New("bar"),
+		false,
+		metav1.DeletePropagationForeground,
+		true,
+		true,
+	)
+	logger := &tlogging.FakeLogger{}
+	outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), nil)
+	assert.Nil(t, outputs)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns-1/test-pod", "ns-2/test-pod"}, deleted)
+	assert.Equal(t, []string{"DELETE: RUN - []", "DELETE: DONE - []"}, logger.Logs)
+}
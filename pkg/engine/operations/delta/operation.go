@@ -0,0 +1,169 @@
+package delta
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	delta      v1alpha1.Delta
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	delta v1alpha1.Delta,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		delta:      delta,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	obj, err := o.object(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	logger := internal.GetLogger(ctx, obj)
+	defer func() {
+		internal.LogEnd(logger, logging.Delta, _err)
+	}()
+	if err := internal.ApplyNamespacer(o.namespacer, o.client, obj); err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Delta)
+	snapshot, err := o.snapshotValue(bindings)
+	if err != nil {
+		return nil, err
+	}
+	if o.delta.Operator == v1alpha1.DeltaOperatorUnchanged {
+		return nil, o.unchanged(ctx, bindings, obj, snapshot)
+	}
+	number, ok := internal.ToNumber(snapshot)
+	if !ok {
+		return nil, fmt.Errorf("snapshot %s is not a number", o.delta.Snapshot)
+	}
+	return nil, o.execute(ctx, bindings, obj, number)
+}
+
+func (o *operation) object(ctx context.Context, bindings apis.Bindings) (*unstructured.Unstructured, error) {
+	gv, err := o.delta.GroupVersion(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := o.delta.Kind.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	name, err := o.delta.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.delta.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion(gv.String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj, nil
+}
+
+func (o *operation) snapshotValue(bindings apis.Bindings) (any, error) {
+	binding, err := bindings.Get("$" + o.delta.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s not found", o.delta.Snapshot)
+	}
+	return binding.Value()
+}
+
+// unchanged checks the field once, a field that changed during the step is not expected to change back.
+func (o *operation) unchanged(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured, snapshot any) error {
+	var actual unstructured.Unstructured
+	actual.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := o.client.Get(ctx, client.Key(obj), &actual); err != nil {
+		return err
+	}
+	current, err := internal.Field(ctx, o.compilers, actual, o.delta.Field, bindings)
+	if err != nil {
+		return err
+	}
+	if expected, ok := internal.ToNumber(snapshot); ok {
+		if number, ok := internal.ToNumber(current); ok && number == expected {
+			return nil
+		}
+	} else if reflect.DeepEqual(snapshot, current) {
+		return nil
+	}
+	return fmt.Errorf("field changed (snapshot: %v, current: %v)", snapshot, current)
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured, snapshot float64) error {
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		lastErr = o.tryDelta(ctx, bindings, obj, snapshot)
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (o *operation) tryDelta(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured, snapshot float64) error {
+	var actual unstructured.Unstructured
+	actual.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := o.client.Get(ctx, client.Key(obj), &actual); err != nil {
+		return err
+	}
+	current, err := internal.NumericField(ctx, o.compilers, actual, o.delta.Field, bindings)
+	if err != nil {
+		return err
+	}
+	operator := o.delta.Operator
+	if operator == "" {
+		operator = v1alpha1.DeltaOperatorEqual
+	}
+	delta := current - snapshot
+	ok, err := internal.Compare(operator, delta, float64(o.delta.Value))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("delta %s doesn't satisfy %s %d (snapshot: %s, current: %s)", format(delta), operator, o.delta.Value, format(snapshot), format(current))
+	}
+	return nil
+}
+
+func format(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,192 @@
+package delta
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		bindings     apis.Bindings
+		current      []int64
+		operator     v1alpha1.DeltaOperator
+		value        int64
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name:         "increase",
+		bindings:     apis.NewBindings().Register("$replicas", apis.NewBinding(float64(1))),
+		current:      []int64{2},
+		operator:     v1alpha1.DeltaOperatorGreaterThanOrEqual,
+		value:        1,
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "eventual increase",
+		bindings:     apis.NewBindings().Register("$replicas", apis.NewBinding(float64(1))),
+		current:      []int64{1, 1, 3},
+		operator:     v1alpha1.DeltaOperatorGreaterThan,
+		value:        1,
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "no change",
+		bindings:     apis.NewBindings().Register("$replicas", apis.NewBinding(float64(1))),
+		current:      []int64{1},
+		operator:     v1alpha1.DeltaOperatorGreaterThanOrEqual,
+		value:        1,
+		expectedErr:  "delta 0 doesn't satisfy GreaterThanOrEqual 1 (snapshot: 1, current: 1)",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: ERROR - [=== ERROR\ndelta 0 doesn't satisfy GreaterThanOrEqual 1 (snapshot: 1, current: 1)]"},
+	}, {
+		name:         "unchanged by default",
+		bindings:     apis.NewBindings().Register("$replicas", apis.NewBinding(int64(2))),
+		current:      []int64{2},
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "decrease",
+		bindings:     apis.NewBindings().Register("$replicas", apis.NewBinding(float64(3))),
+		current:      []int64{1},
+		operator:     v1alpha1.DeltaOperatorLessThanOrEqual,
+		value:        -2,
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "missing snapshot",
+		bindings:     apis.NewBindings(),
+		current:      []int64{1},
+		expectedErr:  "snapshot replicas not found",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: ERROR - [=== ERROR\nsnapshot replicas not found]"},
+	}, {
+		name:         "invalid snapshot",
+		bindings:     apis.NewBindings().Register("$replicas", apis.NewBinding("foo")),
+		current:      []int64{1},
+		expectedErr:  "snapshot replicas is not a number",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: ERROR - [=== ERROR\nsnapshot replicas is not a number]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				GetFn: func(_ context.Context, call int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					assert.Equal(t, client.ObjectKey{Namespace: "chainsaw", Name: "foo"}, key)
+					current := tt.current[min(call, len(tt.current)-1)]
+					return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, current, "spec", "replicas")
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), v1alpha1.Delta{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+				Snapshot: "replicas",
+				Field:    "(spec.replicas)",
+				Operator: tt.operator,
+				Value:    tt.value,
+			})
+			outputs, err := operation.Exec(ctx, tt.bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
+
+func Test_operation_Exec_Unchanged(t *testing.T) {
+	tests := []struct {
+		name         string
+		bindings     apis.Bindings
+		field        v1alpha1.Expression
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name:         "unchanged",
+		bindings:     apis.NewBindings().Register("$volume", apis.NewBinding("pv-1")),
+		field:        "(spec.volumeName)",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "changed",
+		bindings:     apis.NewBindings().Register("$volume", apis.NewBinding("pv-0")),
+		field:        "(spec.volumeName)",
+		expectedErr:  "field changed (snapshot: pv-0, current: pv-1)",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: ERROR - [=== ERROR\nfield changed (snapshot: pv-0, current: pv-1)]"},
+	}, {
+		name:         "unchanged number",
+		bindings:     apis.NewBindings().Register("$volume", apis.NewBinding(float64(2))),
+		field:        "(spec.replicas)",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "unchanged object",
+		bindings:     apis.NewBindings().Register("$volume", apis.NewBinding(map[string]any{"volumeName": "pv-1", "replicas": int64(2)})),
+		field:        "(spec)",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: DONE - []"},
+	}, {
+		name:         "missing snapshot",
+		bindings:     apis.NewBindings(),
+		field:        "(spec.volumeName)",
+		expectedErr:  "snapshot volume not found",
+		expectedLogs: []string{"DELTA: RUN - []", "DELTA: ERROR - [=== ERROR\nsnapshot volume not found]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					assert.Equal(t, client.ObjectKey{Namespace: "chainsaw", Name: "foo"}, key)
+					obj.(*unstructured.Unstructured).Object["spec"] = map[string]any{
+						"volumeName": "pv-1",
+						"replicas":   int64(2),
+					}
+					return nil
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), v1alpha1.Delta{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "PersistentVolumeClaim",
+				},
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+				Snapshot: "volume",
+				Field:    tt.field,
+				Operator: v1alpha1.DeltaOperatorUnchanged,
+			})
+			outputs, err := operation.Exec(ctx, tt.bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -2,11 +2,15 @@ package error
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/client/dryrun"
 	"github.com/kyverno/chainsaw/pkg/engine/checks"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
@@ -28,6 +32,7 @@ type operation struct {
 	base       unstructured.Unstructured
 	namespacer namespacer.Namespacer
 	template   bool
+	message    string
 }
 
 func New(
@@ -36,6 +41,7 @@ func New(
 	expected unstructured.Unstructured,
 	namespacer namespacer.Namespacer,
 	template bool,
+	message string,
 ) operations.Operation {
 	return &operation{
 		compilers:  compilers,
@@ -43,6 +49,7 @@ func New(
 		base:       expected,
 		namespacer: namespacer,
 		template:   template,
+		message:    message,
 	}
 }
 
@@ -66,9 +73,63 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 		}
 	}
 	internal.LogStart(logger, logging.Error)
+	if o.message != "" {
+		return nil, o.rejected(ctx, obj)
+	}
 	return nil, o.execute(ctx, bindings, obj)
 }
 
+// rejected creates the resource in dry run mode until the creation is rejected with a message matching the expected one.
+// If the resource already exists, the api server refuses the creation before calling admission webhooks so an update is sent in dry run mode instead.
+// A not found error (missing namespace for example) is not considered an admission rejection.
+func (o *operation) rejected(ctx context.Context, obj unstructured.Unstructured) error {
+	if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+		return errors.New("a message requires a resource with an apiVersion and a kind")
+	}
+	pattern, err := regexp.Compile(o.message)
+	if err != nil {
+		return fmt.Errorf("invalid message regular expression: %w", err)
+	}
+	dryRunClient := dryrun.New(o.client)
+	var lastErr error
+	err = wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		candidate := obj.DeepCopy()
+		err := dryRunClient.Create(ctx, candidate)
+		if kerrors.IsAlreadyExists(err) {
+			var actual unstructured.Unstructured
+			actual.SetGroupVersionKind(obj.GroupVersionKind())
+			if err := o.client.Get(ctx, client.Key(&obj), &actual); err != nil {
+				lastErr = err
+				return false, nil
+			}
+			candidate = obj.DeepCopy()
+			candidate.SetResourceVersion(actual.GetResourceVersion())
+			err = dryRunClient.Update(ctx, candidate)
+		}
+		switch {
+		case err == nil:
+			lastErr = fmt.Errorf("%s/%s/%s - resource was not rejected", obj.GetAPIVersion(), obj.GetKind(), client.Name(client.Key(&obj)))
+			return false, nil
+		case kerrors.IsNotFound(err):
+			lastErr = fmt.Errorf("%s/%s/%s - resource was not evaluated by admission: %w", obj.GetAPIVersion(), obj.GetKind(), client.Name(client.Key(&obj)), err)
+			return false, nil
+		case kerrors.IsBadRequest(err) && strings.Contains(err.Error(), "does not support dry run"):
+			return false, fmt.Errorf("admission webhooks must declare sideEffects None or NoneOnDryRun to be evaluated in dry run mode: %w", err)
+		case !pattern.MatchString(err.Error()):
+			lastErr = fmt.Errorf("rejection message doesn't match %q: %w", o.message, err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr != nil && wait.Interrupted(err) {
+		return lastErr
+	}
+	return err
+}
+
 func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj unstructured.Unstructured) error {
 	var lastErrs []error
 	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (_ bool, err error) {
@@ -16,9 +16,11 @@ import (
 	tnamespacer "github.com/kyverno/chainsaw/pkg/engine/namespacer/testing"
 	ttesting "github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func Test_operationError(t *testing.T) {
@@ -216,6 +218,7 @@ func Test_operationError(t *testing.T) {
 				tt.expected,
 				nspacer,
 				false,
+				"",
 			)
 			logger := &tlogging.FakeLogger{}
 			outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), nil)
@@ -229,3 +232,121 @@ func Test_operationError(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationError_Message(t *testing.T) {
+	expected := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"namespace": "foo",
+				"name":      "invalid",
+			},
+		},
+	}
+	denied := errors.New(`admission webhook "validate.example.com" denied the request: label team is required`)
+	tests := []struct {
+		name         string
+		expected     unstructured.Unstructured
+		message      string
+		createErr    error
+		updateErr    error
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name:         "matching message",
+		expected:     expected,
+		message:      "denied the request: .*team",
+		createErr:    denied,
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: DONE - []"},
+	}, {
+		name:         "not matching message",
+		expected:     expected,
+		message:      "label owner is required",
+		createErr:    denied,
+		expectedErr:  `rejection message doesn't match "label owner is required": admission webhook "validate.example.com" denied the request: label team is required`,
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: ERROR - [=== ERROR\nrejection message doesn't match \"label owner is required\": admission webhook \"validate.example.com\" denied the request: label team is required]"},
+	}, {
+		name:         "not rejected",
+		expected:     expected,
+		message:      "denied",
+		expectedErr:  "v1/ConfigMap/foo/invalid - resource was not rejected",
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: ERROR - [=== ERROR\nv1/ConfigMap/foo/invalid - resource was not rejected]"},
+	}, {
+		name:         "already exists",
+		expected:     expected,
+		message:      "denied the request: .*team",
+		createErr:    kerrors.NewAlreadyExists(corev1.Resource("configmaps"), "invalid"),
+		updateErr:    denied,
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: DONE - []"},
+	}, {
+		name:         "namespace not found",
+		expected:     expected,
+		message:      "not found",
+		createErr:    kerrors.NewNotFound(corev1.Resource("namespaces"), "foo"),
+		expectedErr:  `v1/ConfigMap/foo/invalid - resource was not evaluated by admission: namespaces "foo" not found`,
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: ERROR - [=== ERROR\nv1/ConfigMap/foo/invalid - resource was not evaluated by admission: namespaces \"foo\" not found]"},
+	}, {
+		name:         "dry run not supported",
+		expected:     expected,
+		message:      "dry run",
+		createErr:    kerrors.NewBadRequest(`admission webhook "validate.example.com" does not support dry run`),
+		expectedErr:  `admission webhooks must declare sideEffects None or NoneOnDryRun to be evaluated in dry run mode: admission webhook "validate.example.com" does not support dry run`,
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: ERROR - [=== ERROR\nadmission webhooks must declare sideEffects None or NoneOnDryRun to be evaluated in dry run mode: admission webhook \"validate.example.com\" does not support dry run]"},
+	}, {
+		name:         "invalid message",
+		expected:     expected,
+		message:      "(",
+		createErr:    denied,
+		expectedErr:  "invalid message regular expression: error parsing regexp: missing closing ): `(`",
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: ERROR - [=== ERROR\ninvalid message regular expression: error parsing regexp: missing closing ): `(`]"},
+	}, {
+		name: "no kind",
+		expected: unstructured.Unstructured{
+			Object: map[string]any{
+				"foo": "bar",
+			},
+		},
+		message:      "denied",
+		expectedErr:  "a message requires a resource with an apiVersion and a kind",
+		expectedLogs: []string{"ERROR: RUN - []", "ERROR: ERROR - [=== ERROR\na message requires a resource with an apiVersion and a kind]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			fakeClient := &tclient.FakeClient{
+				CreateFn: func(ctx context.Context, _ int, obj client.Object, opts ...client.CreateOption) error {
+					assert.Contains(t, opts, ctrlclient.DryRunAll)
+					return tt.createErr
+				},
+				GetFn: func(ctx context.Context, _ int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					obj.SetResourceVersion("42")
+					return nil
+				},
+				UpdateFn: func(ctx context.Context, _ int, obj client.Object, opts ...client.UpdateOption) error {
+					assert.Contains(t, opts, ctrlclient.DryRunAll)
+					assert.Equal(t, "42", obj.GetResourceVersion())
+					return tt.updateErr
+				},
+			}
+			operation := New(
+				apis.DefaultCompilers,
+				fakeClient,
+				tt.expected,
+				nil,
+				false,
+				tt.message,
+			)
+			logger := &tlogging.FakeLogger{}
+			outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// Executor runs a command in a container of a pod.
+type Executor interface {
+	Exec(ctx context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error
+}
+
+type executor struct {
+	cfg *rest.Config
+}
+
+// NewExecutor returns an Executor streaming the command through the exec subresource of the pod,
+// websockets are used if supported by the api server, spdy otherwise.
+func NewExecutor(cfg *rest.Config) Executor {
+	return &executor{
+		cfg: cfg,
+	}
+}
+
+func (e *executor) Exec(ctx context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error {
+	if e.cfg == nil {
+		return errors.New("a cluster is required to run a command in a pod")
+	}
+	client, err := corev1client.NewForConfig(e.cfg)
+	if err != nil {
+		return err
+	}
+	req := client.RESTClient().
+		Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	websocket, err := remotecommand.NewWebSocketExecutor(e.cfg, "GET", req.URL().String())
+	if err != nil {
+		return err
+	}
+	spdy, err := remotecommand.NewSPDYExecutor(e.cfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	streamer, err := remotecommand.NewFallbackExecutor(websocket, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return err
+	}
+	return streamer.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
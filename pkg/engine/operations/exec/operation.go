@@ -0,0 +1,112 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	apibindings "github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operation struct {
+	compilers compilers.Compilers
+	client    client.Client
+	exec      v1alpha1.Exec
+	namespace string
+	executor  Executor
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	exec v1alpha1.Exec,
+	namespace string,
+	executor Executor,
+) operations.Operation {
+	return &operation{
+		compilers: compilers,
+		client:    client,
+		exec:      exec,
+		namespace: namespace,
+		executor:  executor,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Exec, _err)
+	}()
+	target, err := o.resolve(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Exec, logging.Section("TARGET", target.String()))
+	return o.execute(ctx, bindings, target)
+}
+
+// resolve looks up the target pod until it is running or the operation times out,
+// the pod selected by the operation may not exist yet when the operation starts.
+func (o *operation) resolve(ctx context.Context, bindings apis.Bindings) (target, error) {
+	var resolved target
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		resolved, lastErr = resolveTarget(ctx, o.compilers, o.client, bindings, o.namespace, o.exec)
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return resolved, nil
+	}
+	if lastErr != nil {
+		return target{}, lastErr
+	}
+	return target{}, err
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, target target) (_outputs outputs.Outputs, _err error) {
+	logger := logging.FromContext(ctx)
+	var output internal.CommandOutput
+	defer func() {
+		if sections := output.Sections(); len(sections) != 0 {
+			logger.Log(logging.Exec, logging.LogStatus, color.BoldFgCyan, sections...)
+		}
+	}()
+	err := o.executor.Exec(ctx, target.namespace, target.pod, target.container, o.exec.Command, &output.Stdout, &output.Stderr)
+	bindings = apibindings.RegisterBinding(ctx, bindings, "stdout", output.Out())
+	bindings = apibindings.RegisterBinding(ctx, bindings, "stderr", output.Err())
+	if err == nil {
+		bindings = apibindings.RegisterBinding(ctx, bindings, "error", nil)
+	} else {
+		bindings = apibindings.RegisterBinding(ctx, bindings, "error", err.Error())
+	}
+	defer func(bindings apis.Bindings) {
+		if _err == nil {
+			outputs, err := outputs.Process(ctx, o.compilers, bindings, nil, o.exec.Outputs...)
+			if err != nil {
+				_err = err
+				return
+			}
+			_outputs = outputs
+		}
+	}(bindings)
+	if o.exec.Check == nil || o.exec.Check.IsNil() {
+		return nil, err
+	}
+	if errs, err := checks.Check(ctx, o.compilers, nil, bindings, o.exec.Check); err != nil {
+		return nil, err
+	} else {
+		return nil, errs.ToAggregate()
+	}
+}
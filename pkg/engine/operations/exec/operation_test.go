@@ -0,0 +1,229 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+type fakeExecutor struct {
+	targets []string
+	stdout  string
+	stderr  string
+	err     error
+}
+
+func (e *fakeExecutor) Exec(_ context.Context, namespace, pod, container string, command []string, stdout, stderr io.Writer) error {
+	e.targets = append(e.targets, fmt.Sprintf("%s/%s/%s: %s", namespace, pod, container, strings.Join(command, " ")))
+	if _, err := io.WriteString(stdout, e.stdout); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(stderr, e.stderr); err != nil {
+		return err
+	}
+	return e.err
+}
+
+func pod(name string, phase string, annotations map[string]string, containers ...string) unstructured.Unstructured {
+	var specContainers []any
+	for _, container := range containers {
+		specContainers = append(specContainers, map[string]any{"name": container})
+	}
+	obj := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": name,
+			},
+			"spec": map[string]any{
+				"containers": specContainers,
+			},
+			"status": map[string]any{
+				"phase": phase,
+			},
+		},
+	}
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func Test_operationExec(t *testing.T) {
+	outputs := v1alpha1.ActionOutputs{
+		Outputs: []v1alpha1.Output{{
+			Binding: v1alpha1.Binding{
+				Name:  "hostname",
+				Value: v1alpha1.NewProjection("($stdout)"),
+			},
+		}},
+	}
+	bySelector := v1alpha1.Exec{
+		ActionObjectSelector: v1alpha1.ActionObjectSelector{
+			Selector: "app=test",
+		},
+		ActionOutputs: outputs,
+		Command:       []string{"cat", "/etc/hostname"},
+	}
+	byName := v1alpha1.Exec{
+		ActionObjectSelector: v1alpha1.ActionObjectSelector{
+			ObjectName: v1alpha1.ObjectName{
+				Name:      "app-0",
+				Namespace: "other",
+			},
+		},
+		ActionOutputs: outputs,
+		Container:     "sidecar",
+		Command:       []string{"hostname"},
+	}
+	tests := []struct {
+		name        string
+		exec        v1alpha1.Exec
+		pods        []unstructured.Unstructured
+		lists       int
+		executor    *fakeExecutor
+		wantTargets []string
+		want        any
+		wantErr     string
+	}{{
+		name:        "running pod",
+		exec:        bySelector,
+		pods:        []unstructured.Unstructured{pod("app-1", "Running", nil, "app"), pod("app-0", "Running", nil, "app", "sidecar")},
+		executor:    &fakeExecutor{stdout: "app-0\n"},
+		wantTargets: []string{"chainsaw/app-0/app: cat /etc/hostname"},
+		want:        "app-0\n",
+	}, {
+		name:        "default container annotation",
+		exec:        bySelector,
+		pods:        []unstructured.Unstructured{pod("app-0", "Running", map[string]string{defaultContainerAnnotation: "sidecar"}, "app", "sidecar")},
+		executor:    &fakeExecutor{stdout: "app-0\n"},
+		wantTargets: []string{"chainsaw/app-0/sidecar: cat /etc/hostname"},
+		want:        "app-0\n",
+	}, {
+		name:        "pod running later",
+		exec:        bySelector,
+		pods:        []unstructured.Unstructured{pod("app-0", "Running", nil, "app")},
+		lists:       2,
+		executor:    &fakeExecutor{stdout: "app-0\n"},
+		wantTargets: []string{"chainsaw/app-0/app: cat /etc/hostname"},
+		want:        "app-0\n",
+	}, {
+		name:     "no running pod",
+		exec:     bySelector,
+		pods:     []unstructured.Unstructured{pod("app-0", "Pending", nil, "app")},
+		executor: &fakeExecutor{},
+		wantErr:  "no running pod matches selector app=test",
+	}, {
+		name:        "by name",
+		exec:        byName,
+		pods:        []unstructured.Unstructured{pod("app-0", "Running", nil, "app", "sidecar")},
+		executor:    &fakeExecutor{stdout: "app-0\n"},
+		wantTargets: []string{"other/app-0/sidecar: hostname"},
+		want:        "app-0\n",
+	}, {
+		name:     "by name not running",
+		exec:     byName,
+		pods:     []unstructured.Unstructured{pod("app-0", "Pending", nil, "app")},
+		executor: &fakeExecutor{},
+		wantErr:  "pod other/app-0 is not running",
+	}, {
+		name:     "by name not found",
+		exec:     byName,
+		executor: &fakeExecutor{},
+		wantErr:  `pods "app-0" not found`,
+	}, {
+		name:        "command failure",
+		exec:        bySelector,
+		pods:        []unstructured.Unstructured{pod("app-0", "Running", nil, "app")},
+		executor:    &fakeExecutor{stderr: "cat: /etc/hostname: No such file or directory\n", err: errors.New("command terminated with exit code 1")},
+		wantTargets: []string{"chainsaw/app-0/app: cat /etc/hostname"},
+		wantErr:     "command terminated with exit code 1",
+	}, {
+		name: "command failure with check",
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: bySelector.ActionObjectSelector,
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: ptr.To(v1alpha1.NewCheck(map[string]any{
+					"($error)":                            "command terminated with exit code 1",
+					"(contains($stderr, 'No such file'))": true,
+				})),
+			},
+			Command: bySelector.Command,
+		},
+		pods:        []unstructured.Unstructured{pod("app-0", "Running", nil, "app")},
+		executor:    &fakeExecutor{stderr: "cat: /etc/hostname: No such file or directory\n", err: errors.New("command terminated with exit code 1")},
+		wantTargets: []string{"chainsaw/app-0/app: cat /etc/hostname"},
+	}, {
+		name:     "no command",
+		exec:     v1alpha1.Exec{ActionObjectSelector: bySelector.ActionObjectSelector},
+		executor: &fakeExecutor{},
+		wantErr:  "a command must be specified",
+	}, {
+		name: "name and selector",
+		exec: v1alpha1.Exec{
+			ActionObjectSelector: v1alpha1.ActionObjectSelector{
+				ObjectName: v1alpha1.ObjectName{Name: "app-0"},
+				Selector:   "app=test",
+			},
+			Command: []string{"ls"},
+		},
+		executor: &fakeExecutor{},
+		wantErr:  "name cannot be provided when a selector is specified",
+	}, {
+		name:     "no name nor selector",
+		exec:     v1alpha1.Exec{Command: []string{"ls"}},
+		executor: &fakeExecutor{},
+		wantErr:  "a name or selector must be specified",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := &tclient.FakeClient{
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					assert.Equal(t, "other", key.Namespace)
+					for _, pod := range tt.pods {
+						if pod.GetName() == key.Name {
+							obj.(*unstructured.Unstructured).Object = pod.DeepCopy().Object
+							return nil
+						}
+					}
+					return kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, key.Name)
+				},
+				ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+					calls++
+					if calls > tt.lists {
+						list.(*unstructured.UnstructuredList).Items = tt.pods
+					}
+					return nil
+				},
+			}
+			ctx := logging.IntoContext(context.TODO(), &tlogging.FakeLogger{})
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, client, tt.exec, "chainsaw", tt.executor)
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, outputs["hostname"])
+			}
+			assert.Equal(t, tt.wantTargets, tt.executor.targets)
+		})
+	}
+}
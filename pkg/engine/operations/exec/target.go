@@ -0,0 +1,144 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// defaultContainerAnnotation is the annotation kubectl uses to select the default container of a pod.
+const defaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+type target struct {
+	namespace string
+	pod       string
+	container string
+}
+
+func (t target) String() string {
+	return fmt.Sprintf("%s/%s (%s)", t.namespace, t.pod, t.container)
+}
+
+// resolveTarget returns the pod and container a command runs in.
+// When a selector is specified, the command runs in the first running pod matching it (sorted by name),
+// pods are looked up in the given namespace if the exec doesn't specify one.
+// The default container of the pod is used if the exec doesn't specify one.
+func resolveTarget(ctx context.Context, compilers compilers.Compilers, c client.Client, bindings apis.Bindings, namespace string, exec v1alpha1.Exec) (target, error) {
+	if len(exec.Command) == 0 {
+		return target{}, errors.New("a command must be specified")
+	}
+	name, err := exec.Name.Value(ctx, compilers, bindings)
+	if err != nil {
+		return target{}, err
+	}
+	ns, err := exec.Namespace.Value(ctx, compilers, bindings)
+	if err != nil {
+		return target{}, err
+	}
+	selector, err := exec.Selector.Value(ctx, compilers, bindings)
+	if err != nil {
+		return target{}, err
+	}
+	container, err := exec.Container.Value(ctx, compilers, bindings)
+	if err != nil {
+		return target{}, err
+	}
+	if name == "" && selector == "" {
+		return target{}, errors.New("a name or selector must be specified")
+	}
+	if name != "" && selector != "" {
+		return target{}, errors.New("name cannot be provided when a selector is specified")
+	}
+	if ns != "" {
+		namespace = ns
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	var pod *unstructured.Unstructured
+	if selector != "" {
+		pod, err = selectPod(ctx, c, namespace, selector)
+	} else {
+		pod, err = getPod(ctx, c, namespace, name)
+	}
+	if err != nil {
+		return target{}, err
+	}
+	if container == "" {
+		container = defaultContainer(pod)
+	}
+	return target{
+		namespace: namespace,
+		pod:       pod.GetName(),
+		container: container,
+	}, nil
+}
+
+// getPod returns the pod with the given name, the pod must be running.
+func getPod(ctx context.Context, c client.Client, namespace string, name string) (*unstructured.Unstructured, error) {
+	var pod unstructured.Unstructured
+	pod.SetAPIVersion("v1")
+	pod.SetKind("Pod")
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &pod); err != nil {
+		return nil, err
+	}
+	if !running(pod) {
+		return nil, fmt.Errorf("pod %s/%s is not running", namespace, name)
+	}
+	return &pod, nil
+}
+
+// selectPod returns the first running pod matching the selector.
+func selectPod(ctx context.Context, c client.Client, namespace string, selector string) (*unstructured.Unstructured, error) {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("PodList")
+	if err := c.List(ctx, &list, client.InNamespace(namespace), client.MatchingSelector{Selector: parsed}); err != nil {
+		return nil, err
+	}
+	var pods []unstructured.Unstructured
+	for _, item := range list.Items {
+		if running(item) {
+			pods = append(pods, item)
+		}
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no running pod matches selector %s", selector)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].GetName() < pods[j].GetName()
+	})
+	return &pods[0], nil
+}
+
+func running(pod unstructured.Unstructured) bool {
+	phase, _, _ := unstructured.NestedString(pod.UnstructuredContent(), "status", "phase")
+	return phase == "Running"
+}
+
+// defaultContainer returns the container designated by the default container annotation, or the first container of the pod.
+func defaultContainer(pod *unstructured.Unstructured) string {
+	if container := pod.GetAnnotations()[defaultContainerAnnotation]; container != "" {
+		return container
+	}
+	containers, _, _ := unstructured.NestedSlice(pod.UnstructuredContent(), "spec", "containers")
+	if len(containers) != 0 {
+		if container, ok := containers[0].(map[string]any); ok {
+			name, _ := container["name"].(string)
+			return name
+		}
+	}
+	return ""
+}
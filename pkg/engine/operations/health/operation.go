@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/output/color"
+)
+
+type operation struct {
+	compilers compilers.Compilers
+	health    v1alpha1.Health
+}
+
+func New(
+	compilers compilers.Compilers,
+	health v1alpha1.Health,
+) operations.Operation {
+	return &operation{
+		compilers: compilers,
+		health:    health,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		if logger != nil && errors.Is(_err, operations.ErrSkip) {
+			logger.Log(logging.Health, logging.WarnStatus, color.BoldYellow, logging.ErrSection(_err))
+		} else {
+			internal.LogEnd(logger, logging.Health, _err)
+		}
+	}()
+	internal.LogStart(logger, logging.Health)
+	url, address, err := o.target(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	// only an unreachable or unhealthy target can skip the test, other errors are configuration errors
+	var unhealthy error
+	if url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		unhealthy = o.get(req)
+	} else {
+		unhealthy = dial(ctx, address)
+	}
+	if unhealthy != nil && o.health.Skip != nil && *o.health.Skip {
+		return nil, fmt.Errorf("%w (%w)", operations.ErrSkip, unhealthy)
+	}
+	return nil, unhealthy
+}
+
+func (o *operation) target(ctx context.Context, bindings apis.Bindings) (string, string, error) {
+	url, err := o.health.URL.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", "", err
+	}
+	address, err := o.health.Address.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case url != "" && address != "":
+		return "", "", errors.New("only one of url or address can be specified")
+	case url == "" && address == "":
+		return "", "", errors.New("a url or an address must be specified")
+	}
+	return url, address, nil
+}
+
+func (o *operation) get(req *http.Request) error {
+	url := req.URL.String()
+	resp, err := httputils.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if o.health.Status != 0 {
+		if resp.StatusCode != o.health.Status {
+			return fmt.Errorf("%s returned status %d, expected %d", url, resp.StatusCode, o.health.Status)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return nil
+}
+
+func dial(ctx context.Context, address string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
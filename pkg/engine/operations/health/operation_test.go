@@ -0,0 +1,159 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	// grab a free port and release it so that nothing listens on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	unreachable := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+	address := strings.TrimPrefix(server.URL, "http://")
+	bindings := apis.NewBindings().Register("$url", apis.NewBinding(server.URL)).Register("$address", apis.NewBinding(address))
+	tests := []struct {
+		name         string
+		health       v1alpha1.Health
+		expectedLogs []string
+		expectedErr  string
+		expectedSkip bool
+	}{{
+		name: "reachable url",
+		health: v1alpha1.Health{
+			URL: v1alpha1.Expression(server.URL),
+		},
+		expectedLogs: []string{"HEALTH: RUN - []", "HEALTH: DONE - []"},
+	}, {
+		name: "url from bindings",
+		health: v1alpha1.Health{
+			URL: "(concat($url, '/ready'))",
+		},
+		expectedLogs: []string{"HEALTH: RUN - []", "HEALTH: DONE - []"},
+	}, {
+		name: "unexpected status",
+		health: v1alpha1.Health{
+			URL: v1alpha1.Expression(server.URL + "/missing"),
+		},
+		expectedErr: server.URL + "/missing returned status 404",
+	}, {
+		name: "expected status",
+		health: v1alpha1.Health{
+			URL:    v1alpha1.Expression(server.URL + "/missing"),
+			Status: http.StatusNotFound,
+		},
+		expectedLogs: []string{"HEALTH: RUN - []", "HEALTH: DONE - []"},
+	}, {
+		name: "status mismatch",
+		health: v1alpha1.Health{
+			URL:    v1alpha1.Expression(server.URL),
+			Status: http.StatusNoContent,
+		},
+		expectedErr: server.URL + " returned status 200, expected 204",
+	}, {
+		name: "unreachable url",
+		health: v1alpha1.Health{
+			URL: v1alpha1.Expression("http://" + unreachable),
+		},
+		expectedErr: "connection refused",
+	}, {
+		name: "reachable address",
+		health: v1alpha1.Health{
+			Address: "($address)",
+		},
+		expectedLogs: []string{"HEALTH: RUN - []", "HEALTH: DONE - []"},
+	}, {
+		name: "unreachable address",
+		health: v1alpha1.Health{
+			Address: v1alpha1.Expression(unreachable),
+		},
+		expectedErr: "connection refused",
+	}, {
+		name: "unreachable address with skip",
+		health: v1alpha1.Health{
+			Address: v1alpha1.Expression(unreachable),
+			Skip:    ptr.To(true),
+		},
+		expectedErr:  "connection refused",
+		expectedSkip: true,
+	}, {
+		name: "unhealthy url with skip",
+		health: v1alpha1.Health{
+			URL:  v1alpha1.Expression(server.URL + "/missing"),
+			Skip: ptr.To(true),
+		},
+		expectedErr:  server.URL + "/missing returned status 404",
+		expectedSkip: true,
+	}, {
+		name:        "missing target",
+		health:      v1alpha1.Health{},
+		expectedErr: "a url or an address must be specified",
+	}, {
+		name: "missing target with skip",
+		health: v1alpha1.Health{
+			Skip: ptr.To(true),
+		},
+		expectedErr: "a url or an address must be specified",
+	}, {
+		name: "invalid expression with skip",
+		health: v1alpha1.Health{
+			URL:  "($missing)",
+			Skip: ptr.To(true),
+		},
+		expectedErr: "variable not defined: $missing",
+	}, {
+		name: "invalid url with skip",
+		health: v1alpha1.Health{
+			URL:  "http://[::1",
+			Skip: ptr.To(true),
+		},
+		expectedErr: "missing ']' in host",
+	}, {
+		name: "both targets",
+		health: v1alpha1.Health{
+			URL:     v1alpha1.Expression(server.URL),
+			Address: v1alpha1.Expression(address),
+		},
+		expectedErr: "only one of url or address can be specified",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			operation := New(apis.DefaultCompilers, tt.health)
+			outputs, err := operation.Exec(ctx, bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedSkip, errors.Is(err, operations.ErrSkip))
+			if tt.expectedLogs != nil {
+				assert.Equal(t, tt.expectedLogs, logger.Logs)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+)
+
+func Capture(capture v1alpha1.Capture, stdout string) (any, error) {
+	switch capture.Parser {
+	case "", v1alpha1.CaptureParserString:
+		return strings.TrimSpace(stdout), nil
+	case v1alpha1.CaptureParserJSON:
+		var value any
+		if err := json.Unmarshal([]byte(stdout), &value); err != nil {
+			return nil, fmt.Errorf("failed to parse stdout as json: %w", err)
+		}
+		return value, nil
+	case v1alpha1.CaptureParserLines:
+		lines := []any{}
+		for _, line := range strings.Split(stdout, "\n") {
+			if line := strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines, nil
+	default:
+		return nil, fmt.Errorf("unsupported capture parser: %s", capture.Parser)
+	}
+}
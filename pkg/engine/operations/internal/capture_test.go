@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapture(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  v1alpha1.CaptureParser
+		stdout  string
+		want    any
+		wantErr bool
+	}{{
+		name:   "default",
+		stdout: "  hello\n",
+		want:   "hello",
+	}, {
+		name:   "string",
+		parser: v1alpha1.CaptureParserString,
+		stdout: "hello\nworld\n",
+		want:   "hello\nworld",
+	}, {
+		name:   "json object",
+		parser: v1alpha1.CaptureParserJSON,
+		stdout: `{"name":"foo","replicas":3,"tags":["a","b"]}`,
+		want: map[string]any{
+			"name":     "foo",
+			"replicas": 3.0,
+			"tags":     []any{"a", "b"},
+		},
+	}, {
+		name:   "json array",
+		parser: v1alpha1.CaptureParserJSON,
+		stdout: "[1, 2]\n",
+		want:   []any{1.0, 2.0},
+	}, {
+		name:    "invalid json",
+		parser:  v1alpha1.CaptureParserJSON,
+		stdout:  "hello",
+		wantErr: true,
+	}, {
+		name:   "lines",
+		parser: v1alpha1.CaptureParserLines,
+		stdout: "foo\r\n\n  \nbar\n",
+		want:   []any{"foo", "bar"},
+	}, {
+		name:   "empty lines",
+		parser: v1alpha1.CaptureParserLines,
+		stdout: "",
+		want:   []any{},
+	}, {
+		name:    "unsupported",
+		parser:  "yaml",
+		stdout:  "foo: bar",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Capture(v1alpha1.Capture{As: "out", Parser: tt.parser}, tt.stdout)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
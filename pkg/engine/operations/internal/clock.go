@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"k8s.io/utils/clock"
+)
+
+// Clock returns the clock registered in the bindings, it defaults to the real clock.
+func Clock(bindings apis.Bindings) clock.PassiveClock {
+	if bindings != nil {
+		if binding, err := bindings.Get("$clock"); err == nil {
+			if value, err := binding.Value(); err == nil {
+				if clock, ok := value.(clock.PassiveClock); ok {
+					return clock
+				}
+			}
+		}
+	}
+	return clock.RealClock{}
+}
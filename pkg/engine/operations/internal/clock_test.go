@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/clock"
+	tclock "k8s.io/utils/clock/testing"
+)
+
+func TestClock(t *testing.T) {
+	fake := tclock.NewFakePassiveClock(time.Unix(0, 0))
+	tests := []struct {
+		name     string
+		bindings apis.Bindings
+		want     clock.PassiveClock
+	}{{
+		name:     "nil",
+		bindings: nil,
+		want:     clock.RealClock{},
+	}, {
+		name:     "not registered",
+		bindings: apis.NewBindings(),
+		want:     clock.RealClock{},
+	}, {
+		name:     "not a clock",
+		bindings: apis.NewBindings().Register("$clock", apis.NewBinding("foo")),
+		want:     clock.RealClock{},
+	}, {
+		name:     "registered",
+		bindings: apis.NewBindings().Register("$clock", apis.NewBinding(fake)),
+		want:     fake,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Clock(tt.bindings))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+)
+
+// Compare compares a number with an expected value, the Unchanged operator is not supported.
+func Compare(operator v1alpha1.DeltaOperator, actual float64, expected float64) (bool, error) {
+	switch operator {
+	case v1alpha1.DeltaOperatorEqual:
+		return actual == expected, nil
+	case v1alpha1.DeltaOperatorNotEqual:
+		return actual != expected, nil
+	case v1alpha1.DeltaOperatorGreaterThan:
+		return actual > expected, nil
+	case v1alpha1.DeltaOperatorGreaterThanOrEqual:
+		return actual >= expected, nil
+	case v1alpha1.DeltaOperatorLessThan:
+		return actual < expected, nil
+	case v1alpha1.DeltaOperatorLessThanOrEqual:
+		return actual <= expected, nil
+	}
+	return false, fmt.Errorf("unsupported operator %s", operator)
+}
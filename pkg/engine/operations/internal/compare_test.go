@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		operator v1alpha1.DeltaOperator
+		actual   float64
+		expected float64
+		want     bool
+		wantErr  bool
+	}{
+		{operator: "Equal", actual: 1, expected: 1, want: true},
+		{operator: "Equal", actual: 1, expected: 2, want: false},
+		{operator: "NotEqual", actual: 1, expected: 2, want: true},
+		{operator: "GreaterThan", actual: 2, expected: 1, want: true},
+		{operator: "GreaterThan", actual: 1, expected: 1, want: false},
+		{operator: "GreaterThanOrEqual", actual: 1, expected: 1, want: true},
+		{operator: "LessThan", actual: 1, expected: 2, want: true},
+		{operator: "LessThanOrEqual", actual: 3, expected: 2, want: false},
+		{operator: "Unchanged", actual: 1, expected: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.operator), func(t *testing.T) {
+			got, err := Compare(tt.operator, tt.actual, tt.expected)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
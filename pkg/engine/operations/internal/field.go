@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/expressions"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Field evaluates a field expression against a resource.
+func Field(ctx context.Context, c compilers.Compilers, obj unstructured.Unstructured, field v1alpha1.Expression, bindings apis.Bindings) (any, error) {
+	expression := expressions.Parse(ctx, string(field))
+	if expression == nil {
+		return nil, fmt.Errorf("field must be an expression (%s)", field)
+	}
+	compiler := c.Compiler(expression.Engine)
+	if compiler == nil {
+		return nil, fmt.Errorf("field must be an expression (%s)", field)
+	}
+	return compilers.Execute(expression.Statement, obj.UnstructuredContent(), bindings, compiler)
+}
+
+// NumericField evaluates a field expression against a resource, the result must be a number.
+func NumericField(ctx context.Context, c compilers.Compilers, obj unstructured.Unstructured, field v1alpha1.Expression, bindings apis.Bindings) (float64, error) {
+	value, err := Field(ctx, c, obj, field, bindings)
+	if err != nil {
+		return 0, err
+	}
+	if number, ok := ToNumber(value); ok {
+		return number, nil
+	}
+	return 0, fmt.Errorf("field didn't evaluate to a number (%s)", field)
+}
+
+// ToNumber converts a numeric value to a float64.
+func ToNumber(value any) (float64, bool) {
+	switch value := value.(type) {
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	}
+	return 0, false
+}
@@ -30,7 +30,9 @@ func LogStart(logger logging.Logger, op logging.Operation, args ...fmt.Stringer)
 
 func LogEnd(logger logging.Logger, op logging.Operation, err error) {
 	if logger != nil {
-		if err != nil {
+		if IsQuotaExceeded(err) {
+			logger.Log(op, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err), logging.Section("QUOTA", "resource quota exceeded, the test may be leaking resources"))
+		} else if err != nil {
 			logger.Log(op, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 		} else {
 			logger.Log(op, logging.DoneStatus, color.BoldGreen)
@@ -8,7 +8,9 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
 	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestGetLogger(t *testing.T) {
@@ -70,4 +72,11 @@ func TestLogEnd(t *testing.T) {
 		LogEnd(l, "aaa", errors.New("some error"))
 		assert.Equal(t, []string{"aaa: ERROR - [=== ERROR\nsome error]"}, logger.Logs)
 	}
+	{
+		logger := &tlogging.FakeLogger{}
+		ctx := logging.IntoContext(context.TODO(), logger)
+		l := GetLogger(ctx, nil)
+		LogEnd(l, "aaa", kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "hello", errors.New("exceeded quota: chainsaw")))
+		assert.Equal(t, []string{"aaa: ERROR - [=== ERROR\npods \"hello\" is forbidden: exceeded quota: chainsaw === QUOTA\nresource quota exceeded, the test may be leaking resources]"}, logger.Logs)
+	}
 }
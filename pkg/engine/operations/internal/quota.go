@@ -0,0 +1,12 @@
+package internal
+
+import (
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// IsQuotaExceeded returns true when the error was caused by a resource quota being exceeded.
+func IsQuotaExceeded(err error) bool {
+	return err != nil && kerrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota")
+}
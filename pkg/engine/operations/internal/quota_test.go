@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsQuotaExceeded(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{{
+		name: "nil",
+		err:  nil,
+		want: false,
+	}, {
+		name: "not forbidden",
+		err:  errors.New("exceeded quota: chainsaw"),
+		want: false,
+	}, {
+		name: "forbidden",
+		err:  kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "foo", errors.New("not allowed")),
+		want: false,
+	}, {
+		name: "quota exceeded",
+		err:  kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "foo", errors.New("exceeded quota: chainsaw, requested: pods=1, used: pods=1, limited: pods=1")),
+		want: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsQuotaExceeded(tt.err))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func CheckRendered(ctx context.Context, compilers compilers.Compilers, obj unstructured.Unstructured, bindings apis.Bindings, rendered *v1alpha1.Check) error {
+	if rendered == nil || rendered.IsNil() {
+		return nil
+	}
+	errs, err := checks.Check(ctx, compilers, obj.UnstructuredContent(), bindings, rendered)
+	if err != nil {
+		return err
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("rendered resource doesn't match: %w", errs.ToAggregate())
+	}
+	return nil
+}
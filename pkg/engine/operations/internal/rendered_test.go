@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func TestCheckRendered(t *testing.T) {
+	obj := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data": map[string]any{
+				"foo": "bar",
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		rendered *v1alpha1.Check
+		wantErr  string
+	}{{
+		name: "nil",
+	}, {
+		name:     "empty",
+		rendered: &v1alpha1.Check{},
+	}, {
+		name:     "match",
+		rendered: ptr.To(v1alpha1.NewCheck(map[string]any{"data": map[string]any{"foo": "bar"}})),
+	}, {
+		name:     "no match",
+		rendered: ptr.To(v1alpha1.NewCheck(map[string]any{"data": map[string]any{"foo": "baz"}})),
+		wantErr:  "rendered resource doesn't match: data.foo: Invalid value: \"bar\": Expected value: \"baz\"",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRendered(context.TODO(), apis.DefaultCompilers, obj, apis.NewBindings(), tt.rendered)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const podRestarts v1alpha1.Expression = "(x_pod_restarts(@))"
+
+// PodRestarts returns the container restart counts of the pods selected by a workload, indexed by pod uid.
+func PodRestarts(ctx context.Context, c compilers.Compilers, cl client.Client, workload unstructured.Unstructured, bindings apis.Bindings) (map[string]any, error) {
+	content, found, err := unstructured.NestedMap(workload.UnstructuredContent(), "spec", "selector")
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%s %s has no selector", workload.GetKind(), workload.GetName())
+	}
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &labelSelector); err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("PodList")
+	if err := cl.List(ctx, &list, client.InNamespace(workload.GetNamespace()), client.MatchingSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+	restarts := map[string]any{}
+	for _, pod := range list.Items {
+		count, err := NumericField(ctx, c, pod, podRestarts, bindings)
+		if err != nil {
+			return nil, err
+		}
+		restarts[string(pod.GetUID())] = count
+	}
+	return restarts, nil
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPodRestarts(t *testing.T) {
+	workload := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]any{
+				"name":      "foo",
+				"namespace": "bar",
+			},
+			"spec": map[string]any{
+				"selector": map[string]any{
+					"matchLabels": map[string]any{
+						"app": "foo",
+					},
+				},
+			},
+		},
+	}
+	pod := func(uid string, restarts ...int64) unstructured.Unstructured {
+		var statuses []any
+		for _, count := range restarts {
+			statuses = append(statuses, map[string]any{"restartCount": count})
+		}
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"metadata": map[string]any{"uid": uid},
+				"status":   map[string]any{"containerStatuses": statuses},
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		workload unstructured.Unstructured
+		pods     []unstructured.Unstructured
+		listErr  error
+		want     map[string]any
+		wantErr  string
+	}{{
+		name:     "no pods",
+		workload: workload,
+		want:     map[string]any{},
+	}, {
+		name:     "pods",
+		workload: workload,
+		pods:     []unstructured.Unstructured{pod("a", 1, 2), pod("b")},
+		want:     map[string]any{"a": float64(3), "b": float64(0)},
+	}, {
+		name:     "list error",
+		workload: workload,
+		listErr:  errors.New("dummy"),
+		wantErr:  "dummy",
+	}, {
+		name: "no selector",
+		workload: unstructured.Unstructured{
+			Object: map[string]any{
+				"kind":     "Deployment",
+				"metadata": map[string]any{"name": "foo"},
+			},
+		},
+		wantErr: "Deployment foo has no selector",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				ListFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+					var options ctrlclient.ListOptions
+					options.ApplyOptions(opts)
+					assert.Equal(t, "bar", options.Namespace)
+					assert.Equal(t, "app=foo", options.LabelSelector.String())
+					list.(*unstructured.UnstructuredList).Items = tt.pods
+					return tt.listErr
+				},
+			}
+			got, err := PodRestarts(context.TODO(), apis.DefaultCompilers, fakeClient, tt.workload, apis.NewBindings())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
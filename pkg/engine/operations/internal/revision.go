@@ -0,0 +1,108 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// ErrRevisionNotFound is returned when the requested revision doesn't exist (yet).
+var ErrRevisionNotFound = errors.New("revision not found")
+
+type revisionEntry struct {
+	revision int64
+	template map[string]any
+}
+
+// Revision returns a copy of the actual resource with its pod template replaced by the one recorded in the given revision.
+// Deployment revisions are read from their ReplicaSets, StatefulSet and DaemonSet revisions are read from their ControllerRevisions.
+// A zero or negative revision is relative to the latest one (0 is the latest, -1 the previous one, etc...).
+func Revision(ctx context.Context, c client.Client, actual unstructured.Unstructured, revision int64) (*unstructured.Unstructured, error) {
+	entries, err := revisions(ctx, c, actual)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(entries, func(a, b revisionEntry) int {
+		switch {
+		case a.revision > b.revision:
+			return -1
+		case a.revision < b.revision:
+			return 1
+		}
+		return 0
+	})
+	var found *revisionEntry
+	if revision > 0 {
+		for i := range entries {
+			if entries[i].revision == revision {
+				found = &entries[i]
+				break
+			}
+		}
+	} else if index := int(-revision); index < len(entries) {
+		found = &entries[index]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w (%d)", ErrRevisionNotFound, revision)
+	}
+	result := actual.DeepCopy()
+	if err := unstructured.SetNestedField(result.Object, found.template, "spec", "template"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func revisions(ctx context.Context, c client.Client, actual unstructured.Unstructured) ([]revisionEntry, error) {
+	gvk := actual.GroupVersionKind()
+	switch gvk.GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		return ownedRevisions(ctx, c, actual, "ReplicaSet", func(obj unstructured.Unstructured) (revisionEntry, bool) {
+			revision, err := strconv.ParseInt(obj.GetAnnotations()[deploymentRevisionAnnotation], 10, 64)
+			if err != nil {
+				return revisionEntry{}, false
+			}
+			template, _, _ := unstructured.NestedMap(obj.Object, "spec", "template")
+			return revisionEntry{revision: revision, template: template}, true
+		})
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		return ownedRevisions(ctx, c, actual, "ControllerRevision", func(obj unstructured.Unstructured) (revisionEntry, bool) {
+			revision, found, err := unstructured.NestedInt64(obj.Object, "revision")
+			if err != nil || !found {
+				return revisionEntry{}, false
+			}
+			template, _, _ := unstructured.NestedMap(obj.Object, "data", "spec", "template")
+			// controller revisions store a strategic merge patch
+			delete(template, "$patch")
+			return revisionEntry{revision: revision, template: template}, true
+		})
+	}
+	return nil, fmt.Errorf("revisions are not supported for %s", gvk.Kind)
+}
+
+func ownedRevisions(ctx context.Context, c client.Client, owner unstructured.Unstructured, kind string, parse func(unstructured.Unstructured) (revisionEntry, bool)) ([]revisionEntry, error) {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: kind + "List"})
+	if err := c.List(ctx, &list, client.InNamespace(owner.GetNamespace())); err != nil {
+		return nil, err
+	}
+	var entries []revisionEntry
+	for _, item := range list.Items {
+		for _, ref := range item.GetOwnerReferences() {
+			if ref.UID == owner.GetUID() {
+				if entry, ok := parse(item); ok {
+					entries = append(entries, entry)
+				}
+				break
+			}
+		}
+	}
+	return entries, nil
+}
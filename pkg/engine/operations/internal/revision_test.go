@@ -0,0 +1,153 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRevision(t *testing.T) {
+	template := func(image string) map[string]any {
+		return map[string]any{
+			"spec": map[string]any{
+				"containers": []any{
+					map[string]any{
+						"name":  "app",
+						"image": image,
+					},
+				},
+			},
+		}
+	}
+	owner := func(kind string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       kind,
+				"metadata": map[string]any{
+					"name":      "test",
+					"namespace": "default",
+					"uid":       "owner-uid",
+				},
+				"spec": map[string]any{
+					"template": template("app:v2"),
+				},
+			},
+		}
+	}
+	ownerReferences := func(uid string) []any {
+		return []any{
+			map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "StatefulSet",
+				"name":       "test",
+				"uid":        uid,
+			},
+		}
+	}
+	controllerRevision := func(revision int64, uid string, image string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "ControllerRevision",
+				"metadata": map[string]any{
+					"name":            "test-rev",
+					"ownerReferences": ownerReferences(uid),
+				},
+				"revision": revision,
+				"data": map[string]any{
+					"spec": map[string]any{
+						"template": map[string]any{
+							"$patch": "replace",
+							"spec":   template(image)["spec"],
+						},
+					},
+				},
+			},
+		}
+	}
+	revisions := &tclient.FakeClient{
+		ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+			assert.Equal(t, "ControllerRevisionList", list.GetObjectKind().GroupVersionKind().Kind)
+			list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{
+				controllerRevision(2, "owner-uid", "app:v2"),
+				controllerRevision(1, "owner-uid", "app:v1"),
+				controllerRevision(1, "other-uid", "other:v1"),
+			}
+			return nil
+		},
+	}
+	tests := []struct {
+		name     string
+		actual   unstructured.Unstructured
+		client   *tclient.FakeClient
+		revision int64
+		want     map[string]any
+		wantErr  error
+	}{{
+		name:     "latest",
+		actual:   owner("StatefulSet"),
+		client:   revisions,
+		revision: 0,
+		want:     template("app:v2"),
+	}, {
+		name:     "previous",
+		actual:   owner("StatefulSet"),
+		client:   revisions,
+		revision: -1,
+		want:     template("app:v1"),
+	}, {
+		name:     "absolute",
+		actual:   owner("DaemonSet"),
+		client:   revisions,
+		revision: 1,
+		want:     template("app:v1"),
+	}, {
+		name:     "not found",
+		actual:   owner("StatefulSet"),
+		client:   revisions,
+		revision: -2,
+		wantErr:  ErrRevisionNotFound,
+	}, {
+		name:     "unsupported",
+		actual:   owner("ReplicaSet"),
+		client:   revisions,
+		revision: 1,
+		wantErr:  errors.New("revisions are not supported for ReplicaSet"),
+	}, {
+		name:   "list error",
+		actual: owner("StatefulSet"),
+		client: &tclient.FakeClient{
+			ListFn: func(ctx context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+				return errors.New("list failed")
+			},
+		},
+		revision: 1,
+		wantErr:  errors.New("list failed"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Revision(context.TODO(), tt.client, tt.actual, tt.revision)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.wantErr, ErrRevisionNotFound) {
+					assert.ErrorIs(t, err, ErrRevisionNotFound)
+				} else {
+					assert.EqualError(t, err, tt.wantErr.Error())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			actual, _, err := unstructured.NestedMap(got.Object, "spec", "template")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, actual)
+			// the actual resource must not be modified
+			assert.Equal(t, template("app:v2"), tt.actual.Object["spec"].(map[string]any)["template"])
+		})
+	}
+}
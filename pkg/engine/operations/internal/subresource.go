@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Subresource fetches the given subresource (scale or status) of the actual resource through the subresource client.
+// A not found error is reported as the subresource not being supported by the resource kind.
+func Subresource(ctx context.Context, c client.Client, actual unstructured.Unstructured, subresource string) (*unstructured.Unstructured, error) {
+	var result unstructured.Unstructured
+	switch subresource {
+	case "scale":
+		result.SetAPIVersion("autoscaling/v1")
+		result.SetKind("Scale")
+	case "status":
+		result.SetAPIVersion(actual.GetAPIVersion())
+		result.SetKind(actual.GetKind())
+	default:
+		return nil, fmt.Errorf("unsupported subresource %s (expected scale or status)", subresource)
+	}
+	if err := c.SubResource(subresource).Get(ctx, &actual, &result); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("subresource %s is not supported by kind %s", subresource, actual.GetKind())
+		}
+		return nil, err
+	}
+	return &result, nil
+}
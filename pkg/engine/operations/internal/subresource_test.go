@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSubresource(t *testing.T) {
+	actual := func(kind string) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       kind,
+				"metadata": map[string]any{
+					"name":      "test",
+					"namespace": "default",
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		actual      unstructured.Unstructured
+		subresource string
+		getErr      error
+		want        *unstructured.Unstructured
+		wantErr     string
+	}{{
+		name:        "scale",
+		actual:      actual("Deployment"),
+		subresource: "scale",
+		want: &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "autoscaling/v1",
+				"kind":       "Scale",
+				"spec": map[string]any{
+					"replicas": int64(3),
+				},
+			},
+		},
+	}, {
+		name:        "status",
+		actual:      actual("Deployment"),
+		subresource: "status",
+		want: &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"spec": map[string]any{
+					"replicas": int64(3),
+				},
+			},
+		},
+	}, {
+		name:        "unsupported subresource",
+		actual:      actual("Deployment"),
+		subresource: "exec",
+		wantErr:     "unsupported subresource exec (expected scale or status)",
+	}, {
+		name:        "not supported by kind",
+		actual:      actual("ConfigMap"),
+		subresource: "scale",
+		getErr:      kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps/scale"}, "test"),
+		wantErr:     "subresource scale is not supported by kind ConfigMap",
+	}, {
+		name:        "error",
+		actual:      actual("Deployment"),
+		subresource: "scale",
+		getErr:      errors.New("dummy"),
+		wantErr:     "dummy",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &tclient.FakeClient{
+				SubResourceFn: func(call int, subResource string) client.SubResourceClient {
+					assert.Equal(t, tt.subresource, subResource)
+					return &tclient.FakeSubResourceClient{
+						GetFn: func(ctx context.Context, call int, obj client.Object, subResource client.Object, opts ...ctrlclient.SubResourceGetOption) error {
+							if tt.getErr != nil {
+								return tt.getErr
+							}
+							u := subResource.(*unstructured.Unstructured)
+							return unstructured.SetNestedField(u.Object, int64(3), "spec", "replicas")
+						},
+					}
+				},
+			}
+			got, err := Subresource(context.TODO(), c, tt.actual, tt.subresource)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
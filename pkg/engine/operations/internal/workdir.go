@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+func WorkDir(ctx context.Context, compilers compilers.Compilers, bindings apis.Bindings, basePath string, workDir *v1alpha1.Expression) (string, error) {
+	if workDir == nil {
+		return basePath, nil
+	}
+	dir, err := workDir.Value(ctx, compilers, bindings)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(basePath, dir)
+	}
+	if info, err := os.Stat(dir); err != nil {
+		return "", err
+	} else if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", dir)
+	}
+	return dir, nil
+}
@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestWorkDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		workDir  *v1alpha1.Expression
+		bindings apis.Bindings
+		want     string
+		wantErr  bool
+	}{{
+		name:     "nil",
+		basePath: "..",
+		want:     "..",
+	}, {
+		name:     "relative",
+		basePath: "..",
+		workDir:  ptr.To(v1alpha1.Expression("internal")),
+		want:     "../internal",
+	}, {
+		name:     "absolute",
+		basePath: "..",
+		workDir:  ptr.To(v1alpha1.Expression("/")),
+		want:     "/",
+	}, {
+		name:     "templated",
+		basePath: "..",
+		workDir:  ptr.To(v1alpha1.Expression("($dir)")),
+		bindings: apis.NewBindings().Register("$dir", apis.NewBinding("internal")),
+		want:     "../internal",
+	}, {
+		name:     "not found",
+		basePath: "..",
+		workDir:  ptr.To(v1alpha1.Expression("foo")),
+		wantErr:  true,
+	}, {
+		name:     "not a directory",
+		basePath: "..",
+		workDir:  ptr.To(v1alpha1.Expression("internal/workdir.go")),
+		wantErr:  true,
+	}, {
+		name:     "bad expression",
+		basePath: "..",
+		workDir:  ptr.To(v1alpha1.Expression("($foo)")),
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindings := tt.bindings
+			if bindings == nil {
+				bindings = apis.NewBindings()
+			}
+			got, err := WorkDir(context.TODO(), apis.DefaultCompilers, bindings, tt.basePath, tt.workDir)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// LogsFactory creates the operation collecting the logs of the pods matching a label selector.
+// The check is evaluated against the collected logs.
+type LogsFactory = func(ctx context.Context, selector string, namespace string, check v1alpha1.Check) (operations.Operation, error)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	job        v1alpha1.Job
+	logs       LogsFactory
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	job v1alpha1.Job,
+	logs LogsFactory,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		job:        job,
+		logs:       logs,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	obj, err := o.object(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	logger := internal.GetLogger(ctx, obj)
+	defer func() {
+		internal.LogEnd(logger, logging.Job, _err)
+	}()
+	if err := internal.ApplyNamespacer(o.namespacer, o.client, obj); err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Job)
+	job, err := o.wait(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, o.checkLogs(ctx, bindings, job)
+}
+
+func (o *operation) object(ctx context.Context, bindings apis.Bindings) (*unstructured.Unstructured, error) {
+	name, err := o.job.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errors.New("a job name must be specified")
+	}
+	namespace, err := o.job.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion("batch/v1")
+	if o.job.CronJob {
+		obj.SetKind("CronJob")
+	} else {
+		obj.SetKind("Job")
+	}
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj, nil
+}
+
+// wait polls the job until it completes, a failed job stops the operation immediately.
+func (o *operation) wait(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var job *unstructured.Unstructured
+	var lastErr, failure error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		current, err := o.current(ctx, obj)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		complete, failed := status(current)
+		if failed != "" {
+			failure = fmt.Errorf("job %s failed: %s", current.GetName(), failed)
+			return false, failure
+		}
+		if !complete {
+			lastErr = fmt.Errorf("job %s is not complete", current.GetName())
+			return false, nil
+		}
+		job = current
+		return true, nil
+	})
+	if err == nil {
+		return job, nil
+	}
+	if failure != nil {
+		return nil, failure
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, err
+}
+
+// current returns the job to wait for, for a cron job it is the latest job created by the cron job.
+func (o *operation) current(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	var actual unstructured.Unstructured
+	actual.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := o.client.Get(ctx, client.Key(obj), &actual); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%s %s not found", obj.GetKind(), client.Name(client.Key(obj)))
+		}
+		return nil, err
+	}
+	if !o.job.CronJob {
+		return &actual, nil
+	}
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("batch/v1")
+	list.SetKind("JobList")
+	if err := o.client.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, err
+	}
+	var latest *unstructured.Unstructured
+	for i := range list.Items {
+		item := &list.Items[i]
+		owned := false
+		for _, owner := range item.GetOwnerReferences() {
+			if owner.UID == actual.GetUID() {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			continue
+		}
+		if latest == nil || latest.GetCreationTimestamp().Time.Before(item.GetCreationTimestamp().Time) {
+			latest = item
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no job created by cron job %s yet", client.Name(client.Key(obj)))
+	}
+	return latest, nil
+}
+
+func (o *operation) checkLogs(ctx context.Context, bindings apis.Bindings, job *unstructured.Unstructured) error {
+	if len(o.job.Logs) == 0 {
+		return nil
+	}
+	patterns := make([]any, 0, len(o.job.Logs))
+	for _, pattern := range o.job.Logs {
+		patterns = append(patterns, pattern)
+	}
+	bindings = bindings.Register("$patterns", apis.NewBinding(patterns))
+	check := v1alpha1.NewCheck(map[string]any{
+		"(x_match_sequence($stdout, $patterns))": true,
+	})
+	op, err := o.logs(ctx, fmt.Sprintf("job-name=%s", job.GetName()), job.GetNamespace(), check)
+	if err != nil {
+		return err
+	}
+	_, err = op.Exec(ctx, bindings)
+	return err
+}
+
+// status returns whether the job completed, or the reason why it failed.
+func status(job *unstructured.Unstructured) (bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(job.UnstructuredContent(), "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]any)
+		if !ok || condition["status"] != "True" {
+			continue
+		}
+		switch condition["type"] {
+		case "Complete":
+			return true, ""
+		case "Failed":
+			message, _ := condition["message"].(string)
+			reason, _ := condition["reason"].(string)
+			if message == "" {
+				return false, reason
+			}
+			return false, fmt.Sprintf("%s (%s)", message, reason)
+		}
+	}
+	return false, ""
+}
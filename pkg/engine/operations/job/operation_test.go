@@ -0,0 +1,201 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	mock "github.com/kyverno/chainsaw/pkg/engine/operations/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func job(name string, created time.Time, owner types.UID, conditions ...map[string]any) unstructured.Unstructured {
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion("batch/v1")
+	obj.SetKind("Job")
+	obj.SetName(name)
+	obj.SetNamespace("chainsaw")
+	obj.SetCreationTimestamp(metav1.NewTime(created))
+	if owner != "" {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{UID: owner}})
+	}
+	if len(conditions) != 0 {
+		var items []any
+		for _, condition := range conditions {
+			items = append(items, condition)
+		}
+		_ = unstructured.SetNestedSlice(obj.Object, items, "status", "conditions")
+	}
+	return obj
+}
+
+func Test_operation_Exec(t *testing.T) {
+	now := time.Now()
+	complete := map[string]any{"type": "Complete", "status": "True"}
+	failed := map[string]any{"type": "Failed", "status": "True", "reason": "BackoffLimitExceeded", "message": "Job has reached the specified backoff limit"}
+	tests := []struct {
+		name         string
+		job          v1alpha1.Job
+		jobs         []unstructured.Unstructured
+		getErr       error
+		logsErr      error
+		expectedErr  string
+		expectedLogs []string
+		selector     string
+		patterns     []any
+	}{{
+		name: "complete",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+		},
+		jobs:         []unstructured.Unstructured{job("foo", now, "", complete)},
+		expectedLogs: []string{"JOB: RUN - []", "JOB: DONE - []"},
+	}, {
+		name: "failed",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+		},
+		jobs:         []unstructured.Unstructured{job("foo", now, "", failed)},
+		expectedErr:  "job foo failed: Job has reached the specified backoff limit (BackoffLimitExceeded)",
+		expectedLogs: []string{"JOB: RUN - []", "JOB: ERROR - [=== ERROR\njob foo failed: Job has reached the specified backoff limit (BackoffLimitExceeded)]"},
+	}, {
+		name: "not complete",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+		},
+		jobs:         []unstructured.Unstructured{job("foo", now, "")},
+		expectedErr:  "job foo is not complete",
+		expectedLogs: []string{"JOB: RUN - []", "JOB: ERROR - [=== ERROR\njob foo is not complete]"},
+	}, {
+		name: "get error",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+		},
+		getErr:       errors.New("dummy"),
+		expectedErr:  "dummy",
+		expectedLogs: []string{"JOB: RUN - []", "JOB: ERROR - [=== ERROR\ndummy]"},
+	}, {
+		name: "logs",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Logs:       []string{"hello", "world"},
+		},
+		jobs:         []unstructured.Unstructured{job("foo", now, "", complete)},
+		selector:     "job-name=foo",
+		patterns:     []any{"hello", "world"},
+		expectedLogs: []string{"JOB: RUN - []", "JOB: DONE - []"},
+	}, {
+		name: "logs mismatch",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Logs:       []string{"hello"},
+		},
+		jobs:         []unstructured.Unstructured{job("foo", now, "", complete)},
+		logsErr:      errors.New("pattern not found"),
+		selector:     "job-name=foo",
+		patterns:     []any{"hello"},
+		expectedErr:  "pattern not found",
+		expectedLogs: []string{"JOB: RUN - []", "JOB: ERROR - [=== ERROR\npattern not found]"},
+	}, {
+		name: "cron job",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			CronJob:    true,
+			Logs:       []string{"hello"},
+		},
+		jobs: []unstructured.Unstructured{
+			job("foo-1", now.Add(-2*time.Minute), "uid", failed),
+			job("foo-2", now.Add(-time.Minute), "uid", complete),
+			job("bar", now, "other", failed),
+		},
+		selector:     "job-name=foo-2",
+		patterns:     []any{"hello"},
+		expectedLogs: []string{"JOB: RUN - []", "JOB: DONE - []"},
+	}, {
+		name: "cron job without jobs",
+		job: v1alpha1.Job{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			CronJob:    true,
+		},
+		expectedErr:  "no job created by cron job chainsaw/foo yet",
+		expectedLogs: []string{"JOB: RUN - []", "JOB: ERROR - [=== ERROR\nno job created by cron job chainsaw/foo yet]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					assert.Equal(t, "chainsaw", key.Namespace)
+					if tt.getErr != nil {
+						return tt.getErr
+					}
+					if tt.job.CronJob {
+						assert.Equal(t, "CronJob", obj.GetObjectKind().GroupVersionKind().Kind)
+						obj.SetUID("uid")
+						return nil
+					}
+					for _, item := range tt.jobs {
+						if item.GetName() == key.Name {
+							obj.(*unstructured.Unstructured).Object = item.DeepCopy().Object
+							return nil
+						}
+					}
+					return errors.New("not found")
+				},
+				ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+					list.(*unstructured.UnstructuredList).Items = tt.jobs
+					return nil
+				},
+			}
+			var selector string
+			var patterns any
+			logs := func(_ context.Context, sel string, namespace string, check v1alpha1.Check) (operations.Operation, error) {
+				assert.Equal(t, "chainsaw", namespace)
+				selector = sel
+				return mock.MockOperation{
+					ExecFn: func(_ context.Context, bindings apis.Bindings) (outputs.Outputs, error) {
+						binding, err := bindings.Get("$patterns")
+						assert.NoError(t, err)
+						patterns, err = binding.Value()
+						assert.NoError(t, err)
+						return nil, tt.logsErr
+					},
+				}, nil
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), tt.job, logs)
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.selector, selector)
+			if tt.patterns != nil {
+				assert.Equal(t, tt.patterns, patterns)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package nocrashloop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const reason = "CrashLoopBackOff"
+
+type operation struct {
+	compilers   compilers.Compilers
+	client      client.Client
+	namespacer  namespacer.Namespacer
+	noCrashLoop v1alpha1.NoCrashLoop
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	noCrashLoop v1alpha1.NoCrashLoop,
+) operations.Operation {
+	return &operation{
+		compilers:   compilers,
+		client:      client,
+		namespacer:  namespacer,
+		noCrashLoop: noCrashLoop,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.NoCrashLoop, _err)
+	}()
+	internal.LogStart(logger, logging.NoCrashLoop)
+	namespace, selector, err := o.target(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	return nil, o.execute(ctx, namespace, selector)
+}
+
+func (o *operation) target(ctx context.Context, bindings apis.Bindings) (string, labels.Selector, error) {
+	namespace, err := o.noCrashLoop.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", nil, err
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	expression, err := o.noCrashLoop.Selector.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", nil, err
+	}
+	selector, err := labels.Parse(expression)
+	if err != nil {
+		return "", nil, err
+	}
+	return namespace, selector, nil
+}
+
+// execute watches the pods for the whole operation window, it fails as soon as a container shows crash loop evidence
+// and passes only once the window elapsed without any.
+func (o *operation) execute(ctx context.Context, namespace string, selector labels.Selector) error {
+	restarts := map[string]int64{}
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, true, func(ctx context.Context) (bool, error) {
+		lastErr = o.tryCheck(ctx, namespace, selector, restarts)
+		var crashErr crashLoopError
+		if errors.As(lastErr, &crashErr) {
+			return false, lastErr
+		}
+		return false, nil
+	})
+	var crashErr crashLoopError
+	if errors.As(err, &crashErr) {
+		return err
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	// the window elapsed, pods are not crash looping unless the pods could not be listed
+	return lastErr
+}
+
+type crashLoopError struct {
+	containers []string
+}
+
+func (e crashLoopError) Error() string {
+	return fmt.Sprintf("container(s) crash looping: %s", strings.Join(e.containers, ", "))
+}
+
+func (o *operation) tryCheck(ctx context.Context, namespace string, selector labels.Selector, restarts map[string]int64) error {
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("PodList")
+	if err := o.client.List(ctx, &list, client.InNamespace(namespace), client.MatchingSelector{Selector: selector}); err != nil {
+		return err
+	}
+	var crashing []string
+	for _, pod := range list.Items {
+		crashing = append(crashing, crashLoopEvidence(pod, restarts)...)
+	}
+	if len(crashing) != 0 {
+		return crashLoopError{containers: crashing}
+	}
+	return nil
+}
+
+// crashLoopEvidence returns the init and regular containers of the pod showing crash loop evidence:
+// - waiting with the CrashLoopBackOff reason
+// - restarted after terminating (last terminated state)
+// - restart count growing since the container was first seen
+func crashLoopEvidence(pod unstructured.Unstructured, restarts map[string]int64) []string {
+	podName := client.Name(client.Key(&pod))
+	var containers []string
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		statuses, _, _ := unstructured.NestedSlice(pod.UnstructuredContent(), "status", field)
+		for _, status := range statuses {
+			status, ok := status.(map[string]any)
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(status, "name")
+			key := string(pod.GetUID()) + "/" + podName + "/" + name
+			count, _, _ := unstructured.NestedInt64(status, "restartCount")
+			first, seen := restarts[key]
+			if !seen {
+				restarts[key] = count
+			}
+			if value, _, _ := unstructured.NestedString(status, "state", "waiting", "reason"); value == reason {
+				containers = append(containers, fmt.Sprintf("%s/%s (%s)", podName, name, reason))
+			} else if terminated, ok, _ := unstructured.NestedMap(status, "lastState", "terminated"); ok {
+				exitCode, _, _ := unstructured.NestedInt64(terminated, "exitCode")
+				containers = append(containers, fmt.Sprintf("%s/%s (restarted after terminating with exit code %d)", podName, name, exitCode))
+			} else if seen && count > first {
+				containers = append(containers, fmt.Sprintf("%s/%s (restarted %d time(s) during the check)", podName, name, count-first))
+			}
+		}
+	}
+	return containers
+}
@@ -0,0 +1,186 @@
+package nocrashloop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// status builds a container status, reason is the waiting reason (running when empty).
+func status(name string, reason string, restarts int64, lastExitCode *int64) map[string]any {
+	state := map[string]any{"running": map[string]any{}}
+	if reason != "" {
+		state = map[string]any{"waiting": map[string]any{"reason": reason}}
+	}
+	status := map[string]any{
+		"name":         name,
+		"state":        state,
+		"restartCount": restarts,
+	}
+	if lastExitCode != nil {
+		status["lastState"] = map[string]any{"terminated": map[string]any{"exitCode": *lastExitCode}}
+	}
+	return status
+}
+
+func pod(name string, field string, reasons ...string) unstructured.Unstructured {
+	var statuses []any
+	for i, reason := range reasons {
+		statuses = append(statuses, status([]string{"app", "sidecar"}[i], reason, 0, nil))
+	}
+	return podWithStatuses(name, field, statuses...)
+}
+
+func podWithStatuses(name string, field string, statuses ...any) unstructured.Unstructured {
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": "chainsaw",
+			},
+			"status": map[string]any{
+				field: statuses,
+			},
+		},
+	}
+}
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		noCrashLoop  v1alpha1.NoCrashLoop
+		selector     string
+		pods         [][]unstructured.Unstructured
+		listErr      error
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name: "healthy namespace",
+		pods: [][]unstructured.Unstructured{{
+			pod("foo", "containerStatuses", "", ""),
+			pod("bar", "containerStatuses", "ContainerCreating"),
+		}},
+		expectedLogs: []string{"NO-CRASH-LOOP: RUN - []", "NO-CRASH-LOOP: DONE - []"},
+	}, {
+		name:         "no pods",
+		pods:         [][]unstructured.Unstructured{nil},
+		expectedLogs: []string{"NO-CRASH-LOOP: RUN - []", "NO-CRASH-LOOP: DONE - []"},
+	}, {
+		name: "crash looping pod",
+		pods: [][]unstructured.Unstructured{{
+			pod("foo", "containerStatuses", ""),
+			pod("bar", "containerStatuses", "", "CrashLoopBackOff"),
+		}},
+		expectedErr:  "container(s) crash looping: chainsaw/bar/sidecar (CrashLoopBackOff)",
+		expectedLogs: []string{"NO-CRASH-LOOP: RUN - []", "NO-CRASH-LOOP: ERROR - [=== ERROR\ncontainer(s) crash looping: chainsaw/bar/sidecar (CrashLoopBackOff)]"},
+	}, {
+		name: "crash looping init container",
+		pods: [][]unstructured.Unstructured{{
+			pod("foo", "initContainerStatuses", "CrashLoopBackOff"),
+		}},
+		expectedErr:  "container(s) crash looping: chainsaw/foo/app (CrashLoopBackOff)",
+		expectedLogs: []string{"NO-CRASH-LOOP: RUN - []", "NO-CRASH-LOOP: ERROR - [=== ERROR\ncontainer(s) crash looping: chainsaw/foo/app (CrashLoopBackOff)]"},
+	}, {
+		name: "flipping between running and crash looping",
+		pods: [][]unstructured.Unstructured{
+			{pod("foo", "containerStatuses", "")},
+			{pod("foo", "containerStatuses", "")},
+			{pod("foo", "containerStatuses", "CrashLoopBackOff")},
+			{pod("foo", "containerStatuses", "")},
+		},
+		expectedErr: "container(s) crash looping: chainsaw/foo/app (CrashLoopBackOff)",
+	}, {
+		name: "creating then crash looping",
+		pods: [][]unstructured.Unstructured{
+			{pod("foo", "containerStatuses", "ContainerCreating")},
+			{pod("foo", "containerStatuses", "")},
+			{pod("foo", "containerStatuses", "CrashLoopBackOff")},
+		},
+		expectedErr: "container(s) crash looping: chainsaw/foo/app (CrashLoopBackOff)",
+	}, {
+		name: "running after terminating",
+		pods: [][]unstructured.Unstructured{{
+			podWithStatuses("foo", "containerStatuses", status("app", "", 1, ptr.To[int64](1))),
+		}},
+		expectedErr: "container(s) crash looping: chainsaw/foo/app (restarted after terminating with exit code 1)",
+	}, {
+		name: "restart count growing",
+		pods: [][]unstructured.Unstructured{
+			{podWithStatuses("foo", "containerStatuses", status("app", "", 0, nil))},
+			{podWithStatuses("foo", "containerStatuses", status("app", "", 2, nil))},
+		},
+		expectedErr: "container(s) crash looping: chainsaw/foo/app (restarted 2 time(s) during the check)",
+	}, {
+		name: "selector",
+		noCrashLoop: v1alpha1.NoCrashLoop{
+			Namespace: "other",
+			Selector:  "app=foo",
+		},
+		selector:     "app=foo",
+		pods:         [][]unstructured.Unstructured{{pod("foo", "containerStatuses", "")}},
+		expectedLogs: []string{"NO-CRASH-LOOP: RUN - []", "NO-CRASH-LOOP: DONE - []"},
+	}, {
+		name:        "list error",
+		pods:        [][]unstructured.Unstructured{nil},
+		listErr:     errors.New("connection refused"),
+		expectedErr: "connection refused",
+	}, {
+		name: "invalid selector",
+		noCrashLoop: v1alpha1.NoCrashLoop{
+			Selector: "app in (",
+		},
+		expectedErr: "unable to parse requirement",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				ListFn: func(_ context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+					var options ctrlclient.ListOptions
+					options.ApplyOptions(opts)
+					namespace := "chainsaw"
+					if tt.noCrashLoop.Namespace != "" {
+						namespace = string(tt.noCrashLoop.Namespace)
+					}
+					assert.Equal(t, namespace, options.Namespace)
+					assert.Equal(t, tt.selector, options.LabelSelector.String())
+					if tt.listErr != nil {
+						return tt.listErr
+					}
+					list.(*unstructured.UnstructuredList).Items = tt.pods[min(call, len(tt.pods)-1)]
+					return nil
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), tt.noCrashLoop)
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedLogs != nil {
+				assert.Equal(t, tt.expectedLogs, logger.Logs)
+			}
+		})
+	}
+}
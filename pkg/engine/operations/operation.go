@@ -2,6 +2,7 @@ package operations
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
@@ -10,3 +11,6 @@ import (
 type Operation interface {
 	Exec(context.Context, apis.Bindings) (outputs.Outputs, error)
 }
+
+// ErrSkip is returned by operations that require the running test to be skipped.
+var ErrSkip = errors.New("test skipped")
@@ -214,6 +214,7 @@ func Test_create(t *testing.T) {
 				tt.object,
 				nil,
 				false,
+				nil,
 				tt.expect,
 				nil,
 			)
@@ -0,0 +1,130 @@
+package podcount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	podCount   v1alpha1.PodCount
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	podCount v1alpha1.PodCount,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		podCount:   podCount,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.PodCount, _err)
+	}()
+	internal.LogStart(logger, logging.PodCount)
+	namespace, selector, err := o.target(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	return nil, o.execute(ctx, namespace, selector)
+}
+
+func (o *operation) target(ctx context.Context, bindings apis.Bindings) (string, labels.Selector, error) {
+	namespace, err := o.podCount.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", nil, err
+	}
+	if namespace == "" && o.namespacer != nil {
+		namespace = o.namespacer.GetNamespace()
+	}
+	expression, err := o.podCount.Selector.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", nil, err
+	}
+	if expression == "" {
+		return "", nil, errors.New("a selector must be specified")
+	}
+	selector, err := labels.Parse(expression)
+	if err != nil {
+		return "", nil, err
+	}
+	return namespace, selector, nil
+}
+
+// execute polls the pods until their count satisfies the operator, the last count is reported when the operation times out.
+func (o *operation) execute(ctx context.Context, namespace string, selector labels.Selector) error {
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		lastErr = o.tryCount(ctx, namespace, selector)
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (o *operation) tryCount(ctx context.Context, namespace string, selector labels.Selector) error {
+	var list unstructured.UnstructuredList
+	list.SetAPIVersion("v1")
+	list.SetKind("PodList")
+	if err := o.client.List(ctx, &list, client.InNamespace(namespace), client.MatchingSelector{Selector: selector}); err != nil {
+		return err
+	}
+	phase := o.podCount.Phase
+	if phase == "" {
+		phase = "Running"
+	}
+	var count int64
+	for _, pod := range list.Items {
+		// terminating pods keep their phase until they are gone, they are not counted
+		if pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if value, _, _ := unstructured.NestedString(pod.UnstructuredContent(), "status", "phase"); value == phase {
+			count++
+		}
+	}
+	operator := o.podCount.Operator
+	if operator == "" {
+		operator = v1alpha1.DeltaOperatorEqual
+	}
+	ok, err := internal.Compare(operator, float64(count), float64(o.podCount.Count))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%d %s pod(s) matching %s, expected %s %d", count, phase, selector, operator, o.podCount.Count)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package podcount
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func pods(phases ...string) []unstructured.Unstructured {
+	var pods []unstructured.Unstructured
+	for _, phase := range phases {
+		pods = append(pods, unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"status": map[string]any{
+					"phase": phase,
+				},
+			},
+		})
+	}
+	return pods
+}
+
+func terminating(pods []unstructured.Unstructured) []unstructured.Unstructured {
+	for i := range pods {
+		pods[i].SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+	}
+	return pods
+}
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		podCount     v1alpha1.PodCount
+		pods         [][]unstructured.Unstructured
+		timeout      time.Duration
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name: "count reached",
+		podCount: v1alpha1.PodCount{
+			Selector: "app=foo",
+			Count:    2,
+		},
+		pods:         [][]unstructured.Unstructured{pods("Running", "Running")},
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: DONE - []"},
+	}, {
+		name: "count eventually reached",
+		podCount: v1alpha1.PodCount{
+			Selector: "app=foo",
+			Count:    3,
+		},
+		pods: [][]unstructured.Unstructured{
+			pods("Pending", "Pending", "Pending"),
+			pods("Running", "Pending", "Pending"),
+			pods("Running", "Running", "Running"),
+		},
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: DONE - []"},
+	}, {
+		name: "greater than or equal",
+		podCount: v1alpha1.PodCount{
+			Selector: "app=foo",
+			Operator: v1alpha1.DeltaOperatorGreaterThanOrEqual,
+			Count:    2,
+		},
+		pods:         [][]unstructured.Unstructured{pods("Running", "Running", "Running")},
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: DONE - []"},
+	}, {
+		name: "phase",
+		podCount: v1alpha1.PodCount{
+			Selector: "app=foo",
+			Phase:    "Succeeded",
+			Count:    1,
+		},
+		pods:         [][]unstructured.Unstructured{pods("Running", "Succeeded")},
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: DONE - []"},
+	}, {
+		name: "terminating pods",
+		podCount: v1alpha1.PodCount{
+			Selector: "app=foo",
+			Count:    0,
+		},
+		pods: [][]unstructured.Unstructured{
+			pods("Running", "Running"),
+			terminating(pods("Running", "Running")),
+		},
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: DONE - []"},
+	}, {
+		name: "timeout",
+		podCount: v1alpha1.PodCount{
+			Selector: "app=foo",
+			Count:    5,
+		},
+		pods:         [][]unstructured.Unstructured{pods("Running", "Running", "Pending")},
+		timeout:      500 * time.Millisecond,
+		expectedErr:  "2 Running pod(s) matching app=foo, expected Equal 5",
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: ERROR - [=== ERROR\n2 Running pod(s) matching app=foo, expected Equal 5]"},
+	}, {
+		name:         "no selector",
+		podCount:     v1alpha1.PodCount{},
+		expectedErr:  "a selector must be specified",
+		expectedLogs: []string{"POD-COUNT: RUN - []", "POD-COUNT: ERROR - [=== ERROR\na selector must be specified]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				ListFn: func(_ context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+					var options ctrlclient.ListOptions
+					options.ApplyOptions(opts)
+					assert.Equal(t, "chainsaw", options.Namespace)
+					assert.Equal(t, "app=foo", options.LabelSelector.String())
+					list.(*unstructured.UnstructuredList).Items = tt.pods[min(call, len(tt.pods)-1)]
+					return nil
+				},
+			}
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 2 * time.Second
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), tt.podCount)
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// requestTimeout bounds every request so that a hung request doesn't consume the whole operation timeout.
+var requestTimeout = 5 * time.Second
+
+type operation struct {
+	compilers compilers.Compilers
+	probe     v1alpha1.Probe
+}
+
+func New(
+	compilers compilers.Compilers,
+	probe v1alpha1.Probe,
+) operations.Operation {
+	return &operation{
+		compilers: compilers,
+		probe:     probe,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	logger := internal.GetLogger(ctx, nil)
+	defer func() {
+		internal.LogEnd(logger, logging.Probe, _err)
+	}()
+	internal.LogStart(logger, logging.Probe)
+	target, httpClient, err := o.target(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var body *regexp.Regexp
+	if o.probe.Body != "" {
+		body, err = regexp.Compile(o.probe.Body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, o.execute(ctx, httpClient, target, body)
+}
+
+// target resolves the url to probe and builds a client sending the requests to the address override when it is set.
+func (o *operation) target(ctx context.Context, bindings apis.Bindings) (string, *http.Client, error) {
+	target, err := o.probe.URL.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", nil, err
+	}
+	if target == "" {
+		return "", nil, errors.New("a url must be specified")
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", nil, err
+	}
+	address, err := o.probe.Address.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return "", nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		ServerName:         parsed.Hostname(),
+		InsecureSkipVerify: o.probe.Insecure, //nolint:gosec
+	}
+	transport.Proxy = httputils.Proxy
+	if address != "" {
+		address = withPort(address, parsed)
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		}
+	}
+	return target, &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+		// the probe asserts the response of the url itself, redirects are not followed
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}, nil
+}
+
+// execute polls the url until the response matches, the last mismatch is reported when the operation times out.
+func (o *operation) execute(ctx context.Context, httpClient *http.Client, target string, body *regexp.Regexp) error {
+	defer httpClient.CloseIdleConnections()
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, true, func(ctx context.Context) (bool, error) {
+		if err := o.tryProbe(ctx, httpClient, target, body); err != nil {
+			// a request interrupted by the timeout doesn't tell why the probe failed
+			if ctx.Err() == nil {
+				lastErr = err
+			}
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (o *operation) tryProbe(ctx context.Context, httpClient *http.Client, target string, body *regexp.Regexp) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if o.probe.Status != 0 {
+		if resp.StatusCode != o.probe.Status {
+			return fmt.Errorf("%s returned status %d, expected %d", target, resp.StatusCode, o.probe.Status)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned status %d", target, resp.StatusCode)
+	}
+	if body != nil {
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if !body.Match(content) {
+			return fmt.Errorf("%s returned a body not matching %s", target, body)
+		}
+	}
+	return nil
+}
+
+// withPort returns the address with the port of the url when the address doesn't specify one.
+func withPort(address string, target *url.URL) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	port := target.Port()
+	if port == "" {
+		port = "80"
+		if target.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(strings.Trim(address, "[]"), port)
+}
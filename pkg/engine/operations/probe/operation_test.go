@@ -0,0 +1,283 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	timeout := requestTimeout
+	defer func() { requestTimeout = timeout }()
+	requestTimeout = 200 * time.Millisecond
+	// the backend only serves requests for the ingress host, like an ingress controller would
+	var requests atomic.Int64
+	backend := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.Host != "app.example.com" && !strings.HasPrefix(r.Host, "app.example.com:") && !strings.HasPrefix(r.Host, "127.0.0.1:") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/redirect":
+			http.Redirect(w, r, "/", http.StatusFound)
+		case "/hang":
+			<-r.Context().Done()
+		case "/eventually":
+			if requests.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, "ready")
+		default:
+			fmt.Fprintf(w, "hello from %s", r.Host)
+		}
+	})
+	server := httptest.NewServer(backend)
+	defer server.Close()
+	tlsServer := httptest.NewUnstartedServer(backend)
+	tlsServer.Config.ErrorLog = log.New(io.Discard, "", 0)
+	tlsServer.StartTLS()
+	defer tlsServer.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+	tlsAddress := strings.TrimPrefix(tlsServer.URL, "https://")
+	serverURL, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	bindings := apis.NewBindings().Register("$url", apis.NewBinding(server.URL)).Register("$address", apis.NewBinding(address))
+	tests := []struct {
+		name         string
+		probe        v1alpha1.Probe
+		expectedLogs []string
+		expectedErr  string
+	}{{
+		name: "reachable url",
+		probe: v1alpha1.Probe{
+			URL: v1alpha1.Expression(server.URL),
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "url from bindings",
+		probe: v1alpha1.Probe{
+			URL:  "(concat($url, '/ready'))",
+			Body: "^hello",
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "host override",
+		probe: v1alpha1.Probe{
+			URL:     "http://app.example.com/path",
+			Address: "($address)",
+			Body:    "hello from app.example.com$",
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "host override without port",
+		probe: v1alpha1.Probe{
+			URL:     v1alpha1.Expression("http://app.example.com:" + serverURL.Port() + "/path"),
+			Address: "127.0.0.1",
+			Body:    "hello from app.example.com:" + serverURL.Port(),
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "unknown host",
+		probe: v1alpha1.Probe{
+			URL:     "http://other.example.com",
+			Address: v1alpha1.Expression(address),
+		},
+		expectedErr: "http://other.example.com returned status 404",
+	}, {
+		name: "expected status",
+		probe: v1alpha1.Probe{
+			URL:    v1alpha1.Expression(server.URL + "/missing"),
+			Status: http.StatusNotFound,
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "status mismatch",
+		probe: v1alpha1.Probe{
+			URL:    v1alpha1.Expression(server.URL),
+			Status: http.StatusNoContent,
+		},
+		expectedErr: server.URL + " returned status 200, expected 204",
+	}, {
+		name: "redirect not followed",
+		probe: v1alpha1.Probe{
+			URL: v1alpha1.Expression(server.URL + "/redirect"),
+		},
+		expectedErr: server.URL + "/redirect returned status 302",
+	}, {
+		name: "expected redirect",
+		probe: v1alpha1.Probe{
+			URL:    v1alpha1.Expression(server.URL + "/redirect"),
+			Status: http.StatusFound,
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "hung request",
+		probe: v1alpha1.Probe{
+			URL: v1alpha1.Expression(server.URL + "/hang"),
+		},
+		expectedErr: "Client.Timeout exceeded",
+	}, {
+		name: "body mismatch",
+		probe: v1alpha1.Probe{
+			URL:  v1alpha1.Expression(server.URL),
+			Body: "^goodbye",
+		},
+		expectedErr: server.URL + " returned a body not matching ^goodbye",
+	}, {
+		name: "eventually ready",
+		probe: v1alpha1.Probe{
+			URL:  v1alpha1.Expression(server.URL + "/eventually"),
+			Body: "^ready$",
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "tls with insecure",
+		probe: v1alpha1.Probe{
+			URL:      "https://app.example.com",
+			Address:  v1alpha1.Expression(tlsAddress),
+			Insecure: true,
+		},
+		expectedLogs: []string{"PROBE: RUN - []", "PROBE: DONE - []"},
+	}, {
+		name: "tls without insecure",
+		probe: v1alpha1.Probe{
+			URL:     "https://app.example.com",
+			Address: v1alpha1.Expression(tlsAddress),
+		},
+		expectedErr: "certificate",
+	}, {
+		name: "invalid body",
+		probe: v1alpha1.Probe{
+			URL:  v1alpha1.Expression(server.URL),
+			Body: "(",
+		},
+		expectedErr: "error parsing regexp",
+	}, {
+		name:        "missing url",
+		probe:       v1alpha1.Probe{},
+		expectedErr: "a url must be specified",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, tt.probe)
+			outputs, err := operation.Exec(ctx, bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedLogs != nil {
+				assert.Equal(t, tt.expectedLogs, logger.Logs)
+			}
+		})
+	}
+}
+
+func Test_operation_target_proxy(t *testing.T) {
+	httputils.SetProxy("http://proxy.example.com:3128")
+	defer httputils.SetProxy("")
+	tests := []struct {
+		name  string
+		probe v1alpha1.Probe
+		want  *url.URL
+	}{{
+		name: "proxy",
+		probe: v1alpha1.Probe{
+			URL: "http://app.example.com",
+		},
+		want: &url.URL{Scheme: "http", Host: "proxy.example.com:3128"},
+	}, {
+		name: "address bypasses proxy",
+		probe: v1alpha1.Probe{
+			URL:     "http://app.example.com",
+			Address: "10.0.0.1",
+		},
+		want: nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation := &operation{
+				compilers: apis.DefaultCompilers,
+				probe:     tt.probe,
+			}
+			target, httpClient, err := operation.target(context.TODO(), apis.NewBindings())
+			assert.NoError(t, err)
+			transport := httpClient.Transport.(*http.Transport)
+			if tt.want == nil {
+				assert.Nil(t, transport.Proxy)
+				return
+			}
+			req, err := http.NewRequest(http.MethodGet, target, nil)
+			assert.NoError(t, err)
+			proxy, err := transport.Proxy(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, proxy)
+		})
+	}
+}
+
+func Test_withPort(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		url     string
+		want    string
+	}{{
+		name:    "with port",
+		address: "10.0.0.1:8080",
+		url:     "http://app.example.com",
+		want:    "10.0.0.1:8080",
+	}, {
+		name:    "port from url",
+		address: "10.0.0.1",
+		url:     "http://app.example.com:8443",
+		want:    "10.0.0.1:8443",
+	}, {
+		name:    "http",
+		address: "10.0.0.1",
+		url:     "http://app.example.com",
+		want:    "10.0.0.1:80",
+	}, {
+		name:    "https",
+		address: "10.0.0.1",
+		url:     "https://app.example.com",
+		want:    "10.0.0.1:443",
+	}, {
+		name:    "ipv6",
+		address: "[::1]",
+		url:     "https://app.example.com",
+		want:    "[::1]:443",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := url.Parse(tt.url)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, withPort(tt.address, target))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const observedGeneration v1alpha1.Expression = "(status.observedGeneration)"
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	rollout    v1alpha1.Rollout
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	rollout v1alpha1.Rollout,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		rollout:    rollout,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	obj, err := o.object(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	logger := internal.GetLogger(ctx, obj)
+	defer func() {
+		internal.LogEnd(logger, logging.Rollout, _err)
+	}()
+	if err := internal.ApplyNamespacer(o.namespacer, o.client, obj); err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Rollout)
+	snapshot, err := o.snapshotValue(bindings)
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := o.snapshotRestarts(bindings)
+	if err != nil {
+		return nil, err
+	}
+	return nil, o.execute(ctx, bindings, obj, snapshot, baseline)
+}
+
+func (o *operation) object(ctx context.Context, bindings apis.Bindings) (*unstructured.Unstructured, error) {
+	name, err := o.rollout.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errors.New("a deployment name must be specified")
+	}
+	namespace, err := o.rollout.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj, nil
+}
+
+func (o *operation) snapshotValue(bindings apis.Bindings) (float64, error) {
+	binding, err := bindings.Get("$" + o.rollout.Snapshot)
+	if err != nil {
+		return 0, fmt.Errorf("snapshot %s not found", o.rollout.Snapshot)
+	}
+	value, err := binding.Value()
+	if err != nil {
+		return 0, err
+	}
+	number, ok := internal.ToNumber(value)
+	if !ok {
+		return 0, fmt.Errorf("snapshot %s is not a number", o.rollout.Snapshot)
+	}
+	return number, nil
+}
+
+// snapshotRestarts returns the pod restart counts captured alongside the snapshot, indexed by pod uid.
+func (o *operation) snapshotRestarts(bindings apis.Bindings) (map[string]any, error) {
+	binding, err := bindings.Get("$" + o.rollout.Snapshot + "_restarts")
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %s doesn't capture pod restarts (restarts must be enabled)", o.rollout.Snapshot)
+	}
+	value, err := binding.Value()
+	if err != nil {
+		return nil, err
+	}
+	restarts, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("snapshot %s pod restarts are not valid", o.rollout.Snapshot)
+	}
+	return restarts, nil
+}
+
+// execute polls the deployment until the rollout completes, restarts since the snapshot above the threshold stop the operation immediately.
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured, snapshot float64, baseline map[string]any) error {
+	var lastErr, failure error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		var actual unstructured.Unstructured
+		actual.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := o.client.Get(ctx, client.Key(obj), &actual); err != nil {
+			if kerrors.IsNotFound(err) {
+				lastErr = fmt.Errorf("deployment %s not found", client.Name(client.Key(obj)))
+			} else {
+				lastErr = err
+			}
+			return false, nil
+		}
+		restarts, err := o.restarts(ctx, bindings, &actual, baseline)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		if restarts > o.rollout.MaxRestarts {
+			failure = fmt.Errorf("deployment %s pods restarted %d time(s) since the snapshot, more than the %d allowed", actual.GetName(), restarts, o.rollout.MaxRestarts)
+			return false, failure
+		}
+		lastErr = o.rolledOut(ctx, bindings, &actual, snapshot)
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if failure != nil {
+		return failure
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// rolledOut checks the observed generation advanced compared to the snapshot and all replicas were updated and are available.
+func (o *operation) rolledOut(ctx context.Context, bindings apis.Bindings, deployment *unstructured.Unstructured, snapshot float64) error {
+	current, err := internal.NumericField(ctx, o.compilers, *deployment, observedGeneration, bindings)
+	if err != nil {
+		return err
+	}
+	if current <= snapshot {
+		return fmt.Errorf("deployment %s observed generation didn't advance (snapshot: %s, current: %s)", deployment.GetName(), format(snapshot), format(current))
+	}
+	if current < float64(deployment.GetGeneration()) {
+		return fmt.Errorf("deployment %s generation %d not observed yet", deployment.GetName(), deployment.GetGeneration())
+	}
+	desired, found, err := unstructured.NestedInt64(deployment.UnstructuredContent(), "spec", "replicas")
+	if err != nil {
+		return err
+	}
+	if !found {
+		desired = 1
+	}
+	replicas, _, _ := unstructured.NestedInt64(deployment.UnstructuredContent(), "status", "replicas")
+	updated, _, _ := unstructured.NestedInt64(deployment.UnstructuredContent(), "status", "updatedReplicas")
+	available, _, _ := unstructured.NestedInt64(deployment.UnstructuredContent(), "status", "availableReplicas")
+	if updated != desired || replicas != updated || available != updated {
+		return fmt.Errorf("deployment %s rollout is not complete (desired: %d, replicas: %d, updated: %d, available: %d)", deployment.GetName(), desired, replicas, updated, available)
+	}
+	return nil
+}
+
+// restarts sums the container restarts of the pods selected by the deployment since the snapshot.
+// Pods that didn't exist when the snapshot was taken count all their restarts.
+func (o *operation) restarts(ctx context.Context, bindings apis.Bindings, deployment *unstructured.Unstructured, baseline map[string]any) (int64, error) {
+	current, err := internal.PodRestarts(ctx, o.compilers, o.client, *deployment, bindings)
+	if err != nil {
+		return 0, err
+	}
+	var restarts float64
+	for uid, count := range current {
+		count, _ := internal.ToNumber(count)
+		previous, _ := internal.ToNumber(baseline[uid])
+		if count > previous {
+			restarts += count - previous
+		}
+	}
+	return int64(restarts), nil
+}
+
+func format(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
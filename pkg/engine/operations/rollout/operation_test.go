@@ -0,0 +1,253 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func deployment(generation, observed, updated, available int64) map[string]any {
+	return map[string]any{
+		"metadata": map[string]any{
+			"name":       "foo",
+			"namespace":  "chainsaw",
+			"generation": generation,
+		},
+		"spec": map[string]any{
+			"replicas": int64(2),
+			"selector": map[string]any{
+				"matchLabels": map[string]any{
+					"app": "foo",
+				},
+			},
+		},
+		"status": map[string]any{
+			"observedGeneration": observed,
+			"replicas":           updated,
+			"updatedReplicas":    updated,
+			"availableReplicas":  available,
+		},
+	}
+}
+
+func pod(uid string, restarts ...int64) unstructured.Unstructured {
+	var statuses []any
+	for _, count := range restarts {
+		statuses = append(statuses, map[string]any{"restartCount": count})
+	}
+	return unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"uid": uid,
+			},
+			"status": map[string]any{
+				"containerStatuses": statuses,
+			},
+		},
+	}
+}
+
+func snapshot(generation any, restarts map[string]any) apis.Bindings {
+	return apis.NewBindings().Register("$generation", apis.NewBinding(generation)).Register("$generation_restarts", apis.NewBinding(restarts))
+}
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		rollout      v1alpha1.Rollout
+		bindings     apis.Bindings
+		deployments  []map[string]any
+		pods         [][]unstructured.Unstructured
+		notFound     bool
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name: "clean rollout",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(int64(1), map[string]any{}),
+		deployments:  []map[string]any{deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 0), pod("b", 0)}},
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: DONE - []"},
+	}, {
+		name: "eventual rollout",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(int64(1), map[string]any{}),
+		deployments:  []map[string]any{deployment(2, 1, 2, 2), deployment(2, 2, 1, 0), deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 0), pod("b", 0)}},
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: DONE - []"},
+	}, {
+		name: "restarts allowed",
+		rollout: v1alpha1.Rollout{
+			ObjectName:  v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:    "generation",
+			MaxRestarts: 2,
+		},
+		bindings:     snapshot(int64(1), map[string]any{}),
+		deployments:  []map[string]any{deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 1), pod("b", 0, 1)}},
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: DONE - []"},
+	}, {
+		name: "churny rollout",
+		rollout: v1alpha1.Rollout{
+			ObjectName:  v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:    "generation",
+			MaxRestarts: 1,
+		},
+		bindings:     snapshot(int64(1), map[string]any{}),
+		deployments:  []map[string]any{deployment(2, 2, 1, 1), deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 0)}, {pod("a", 1), pod("b", 2)}},
+		expectedErr:  "deployment foo pods restarted 3 time(s) since the snapshot, more than the 1 allowed",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\ndeployment foo pods restarted 3 time(s) since the snapshot, more than the 1 allowed]"},
+	}, {
+		name: "restarts before the snapshot",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(int64(1), map[string]any{"a": float64(5)}),
+		deployments:  []map[string]any{deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 5), pod("b", 0)}},
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: DONE - []"},
+	}, {
+		name: "restarts since the snapshot",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(int64(1), map[string]any{"a": float64(5)}),
+		deployments:  []map[string]any{deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 6), pod("b", 0)}},
+		expectedErr:  "deployment foo pods restarted 1 time(s) since the snapshot, more than the 0 allowed",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\ndeployment foo pods restarted 1 time(s) since the snapshot, more than the 0 allowed]"},
+	}, {
+		name: "generation not advanced",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(float64(2), map[string]any{}),
+		deployments:  []map[string]any{deployment(2, 2, 2, 2)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 0), pod("b", 0)}},
+		expectedErr:  "deployment foo observed generation didn't advance (snapshot: 2, current: 2)",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\ndeployment foo observed generation didn't advance (snapshot: 2, current: 2)]"},
+	}, {
+		name: "rollout not complete",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(int64(1), map[string]any{}),
+		deployments:  []map[string]any{deployment(2, 2, 2, 1)},
+		pods:         [][]unstructured.Unstructured{{pod("a", 0), pod("b", 0)}},
+		expectedErr:  "deployment foo rollout is not complete (desired: 2, replicas: 2, updated: 2, available: 1)",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\ndeployment foo rollout is not complete (desired: 2, replicas: 2, updated: 2, available: 1)]"},
+	}, {
+		name: "not found",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot(int64(1), map[string]any{}),
+		notFound:     true,
+		expectedErr:  "deployment chainsaw/foo not found",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\ndeployment chainsaw/foo not found]"},
+	}, {
+		name: "missing snapshot",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     apis.NewBindings(),
+		expectedErr:  "snapshot generation not found",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\nsnapshot generation not found]"},
+	}, {
+		name: "missing snapshot restarts",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     apis.NewBindings().Register("$generation", apis.NewBinding(int64(1))),
+		expectedErr:  "snapshot generation doesn't capture pod restarts (restarts must be enabled)",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\nsnapshot generation doesn't capture pod restarts (restarts must be enabled)]"},
+	}, {
+		name: "invalid snapshot",
+		rollout: v1alpha1.Rollout{
+			ObjectName: v1alpha1.ObjectName{Name: "foo"},
+			Snapshot:   "generation",
+		},
+		bindings:     snapshot("foo", map[string]any{}),
+		expectedErr:  "snapshot generation is not a number",
+		expectedLogs: []string{"ROLLOUT: RUN - []", "ROLLOUT: ERROR - [=== ERROR\nsnapshot generation is not a number]"},
+	}, {
+		name: "no name",
+		rollout: v1alpha1.Rollout{
+			Snapshot: "generation",
+		},
+		expectedErr: "a deployment name must be specified",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gets, lists := 0, 0
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					assert.Equal(t, client.ObjectKey{Namespace: "chainsaw", Name: "foo"}, key)
+					if tt.notFound {
+						return kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name)
+					}
+					current := tt.deployments[min(gets, len(tt.deployments)-1)]
+					gets++
+					obj.(*unstructured.Unstructured).Object = runtime.DeepCopyJSON(current)
+					return nil
+				},
+				ListFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) error {
+					var options ctrlclient.ListOptions
+					options.ApplyOptions(opts)
+					assert.Equal(t, "chainsaw", options.Namespace)
+					assert.Equal(t, "app=foo", options.LabelSelector.String())
+					list.(*unstructured.UnstructuredList).Items = tt.pods[min(lists, len(tt.pods)-1)]
+					lists++
+					return nil
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), tt.rollout)
+			outputs, err := operation.Exec(ctx, tt.bindings)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	scale      v1alpha1.Scale
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	scale v1alpha1.Scale,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		scale:      scale,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	obj, err := o.object(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	logger := internal.GetLogger(ctx, obj)
+	defer func() {
+		internal.LogEnd(logger, logging.Scale, _err)
+	}()
+	if err := internal.ApplyNamespacer(o.namespacer, o.client, obj); err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Scale)
+	return nil, o.execute(ctx, obj)
+}
+
+func (o *operation) object(ctx context.Context, bindings apis.Bindings) (*unstructured.Unstructured, error) {
+	gv, err := o.scale.GroupVersion(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := o.scale.Kind.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	name, err := o.scale.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.scale.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion(gv.String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj, nil
+}
+
+// terminalError is an error that retrying can't fix.
+type terminalError struct {
+	error
+}
+
+func (o *operation) execute(ctx context.Context, obj *unstructured.Unstructured) error {
+	// the desired replicas are computed once so that a relative change is not applied again when an update is retried
+	var desired *int64
+	var lastErr error
+	err := wait.PollUntilContextCancel(ctx, client.PollInterval, false, func(ctx context.Context) (bool, error) {
+		lastErr = o.tryScale(ctx, obj, &desired)
+		var terminal terminalError
+		if errors.As(lastErr, &terminal) {
+			lastErr = terminal.error
+			return false, lastErr
+		}
+		return lastErr == nil, nil
+	})
+	if err == nil {
+		return nil
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+func (o *operation) tryScale(ctx context.Context, obj *unstructured.Unstructured, desired **int64) error {
+	var scale unstructured.Unstructured
+	scale.SetAPIVersion("autoscaling/v1")
+	scale.SetKind("Scale")
+	if err := o.client.SubResource("scale").Get(ctx, obj, &scale); err != nil {
+		return o.classify(ctx, obj, err)
+	}
+	if *desired == nil {
+		current, _, err := unstructured.NestedInt64(scale.UnstructuredContent(), "spec", "replicas")
+		if err != nil {
+			return terminalError{err}
+		}
+		replicas, err := desiredReplicas(o.scale.Replicas, current)
+		if err != nil {
+			return terminalError{err}
+		}
+		*desired = &replicas
+	}
+	if err := unstructured.SetNestedField(scale.UnstructuredContent(), **desired, "spec", "replicas"); err != nil {
+		return terminalError{err}
+	}
+	if err := o.client.SubResource("scale").Update(ctx, obj, client.WithSubResourceBody(&scale)); err != nil {
+		return o.classify(ctx, obj, err)
+	}
+	return nil
+}
+
+// classify marks the errors that retrying can't fix as terminal.
+// A not found error is retried only if the resource doesn't exist yet, otherwise its kind doesn't support scaling.
+func (o *operation) classify(ctx context.Context, obj *unstructured.Unstructured, err error) error {
+	switch {
+	case kerrors.IsInvalid(err), kerrors.IsBadRequest(err):
+		return terminalError{err}
+	case kerrors.IsNotFound(err):
+		var actual unstructured.Unstructured
+		actual.SetGroupVersionKind(obj.GroupVersionKind())
+		if getErr := o.client.Get(ctx, client.Key(obj), &actual); getErr == nil {
+			return terminalError{fmt.Errorf("subresource scale is not supported by kind %s", obj.GetKind())}
+		}
+	}
+	return err
+}
+
+func desiredReplicas(replicas intstr.IntOrString, current int64) (int64, error) {
+	desired := int64(replicas.IntVal)
+	if replicas.Type == intstr.String {
+		value := strings.TrimSpace(replicas.StrVal)
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid replicas: %s", replicas.StrVal)
+		}
+		desired = parsed
+		if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
+			desired = current + parsed
+		}
+	}
+	if desired < 0 {
+		return 0, fmt.Errorf("cannot scale to a negative number of replicas (%d)", desired)
+	}
+	return desired, nil
+}
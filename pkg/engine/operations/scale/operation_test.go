@@ -0,0 +1,200 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name         string
+		current      int64
+		replicas     intstr.IntOrString
+		getErr       error
+		updateErrs   []error
+		exists       bool
+		expected     int64
+		expectedErr  string
+		expectedLogs []string
+	}{{
+		name:         "scale up",
+		current:      1,
+		replicas:     intstr.FromInt32(3),
+		expected:     3,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: DONE - []"},
+	}, {
+		name:         "scale down",
+		current:      3,
+		replicas:     intstr.FromInt32(1),
+		expected:     1,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: DONE - []"},
+	}, {
+		name:         "scale to zero",
+		current:      3,
+		replicas:     intstr.FromString("0"),
+		expected:     0,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: DONE - []"},
+	}, {
+		name:         "scale up relatively",
+		current:      2,
+		replicas:     intstr.FromString("+1"),
+		expected:     3,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: DONE - []"},
+	}, {
+		name:         "scale down relatively",
+		current:      2,
+		replicas:     intstr.FromString("-1"),
+		expected:     1,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: DONE - []"},
+	}, {
+		name:         "scale below zero",
+		current:      1,
+		replicas:     intstr.FromString("-2"),
+		expectedErr:  "cannot scale to a negative number of replicas (-1)",
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: ERROR - [=== ERROR\ncannot scale to a negative number of replicas (-1)]"},
+	}, {
+		name:         "invalid replicas",
+		current:      1,
+		replicas:     intstr.FromString("two"),
+		expectedErr:  "invalid replicas: two",
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: ERROR - [=== ERROR\ninvalid replicas: two]"},
+	}, {
+		name:         "get error",
+		replicas:     intstr.FromInt32(1),
+		getErr:       errors.New("not found"),
+		expectedErr:  "not found",
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: ERROR - [=== ERROR\nnot found]"},
+	}, {
+		name:         "relative change applied once",
+		current:      2,
+		replicas:     intstr.FromString("+1"),
+		updateErrs:   []error{kerrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "foo", errors.New("conflict"))},
+		expected:     3,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: DONE - []"},
+	}, {
+		name:         "invalid update",
+		current:      1,
+		replicas:     intstr.FromInt32(3),
+		updateErrs:   []error{kerrors.NewInvalid(schema.GroupKind{Group: "autoscaling", Kind: "Scale"}, "foo", nil)},
+		expectedErr:  `Scale.autoscaling "foo" is invalid`,
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: ERROR - [=== ERROR\nScale.autoscaling \"foo\" is invalid]"},
+	}, {
+		name:         "bad request",
+		current:      1,
+		replicas:     intstr.FromInt32(3),
+		updateErrs:   []error{kerrors.NewBadRequest("bad request")},
+		expectedErr:  "bad request",
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: ERROR - [=== ERROR\nbad request]"},
+	}, {
+		name:         "subresource not supported",
+		replicas:     intstr.FromInt32(1),
+		getErr:       kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "foo"),
+		exists:       true,
+		expectedErr:  "subresource scale is not supported by kind Deployment",
+		expectedLogs: []string{"SCALE: RUN - []", "SCALE: ERROR - [=== ERROR\nsubresource scale is not supported by kind Deployment]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *int64
+			var gets, updates int
+			subResourceClient := &tclient.FakeSubResourceClient{
+				GetFn: func(_ context.Context, _ int, obj client.Object, subResource client.Object, _ ...ctrlclient.SubResourceGetOption) error {
+					assert.Equal(t, "Deployment", obj.GetObjectKind().GroupVersionKind().Kind)
+					assert.Equal(t, "foo", obj.GetName())
+					assert.Equal(t, "chainsaw", obj.GetNamespace())
+					if tt.getErr != nil {
+						return tt.getErr
+					}
+					// the replicas observed by later reads include the changes of the failed updates
+					current := tt.current + int64(gets)
+					gets++
+					subResource.(*unstructured.Unstructured).Object = map[string]any{
+						"apiVersion": "autoscaling/v1",
+						"kind":       "Scale",
+						"metadata": map[string]any{
+							"name":      "foo",
+							"namespace": "chainsaw",
+						},
+						"spec": map[string]any{
+							"replicas": current,
+						},
+					}
+					return nil
+				},
+				UpdateFn: func(_ context.Context, _ int, obj client.Object, opts ...ctrlclient.SubResourceUpdateOption) error {
+					updates++
+					if updates <= len(tt.updateErrs) {
+						return tt.updateErrs[updates-1]
+					}
+					var options ctrlclient.SubResourceUpdateOptions
+					options.ApplyOptions(opts)
+					replicas, _, err := unstructured.NestedInt64(options.SubResourceBody.(*unstructured.Unstructured).Object, "spec", "replicas")
+					assert.NoError(t, err)
+					updated = &replicas
+					return nil
+				},
+			}
+			fakeClient := &tclient.FakeClient{
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, _ client.Object, _ ...ctrlclient.GetOption) error {
+					if tt.exists {
+						return nil
+					}
+					return kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name)
+				},
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				SubResourceFn: func(_ int, subResource string) client.SubResourceClient {
+					assert.Equal(t, "scale", subResource)
+					return subResourceClient
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), v1alpha1.Scale{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+				Replicas: tt.replicas,
+			})
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, updated)
+				// terminal errors are not retried
+				assert.LessOrEqual(t, updates, max(1, len(tt.updateErrs)))
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, updated) {
+					assert.Equal(t, tt.expected, *updated)
+				}
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
@@ -3,9 +3,10 @@ package script
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"strings"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -15,6 +16,8 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/engine/throttle"
+	"github.com/kyverno/chainsaw/pkg/expressions"
 	restutils "github.com/kyverno/chainsaw/pkg/utils/rest"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	"github.com/kyverno/pkg/ext/output/color"
@@ -93,15 +96,18 @@ func (o *operation) createCommand(ctx context.Context, bindings apis.Bindings) (
 	}
 	cmd := exec.CommandContext(ctx, "sh", "-c", o.script.Content) //nolint:gosec
 	cmd.Env = env
-	basePath := o.basePath
-	if o.script.WorkDir != nil {
-		if filepath.IsAbs(*o.script.WorkDir) {
-			basePath = *o.script.WorkDir
-		} else {
-			basePath = filepath.Join(basePath, *o.script.WorkDir)
-		}
+	stdin, err := expressions.StringPointer(ctx, o.compilers, o.script.Stdin, bindings)
+	if err != nil {
+		return nil, cancel, err
+	}
+	if stdin != nil {
+		cmd.Stdin = strings.NewReader(*stdin)
+	}
+	workDir, err := internal.WorkDir(ctx, o.compilers, bindings, o.basePath, o.script.WorkDir)
+	if err != nil {
+		return nil, cancel, err
 	}
-	cmd.Dir = basePath
+	cmd.Dir = workDir
 	return cmd, cancel, nil
 }
 
@@ -117,7 +123,7 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 	}
 	cmd.Stdout = &output.Stdout
 	cmd.Stderr = &output.Stderr
-	err := cmd.Run()
+	err := throttle.Run(ctx, cmd)
 	bindings = apibindings.RegisterBinding(ctx, bindings, "stdout", output.Out())
 	bindings = apibindings.RegisterBinding(ctx, bindings, "stderr", output.Err())
 	if err == nil {
@@ -125,6 +131,15 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 	} else {
 		bindings = apibindings.RegisterBinding(ctx, bindings, "error", err.Error())
 	}
+	var captured outputs.Outputs
+	if err == nil && o.script.Capture != nil {
+		value, err := internal.Capture(*o.script.Capture, output.Out())
+		if err != nil {
+			return nil, err
+		}
+		bindings = apibindings.RegisterBinding(ctx, bindings, o.script.Capture.As, value)
+		captured = outputs.Outputs{o.script.Capture.As: value}
+	}
 	defer func(bindings apis.Bindings) {
 		if _err == nil {
 			outputs, err := outputs.Process(ctx, o.compilers, bindings, nil, o.script.Outputs...)
@@ -132,6 +147,11 @@ func (o *operation) execute(ctx context.Context, bindings apis.Bindings, cmd *ex
 				_err = err
 				return
 			}
+			// declared outputs take precedence over the captured stdout
+			if captured != nil {
+				maps.Copy(captured, outputs)
+				outputs = captured
+			}
 			_outputs = outputs
 		}
 	}(bindings)
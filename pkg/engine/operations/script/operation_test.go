@@ -58,7 +58,7 @@ func Test_operationScript(t *testing.T) {
 		script: v1alpha1.Script{
 			Content:   "cat operation.go",
 			ActionEnv: v1alpha1.ActionEnv{SkipLogOutput: true},
-			WorkDir:   ptr.To("/bar"),
+			WorkDir:   ptr.To[v1alpha1.Expression]("/bar"),
 		},
 		basePath:   "..",
 		namespace:  "test-namespace",
@@ -69,12 +69,43 @@ func Test_operationScript(t *testing.T) {
 		script: v1alpha1.Script{
 			Content:   "cat operation.go",
 			ActionEnv: v1alpha1.ActionEnv{SkipLogOutput: true},
-			WorkDir:   ptr.To("./foo"),
+			WorkDir:   ptr.To[v1alpha1.Expression]("./foo"),
 		},
 		basePath:   "..",
 		namespace:  "test-namespace",
 		wantErr:    true,
 		wantErrMsg: "../foo: no such file or directory",
+	}, {
+		name: "Test with existing relative workdir",
+		script: v1alpha1.Script{
+			Content:   "cat operation.go",
+			ActionEnv: v1alpha1.ActionEnv{SkipLogOutput: true},
+			WorkDir:   ptr.To[v1alpha1.Expression]("./script"),
+		},
+		basePath:  "..",
+		namespace: "test-namespace",
+		wantErr:   false,
+	}, {
+		name: "Test with templated workdir",
+		script: v1alpha1.Script{
+			Content:   "cat operation.go",
+			ActionEnv: v1alpha1.ActionEnv{SkipLogOutput: true},
+			WorkDir:   ptr.To[v1alpha1.Expression]("(join('', ['./', 'script']))"),
+		},
+		basePath:  "..",
+		namespace: "test-namespace",
+		wantErr:   false,
+	}, {
+		name: "Test with file workdir",
+		script: v1alpha1.Script{
+			Content:   "cat operation.go",
+			ActionEnv: v1alpha1.ActionEnv{SkipLogOutput: true},
+			WorkDir:   ptr.To[v1alpha1.Expression]("./script/operation.go"),
+		},
+		basePath:   "..",
+		namespace:  "test-namespace",
+		wantErr:    true,
+		wantErrMsg: "is not a directory",
 	}, {
 		name: "with check",
 		script: v1alpha1.Script{
@@ -146,3 +177,163 @@ func Test_operationScript(t *testing.T) {
 		})
 	}
 }
+
+func Test_operationScriptCapture(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  v1alpha1.Script
+		want    map[string]any
+		wantErr string
+	}{{
+		name: "json",
+		script: v1alpha1.Script{
+			Content: `echo '{"items":[{"name":"a"},{"name":"b","replicas":2}]}'`,
+			ActionCapture: v1alpha1.ActionCapture{
+				Capture: &v1alpha1.Capture{As: "data", Parser: v1alpha1.CaptureParserJSON},
+			},
+			ActionCheck: v1alpha1.ActionCheck{
+				Check: ptr.To(v1alpha1.NewCheck(
+					map[string]any{
+						"($data.items[1].replicas)": 2.0,
+					},
+				)),
+			},
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{Name: "second", Value: v1alpha1.NewProjection("($data.items[1].name)")},
+				}},
+			},
+		},
+		want: map[string]any{
+			"data": map[string]any{
+				"items": []any{
+					map[string]any{"name": "a"},
+					map[string]any{"name": "b", "replicas": 2.0},
+				},
+			},
+			"second": "b",
+		},
+	}, {
+		name: "lines",
+		script: v1alpha1.Script{
+			Content: `printf 'foo\n\nbar\n'`,
+			ActionCapture: v1alpha1.ActionCapture{
+				Capture: &v1alpha1.Capture{As: "lines", Parser: v1alpha1.CaptureParserLines},
+			},
+		},
+		want: map[string]any{
+			"lines": []any{"foo", "bar"},
+		},
+	}, {
+		name: "string",
+		script: v1alpha1.Script{
+			Content: "echo hello",
+			ActionCapture: v1alpha1.ActionCapture{
+				Capture: &v1alpha1.Capture{As: "greeting"},
+			},
+		},
+		want: map[string]any{
+			"greeting": "hello",
+		},
+	}, {
+		name: "invalid json",
+		script: v1alpha1.Script{
+			Content: "echo hello",
+			ActionCapture: v1alpha1.ActionCapture{
+				Capture: &v1alpha1.Capture{As: "data", Parser: v1alpha1.CaptureParserJSON},
+			},
+		},
+		wantErr: "failed to parse stdout as json",
+	}, {
+		name: "output overrides capture",
+		script: v1alpha1.Script{
+			Content: "echo hello",
+			ActionCapture: v1alpha1.ActionCapture{
+				Capture: &v1alpha1.Capture{As: "greeting"},
+			},
+			ActionOutputs: v1alpha1.ActionOutputs{
+				Outputs: []v1alpha1.Output{{
+					Binding: v1alpha1.Binding{Name: "greeting", Value: v1alpha1.NewProjection("(to_upper($greeting))")},
+				}},
+			},
+		},
+		want: map[string]any{
+			"greeting": "HELLO",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.IntoContext(context.TODO(), &tlogging.FakeLogger{})
+			operation := New(apis.DefaultCompilers, tt.script, "..", "test-namespace", nil)
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, outputs)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, outputs)
+			}
+		})
+	}
+}
+
+func Test_operationScriptStdin(t *testing.T) {
+	bindings := apis.NewBindings().Register("$payload", apis.NewBinding(map[string]any{
+		"name":     "foo",
+		"replicas": 2,
+	}))
+	tests := []struct {
+		name    string
+		stdin   *string
+		parser  v1alpha1.CaptureParser
+		want    map[string]any
+		wantErr string
+	}{{
+		name:  "none",
+		stdin: nil,
+		want: map[string]any{
+			"out": "",
+		},
+	}, {
+		name:  "plain",
+		stdin: ptr.To("hello"),
+		want: map[string]any{
+			"out": "hello",
+		},
+	}, {
+		name:   "rendered json",
+		stdin:  ptr.To("(to_string($payload))"),
+		parser: v1alpha1.CaptureParserJSON,
+		want: map[string]any{
+			"out": map[string]any{
+				"name":     "foo",
+				"replicas": 2.0,
+			},
+		},
+	}, {
+		name:    "not a string",
+		stdin:   ptr.To("($payload)"),
+		wantErr: "expression didn't evaluate to a string",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := logging.IntoContext(context.TODO(), &tlogging.FakeLogger{})
+			script := v1alpha1.Script{
+				Content: "cat",
+				Stdin:   tt.stdin,
+				ActionCapture: v1alpha1.ActionCapture{
+					Capture: &v1alpha1.Capture{As: "out", Parser: tt.parser},
+				},
+			}
+			operation := New(apis.DefaultCompilers, script, "..", "test-namespace", nil)
+			outputs, err := operation.Exec(ctx, bindings)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, outputs)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, outputs)
+			}
+		})
+	}
+}
@@ -28,10 +28,16 @@ func (o *operation) Exec(ctx context.Context, _ apis.Bindings) (_ outputs.Output
 		internal.LogEnd(logger, logging.Sleep, _err)
 	}()
 	internal.LogStart(logger, logging.Sleep)
-	return nil, o.execute()
+	return nil, o.execute(ctx)
 }
 
-func (o *operation) execute() error {
-	time.Sleep(o.duration.Duration.Duration)
-	return nil
+func (o *operation) execute(ctx context.Context) error {
+	timer := time.NewTimer(o.duration.Duration.Duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -17,21 +17,33 @@ func Test_operation_Exec(t *testing.T) {
 	tests := []struct {
 		name         string
 		sleep        v1alpha1.Sleep
+		timeout      time.Duration
+		wantErr      bool
 		expectedLogs []string
 	}{{
 		name:         "zero",
 		sleep:        v1alpha1.Sleep{},
+		timeout:      time.Second,
 		expectedLogs: []string{"SLEEP: RUN - []", "SLEEP: DONE - []"},
 	}, {
 		name: "1s",
 		sleep: v1alpha1.Sleep{
 			Duration: metav1.Duration{Duration: time.Second},
 		},
+		timeout:      5 * time.Second,
 		expectedLogs: []string{"SLEEP: RUN - []", "SLEEP: DONE - []"},
+	}, {
+		name: "cancelled",
+		sleep: v1alpha1.Sleep{
+			Duration: metav1.Duration{Duration: time.Minute},
+		},
+		timeout:      100 * time.Millisecond,
+		wantErr:      true,
+		expectedLogs: []string{"SLEEP: RUN - []", "SLEEP: ERROR - [=== ERROR\ncontext deadline exceeded]"},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
 			defer cancel()
 			operation := New(
 				tt.sleep,
@@ -39,7 +51,11 @@ func Test_operation_Exec(t *testing.T) {
 			logger := &tlogging.FakeLogger{}
 			outputs, err := operation.Exec(ttesting.IntoContext(logging.IntoContext(ctx, logger), t), nil)
 			assert.Nil(t, outputs)
-			assert.NoError(t, err)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
 			assert.Equal(t, tt.expectedLogs, logger.Logs)
 		})
 	}
@@ -0,0 +1,106 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	snapshot   v1alpha1.Snapshot
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	snapshot v1alpha1.Snapshot,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		snapshot:   snapshot,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	obj, err := o.object(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	logger := internal.GetLogger(ctx, obj)
+	defer func() {
+		internal.LogEnd(logger, logging.Snapshot, _err)
+	}()
+	if err := internal.ApplyNamespacer(o.namespacer, o.client, obj); err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Snapshot)
+	return o.execute(ctx, bindings, obj)
+}
+
+func (o *operation) object(ctx context.Context, bindings apis.Bindings) (*unstructured.Unstructured, error) {
+	gv, err := o.snapshot.GroupVersion(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := o.snapshot.Kind.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	name, err := o.snapshot.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.snapshot.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion(gv.String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj, nil
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured) (outputs.Outputs, error) {
+	var actual unstructured.Unstructured
+	actual.SetGroupVersionKind(obj.GroupVersionKind())
+	if err := o.client.Get(ctx, client.Key(obj), &actual); err != nil {
+		return nil, err
+	}
+	value, err := internal.Field(ctx, o.compilers, actual, o.snapshot.Field, bindings)
+	if err != nil {
+		return nil, err
+	}
+	// numbers are normalized so that a delta can compare them regardless of their original type
+	if number, ok := internal.ToNumber(value); ok {
+		value = number
+	}
+	outputs := outputs.Outputs{o.snapshot.As: value}
+	if o.snapshot.Restarts {
+		restarts, err := internal.PodRestarts(ctx, o.compilers, o.client, actual, bindings)
+		if err != nil {
+			return nil, err
+		}
+		outputs[o.snapshot.As+"_restarts"] = restarts
+	}
+	return outputs, nil
+}
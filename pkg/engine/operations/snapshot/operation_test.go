@@ -0,0 +1,121 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name            string
+		field           v1alpha1.Expression
+		restarts        bool
+		notFound        bool
+		expectedOutputs outputs.Outputs
+		expectedErr     string
+		expectedLogs    []string
+	}{{
+		name:            "replicas",
+		field:           "(spec.replicas)",
+		expectedOutputs: outputs.Outputs{"replicas": float64(2)},
+		expectedLogs:    []string{"SNAPSHOT: RUN - []", "SNAPSHOT: DONE - []"},
+	}, {
+		name:            "computed",
+		field:           "(spec.replicas * `2`)",
+		expectedOutputs: outputs.Outputs{"replicas": float64(4)},
+		expectedLogs:    []string{"SNAPSHOT: RUN - []", "SNAPSHOT: DONE - []"},
+	}, {
+		name:            "string",
+		field:           "(metadata.name)",
+		expectedOutputs: outputs.Outputs{"replicas": "foo"},
+		expectedLogs:    []string{"SNAPSHOT: RUN - []", "SNAPSHOT: DONE - []"},
+	}, {
+		name:     "restarts",
+		field:    "(spec.replicas)",
+		restarts: true,
+		expectedOutputs: outputs.Outputs{
+			"replicas":          float64(2),
+			"replicas_restarts": map[string]any{"pod-1": float64(3)},
+		},
+		expectedLogs: []string{"SNAPSHOT: RUN - []", "SNAPSHOT: DONE - []"},
+	}, {
+		name:         "not an expression",
+		field:        "spec.replicas",
+		expectedErr:  "field must be an expression (spec.replicas)",
+		expectedLogs: []string{"SNAPSHOT: RUN - []", "SNAPSHOT: ERROR - [=== ERROR\nfield must be an expression (spec.replicas)]"},
+	}, {
+		name:         "not found",
+		field:        "(spec.replicas)",
+		notFound:     true,
+		expectedErr:  `deployments.apps "foo" not found`,
+		expectedLogs: []string{"SNAPSHOT: RUN - []", "SNAPSHOT: ERROR - [=== ERROR\ndeployments.apps \"foo\" not found]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					if tt.notFound {
+						return kerrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, key.Name)
+					}
+					u := obj.(*unstructured.Unstructured)
+					u.SetName(key.Name)
+					u.SetNamespace(key.Namespace)
+					if err := unstructured.SetNestedStringMap(u.Object, map[string]string{"app": "foo"}, "spec", "selector", "matchLabels"); err != nil {
+						return err
+					}
+					return unstructured.SetNestedField(u.Object, int64(2), "spec", "replicas")
+				},
+				ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+					var pod unstructured.Unstructured
+					pod.SetUID("pod-1")
+					if err := unstructured.SetNestedSlice(pod.Object, []any{map[string]any{"restartCount": int64(3)}}, "status", "containerStatuses"); err != nil {
+						return err
+					}
+					list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{pod}
+					return nil
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), v1alpha1.Snapshot{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+				},
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+				As:       "replicas",
+				Field:    tt.field,
+				Restarts: tt.restarts,
+			})
+			outputs, err := operation.Exec(ctx, nil)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, outputs)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutputs, outputs)
+			}
+			assert.Equal(t, tt.expectedLogs, logger.Logs)
+		})
+	}
+}
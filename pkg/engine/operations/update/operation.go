@@ -29,6 +29,7 @@ type operation struct {
 	base       unstructured.Unstructured
 	namespacer namespacer.Namespacer
 	template   bool
+	rendered   *v1alpha1.Check
 	expect     []v1alpha1.Expectation
 	outputs    []v1alpha1.Output
 }
@@ -39,6 +40,7 @@ func New(
 	obj unstructured.Unstructured,
 	namespacer namespacer.Namespacer,
 	template bool,
+	rendered *v1alpha1.Check,
 	expect []v1alpha1.Expectation,
 	outputs []v1alpha1.Output,
 ) operations.Operation {
@@ -48,6 +50,7 @@ func New(
 		base:       obj,
 		namespacer: namespacer,
 		template:   template,
+		rendered:   rendered,
 		expect:     expect,
 		outputs:    outputs,
 	}
@@ -70,6 +73,9 @@ func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs
 			obj = merged
 		}
 	}
+	if err := internal.CheckRendered(ctx, o.compilers, obj, bindings, o.rendered); err != nil {
+		return nil, err
+	}
 	if err := internal.ApplyNamespacer(o.namespacer, o.client, &obj); err != nil {
 		return nil, err
 	}
@@ -190,6 +190,7 @@ func Test_update(t *testing.T) {
 				tt.object,
 				nil,
 				false,
+				nil,
 				tt.expect,
 				nil,
 			)
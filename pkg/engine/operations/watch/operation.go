@@ -0,0 +1,156 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine/checks"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	"github.com/kyverno/chainsaw/pkg/engine/operations"
+	"github.com/kyverno/chainsaw/pkg/engine/operations/internal"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+type operation struct {
+	compilers  compilers.Compilers
+	client     client.Client
+	namespacer namespacer.Namespacer
+	watch      v1alpha1.Watch
+}
+
+func New(
+	compilers compilers.Compilers,
+	client client.Client,
+	namespacer namespacer.Namespacer,
+	watch v1alpha1.Watch,
+) operations.Operation {
+	return &operation{
+		compilers:  compilers,
+		client:     client,
+		namespacer: namespacer,
+		watch:      watch,
+	}
+}
+
+func (o *operation) Exec(ctx context.Context, bindings apis.Bindings) (_ outputs.Outputs, _err error) {
+	if bindings == nil {
+		bindings = apis.NewBindings()
+	}
+	obj, err := o.object(ctx, bindings)
+	if err != nil {
+		return nil, err
+	}
+	logger := internal.GetLogger(ctx, obj)
+	defer func() {
+		internal.LogEnd(logger, logging.Watch, _err)
+	}()
+	if err := internal.ApplyNamespacer(o.namespacer, o.client, obj); err != nil {
+		return nil, err
+	}
+	internal.LogStart(logger, logging.Watch)
+	return nil, o.execute(ctx, bindings, obj)
+}
+
+func (o *operation) object(ctx context.Context, bindings apis.Bindings) (*unstructured.Unstructured, error) {
+	gv, err := o.watch.GroupVersion(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := o.watch.Kind.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	name, err := o.watch.Name.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := o.watch.Namespace.Value(ctx, o.compilers, bindings)
+	if err != nil {
+		return nil, err
+	}
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion(gv.String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+	return &obj, nil
+}
+
+func (o *operation) execute(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured) error {
+	if len(o.watch.Sequence) == 0 {
+		return errors.New("watch sequence is empty")
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind + "List"})
+	var opts []client.ListOption
+	if obj.GetNamespace() != "" {
+		opts = append(opts, client.InNamespace(obj.GetNamespace()))
+	}
+	if obj.GetName() != "" {
+		opts = append(opts, client.MatchingFields{"metadata.name": obj.GetName()})
+	}
+	watcher, err := o.client.Watch(ctx, &list, opts...)
+	if err != nil {
+		return err
+	}
+	defer watcher.Stop()
+	// current is the index of the last state reached in the sequence
+	current := -1
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (reached %d of %d states)", ctx.Err(), current+1, len(o.watch.Sequence))
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return fmt.Errorf("watch closed (reached %d of %d states)", current+1, len(o.watch.Sequence))
+			}
+			switch event.Type {
+			case watch.Error:
+				return fmt.Errorf("watch error: %v", event.Object)
+			case watch.Deleted:
+				return fmt.Errorf("resource deleted (reached %d of %d states)", current+1, len(o.watch.Sequence))
+			case watch.Bookmark:
+				continue
+			}
+			actual, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				return fmt.Errorf("unexpected watch object type: %T", event.Object)
+			}
+			errs, err := o.match(ctx, bindings, actual, current+1)
+			if err != nil {
+				return err
+			}
+			if len(errs) == 0 {
+				current++
+				if current == len(o.watch.Sequence)-1 {
+					return nil
+				}
+				continue
+			}
+			if current >= 0 {
+				// the resource can stay in the last reached state
+				if same, err := o.match(ctx, bindings, actual, current); err != nil {
+					return err
+				} else if len(same) == 0 {
+					continue
+				}
+			}
+			return fmt.Errorf("unexpected state after reaching %d of %d states: %w", current+1, len(o.watch.Sequence), errs.ToAggregate())
+		}
+	}
+}
+
+func (o *operation) match(ctx context.Context, bindings apis.Bindings, obj *unstructured.Unstructured, index int) (field.ErrorList, error) {
+	return checks.Check(ctx, o.compilers, obj.UnstructuredContent(), bindings, &o.watch.Sequence[index])
+}
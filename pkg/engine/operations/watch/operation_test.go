@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	tlogging "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
+	ttesting "github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func pod(phase string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name":      "foo",
+				"namespace": "chainsaw",
+			},
+			"status": map[string]any{
+				"phase": phase,
+			},
+		},
+	}
+}
+
+func phase(phase string) v1alpha1.Check {
+	return v1alpha1.NewCheck(map[string]any{
+		"status": map[string]any{
+			"phase": phase,
+		},
+	})
+}
+
+func Test_operation_Exec(t *testing.T) {
+	tests := []struct {
+		name        string
+		sequence    []v1alpha1.Check
+		events      []watch.Event
+		close       bool
+		expectedErr string
+	}{{
+		name:     "expected sequence",
+		sequence: []v1alpha1.Check{phase("Pending"), phase("Running")},
+		events: []watch.Event{
+			{Type: watch.Added, Object: pod("Pending")},
+			{Type: watch.Modified, Object: pod("Pending")},
+			{Type: watch.Modified, Object: pod("Running")},
+		},
+	}, {
+		name:     "unexpected sequence",
+		sequence: []v1alpha1.Check{phase("Pending"), phase("Running")},
+		events: []watch.Event{
+			{Type: watch.Added, Object: pod("Pending")},
+			{Type: watch.Modified, Object: pod("Failed")},
+		},
+		expectedErr: "unexpected state after reaching 1 of 2 states: status.phase: Invalid value: \"Failed\": Expected value: \"Running\"",
+	}, {
+		name:     "unexpected first state",
+		sequence: []v1alpha1.Check{phase("Pending"), phase("Running")},
+		events: []watch.Event{
+			{Type: watch.Added, Object: pod("Running")},
+		},
+		expectedErr: "unexpected state after reaching 0 of 2 states: status.phase: Invalid value: \"Running\": Expected value: \"Pending\"",
+	}, {
+		name:     "deleted",
+		sequence: []v1alpha1.Check{phase("Pending"), phase("Running")},
+		events: []watch.Event{
+			{Type: watch.Added, Object: pod("Pending")},
+			{Type: watch.Deleted, Object: pod("Pending")},
+		},
+		expectedErr: "resource deleted (reached 1 of 2 states)",
+	}, {
+		name:     "incomplete sequence",
+		sequence: []v1alpha1.Check{phase("Pending"), phase("Running")},
+		events: []watch.Event{
+			{Type: watch.Added, Object: pod("Pending")},
+		},
+		close:       true,
+		expectedErr: "watch closed (reached 1 of 2 states)",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeWatch := watch.NewFakeWithChanSize(len(tt.events), false)
+			for _, event := range tt.events {
+				fakeWatch.Action(event.Type, event.Object)
+			}
+			if tt.close {
+				fakeWatch.Stop()
+			}
+			fakeClient := &tclient.FakeClient{
+				IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+					return true, nil
+				},
+				WatchFn: func(_ context.Context, _ int, list client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+					assert.Equal(t, "PodList", list.GetObjectKind().GroupVersionKind().Kind)
+					var options ctrlclient.ListOptions
+					for _, opt := range opts {
+						opt.ApplyToList(&options)
+					}
+					assert.Equal(t, "chainsaw", options.Namespace)
+					assert.Equal(t, "metadata.name=foo", options.FieldSelector.String())
+					return fakeWatch, nil
+				},
+			}
+			logger := &tlogging.FakeLogger{}
+			ctx := ttesting.IntoContext(logging.IntoContext(context.TODO(), logger), t)
+			ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), v1alpha1.Watch{
+				ObjectType: v1alpha1.ObjectType{
+					APIVersion: "v1",
+					Kind:       "Pod",
+				},
+				ObjectName: v1alpha1.ObjectName{
+					Name: "foo",
+				},
+				Sequence: tt.sequence,
+			})
+			outputs, err := operation.Exec(ctx, nil)
+			assert.Nil(t, outputs)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_operation_Exec_Timeout(t *testing.T) {
+	fakeWatch := watch.NewFakeWithChanSize(1, false)
+	fakeWatch.Add(pod("Pending"))
+	fakeClient := &tclient.FakeClient{
+		IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+			return true, nil
+		},
+		WatchFn: func(context.Context, int, client.ObjectList, ...client.ListOption) (watch.Interface, error) {
+			return fakeWatch, nil
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+	defer cancel()
+	operation := New(apis.DefaultCompilers, fakeClient, namespacer.New("chainsaw"), v1alpha1.Watch{
+		ObjectType: v1alpha1.ObjectType{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectName: v1alpha1.ObjectName{
+			Name: "foo",
+		},
+		Sequence: []v1alpha1.Check{phase("Pending"), phase("Running")},
+	})
+	_, err := operation.Exec(ctx, nil)
+	assert.EqualError(t, err, "context deadline exceeded (reached 1 of 2 states)")
+}
@@ -2,6 +2,7 @@ package templating
 
 import (
 	"context"
+	"errors"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
@@ -15,6 +16,18 @@ func Template(ctx context.Context, compilers compilers.Compilers, tpl v1alpha1.P
 	return mutate.Mutate(ctx, compilers, nil, mutate.Parse(ctx, tpl.Value()), value, bindings)
 }
 
+// TemplateObject resolves the expressions of a projection expected to evaluate to an object.
+func TemplateObject(ctx context.Context, compilers compilers.Compilers, tpl v1alpha1.Projection, bindings apis.Bindings) (map[string]any, error) {
+	value, err := Template(ctx, compilers, tpl, nil, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if object, ok := convert(value).(map[string]any); ok {
+		return object, nil
+	}
+	return nil, errors.New("value must be an object")
+}
+
 func TemplateAndMerge(ctx context.Context, compilers compilers.Compilers, obj unstructured.Unstructured, bindings apis.Bindings, templates ...v1alpha1.Projection) (unstructured.Unstructured, error) {
 	for _, modifier := range templates {
 		patch, err := Template(ctx, compilers, modifier, obj.UnstructuredContent(), bindings)
@@ -4,8 +4,12 @@ import (
 	"context"
 	"testing"
 
+	jpfunctions "github.com/jmespath-community/go-jmespath/pkg/functions"
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/engine/functions"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers/jp"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -59,3 +63,81 @@ func TestTemplateAndMerge(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplate_CustomFunction(t *testing.T) {
+	registry := functions.NewRegistry()
+	assert.NoError(t, registry.Register(false, jpfunctions.FunctionEntry{
+		Name: "greet",
+		Arguments: []jpfunctions.ArgSpec{
+			{Types: []jpfunctions.JpType{jpfunctions.JpString}},
+		},
+		Handler: func(arguments []any) (any, error) {
+			return "hello " + arguments[0].(string), nil
+		},
+	}))
+	compilers := compilers.Compilers{
+		Jp: jp.NewCompiler(jp.WithFunctionCaller(registry)),
+	}.WithDefaultCompiler(compilers.CompilerJP)
+	bindings := apis.NewBindings().Register("$name", apis.NewBinding("chainsaw"))
+	obj := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+		},
+	}
+	template := v1alpha1.NewProjection(map[string]any{
+		"data": map[string]any{
+			"greeting": "(greet($name))",
+		},
+	})
+	got, err := TemplateAndMerge(context.TODO(), compilers, obj, bindings, template)
+	assert.NoError(t, err)
+	assert.Equal(t, unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data": map[string]any{
+				"greeting": "hello chainsaw",
+			},
+		},
+	}, got)
+}
+
+func TestTemplateObject(t *testing.T) {
+	bindings := apis.NewBindings().Register("$replicas", apis.NewBinding(3))
+	tests := []struct {
+		name    string
+		tpl     v1alpha1.Projection
+		want    map[string]any
+		wantErr bool
+	}{{
+		name: "object",
+		tpl: v1alpha1.NewProjection(map[string]any{
+			"replicas": "($replicas)",
+			"image": map[string]any{
+				"tag": "1.27.0",
+			},
+		}),
+		want: map[string]any{
+			"replicas": 3,
+			"image": map[string]any{
+				"tag": "1.27.0",
+			},
+		},
+	}, {
+		name:    "not an object",
+		tpl:     v1alpha1.NewProjection("($replicas)"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateObject(context.TODO(), apis.DefaultCompilers, tt.tpl, bindings)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
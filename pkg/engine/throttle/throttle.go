@@ -0,0 +1,50 @@
+package throttle
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+)
+
+var (
+	lock      sync.RWMutex
+	semaphore chan struct{}
+)
+
+// SetLimit sets the maximum number of external commands (commands, scripts and collectors) running at once.
+// A zero or negative limit removes the limit.
+func SetLimit(limit int) {
+	lock.Lock()
+	defer lock.Unlock()
+	if limit <= 0 {
+		semaphore = nil
+	} else {
+		semaphore = make(chan struct{}, limit)
+	}
+}
+
+// Acquire waits until a command can run, the returned func must be called once the command completed.
+func Acquire(ctx context.Context) (func(), error) {
+	lock.RLock()
+	sem := semaphore
+	lock.RUnlock()
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Run runs the command once the limit allows it.
+func Run(ctx context.Context, cmd *exec.Cmd) error {
+	release, err := Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return cmd.Run()
+}
@@ -0,0 +1,80 @@
+package throttle
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquire(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+	}{{
+		name:  "one",
+		limit: 1,
+	}, {
+		name:  "two",
+		limit: 2,
+	}, {
+		name:  "four",
+		limit: 4,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetLimit(tt.limit)
+			defer SetLimit(0)
+			var running, max atomic.Int32
+			var wg sync.WaitGroup
+			start := make(chan struct{})
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					release, err := Acquire(context.TODO())
+					assert.NoError(t, err)
+					defer release()
+					current := running.Add(1)
+					for {
+						previous := max.Load()
+						if current <= previous || max.CompareAndSwap(previous, current) {
+							break
+						}
+					}
+					time.Sleep(50 * time.Millisecond)
+					running.Add(-1)
+				}()
+			}
+			close(start)
+			wg.Wait()
+			assert.LessOrEqual(t, max.Load(), int32(tt.limit))
+		})
+	}
+}
+
+func TestAcquire_Cancelled(t *testing.T) {
+	SetLimit(1)
+	defer SetLimit(0)
+	release, err := Acquire(context.TODO())
+	assert.NoError(t, err)
+	defer release()
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = Acquire(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRun(t *testing.T) {
+	SetLimit(1)
+	defer SetLimit(0)
+	assert.NoError(t, Run(context.TODO(), exec.Command("true")))
+	// the slot is released once the command completed
+	assert.Error(t, Run(context.TODO(), exec.Command("false")))
+	assert.NoError(t, Run(context.TODO(), exec.Command("true")))
+}
@@ -0,0 +1,23 @@
+package expressions
+
+import (
+	"context"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+func Any(ctx context.Context, c compilers.Compilers, in string, bindings apis.Bindings) (any, error) {
+	if in == "" {
+		return nil, nil
+	}
+	expression := Parse(ctx, in)
+	if expression == nil {
+		return in, nil
+	}
+	if compiler := c.Compiler(expression.Engine); compiler == nil {
+		return expression.Statement, nil
+	} else {
+		return compilers.Execute(expression.Statement, nil, bindings, compiler)
+	}
+}
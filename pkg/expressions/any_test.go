@@ -0,0 +1,60 @@
+package expressions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		bindings apis.Bindings
+		want     any
+		wantErr  bool
+	}{{
+		name:     "empty",
+		in:       "",
+		bindings: apis.NewBindings(),
+		want:     nil,
+		wantErr:  false,
+	}, {
+		name:     "error",
+		in:       "($foo)",
+		bindings: apis.NewBindings(),
+		want:     nil,
+		wantErr:  true,
+	}, {
+		name:     "number",
+		in:       "(`42`)",
+		bindings: apis.NewBindings(),
+		want:     42.0,
+		wantErr:  false,
+	}, {
+		name:     "string",
+		in:       "foo",
+		bindings: apis.NewBindings(),
+		want:     "foo",
+		wantErr:  false,
+	}, {
+		name:     "binding",
+		in:       "($foo)",
+		bindings: apis.NewBindings().Register("$foo", apis.NewBinding(map[string]any{"bar": "baz"})),
+		want:     map[string]any{"bar": "baz"},
+		wantErr:  false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Any(context.TODO(), apis.DefaultCompilers, tt.in, tt.bindings)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package expressions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+func Bool(ctx context.Context, c compilers.Compilers, in string, bindings apis.Bindings) (bool, error) {
+	statement := in
+	var compiler compilers.Compiler
+	if expression := Parse(ctx, in); expression != nil {
+		statement = expression.Statement
+		compiler = c.Compiler(expression.Engine)
+	}
+	if compiler == nil {
+		if converted, err := strconv.ParseBool(statement); err != nil {
+			return false, fmt.Errorf("value is not a boolean (%s)", in)
+		} else {
+			return converted, nil
+		}
+	} else if converted, err := compilers.Execute(statement, nil, bindings, compiler); err != nil {
+		return false, err
+	} else {
+		switch converted := converted.(type) {
+		case bool:
+			return converted, nil
+		case string:
+			if converted, err := strconv.ParseBool(converted); err == nil {
+				return converted, nil
+			}
+		}
+		return false, fmt.Errorf("expression didn't evaluate to a boolean (%s)", in)
+	}
+}
@@ -0,0 +1,78 @@
+package expressions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		bindings apis.Bindings
+		want     bool
+		wantErr  bool
+	}{{
+		name:     "true",
+		in:       "true",
+		bindings: apis.NewBindings(),
+		want:     true,
+		wantErr:  false,
+	}, {
+		name:     "false",
+		in:       "false",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  false,
+	}, {
+		name:     "not boolean string",
+		in:       "foo",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  true,
+	}, {
+		name:     "expression",
+		in:       "(`1` == `1`)",
+		bindings: apis.NewBindings(),
+		want:     true,
+		wantErr:  false,
+	}, {
+		name:     "binding",
+		in:       "($enabled)",
+		bindings: apis.NewBindings().Register("$enabled", apis.NewBinding(false)),
+		want:     false,
+		wantErr:  false,
+	}, {
+		name:     "string binding",
+		in:       "($enabled)",
+		bindings: apis.NewBindings().Register("$enabled", apis.NewBinding("true")),
+		want:     true,
+		wantErr:  false,
+	}, {
+		name:     "not boolean binding",
+		in:       "($enabled)",
+		bindings: apis.NewBindings().Register("$enabled", apis.NewBinding(1)),
+		want:     false,
+		wantErr:  true,
+	}, {
+		name:     "error",
+		in:       "($foo)",
+		bindings: apis.NewBindings(),
+		want:     false,
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Bool(context.TODO(), apis.DefaultCompilers, tt.in, tt.bindings)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
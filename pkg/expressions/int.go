@@ -0,0 +1,49 @@
+package expressions
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Int(ctx context.Context, c compilers.Compilers, in intstr.IntOrString, bindings apis.Bindings) (int, error) {
+	if in.Type == intstr.Int {
+		return in.IntValue(), nil
+	}
+	statement := in.StrVal
+	var compiler compilers.Compiler
+	if expression := Parse(ctx, in.StrVal); expression != nil {
+		statement = expression.Statement
+		compiler = c.Compiler(expression.Engine)
+	}
+	if compiler == nil {
+		if converted, err := strconv.Atoi(statement); err != nil {
+			return 0, fmt.Errorf("value is not an integer (%s)", in.StrVal)
+		} else {
+			return converted, nil
+		}
+	} else if converted, err := compilers.Execute(statement, nil, bindings, compiler); err != nil {
+		return 0, err
+	} else {
+		switch converted := converted.(type) {
+		case int:
+			return converted, nil
+		case int64:
+			return int(converted), nil
+		case float64:
+			if converted == math.Trunc(converted) {
+				return int(converted), nil
+			}
+		case string:
+			if converted, err := strconv.Atoi(converted); err == nil {
+				return converted, nil
+			}
+		}
+		return 0, fmt.Errorf("expression didn't evaluate to an integer (%s)", in.StrVal)
+	}
+}
@@ -0,0 +1,85 @@
+package expressions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       intstr.IntOrString
+		bindings apis.Bindings
+		want     int
+		wantErr  bool
+	}{{
+		name:     "int",
+		in:       intstr.FromInt(42),
+		bindings: apis.NewBindings(),
+		want:     42,
+		wantErr:  false,
+	}, {
+		name:     "numeric string",
+		in:       intstr.FromString("42"),
+		bindings: apis.NewBindings(),
+		want:     42,
+		wantErr:  false,
+	}, {
+		name:     "not numeric string",
+		in:       intstr.FromString("foo"),
+		bindings: apis.NewBindings(),
+		want:     0,
+		wantErr:  true,
+	}, {
+		name:     "number",
+		in:       intstr.FromString("(`42`)"),
+		bindings: apis.NewBindings(),
+		want:     42,
+		wantErr:  false,
+	}, {
+		name:     "not integer",
+		in:       intstr.FromString("(`4.2`)"),
+		bindings: apis.NewBindings(),
+		want:     0,
+		wantErr:  true,
+	}, {
+		name:     "binding",
+		in:       intstr.FromString("($restarts * `10`)"),
+		bindings: apis.NewBindings().Register("$restarts", apis.NewBinding(3)),
+		want:     30,
+		wantErr:  false,
+	}, {
+		name:     "string binding",
+		in:       intstr.FromString("($tail)"),
+		bindings: apis.NewBindings().Register("$tail", apis.NewBinding("15")),
+		want:     15,
+		wantErr:  false,
+	}, {
+		name:     "not numeric binding",
+		in:       intstr.FromString("($tail)"),
+		bindings: apis.NewBindings().Register("$tail", apis.NewBinding("foo")),
+		want:     0,
+		wantErr:  true,
+	}, {
+		name:     "error",
+		in:       intstr.FromString("($foo)"),
+		bindings: apis.NewBindings(),
+		want:     0,
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Int(context.TODO(), apis.DefaultCompilers, tt.in, tt.bindings)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package expressions
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+)
+
+// Path evaluates a path that is either a single expression or contains expressions between parentheses
+// in place of some of its parts (e.g. manifests/($kind).yaml).
+// Every expression must evaluate to a string, the check function (if any) is called with the expression
+// and its value before the value is substituted in the path.
+func Path(ctx context.Context, c compilers.Compilers, in string, bindings apis.Bindings, check func(string, string) error) (string, error) {
+	// escaped values are taken literally
+	if escapeRegex.MatchString(in) {
+		return String(ctx, c, in, bindings)
+	}
+	var out strings.Builder
+	last := 0
+	for _, group := range pathExpressions(in) {
+		expression := in[group[0]:group[1]]
+		value, err := String(ctx, c, expression, bindings)
+		if err != nil {
+			return "", err
+		}
+		if check != nil {
+			if err := check(expression, value); err != nil {
+				return "", err
+			}
+		}
+		out.WriteString(in[last:group[0]])
+		out.WriteString(value)
+		last = group[1]
+	}
+	out.WriteString(in[last:])
+	return out.String(), nil
+}
+
+// IsTemplatedPath returns true if the path contains expressions.
+func IsTemplatedPath(in string) bool {
+	return !escapeRegex.MatchString(in) && len(pathExpressions(in)) != 0
+}
+
+// PathLiteral returns the path with every expression replaced by the given placeholder.
+func PathLiteral(in string, placeholder string) string {
+	if escapeRegex.MatchString(in) {
+		return in
+	}
+	var out strings.Builder
+	last := 0
+	for _, group := range pathExpressions(in) {
+		out.WriteString(in[last:group[0]])
+		out.WriteString(placeholder)
+		last = group[1]
+	}
+	out.WriteString(in[last:])
+	return out.String()
+}
+
+// pathExpressions returns the bounds of the top level balanced parentheses groups in a path.
+func pathExpressions(in string) [][2]int {
+	var groups [][2]int
+	start, depth := 0, 0
+	for i, r := range in {
+		switch r {
+		case '(':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case ')':
+			if depth == 0 {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				groups = append(groups, [2]int{start, i + 1})
+			}
+		}
+	}
+	return groups
+}
@@ -0,0 +1,138 @@
+package expressions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	bindings := apis.NewBindings().
+		Register("$dir", apis.NewBinding("manifests")).
+		Register("$kind", apis.NewBinding("Deployment"))
+	tests := []struct {
+		name    string
+		in      string
+		check   func(string, string) error
+		want    string
+		wantErr string
+	}{{
+		name: "empty",
+		in:   "",
+		want: "",
+	}, {
+		name: "literal",
+		in:   "manifests/deployment.yaml",
+		want: "manifests/deployment.yaml",
+	}, {
+		name: "expression",
+		in:   "($dir)",
+		want: "manifests",
+	}, {
+		name: "embedded expressions",
+		in:   "($dir)/(to_lower($kind)).yaml",
+		want: "manifests/deployment.yaml",
+	}, {
+		name: "nested parentheses",
+		in:   "manifests/(join('-', [to_lower($kind), 'v1'])).yaml",
+		want: "manifests/deployment-v1.yaml",
+	}, {
+		name: "unbalanced",
+		in:   "manifests/($kind.yaml",
+		want: "manifests/($kind.yaml",
+	}, {
+		name: "escaped",
+		in:   `\($dir)/($kind)\`,
+		want: "($dir)/($kind)",
+	}, {
+		name:    "error",
+		in:      "($foo)/deployment.yaml",
+		wantErr: "variable not defined: $foo",
+	}, {
+		name: "check",
+		in:   "($dir)/($kind).yaml",
+		check: func(expression, value string) error {
+			if value == "Deployment" {
+				return errors.New(expression + " is not allowed")
+			}
+			return nil
+		},
+		wantErr: "($kind) is not allowed",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Path(context.TODO(), apis.DefaultCompilers, tt.in, bindings, tt.check)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIsTemplatedPath(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want bool
+	}{{
+		name: "empty",
+		in:   "",
+		want: false,
+	}, {
+		name: "literal",
+		in:   "manifests/*.yaml",
+		want: false,
+	}, {
+		name: "expression",
+		in:   "($file)",
+		want: true,
+	}, {
+		name: "embedded",
+		in:   "manifests/($kind).yaml",
+		want: true,
+	}, {
+		name: "escaped",
+		in:   `\($file)\`,
+		want: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTemplatedPath(tt.in))
+		})
+	}
+}
+
+func TestPathLiteral(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{{
+		name: "literal",
+		in:   "manifests/*.yaml",
+		want: "manifests/*.yaml",
+	}, {
+		name: "embedded",
+		in:   "../manifests/($env)/($kind).yaml",
+		want: "../manifests/_/_.yaml",
+	}, {
+		name: "adjacent",
+		in:   "($a)($b)/x.yaml",
+		want: "__/x.yaml",
+	}, {
+		name: "escaped",
+		in:   `\($file)\`,
+		want: `\($file)\`,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PathLiteral(tt.in, "_"))
+		})
+	}
+}
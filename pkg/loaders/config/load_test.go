@@ -68,6 +68,10 @@ func TestLoad(t *testing.T) {
 				Cleanup: v1alpha2.CleanupOptions{
 					SkipDelete: false,
 				},
+				Client: v1alpha2.ClientOptions{
+					QPS:   300,
+					Burst: 300,
+				},
 				Deletion: v1alpha2.DeletionOptions{
 					Propagation: metav1.DeletePropagationBackground,
 				},
@@ -108,6 +112,10 @@ func TestLoad(t *testing.T) {
 				Cleanup: v1alpha2.CleanupOptions{
 					SkipDelete: true,
 				},
+				Client: v1alpha2.ClientOptions{
+					QPS:   300,
+					Burst: 300,
+				},
 				Deletion: v1alpha2.DeletionOptions{
 					Propagation: metav1.DeletePropagationBackground,
 				},
@@ -139,6 +147,16 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoad_force(t *testing.T) {
+	got, err := Load("../../../testdata/config/v1alpha2/force-parallel.yaml")
+	assert.NoError(t, err)
+	assert.False(t, got.Spec.Execution.ForceSerial)
+	assert.True(t, got.Spec.Execution.ForceParallel)
+	got, err = Load("../../../testdata/config/v1alpha2/force-serial-and-parallel.yaml")
+	assert.Error(t, err)
+	assert.Nil(t, got)
+}
+
 func Test_parse(t *testing.T) {
 	content, err := os.ReadFile("../../../testdata/config/v1alpha1/custom-config.yaml")
 	assert.NoError(t, err)
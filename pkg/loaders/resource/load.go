@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-getter"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
 	extyaml "github.com/kyverno/pkg/ext/yaml"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -21,13 +23,16 @@ type (
 	converter = func([]byte) ([]byte, error)
 )
 
+// ErrNoMatch is returned when a pattern doesn't match any file.
+var ErrNoMatch = errors.New("no files found matching path")
+
 func Load(pattern string, manifest bool) ([]unstructured.Unstructured, error) {
 	matchingFiles, err := filepath.Glob(pattern)
 	if err != nil {
 		return nil, fmt.Errorf(`failed to match files "%s": %w`, pattern, err)
 	}
 	if len(matchingFiles) == 0 {
-		return nil, fmt.Errorf("no files found matching path: %s", pattern)
+		return nil, fmt.Errorf("%w: %s", ErrNoMatch, pattern)
 	}
 	var resources []unstructured.Unstructured
 	for _, file := range matchingFiles {
@@ -54,10 +59,11 @@ func LoadFromURI(url *url.URL, manifest bool) ([]unstructured.Unstructured, erro
 	}
 	defer os.Remove(tempFile.Name())
 	client := &getter.Client{
-		Ctx:  context.Background(),
-		Src:  url.String(),
-		Dst:  tempFile.Name(),
-		Mode: getter.ClientModeFile,
+		Ctx:     context.Background(),
+		Src:     url.String(),
+		Dst:     tempFile.Name(),
+		Mode:    getter.ClientModeFile,
+		Getters: httputils.Getters(),
 	}
 	backoff := wait.Backoff{
 		Steps:    3,
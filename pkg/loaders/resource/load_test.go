@@ -61,6 +61,11 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadNoMatch(t *testing.T) {
+	_, err := Load(filepath.Join("..", "..", "..", "testdata", "resource", "folder-nonexistent", "*.yaml"), true)
+	assert.ErrorIs(t, err, ErrNoMatch)
+}
+
 func TestLoadFromURI(t *testing.T) {
 	tests := []struct {
 		fileName    string
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+)
+
+// Load loads a JSON schema from a JSON or YAML file.
+func Load(path string) (*gojsonschema.Schema, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
+
+// Parse parses a JSON schema from JSON or YAML content.
+func Parse(content []byte) (*gojsonschema.Schema, error) {
+	data, err := yaml.YAMLToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema (%w)", err)
+	}
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema (%w)", err)
+	}
+	return schema, nil
+}
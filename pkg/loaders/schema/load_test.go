@@ -0,0 +1,44 @@
+package schema
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	basePath := "../../../testdata/schema"
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{{
+		name: "valid",
+		path: filepath.Join(basePath, "configmap.yaml"),
+	}, {
+		name:    "invalid schema",
+		path:    filepath.Join(basePath, "invalid.yaml"),
+		wantErr: true,
+	}, {
+		name:    "malformed",
+		path:    filepath.Join(basePath, "malformed.yaml"),
+		wantErr: true,
+	}, {
+		name:    "not found",
+		path:    filepath.Join(basePath, "not-found.yaml"),
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
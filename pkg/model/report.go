@@ -9,16 +9,30 @@ import (
 type OperationType string
 
 const (
-	OperationTypeApply   OperationType = "apply"
-	OperationTypeAssert  OperationType = "assert"
-	OperationTypeCommand OperationType = "command"
-	OperationTypeCreate  OperationType = "create"
-	OperationTypeDelete  OperationType = "delete"
-	OperationTypeError   OperationType = "error"
-	OperationTypePatch   OperationType = "patch"
-	OperationTypeScript  OperationType = "script"
-	OperationTypeSleep   OperationType = "sleep"
-	OperationTypeUpdate  OperationType = "update"
+	OperationTypeApply       OperationType = "apply"
+	OperationTypeAssert      OperationType = "assert"
+	OperationTypeCanI        OperationType = "canI"
+	OperationTypeCollect     OperationType = "collect"
+	OperationTypeCommand     OperationType = "command"
+	OperationTypeCompare     OperationType = "compare"
+	OperationTypeCreate      OperationType = "create"
+	OperationTypeDelete      OperationType = "delete"
+	OperationTypeDelta       OperationType = "delta"
+	OperationTypeError       OperationType = "error"
+	OperationTypeExec        OperationType = "exec"
+	OperationTypeHealth      OperationType = "health"
+	OperationTypeJob         OperationType = "job"
+	OperationTypeNoCrashLoop OperationType = "noCrashLoop"
+	OperationTypePatch       OperationType = "patch"
+	OperationTypePodCount    OperationType = "podCount"
+	OperationTypeProbe       OperationType = "probe"
+	OperationTypeRollout     OperationType = "rollout"
+	OperationTypeScale       OperationType = "scale"
+	OperationTypeScript      OperationType = "script"
+	OperationTypeSleep       OperationType = "sleep"
+	OperationTypeSnapshot    OperationType = "snapshot"
+	OperationTypeUpdate      OperationType = "update"
+	OperationTypeWatch       OperationType = "watch"
 )
 
 type Report struct {
@@ -58,9 +72,12 @@ type StepReport struct {
 	StartTime  time.Time
 	EndTime    time.Time
 	Operations []*OperationReport
+	lock       sync.Mutex
 }
 
 func (r *StepReport) Add(report *OperationReport) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 	if report.Name == "" {
 		report.Name = fmt.Sprintf("operation %d", len(r.Operations)+1)
 	}
@@ -81,5 +98,10 @@ type OperationReport struct {
 	Type      OperationType
 	StartTime time.Time
 	EndTime   time.Time
+	Skipped   bool
 	Err       error
 }
+
+func (r *OperationReport) Duration() time.Duration {
+	return r.EndTime.Sub(r.StartTime)
+}
@@ -1,19 +1,47 @@
 package model
 
 import (
+	"sort"
+	"sync"
 	"sync/atomic"
 )
 
+// FailureCategory classifies the failures of a run.
+type FailureCategory string
+
+const (
+	// FailureCategoryNone means the run didn't fail.
+	FailureCategoryNone FailureCategory = ""
+	// FailureCategoryTest means some tests failed (assertions, operations, etc).
+	FailureCategoryTest FailureCategory = "test"
+	// FailureCategorySetup means the run failed to set up the environment of some tests (namespace, quota, service account, etc).
+	FailureCategorySetup FailureCategory = "setup"
+	// FailureCategoryTimeout means the run reached its overall timeout.
+	FailureCategoryTimeout FailureCategory = "timeout"
+)
+
 type SummaryResult interface {
 	Passed() int32
 	Failed() int32
 	Skipped() int32
+	Flaky() []string
+	Failure() FailureCategory
 }
 
 type Summary struct {
-	passed  atomic.Int32
-	failed  atomic.Int32
-	skipped atomic.Int32
+	passed        atomic.Int32
+	failed        atomic.Int32
+	skipped       atomic.Int32
+	setupFailures atomic.Int32
+	timeouts      atomic.Int32
+	lock          sync.Mutex
+	outcomes      map[string]*testOutcomes
+}
+
+// testOutcomes counts the iterations of a test that passed and failed.
+type testOutcomes struct {
+	passed int
+	failed int
 }
 
 func (s *Summary) IncPassed() {
@@ -28,6 +56,34 @@ func (s *Summary) IncSkipped() {
 	s.skipped.Add(1)
 }
 
+func (s *Summary) IncSetupFailure() {
+	s.setupFailures.Add(1)
+}
+
+func (s *Summary) IncTimeout() {
+	s.timeouts.Add(1)
+}
+
+// RecordOutcome records the outcome of an iteration of the given test.
+// When a test is repeated, it is used to detect tests that passed some iterations and failed others.
+func (s *Summary) RecordOutcome(test string, passed bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.outcomes == nil {
+		s.outcomes = map[string]*testOutcomes{}
+	}
+	outcomes := s.outcomes[test]
+	if outcomes == nil {
+		outcomes = &testOutcomes{}
+		s.outcomes[test] = outcomes
+	}
+	if passed {
+		outcomes.passed++
+	} else {
+		outcomes.failed++
+	}
+}
+
 func (s *Summary) Passed() int32 {
 	return s.passed.Load()
 }
@@ -39,3 +95,32 @@ func (s *Summary) Failed() int32 {
 func (s *Summary) Skipped() int32 {
 	return s.skipped.Load()
 }
+
+// Flaky returns the sorted names of the tests that both passed and failed across iterations.
+func (s *Summary) Flaky() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var flaky []string
+	for test, outcomes := range s.outcomes {
+		if outcomes.passed > 0 && outcomes.failed > 0 {
+			flaky = append(flaky, test)
+		}
+	}
+	sort.Strings(flaky)
+	return flaky
+}
+
+// Failure returns the dominant failure category of the run.
+// Setup failures take precedence over timeouts, which take precedence over test failures.
+func (s *Summary) Failure() FailureCategory {
+	switch {
+	case s.setupFailures.Load() > 0:
+		return FailureCategorySetup
+	case s.timeouts.Load() > 0:
+		return FailureCategoryTimeout
+	case s.failed.Load() > 0:
+		return FailureCategoryTest
+	default:
+		return FailureCategoryNone
+	}
+}
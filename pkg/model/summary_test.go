@@ -31,3 +31,59 @@ func Test_summay(t *testing.T) {
 	assert.Equal(t, count, s.Passed())
 	assert.Equal(t, count, s.Skipped())
 }
+
+func TestSummary_Failure(t *testing.T) {
+	tests := []struct {
+		name string
+		inc  func(*Summary)
+		want FailureCategory
+	}{{
+		name: "none",
+		inc:  func(s *Summary) { s.IncPassed() },
+		want: FailureCategoryNone,
+	}, {
+		name: "test",
+		inc:  func(s *Summary) { s.IncFailed() },
+		want: FailureCategoryTest,
+	}, {
+		name: "timeout",
+		inc: func(s *Summary) {
+			s.IncFailed()
+			s.IncTimeout()
+		},
+		want: FailureCategoryTimeout,
+	}, {
+		name: "setup",
+		inc: func(s *Summary) {
+			s.IncFailed()
+			s.IncTimeout()
+			s.IncSetupFailure()
+		},
+		want: FailureCategorySetup,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Summary
+			tt.inc(&s)
+			assert.Equal(t, tt.want, s.Failure())
+		})
+	}
+}
+
+func TestSummary_Flaky(t *testing.T) {
+	var s Summary
+	assert.Nil(t, s.Flaky())
+	// flaky test passed some iterations and failed others
+	s.RecordOutcome("chainsaw/flaky", true)
+	s.RecordOutcome("chainsaw/flaky", false)
+	s.RecordOutcome("chainsaw/flaky", true)
+	// stable tests
+	s.RecordOutcome("chainsaw/passing", true)
+	s.RecordOutcome("chainsaw/passing", true)
+	s.RecordOutcome("chainsaw/failing", false)
+	s.RecordOutcome("chainsaw/failing", false)
+	assert.Equal(t, []string{"chainsaw/flaky"}, s.Flaky())
+	s.RecordOutcome("chainsaw/another", false)
+	s.RecordOutcome("chainsaw/another", true)
+	assert.Equal(t, []string{"chainsaw/another", "chainsaw/flaky"}, s.Flaky())
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// TestRef identifies a test in a report.
+type TestRef struct {
+	BasePath string
+	Name     string
+}
+
+// LoadFailed reads a JSON report and returns the tests that failed.
+// A test failed if at least one of its operations reported a failure.
+func LoadFailed(file string) ([]TestRef, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var report struct {
+		Tests []struct {
+			BasePath string `json:"basePath"`
+			Name     string `json:"name"`
+			Steps    []struct {
+				Operations []struct {
+					Failure *struct{} `json:"failure"`
+				} `json:"operations"`
+			} `json:"steps"`
+		} `json:"tests"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	var failed []TestRef
+	seen := map[TestRef]struct{}{}
+	for _, test := range report.Tests {
+		ref := TestRef{BasePath: test.BasePath, Name: test.Name}
+		if _, ok := seen[ref]; ok {
+			continue
+		}
+		for _, step := range test.Steps {
+			for _, operation := range step.Operations {
+				if operation.Failure != nil {
+					seen[ref] = struct{}{}
+				}
+			}
+		}
+		if _, ok := seen[ref]; ok {
+			failed = append(failed, ref)
+		}
+	}
+	return failed, nil
+}
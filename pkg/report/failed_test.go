@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFailed(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		want    []TestRef
+		wantErr bool
+	}{{
+		name: "failures",
+		file: "../../testdata/report/failed.json",
+		want: []TestRef{
+			{BasePath: "tests/foo", Name: "foo"},
+			{BasePath: "tests/baz", Name: "baz"},
+		},
+	}, {
+		name: "no failures",
+		file: "../../testdata/report/passed.json",
+	}, {
+		name:    "not found",
+		file:    "../../testdata/report/not-found.json",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadFailed(tt.file)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
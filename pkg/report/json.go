@@ -17,6 +17,8 @@ func saveJson(report *model.Report, file string) error {
 		Type      model.OperationType `json:"type,omitempty"`
 		StartTime time.Time           `json:"startTime"`
 		EndTime   time.Time           `json:"endTime"`
+		Duration  string              `json:"duration"`
+		Skipped   bool                `json:"skipped,omitempty"`
 		Failure   *Failure            `json:"failure,omitempty"`
 	}
 	type StepReport struct {
@@ -68,6 +70,8 @@ func saveJson(report *model.Report, file string) error {
 					Type:      operation.Type,
 					StartTime: operation.StartTime,
 					EndTime:   operation.EndTime,
+					Duration:  operation.Duration().String(),
+					Skipped:   operation.Skipped,
 				}
 				if operation.Err != nil {
 					operationReport.Failure = &Failure{
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveJson(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := &model.Report{
+		Name:      "chainsaw-report",
+		StartTime: start,
+		EndTime:   start.Add(time.Minute),
+		Tests: []*model.TestReport{{
+			Name:      "test",
+			StartTime: start,
+			EndTime:   start.Add(time.Minute),
+			Steps: []*model.StepReport{{
+				Name:      "step",
+				StartTime: start,
+				EndTime:   start.Add(time.Minute),
+				Operations: []*model.OperationReport{{
+					Name:      "apply",
+					Type:      model.OperationTypeApply,
+					StartTime: start,
+					EndTime:   start.Add(1500 * time.Millisecond),
+				}, {
+					Name:      "assert",
+					Type:      model.OperationTypeAssert,
+					StartTime: start.Add(1500 * time.Millisecond),
+					EndTime:   start.Add(time.Minute),
+					Err:       errors.New("failed"),
+				}},
+			}},
+		}},
+	}
+	file := filepath.Join(t.TempDir(), "report.json")
+	assert.NoError(t, saveJson(report, file))
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	var got struct {
+		StartTime time.Time `json:"startTime"`
+		EndTime   time.Time `json:"endTime"`
+		Tests     []struct {
+			StartTime time.Time `json:"startTime"`
+			EndTime   time.Time `json:"endTime"`
+			Steps     []struct {
+				Operations []struct {
+					Name      string    `json:"name"`
+					StartTime time.Time `json:"startTime"`
+					EndTime   time.Time `json:"endTime"`
+					Duration  string    `json:"duration"`
+					Failure   *struct {
+						Err string `json:"error"`
+					} `json:"failure"`
+				} `json:"operations"`
+			} `json:"steps"`
+		} `json:"tests"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, start, got.StartTime)
+	assert.Equal(t, start.Add(time.Minute), got.EndTime)
+	assert.Len(t, got.Tests, 1)
+	assert.Equal(t, start, got.Tests[0].StartTime)
+	assert.Equal(t, start.Add(time.Minute), got.Tests[0].EndTime)
+	assert.Len(t, got.Tests[0].Steps, 1)
+	operations := got.Tests[0].Steps[0].Operations
+	assert.Len(t, operations, 2)
+	assert.Equal(t, "apply", operations[0].Name)
+	assert.Equal(t, start, operations[0].StartTime)
+	assert.Equal(t, start.Add(1500*time.Millisecond), operations[0].EndTime)
+	assert.Equal(t, "1.5s", operations[0].Duration)
+	assert.Nil(t, operations[0].Failure)
+	assert.Equal(t, "assert", operations[1].Name)
+	assert.Equal(t, "58.5s", operations[1].Duration)
+	assert.Equal(t, "failed", operations[1].Failure.Err)
+}
@@ -4,10 +4,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/jstemmer/go-junit-report/v2/junit"
 	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/chainsaw/pkg/version"
 	"go.uber.org/multierr"
 )
 
@@ -16,7 +18,81 @@ func durationInSecondsString(start, end time.Time) string {
 	return fmt.Sprintf("%.6f", duration.Seconds())
 }
 
-func saveJUnitTest(report *model.Report, file string) error {
+// testsuites, testsuite and testcase extend the junit types to support properties at the test case level.
+type testsuites struct {
+	junit.Testsuites
+	Suites []testsuite `xml:"testsuite,omitempty"`
+}
+
+type testsuite struct {
+	junit.Testsuite
+	Testcases []testcase `xml:"testcase,omitempty"`
+}
+
+type testcase struct {
+	junit.Testcase
+	Properties *[]junit.Property `xml:"properties>property,omitempty"`
+}
+
+func sortedProperties(properties map[string]string) []junit.Property {
+	var out []junit.Property
+	for name, value := range properties {
+		out = append(out, junit.Property{Name: name, Value: value})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+func runProperties(report *model.Report) map[string]string {
+	properties := map[string]string{
+		"chainsaw.version":   version.Version(),
+		"chainsaw.startTime": report.StartTime.Format(time.RFC3339),
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		properties["chainsaw.hostname"] = hostname
+	}
+	return properties
+}
+
+// writeJUnit adds run metadata and user properties to test suites, user properties are added to test cases too.
+func writeJUnit(suites *junit.Testsuites, file string, report *model.Report, properties map[string]string) error {
+	suiteProperties := runProperties(report)
+	for name, value := range properties {
+		suiteProperties[name] = value
+	}
+	sortedSuiteProperties := sortedProperties(suiteProperties)
+	caseProperties := sortedProperties(properties)
+	out := testsuites{
+		Testsuites: *suites,
+	}
+	for _, suite := range suites.Suites {
+		for _, property := range sortedSuiteProperties {
+			suite.AddProperty(property.Name, property.Value)
+		}
+		s := testsuite{
+			Testsuite: suite,
+		}
+		for _, tc := range suite.Testcases {
+			c := testcase{
+				Testcase: tc,
+			}
+			if len(caseProperties) != 0 {
+				c.Properties = &caseProperties
+			}
+			s.Testcases = append(s.Testcases, c)
+		}
+		out.Suites = append(out.Suites, s)
+	}
+	data, err := xml.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o600)
+}
+
+func saveJUnitTest(report *model.Report, file string, properties map[string]string) error {
 	testSuites := &junit.Testsuites{
 		Name: report.Name,
 		Time: durationInSecondsString(report.StartTime, report.EndTime),
@@ -58,14 +134,10 @@ func saveJUnitTest(report *model.Report, file string) error {
 	for folder, tests := range perFolder {
 		addTestSuite(folder, tests...)
 	}
-	data, err := xml.MarshalIndent(testSuites, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(file, data, 0o600)
+	return writeJUnit(testSuites, file, report, properties)
 }
 
-func saveJUnitStep(report *model.Report, file string) error {
+func saveJUnitStep(report *model.Report, file string, properties map[string]string) error {
 	testSuites := &junit.Testsuites{
 		Name: report.Name,
 		Time: durationInSecondsString(report.StartTime, report.EndTime),
@@ -110,14 +182,10 @@ func saveJUnitStep(report *model.Report, file string) error {
 	for _, test := range report.Tests {
 		addTestSuite(test)
 	}
-	data, err := xml.MarshalIndent(testSuites, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(file, data, 0o600)
+	return writeJUnit(testSuites, file, report, properties)
 }
 
-func saveJUnitOperation(report *model.Report, file string) error {
+func saveJUnitOperation(report *model.Report, file string, properties map[string]string) error {
 	testSuites := &junit.Testsuites{
 		Name: report.Name,
 		Time: durationInSecondsString(report.StartTime, report.EndTime),
@@ -145,6 +213,9 @@ func saveJUnitOperation(report *model.Report, file string) error {
 						Classname: string(operation.Type),
 						Time:      durationInSecondsString(operation.StartTime, operation.EndTime),
 					}
+					if operation.Skipped {
+						testCase.Skipped = &junit.Result{}
+					}
 					if err := operation.Err; err != nil {
 						testCase.Failure = &junit.Result{
 							Message: err.Error(),
@@ -159,9 +230,5 @@ func saveJUnitOperation(report *model.Report, file string) error {
 	for _, test := range report.Tests {
 		addTestSuite(test)
 	}
-	data, err := xml.MarshalIndent(testSuites, "", "  ")
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(file, data, 0o600)
+	return writeJUnit(testSuites, file, report, properties)
 }
@@ -0,0 +1,109 @@
+package report
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jstemmer/go-junit-report/v2/junit"
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveJUnitProperties(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := &model.Report{
+		Name:      "chainsaw-report",
+		StartTime: start,
+		EndTime:   start.Add(time.Minute),
+		Tests: []*model.TestReport{{
+			Name:      "test",
+			BasePath:  "tests",
+			Namespace: "chainsaw-test",
+			StartTime: start,
+			EndTime:   start.Add(time.Minute),
+			Steps: []*model.StepReport{{
+				Name:      "step",
+				StartTime: start,
+				EndTime:   start.Add(time.Minute),
+				Operations: []*model.OperationReport{{
+					Name:      "assert",
+					Type:      model.OperationTypeAssert,
+					StartTime: start,
+					EndTime:   start.Add(time.Minute),
+					Err:       errors.New("failed"),
+				}},
+			}},
+		}},
+	}
+	properties := map[string]string{
+		"commit": "abcdef",
+		"branch": "main",
+	}
+	type result struct {
+		Suites []struct {
+			Properties []junit.Property `xml:"properties>property"`
+			Testcases  []struct {
+				Name       string           `xml:"name,attr"`
+				Properties []junit.Property `xml:"properties>property"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	tests := []struct {
+		name string
+		save func(*model.Report, string, map[string]string) error
+	}{{
+		name: "test",
+		save: saveJUnitTest,
+	}, {
+		name: "step",
+		save: saveJUnitStep,
+	}, {
+		name: "operation",
+		save: saveJUnitOperation,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "report.xml")
+			assert.NoError(t, tt.save(report, file, properties))
+			data, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			var got result
+			assert.NoError(t, xml.Unmarshal(data, &got))
+			assert.Len(t, got.Suites, 1)
+			suite := got.Suites[0]
+			assert.Contains(t, suite.Properties, junit.Property{Name: "branch", Value: "main"})
+			assert.Contains(t, suite.Properties, junit.Property{Name: "commit", Value: "abcdef"})
+			assert.Contains(t, suite.Properties, junit.Property{Name: "chainsaw.startTime", Value: "2024-01-01T00:00:00Z"})
+			assert.NotEmpty(t, suite.Testcases)
+			for _, testCase := range suite.Testcases {
+				assert.Equal(t, []junit.Property{{Name: "branch", Value: "main"}, {Name: "commit", Value: "abcdef"}}, testCase.Properties)
+			}
+		})
+	}
+}
+
+func TestSaveJUnitWithoutProperties(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := &model.Report{
+		Name:      "chainsaw-report",
+		StartTime: start,
+		EndTime:   start.Add(time.Minute),
+		Tests: []*model.TestReport{{
+			Name:      "test",
+			StartTime: start,
+			EndTime:   start.Add(time.Minute),
+			Skipped:   true,
+		}},
+	}
+	file := filepath.Join(t.TempDir(), "report.xml")
+	assert.NoError(t, saveJUnitTest(report, file, nil))
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `<property name="chainsaw.version"`)
+	assert.Contains(t, string(data), `<testcase name="test" classname="" time="60.000000">`)
+	assert.NotContains(t, string(data), "<testcase name=\"test\" classname=\"\" time=\"60.000000\">\n      <properties>")
+}
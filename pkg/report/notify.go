@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/kyverno/chainsaw/pkg/model"
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
+	"k8s.io/client-go/util/retry"
+)
+
+const DefaultNotificationTemplate = `{"text": {{ printf "Chainsaw %s: %d passed, %d failed, %d skipped (%s)" .Report.Name .Summary.Passed .Summary.Failed .Summary.Skipped (.Report.EndTime.Sub .Report.StartTime) | toJson }}}`
+
+type notificationData struct {
+	Summary notificationSummary
+	Report  *model.Report
+}
+
+type notificationSummary struct {
+	Passed  int32
+	Failed  int32
+	Skipped int32
+}
+
+type notificationError struct {
+	statusCode int
+	status     string
+}
+
+func (e notificationError) Error() string {
+	return fmt.Sprintf("notification failed: %s", e.status)
+}
+
+func Notify(ctx context.Context, summary model.SummaryResult, report *model.Report, url, tmpl string) error {
+	if tmpl == "" {
+		tmpl = DefaultNotificationTemplate
+	}
+	parsed, err := template.New("notification").Funcs(sprig.TxtFuncMap()).Parse(tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to parse notification template (%w)", err)
+	}
+	data := notificationData{
+		Summary: notificationSummary{
+			Passed:  summary.Passed(),
+			Failed:  summary.Failed(),
+			Skipped: summary.Skipped(),
+		},
+		Report: report,
+	}
+	var body bytes.Buffer
+	if err := parsed.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to render notification template (%w)", err)
+	}
+	// retry on transport errors and server errors only
+	retriable := func(err error) bool {
+		if err, ok := err.(notificationError); ok {
+			return err.statusCode >= http.StatusInternalServerError
+		}
+		return true
+	}
+	return retry.OnError(retry.DefaultBackoff, retriable, func() error {
+		return post(ctx, url, body.Bytes())
+	})
+}
+
+func post(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httputils.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return notificationError{
+			statusCode: resp.StatusCode,
+			status:     resp.Status,
+		}
+	}
+	return nil
+}
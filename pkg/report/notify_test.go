@@ -0,0 +1,89 @@
+package report
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(t *testing.T) {
+	summary := &model.Summary{}
+	summary.IncPassed()
+	summary.IncPassed()
+	summary.IncFailed()
+	report := &model.Report{
+		Name:      "chainsaw-report",
+		StartTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name     string
+		template string
+		statuses []int
+		want     string
+		calls    int32
+		wantErr  bool
+	}{{
+		name:     "default template",
+		statuses: []int{http.StatusOK},
+		want:     `{"text": "Chainsaw chainsaw-report: 2 passed, 1 failed, 0 skipped (1m0s)"}`,
+		calls:    1,
+	}, {
+		name:     "custom template",
+		template: `{"failed": {{ .Summary.Failed }}, "tests": {{ len .Report.Tests }}}`,
+		statuses: []int{http.StatusOK},
+		want:     `{"failed": 1, "tests": 0}`,
+		calls:    1,
+	}, {
+		name:     "retry on server error",
+		statuses: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+		want:     `{"text": "Chainsaw chainsaw-report: 2 passed, 1 failed, 0 skipped (1m0s)"}`,
+		calls:    3,
+	}, {
+		name:     "no retry on client error",
+		statuses: []int{http.StatusBadRequest},
+		calls:    1,
+		wantErr:  true,
+	}, {
+		name:     "too many server errors",
+		statuses: []int{http.StatusInternalServerError},
+		calls:    4,
+		wantErr:  true,
+	}, {
+		name:     "bad template",
+		template: `{{ .Foo `,
+		calls:    0,
+		wantErr:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			var body string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1)) - 1
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				data, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				body = string(data)
+				w.WriteHeader(tt.statuses[min(call, len(tt.statuses)-1)])
+			}))
+			defer server.Close()
+			err := Notify(context.TODO(), summary, report, server.URL, tt.template)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, body)
+			}
+			assert.Equal(t, tt.calls, calls.Load())
+		})
+	}
+}
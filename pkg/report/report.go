@@ -9,7 +9,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/model"
 )
 
-func Save(report *model.Report, format v1alpha2.ReportFormatType, path, name string) error {
+func Save(report *model.Report, format v1alpha2.ReportFormatType, path, name string, properties map[string]string) error {
 	getFile := func(extension string) string {
 		if filepath.Ext(name) == "" {
 			name += "." + strings.ToLower(extension)
@@ -22,11 +22,11 @@ func Save(report *model.Report, format v1alpha2.ReportFormatType, path, name str
 	}
 	switch format {
 	case v1alpha2.XMLFormat, v1alpha2.JUnitTestFormat:
-		return saveJUnitTest(report, getFile("xml"))
+		return saveJUnitTest(report, getFile("xml"), properties)
 	case v1alpha2.JUnitStepFormat:
-		return saveJUnitStep(report, getFile("xml"))
+		return saveJUnitStep(report, getFile("xml"), properties)
 	case v1alpha2.JUnitOperationFormat:
-		return saveJUnitOperation(report, getFile("xml"))
+		return saveJUnitOperation(report, getFile("xml"), properties)
 	case v1alpha2.JSONFormat:
 		return saveJson(report, getFile("json"))
 	default:
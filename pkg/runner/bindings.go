@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var invalidBindingChars = regexp.MustCompile(`\W`)
+
+// withBindingsFrom registers the keys of the referenced Secrets and ConfigMaps as bindings.
+// Secret values are base64 decoded and returned so that they can be redacted from the logs.
+// A key can't shadow a built-in binding.
+func withBindingsFrom(ctx context.Context, tc engine.Context, sources ...v1alpha2.BindingSource) (engine.Context, []string, error) {
+	if len(sources) == 0 {
+		return tc, nil, nil
+	}
+	_, c, err := tc.CurrentClusterClient()
+	if err != nil {
+		return tc, nil, err
+	}
+	if c == nil {
+		return tc, nil, errors.New("a cluster is required to load bindings from secrets or config maps")
+	}
+	var redacted []string
+	for _, source := range sources {
+		values, err := loadBindingSource(ctx, c, source)
+		if err != nil {
+			return tc, nil, err
+		}
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := source.Prefix + invalidBindingChars.ReplaceAllString(key, "_")
+			if bindings.IsReserved(name) {
+				return tc, nil, fmt.Errorf("failed to load bindings from %s %s/%s: key %s would shadow the built-in $%s binding", source.Kind, source.Namespace, source.Name, key, name)
+			}
+			if source.Kind == "Secret" {
+				redacted = append(redacted, values[key])
+			}
+			tc = tc.WithBinding(ctx, name, values[key])
+		}
+	}
+	return tc, redacted, nil
+}
+
+func loadBindingSource(ctx context.Context, c client.Client, source v1alpha2.BindingSource) (map[string]string, error) {
+	var obj unstructured.Unstructured
+	obj.SetAPIVersion("v1")
+	obj.SetKind(source.Kind)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: source.Namespace, Name: source.Name}, &obj); err != nil {
+		return nil, fmt.Errorf("failed to load bindings from %s %s/%s: %w", source.Kind, source.Namespace, source.Name, err)
+	}
+	values := map[string]string{}
+	switch source.Kind {
+	case "Secret":
+		if err := decodeData(obj, values, "data"); err != nil {
+			return nil, fmt.Errorf("failed to load bindings from %s %s/%s: %w", source.Kind, source.Namespace, source.Name, err)
+		}
+	case "ConfigMap":
+		data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load bindings from %s %s/%s: %w", source.Kind, source.Namespace, source.Name, err)
+		}
+		for key, value := range data {
+			values[key] = value
+		}
+		if err := decodeData(obj, values, "binaryData"); err != nil {
+			return nil, fmt.Errorf("failed to load bindings from %s %s/%s: %w", source.Kind, source.Namespace, source.Name, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported binding source kind %s", source.Kind)
+	}
+	return values, nil
+}
+
+func decodeData(obj unstructured.Unstructured, values map[string]string, field string) error {
+	data, _, err := unstructured.NestedStringMap(obj.Object, field)
+	if err != nil {
+		return err
+	}
+	for key, value := range data {
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("failed to decode key %s: %w", key, err)
+		}
+		values[key] = string(decoded)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+)
+
+func Test_withBindingsFrom(t *testing.T) {
+	objects := map[string]map[string]any{
+		"Secret/chainsaw/credentials": {
+			"data": map[string]any{
+				// admin
+				"user": "YWRtaW4=",
+				// hunter2
+				"db.password": "aHVudGVyMg==",
+			},
+		},
+		"ConfigMap/chainsaw/settings": {
+			"data": map[string]any{
+				"endpoint": "https://example.com",
+			},
+		},
+		"ConfigMap/chainsaw/reserved": {
+			"data": map[string]any{
+				"namespace": "default",
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		sources      []v1alpha2.BindingSource
+		want         map[string]any
+		wantRedacted []string
+		wantErr      string
+	}{{
+		name: "none",
+	}, {
+		name: "secret",
+		sources: []v1alpha2.BindingSource{{
+			Kind:      "Secret",
+			Namespace: "chainsaw",
+			Name:      "credentials",
+		}},
+		want: map[string]any{
+			"$user":        "admin",
+			"$db_password": "hunter2",
+		},
+		wantRedacted: []string{"hunter2", "admin"},
+	}, {
+		name: "config map with prefix",
+		sources: []v1alpha2.BindingSource{{
+			Kind:      "ConfigMap",
+			Namespace: "chainsaw",
+			Name:      "settings",
+			Prefix:    "settings_",
+		}},
+		want: map[string]any{
+			"$settings_endpoint": "https://example.com",
+		},
+	}, {
+		name: "missing",
+		sources: []v1alpha2.BindingSource{{
+			Kind:      "Secret",
+			Namespace: "chainsaw",
+			Name:      "missing",
+		}},
+		wantErr: `failed to load bindings from Secret chainsaw/missing: secrets "missing" not found`,
+	}, {
+		name: "reserved",
+		sources: []v1alpha2.BindingSource{{
+			Kind:      "ConfigMap",
+			Namespace: "chainsaw",
+			Name:      "reserved",
+		}},
+		wantErr: "failed to load bindings from ConfigMap chainsaw/reserved: key namespace would shadow the built-in $namespace binding",
+	}, {
+		name: "reserved with prefix",
+		sources: []v1alpha2.BindingSource{{
+			Kind:      "ConfigMap",
+			Namespace: "chainsaw",
+			Name:      "reserved",
+			Prefix:    "cm_",
+		}},
+		want: map[string]any{
+			"$cm_namespace": "default",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := clusters.NewRegistry(func(cluster clusters.Cluster) (*rest.Config, client.Client, error) {
+				return nil, &tclient.FakeClient{
+					GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+						kind := obj.GetObjectKind().GroupVersionKind().Kind
+						content, ok := objects[kind+"/"+key.Namespace+"/"+key.Name]
+						if !ok {
+							return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+						}
+						u := obj.(*unstructured.Unstructured)
+						for k, v := range content {
+							u.Object[k] = v
+						}
+						return nil
+					},
+				}, nil
+			})
+			cluster, err := clusters.NewClusterFromConfig(&rest.Config{})
+			assert.NoError(t, err)
+			tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+			tc = tc.WithCluster(context.TODO(), clusters.DefaultClient, cluster)
+			tc = tc.WithCurrentCluster(context.TODO(), clusters.DefaultClient)
+			tc, redacted, err := withBindingsFrom(context.TODO(), tc, tt.sources...)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRedacted, redacted)
+			for name, want := range tt.want {
+				binding, err := tc.Bindings().Get(name)
+				assert.NoError(t, err)
+				value, err := binding.Value()
+				assert.NoError(t, err)
+				assert.Equal(t, want, value)
+			}
+		})
+	}
+}
+
+func Test_withBindingsFrom_NoCluster(t *testing.T) {
+	tc := enginecontext.MakeContext(apis.NewBindings(), clusters.NewRegistry(nil))
+	_, _, err := withBindingsFrom(context.TODO(), tc, v1alpha2.BindingSource{Kind: "Secret", Namespace: "chainsaw", Name: "credentials"})
+	assert.EqualError(t, err, "a cluster is required to load bindings from secrets or config maps")
+}
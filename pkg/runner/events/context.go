@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+func FromContext(ctx context.Context) Sink {
+	if ctx != nil {
+		if v, ok := ctx.Value(contextKey{}).(Sink); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func IntoContext(ctx context.Context, sink Sink) context.Context {
+	return context.WithValue(ctx, contextKey{}, sink)
+}
+
+// Send forwards the event to the sink registered in the context, if any.
+func Send(ctx context.Context, event Event) {
+	if sink := FromContext(ctx); sink != nil {
+		sink.Send(event)
+	}
+}
@@ -0,0 +1,24 @@
+package events
+
+type Type string
+
+const (
+	TestStarted Type = "TestStarted"
+	TestPassed  Type = "TestPassed"
+	TestFailed  Type = "TestFailed"
+	TestSkipped Type = "TestSkipped"
+)
+
+// Event describes a change in the lifecycle of a test.
+type Event struct {
+	Type Type
+	// Id uniquely identifies a test run (scenarios and repeated runs share the same name).
+	Id string
+	// Name is the display name of the test.
+	Name string
+}
+
+// Sink receives the events emitted by the runner.
+type Sink interface {
+	Send(Event)
+}
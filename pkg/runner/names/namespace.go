@@ -0,0 +1,10 @@
+package names
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+func Namespace(rand *rand.Rand) string {
+	return fmt.Sprintf("chainsaw-%s-%s", adjectives[rand.Intn(len(adjectives))], animals[rand.Intn(len(animals))])
+}
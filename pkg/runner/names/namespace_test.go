@@ -0,0 +1,28 @@
+package names
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespace(t *testing.T) {
+	generate := func(seed int64) []string {
+		rand := rand.New(rand.NewSource(seed))
+		var out []string
+		for range 10 {
+			out = append(out, Namespace(rand))
+		}
+		return out
+	}
+	first := generate(42)
+	second := generate(42)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, generate(43))
+	for _, name := range first {
+		assert.True(t, strings.HasPrefix(name, "chainsaw-"))
+		assert.Len(t, strings.Split(name, "-"), 3)
+	}
+}
@@ -0,0 +1,98 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+)
+
+// MarkerFile is the file created in every run artifacts directory, only directories containing it are pruned.
+const MarkerFile = ".chainsaw-run"
+
+const dirLayout = "20060102-150405.000000000"
+
+type run struct {
+	path      string
+	startTime time.Time
+}
+
+// Prepare prunes the run artifacts directories of previous runs according to the retention policy
+// and creates the artifacts directory of the current run, it returns the path of the created directory.
+func Prepare(dir string, now time.Time, retention *v1alpha2.RetentionOptions) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	now = now.UTC()
+	path := filepath.Join(dir, now.Format(dirLayout))
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create run artifacts directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, MarkerFile), []byte(now.Format(time.RFC3339Nano)), 0o600); err != nil {
+		return "", fmt.Errorf("failed to create run artifacts directory: %w", err)
+	}
+	if retention != nil {
+		if err := prune(dir, path, now, retention); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// prune deletes the run artifacts directories exceeding the retention policy, the current run directory is always kept.
+func prune(dir string, current string, now time.Time, retention *v1alpha2.RetentionOptions) error {
+	runs, err := list(dir)
+	if err != nil {
+		return err
+	}
+	// most recent runs first
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].startTime.After(runs[j].startTime)
+	})
+	kept := 0
+	for _, run := range runs {
+		if run.path == current {
+			kept++
+			continue
+		}
+		expired := retention.MaxAge != nil && now.Sub(run.startTime) > retention.MaxAge.Duration
+		exceeded := retention.MaxRuns != nil && kept >= *retention.MaxRuns
+		if !expired && !exceeded {
+			kept++
+			continue
+		}
+		if err := os.RemoveAll(run.path); err != nil {
+			return fmt.Errorf("failed to prune run artifacts directory %s: %w", run.path, err)
+		}
+	}
+	return nil
+}
+
+// list returns the run artifacts directories found in dir, directories without a valid marker file are ignored.
+func list(dir string) ([]run, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output directory: %w", err)
+	}
+	var runs []run
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(filepath.Join(path, MarkerFile))
+		if err != nil {
+			continue
+		}
+		startTime, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+		if err != nil {
+			continue
+		}
+		runs = append(runs, run{path: path, startTime: startTime})
+	}
+	return runs, nil
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestPrepare(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		retention *v1alpha2.RetentionOptions
+		want      []string
+	}{{
+		name: "no retention",
+		want: []string{"20240610-080000.000000000", "20240610-100000.000000000", "20240610-110000.000000000", "20240610-120000.000000000", "unmarked"},
+	}, {
+		name:      "max runs",
+		retention: &v1alpha2.RetentionOptions{MaxRuns: ptr.To(2)},
+		want:      []string{"20240610-110000.000000000", "20240610-120000.000000000", "unmarked"},
+	}, {
+		name:      "max age",
+		retention: &v1alpha2.RetentionOptions{MaxAge: &metav1.Duration{Duration: 3 * time.Hour}},
+		want:      []string{"20240610-100000.000000000", "20240610-110000.000000000", "20240610-120000.000000000", "unmarked"},
+	}, {
+		name: "max runs and max age",
+		retention: &v1alpha2.RetentionOptions{
+			MaxRuns: ptr.To(3),
+			MaxAge:  &metav1.Duration{Duration: 90 * time.Minute},
+		},
+		want: []string{"20240610-110000.000000000", "20240610-120000.000000000", "unmarked"},
+	}, {
+		name:      "keeps the current run",
+		retention: &v1alpha2.RetentionOptions{MaxRuns: ptr.To(1), MaxAge: &metav1.Duration{}},
+		want:      []string{"20240610-120000.000000000", "unmarked"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, hours := range []int{4, 2, 1} {
+				_, err := Prepare(dir, now.Add(-time.Duration(hours)*time.Hour), nil)
+				assert.NoError(t, err)
+			}
+			// directories without a marker file are never pruned
+			assert.NoError(t, os.Mkdir(filepath.Join(dir, "unmarked"), 0o755))
+			path, err := Prepare(dir, now, tt.retention)
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, "20240610-120000.000000000"), path)
+			assert.FileExists(t, filepath.Join(path, MarkerFile))
+			entries, err := os.ReadDir(dir)
+			assert.NoError(t, err)
+			var got []string
+			for _, entry := range entries {
+				got = append(got, entry.Name())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,11 +2,15 @@ package processors
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/expressions"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -17,6 +21,13 @@ type namespaceData struct {
 	compilers compilers.Compilers
 	template  *v1alpha1.Projection
 	cleaner   cleaner.CleanerCollector
+	existing  v1alpha2.NamespaceExistingPolicy
+	// confirmRecreate must be set for the existing namespace to be recreated
+	confirmRecreate bool
+	// timeout bounds the deletion of an existing namespace when it is recreated
+	timeout time.Duration
+	// created is set by setupContextData when the namespace didn't exist and was created
+	created bool
 }
 
 type contextData struct {
@@ -29,12 +40,19 @@ type contextData struct {
 }
 
 func setupContextData(ctx context.Context, tc engine.Context, data contextData) (engine.Context, *corev1.Namespace, error) {
-	tc = engine.WithClusters(ctx, tc, data.basePath, data.clusters)
+	if _tc, err := engine.WithClusters(ctx, tc, data.basePath, data.clusters); err != nil {
+		return tc, nil, err
+	} else {
+		tc = _tc
+	}
 	if data.dryRun != nil {
 		tc = tc.WithDryRun(ctx, *data.dryRun)
 	}
 	if data.cluster != nil {
-		if _tc, err := engine.WithCurrentCluster(ctx, tc, *data.cluster); err != nil {
+		// the cluster name is resolved at execution time to support bindings (scenarios, step info, etc...)
+		if cluster, err := expressions.String(ctx, tc.Compilers(), *data.cluster, tc.Bindings()); err != nil {
+			return tc, nil, err
+		} else if _tc, err := engine.WithCurrentCluster(ctx, tc, cluster); err != nil {
 			return tc, nil, err
 		} else {
 			tc = _tc
@@ -47,14 +65,34 @@ func setupContextData(ctx context.Context, tc engine.Context, data contextData)
 		} else if _, clusterClient, err := tc.CurrentClusterClient(); err != nil {
 			return tc, nil, err
 		} else if clusterClient != nil {
+			exists := true
 			if err := clusterClient.Get(ctx, client.Key(namespace), namespace.DeepCopy()); err != nil {
 				if !errors.IsNotFound(err) {
 					return tc, nil, err
-				} else if err := clusterClient.Create(ctx, namespace.DeepCopy()); err != nil {
+				}
+				exists = false
+			}
+			if exists {
+				switch data.namespace.existing {
+				case v1alpha2.NamespaceExistingFail:
+					return tc, nil, fmt.Errorf("namespace %s already exists", namespace.GetName())
+				case v1alpha2.NamespaceExistingRecreate:
+					if !data.namespace.confirmRecreate {
+						return tc, nil, fmt.Errorf("refusing to recreate namespace %s, recreating a namespace deletes everything it contains and must be confirmed with confirmRecreate", namespace.GetName())
+					}
+					if err := deleteExistingNamespace(ctx, clusterClient, namespace, data.namespace.timeout); err != nil {
+						return tc, nil, err
+					}
+					exists = false
+				}
+			}
+			if !exists {
+				if err := clusterClient.Create(ctx, namespace.DeepCopy()); err != nil {
 					return tc, nil, err
 				} else if data.namespace.cleaner != nil {
 					data.namespace.cleaner.Add(clusterClient, namespace)
 				}
+				data.namespace.created = true
 			}
 			ns = namespace
 		}
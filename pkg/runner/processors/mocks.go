@@ -6,18 +6,33 @@ import (
 	"k8s.io/client-go/rest"
 )
 
-type registryMock struct {
+type clusterMock struct {
 	client client.Client
 }
 
+func (c clusterMock) Config() (*rest.Config, error) {
+	return nil, nil
+}
+
+type registryMock struct {
+	client   client.Client
+	clusters map[string]client.Client
+}
+
 func (r registryMock) Register(string, clusters.Cluster) clusters.Registry {
 	return r
 }
 
-func (r registryMock) Lookup(string) clusters.Cluster {
+func (r registryMock) Lookup(name string) clusters.Cluster {
+	if client, ok := r.clusters[name]; ok {
+		return clusterMock{client: client}
+	}
 	return nil
 }
 
-func (r registryMock) Build(clusters.Cluster) (*rest.Config, client.Client, error) {
+func (r registryMock) Build(cluster clusters.Cluster) (*rest.Config, client.Client, error) {
+	if cluster, ok := cluster.(clusterMock); ok {
+		return nil, cluster.client, nil
+	}
 	return nil, r.client, nil
 }
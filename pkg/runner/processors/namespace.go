@@ -2,9 +2,14 @@ package processors
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
 	"github.com/kyverno/chainsaw/pkg/engine/bindings"
 	"github.com/kyverno/chainsaw/pkg/engine/templating"
 	"github.com/kyverno/chainsaw/pkg/utils/kube"
@@ -29,3 +34,37 @@ func buildNamespace(ctx context.Context, compilers compilers.Compilers, name str
 	}
 	return convert.To[corev1.Namespace](merged)
 }
+
+func keepNamespace(policy v1alpha2.NamespaceCleanupPolicy, failed bool) bool {
+	switch policy {
+	case v1alpha2.NamespaceCleanupNever:
+		return true
+	case v1alpha2.NamespaceCleanupOnFailure:
+		return failed
+	case v1alpha2.NamespaceCleanupOnSuccess:
+		return !failed
+	default:
+		return false
+	}
+}
+
+// deleteExistingNamespace deletes a namespace that must be recreated and waits until the deletion completes.
+// System namespaces are never deleted.
+func deleteExistingNamespace(ctx context.Context, c client.Client, namespace *corev1.Namespace, timeout time.Duration) error {
+	name := namespace.GetName()
+	if name == "default" || strings.HasPrefix(name, "kube-") {
+		return fmt.Errorf("refusing to recreate system namespace %s", name)
+	}
+	if err := c.Delete(ctx, namespace.DeepCopy()); err != nil {
+		return err
+	}
+	if timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := client.WaitForDeletion(ctx, c, namespace.DeepCopy()); err != nil {
+		return fmt.Errorf("failed to wait for namespace %s deletion: %w", name, err)
+	}
+	return nil
+}
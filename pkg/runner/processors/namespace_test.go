@@ -0,0 +1,73 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_keepNamespace(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy v1alpha2.NamespaceCleanupPolicy
+		failed bool
+		want   bool
+	}{{
+		name:   "default with success",
+		policy: "",
+		failed: false,
+		want:   false,
+	}, {
+		name:   "default with failure",
+		policy: "",
+		failed: true,
+		want:   false,
+	}, {
+		name:   "always with success",
+		policy: v1alpha2.NamespaceCleanupAlways,
+		failed: false,
+		want:   false,
+	}, {
+		name:   "always with failure",
+		policy: v1alpha2.NamespaceCleanupAlways,
+		failed: true,
+		want:   false,
+	}, {
+		name:   "never with success",
+		policy: v1alpha2.NamespaceCleanupNever,
+		failed: false,
+		want:   true,
+	}, {
+		name:   "never with failure",
+		policy: v1alpha2.NamespaceCleanupNever,
+		failed: true,
+		want:   true,
+	}, {
+		name:   "on success with success",
+		policy: v1alpha2.NamespaceCleanupOnSuccess,
+		failed: false,
+		want:   true,
+	}, {
+		name:   "on success with failure",
+		policy: v1alpha2.NamespaceCleanupOnSuccess,
+		failed: true,
+		want:   false,
+	}, {
+		name:   "on failure with success",
+		policy: v1alpha2.NamespaceCleanupOnFailure,
+		failed: false,
+		want:   false,
+	}, {
+		name:   "on failure with failure",
+		policy: v1alpha2.NamespaceCleanupOnFailure,
+		failed: true,
+		want:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := keepNamespace(tt.policy, tt.failed)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,18 +2,29 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/engine"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	"github.com/kyverno/chainsaw/pkg/engine/outputs"
 	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/chainsaw/pkg/runner/tracing"
 	"github.com/kyverno/pkg/ext/output/color"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type operationFactory = func(context.Context, engine.Context) (operations.Operation, *time.Duration, engine.Context, error)
 
+// operationFunc adapts a function to the operations.Operation interface.
+type operationFunc func(context.Context, apis.Bindings) (outputs.Outputs, error)
+
+func (f operationFunc) Exec(ctx context.Context, bindings apis.Bindings) (outputs.Outputs, error) {
+	return f(ctx, bindings)
+}
+
 type operation struct {
 	info      OperationInfo
 	opType    model.OperationType
@@ -37,10 +48,25 @@ func (o operation) execute(ctx context.Context, tc engine.Context, stepReport *m
 		Type:      o.opType,
 		StartTime: time.Now(),
 	}
+	ctx, span := tracing.Start(
+		ctx,
+		string(o.opType),
+		attribute.Int("chainsaw.operation.id", o.info.Id),
+		attribute.Int("chainsaw.operation.resourceId", o.info.ResourceId),
+	)
 	defer func() {
 		report.EndTime = time.Now()
-		report.Err = err
+		// an operation requesting the test to be skipped did not fail
+		skipped := errors.Is(err, operations.ErrSkip)
+		report.Skipped = skipped
+		if !skipped {
+			report.Err = err
+			if err != nil {
+				span.RecordError(err)
+			}
+		}
 		stepReport.Add(report)
+		tracing.End(span, err != nil && !skipped, skipped)
 	}()
 	if operation, timeout, tc, err := o.operation(ctx, tc.WithBinding(ctx, "operation", o.info)); err != nil {
 		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
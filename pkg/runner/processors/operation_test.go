@@ -22,6 +22,7 @@ func TestOperation_Execute(t *testing.T) {
 		expectedFail bool
 		operation    operations.Operation
 		timeout      time.Duration
+		minDuration  time.Duration
 	}{{
 		name: "operation fails but continues",
 		operation: mock.MockOperation{
@@ -47,6 +48,16 @@ func TestOperation_Execute(t *testing.T) {
 			},
 		},
 		timeout: 1 * time.Second,
+	}, {
+		name: "operation duration is reported",
+		operation: mock.MockOperation{
+			ExecFn: func(_ context.Context, _ apis.Bindings) (outputs.Outputs, error) {
+				time.Sleep(20 * time.Millisecond)
+				return nil, nil
+			},
+		},
+		timeout:     1 * time.Second,
+		minDuration: 20 * time.Millisecond,
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -60,12 +71,19 @@ func TestOperation_Execute(t *testing.T) {
 			)
 			tcontext := enginecontext.EmptyContext()
 			ctx := context.Background()
-			_, err := op.execute(ctx, tcontext, &model.StepReport{})
+			stepReport := &model.StepReport{}
+			_, err := op.execute(ctx, tcontext, stepReport)
 			if localTC.expectedFail {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Len(t, stepReport.Operations, 1)
+			operationReport := stepReport.Operations[0]
+			assert.Equal(t, model.OperationTypeApply, operationReport.Type)
+			assert.False(t, operationReport.StartTime.IsZero())
+			assert.False(t, operationReport.EndTime.Before(operationReport.StartTime))
+			assert.GreaterOrEqual(t, operationReport.Duration(), localTC.minDuration)
 		})
 	}
 }
@@ -0,0 +1,72 @@
+package processors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/bindings"
+	"github.com/kyverno/chainsaw/pkg/engine/templating"
+	"github.com/kyverno/chainsaw/pkg/utils/kube"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
+	"github.com/kyverno/pkg/ext/resource/convert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const resourceQuotaName = "chainsaw-quota"
+
+func buildResourceQuota(ctx context.Context, compilers compilers.Compilers, namespace string, template v1alpha1.Projection, tc engine.Context) (*corev1.ResourceQuota, error) {
+	quota := corev1.ResourceQuota{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ResourceQuota",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      resourceQuotaName,
+		},
+	}
+	object := kube.ToUnstructured(&quota)
+	merged, err := templating.TemplateAndMerge(ctx, compilers, object, bindings.RegisterBinding(ctx, tc.Bindings(), "namespace", namespace), template)
+	if err != nil {
+		return nil, err
+	}
+	return convert.To[corev1.ResourceQuota](merged)
+}
+
+func setupResourceQuota(ctx context.Context, tc engine.Context, compilers compilers.Compilers, namespace string, template *v1alpha1.Projection, cleaner cleaner.CleanerCollector) error {
+	if template == nil || template.Value() == nil {
+		return nil
+	}
+	if namespace == "" {
+		return errors.New("a namespace is required to create the test resource quota")
+	}
+	_, clusterClient, err := tc.CurrentClusterClient()
+	if err != nil {
+		return err
+	}
+	if clusterClient == nil {
+		return errors.New("a cluster is required to create the test resource quota")
+	}
+	quota, err := buildResourceQuota(ctx, compilers, namespace, *template, tc)
+	if err != nil {
+		return err
+	}
+	if err := clusterClient.Get(ctx, client.Key(quota), quota.DeepCopy()); err == nil {
+		return nil
+	} else if !kerrors.IsNotFound(err) {
+		return err
+	}
+	if err := clusterClient.Create(ctx, quota.DeepCopy()); err != nil {
+		return err
+	}
+	if cleaner != nil {
+		cleaner.Add(clusterClient, quota)
+	}
+	return nil
+}
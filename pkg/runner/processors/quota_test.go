@@ -0,0 +1,113 @@
+package processors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+func Test_buildResourceQuota(t *testing.T) {
+	tc := enginecontext.MakeContext(apis.NewBindings(), clusters.NewRegistry(nil))
+	template := v1alpha1.NewProjection(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]any{
+				"namespace": "($namespace)",
+			},
+		},
+		"spec": map[string]any{
+			"hard": map[string]any{
+				"pods": "10",
+			},
+		},
+	})
+	got, err := buildResourceQuota(context.TODO(), apis.DefaultCompilers, "chainsaw", template, tc)
+	assert.NoError(t, err)
+	assert.Equal(t, "chainsaw-quota", got.Name)
+	assert.Equal(t, "chainsaw", got.Namespace)
+	assert.Equal(t, map[string]string{"namespace": "chainsaw"}, got.Labels)
+	assert.Equal(t, resource.MustParse("10"), got.Spec.Hard[corev1.ResourcePods])
+}
+
+func Test_setupResourceQuota(t *testing.T) {
+	template := ptr.To(v1alpha1.NewProjection(map[string]any{
+		"spec": map[string]any{
+			"hard": map[string]any{
+				"pods": "10",
+			},
+		},
+	}))
+	tests := []struct {
+		name        string
+		namespace   string
+		template    *v1alpha1.Projection
+		exists      bool
+		wantCreated []string
+		wantErr     string
+	}{{
+		name:      "no template",
+		namespace: "chainsaw",
+	}, {
+		name:        "create",
+		namespace:   "chainsaw",
+		template:    template,
+		wantCreated: []string{"chainsaw/chainsaw-quota"},
+	}, {
+		name:      "already exists",
+		namespace: "chainsaw",
+		template:  template,
+		exists:    true,
+	}, {
+		name:     "no namespace",
+		template: template,
+		wantErr:  "a namespace is required to create the test resource quota",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []string
+			registry := clusters.NewRegistry(func(cluster clusters.Cluster) (*rest.Config, client.Client, error) {
+				return nil, &tclient.FakeClient{
+					GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+						if tt.exists {
+							return nil
+						}
+						return kerrors.NewNotFound(corev1.Resource("resourcequotas"), key.Name)
+					},
+					CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+						quota := obj.(*corev1.ResourceQuota)
+						assert.Equal(t, resource.MustParse("10"), quota.Spec.Hard[corev1.ResourcePods])
+						created = append(created, obj.GetNamespace()+"/"+obj.GetName())
+						return nil
+					},
+				}, nil
+			})
+			cluster, err := clusters.NewClusterFromConfig(&rest.Config{})
+			assert.NoError(t, err)
+			tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+			tc = tc.WithCluster(context.TODO(), clusters.DefaultClient, cluster)
+			tc = tc.WithCurrentCluster(context.TODO(), clusters.DefaultClient)
+			cleaner := cleaner.New(0, nil, metav1.DeletePropagationBackground)
+			err = setupResourceQuota(context.TODO(), tc, apis.DefaultCompilers, tt.namespace, tt.template, cleaner)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, created)
+			assert.Equal(t, len(tt.wantCreated) == 0, cleaner.Empty())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/engine"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/client-go/rest"
+)
+
+func buildServiceAccount(namespace, name string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+func buildRoleBindings(namespace, name string, roles []string, clusterRoles []string) []*rbacv1.RoleBinding {
+	var bindings []*rbacv1.RoleBinding
+	binding := func(kind, role string) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "RoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s-%d", name, len(bindings)),
+			},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Namespace: namespace,
+				Name:      name,
+			}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     kind,
+				Name:     role,
+			},
+		}
+	}
+	for _, role := range roles {
+		bindings = append(bindings, binding("Role", role))
+	}
+	for _, role := range clusterRoles {
+		bindings = append(bindings, binding("ClusterRole", role))
+	}
+	return bindings
+}
+
+func impersonationConfig(namespace, name string) rest.ImpersonationConfig {
+	return rest.ImpersonationConfig{
+		UserName: serviceaccount.MakeUsername(namespace, name),
+		Groups:   append(serviceaccount.MakeGroupNames(namespace), "system:authenticated"),
+	}
+}
+
+func setupServiceAccount(ctx context.Context, tc engine.Context, namespace string, name string, sa v1alpha1.TestServiceAccount, cleaner cleaner.CleanerCollector) (engine.Context, error) {
+	if namespace == "" {
+		return tc, errors.New("a namespace is required to create the test service account")
+	}
+	if sa.Name != "" {
+		name = sa.Name
+	}
+	_, client, err := tc.CurrentClusterClient()
+	if err != nil {
+		return tc, err
+	}
+	if client == nil {
+		return tc, errors.New("a cluster is required to create the test service account")
+	}
+	serviceAccount := buildServiceAccount(namespace, name)
+	if err := client.Create(ctx, serviceAccount.DeepCopy()); err != nil {
+		return tc, err
+	}
+	if cleaner != nil {
+		cleaner.Add(client, serviceAccount)
+	}
+	for _, binding := range buildRoleBindings(namespace, name, sa.Roles, sa.ClusterRoles) {
+		if err := client.Create(ctx, binding.DeepCopy()); err != nil {
+			return tc, err
+		}
+		if cleaner != nil {
+			cleaner.Add(client, binding)
+		}
+	}
+	return engine.WithImpersonation(ctx, tc, impersonationConfig(namespace, name))
+}
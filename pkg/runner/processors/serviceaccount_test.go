@@ -0,0 +1,117 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client"
+	tclient "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/clusters"
+	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
+	"github.com/kyverno/chainsaw/pkg/utils/kube"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+func Test_buildRoleBindings(t *testing.T) {
+	got := buildRoleBindings("ns", "sa", []string{"foo"}, []string{"view"})
+	assert.Len(t, got, 2)
+	assert.Equal(t, "sa-0", got[0].Name)
+	assert.Equal(t, "ns", got[0].Namespace)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "foo"}, got[0].RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: "ns", Name: "sa"}}, got[0].Subjects)
+	assert.Equal(t, "sa-1", got[1].Name)
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"}, got[1].RoleRef)
+}
+
+func Test_setupServiceAccount(t *testing.T) {
+	tests := []struct {
+		name          string
+		namespace     string
+		sa            v1alpha1.TestServiceAccount
+		createErr     error
+		wantUser      string
+		wantCreated   []string
+		wantErr       string
+		wantOperation string
+	}{{
+		name:          "default name",
+		namespace:     "chainsaw",
+		sa:            v1alpha1.TestServiceAccount{ClusterRoles: []string{"view"}},
+		wantUser:      "system:serviceaccount:chainsaw:test",
+		wantCreated:   []string{"ServiceAccount/test", "RoleBinding/test-0"},
+		wantOperation: "system:serviceaccount:chainsaw:test",
+	}, {
+		name:          "custom name",
+		namespace:     "chainsaw",
+		sa:            v1alpha1.TestServiceAccount{Name: "reader", Roles: []string{"foo", "bar"}},
+		wantUser:      "system:serviceaccount:chainsaw:reader",
+		wantCreated:   []string{"ServiceAccount/reader", "RoleBinding/reader-0", "RoleBinding/reader-1"},
+		wantOperation: "system:serviceaccount:chainsaw:reader",
+	}, {
+		name:    "no namespace",
+		wantErr: "a namespace is required to create the test service account",
+	}, {
+		name:        "create error",
+		namespace:   "chainsaw",
+		createErr:   errors.New("forbidden"),
+		wantCreated: []string{"ServiceAccount/test"},
+		wantErr:     "forbidden",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []string
+			var operations []string
+			registry := clusters.NewRegistry(func(cluster clusters.Cluster) (*rest.Config, client.Client, error) {
+				config, err := cluster.Config()
+				if err != nil {
+					return nil, nil, err
+				}
+				identity := config.Impersonate.UserName
+				return config, &tclient.FakeClient{
+					CreateFn: func(_ context.Context, _ int, obj client.Object, _ ...client.CreateOption) error {
+						// the admin identity creates the service account and bindings
+						if identity == "" {
+							created = append(created, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+							return tt.createErr
+						}
+						operations = append(operations, identity)
+						return nil
+					},
+				}, nil
+			})
+			cluster, err := clusters.NewClusterFromConfig(&rest.Config{})
+			assert.NoError(t, err)
+			tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+			tc = tc.WithCluster(context.TODO(), clusters.DefaultClient, cluster)
+			tc = tc.WithCurrentCluster(context.TODO(), clusters.DefaultClient)
+			cleaner := cleaner.New(0, nil, metav1.DeletePropagationBackground)
+			tc, err = setupServiceAccount(context.TODO(), tc, tt.namespace, "test", tt.sa, cleaner)
+			assert.Equal(t, tt.wantCreated, created)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, cleaner.Empty())
+			config, client, err := tc.CurrentClusterClient()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUser, config.Impersonate.UserName)
+			assert.Contains(t, config.Impersonate.Groups, "system:serviceaccounts:"+tt.namespace)
+			// operations run with the impersonated identity
+			configMap := kube.ToUnstructured(&corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Name: "foo"},
+			})
+			assert.NoError(t, client.Create(context.TODO(), &configMap))
+			assert.Equal(t, []string{tt.wantOperation}, operations)
+		})
+	}
+}
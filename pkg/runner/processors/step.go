@@ -6,35 +6,77 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
+	"github.com/kyverno/chainsaw/pkg/client/cassette"
 	"github.com/kyverno/chainsaw/pkg/engine"
+	"github.com/kyverno/chainsaw/pkg/engine/collectors"
 	"github.com/kyverno/chainsaw/pkg/engine/kubectl"
+	"github.com/kyverno/chainsaw/pkg/engine/kustomize"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/engine/operations"
 	opapply "github.com/kyverno/chainsaw/pkg/engine/operations/apply"
 	opassert "github.com/kyverno/chainsaw/pkg/engine/operations/assert"
+	opcani "github.com/kyverno/chainsaw/pkg/engine/operations/cani"
+	opchart "github.com/kyverno/chainsaw/pkg/engine/operations/chart"
+	opcollect "github.com/kyverno/chainsaw/pkg/engine/operations/collect"
 	opcommand "github.com/kyverno/chainsaw/pkg/engine/operations/command"
+	opcompare "github.com/kyverno/chainsaw/pkg/engine/operations/compare"
 	opcreate "github.com/kyverno/chainsaw/pkg/engine/operations/create"
 	opdelete "github.com/kyverno/chainsaw/pkg/engine/operations/delete"
+	opdelta "github.com/kyverno/chainsaw/pkg/engine/operations/delta"
 	operror "github.com/kyverno/chainsaw/pkg/engine/operations/error"
+	opexec "github.com/kyverno/chainsaw/pkg/engine/operations/exec"
+	ophealth "github.com/kyverno/chainsaw/pkg/engine/operations/health"
+	opjob "github.com/kyverno/chainsaw/pkg/engine/operations/job"
+	opnocrashloop "github.com/kyverno/chainsaw/pkg/engine/operations/nocrashloop"
 	oppatch "github.com/kyverno/chainsaw/pkg/engine/operations/patch"
+	oppodcount "github.com/kyverno/chainsaw/pkg/engine/operations/podcount"
+	opprobe "github.com/kyverno/chainsaw/pkg/engine/operations/probe"
+	oprollout "github.com/kyverno/chainsaw/pkg/engine/operations/rollout"
+	opscale "github.com/kyverno/chainsaw/pkg/engine/operations/scale"
 	opscript "github.com/kyverno/chainsaw/pkg/engine/operations/script"
 	opsleep "github.com/kyverno/chainsaw/pkg/engine/operations/sleep"
+	opsnapshot "github.com/kyverno/chainsaw/pkg/engine/operations/snapshot"
 	opupdate "github.com/kyverno/chainsaw/pkg/engine/operations/update"
+	opwatch "github.com/kyverno/chainsaw/pkg/engine/operations/watch"
+	"github.com/kyverno/chainsaw/pkg/engine/outputs"
+	"github.com/kyverno/chainsaw/pkg/expressions"
 	"github.com/kyverno/chainsaw/pkg/loaders/resource"
+	"github.com/kyverno/chainsaw/pkg/loaders/schema"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
 	"github.com/kyverno/chainsaw/pkg/runner/timeout"
 	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	"github.com/kyverno/pkg/ext/output/color"
+	"github.com/xeipuuv/gojsonschema"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+// maxParallelOperations is the maximum number of operations running at once when a step runs its operations concurrently.
+const maxParallelOperations = 4
+
+// newExecutor creates the executor used by exec operations to run commands in pods.
+var newExecutor = opexec.NewExecutor
+
+// nodeMode is the kubectl command a node operation runs against the selected nodes.
+type nodeMode string
+
+const (
+	nodeCordon   nodeMode = "cordon"
+	nodeDrain    nodeMode = "drain"
+	nodeUncordon nodeMode = "uncordon"
 )
 
 type StepProcessor interface {
@@ -45,6 +87,7 @@ func NewStepProcessor(
 	step v1alpha1.TestStep,
 	report *model.TestReport,
 	basePath string,
+	config model.Configuration,
 	delayBeforeCleanup *time.Duration,
 	terminationGracePeriod *metav1.Duration,
 	timeouts v1alpha1.DefaultTimeouts,
@@ -70,6 +113,7 @@ func NewStepProcessor(
 		step:                      step,
 		report:                    report,
 		basePath:                  basePath,
+		config:                    config,
 		delayBeforeCleanup:        delayBeforeCleanup,
 		terminationGracePeriod:    terminationGracePeriod,
 		timeouts:                  timeouts,
@@ -84,6 +128,7 @@ type stepProcessor struct {
 	step                      v1alpha1.TestStep
 	report                    *model.TestReport
 	basePath                  string
+	config                    model.Configuration
 	delayBeforeCleanup        *time.Duration
 	terminationGracePeriod    *metav1.Duration
 	timeouts                  v1alpha1.DefaultTimeouts
@@ -119,6 +164,8 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 	}
 	cleaner := cleaner.New(p.timeouts.Cleanup.Duration, p.delayBeforeCleanup, p.deletionPropagationPolicy)
 	t.Cleanup(func() {
+		// cleanup must run to completion even if the run was interrupted
+		ctx := context.WithoutCancel(ctx)
 		if !cleaner.Empty() || len(p.step.Cleanup) != 0 {
 			report := &model.StepReport{
 				Name:      fmt.Sprintf("cleanup (%s)", report.Name),
@@ -133,7 +180,12 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 				logger.Log(logging.Cleanup, logging.EndStatus, color.BoldFgCyan)
 			}()
 			if !cleaner.Empty() {
-				if errs := cleaner.Run(ctx, report); len(errs) != 0 {
+				if skip, err := skipCleanup(ctx, tc, p.config.Cleanup.SkipDeleteIf); err != nil {
+					logger.Log(logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+					failer.Fail(ctx)
+				} else if skip {
+					logger.Log(logging.Cleanup, logging.SkipStatus, color.BoldYellow, logging.Section("CONDITION", p.config.Cleanup.SkipDeleteIf))
+				} else if errs := cleaner.Run(ctx, report); len(errs) != 0 {
 					for _, err := range errs {
 						logging.Log(ctx, logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 					}
@@ -215,26 +267,53 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 	defer func() {
 		logger.Log(logging.Try, logging.EndStatus, color.BoldFgCyan)
 	}()
+	if p.step.ParallelOperations != nil && *p.step.ParallelOperations {
+		results := make([]tryResult, len(p.step.Try))
+		sem := make(chan struct{}, maxParallelOperations)
+		var wg sync.WaitGroup
+		for i, operation := range p.step.Try {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				results[i] = p.try(ctx, i, namespacer, tc, operation, cleaner, report)
+			}()
+		}
+		wg.Wait()
+		// outputs are registered in the order of the operations, catch and finally blocks can use them
+		for _, result := range results {
+			for _, outputs := range result.outputs {
+				for k, v := range outputs {
+					tc = tc.WithBinding(ctx, k, v)
+				}
+			}
+		}
+		// a failure takes precedence over a skip requested by another operation
+		for _, result := range results {
+			if result.failedNow {
+				failer.FailNow(ctx)
+				return
+			}
+		}
+		for _, result := range results {
+			if result.skip {
+				t.SkipNow()
+				return
+			}
+		}
+		return
+	}
 	for i, operation := range p.step.Try {
-		operationTc := tc
-		if operation.Compiler != nil {
-			operationTc = operationTc.WithDefaultCompiler(string(*operation.Compiler))
+		result := p.try(ctx, i, namespacer, tc, operation, cleaner, report)
+		if result.skip {
+			t.SkipNow()
+			return
 		}
-		continueOnError := operation.ContinueOnError != nil && *operation.ContinueOnError
-		operations, err := p.tryOperation(operationTc.Compilers(), i, namespacer, operationTc.Bindings(), operation, cleaner)
-		if err != nil {
-			logger.Log(logging.Try, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		if result.failedNow {
 			failer.FailNow(ctx)
 		}
-		for _, operation := range operations {
-			outputs, err := operation.execute(ctx, operationTc, report)
-			if err != nil {
-				if continueOnError {
-					failer.Fail(ctx)
-				} else {
-					failer.FailNow(ctx)
-				}
-			}
+		for _, outputs := range result.outputs {
 			for k, v := range outputs {
 				tc = tc.WithBinding(ctx, k, v)
 			}
@@ -242,6 +321,61 @@ func (p *stepProcessor) Run(ctx context.Context, namespacer namespacer.Namespace
 	}
 }
 
+// tryResult is the result of running an operation of the try block.
+type tryResult struct {
+	outputs   []outputs.Outputs
+	skip      bool
+	failedNow bool
+}
+
+// try runs an operation of the try block, failures that don't stop the step are reported immediately
+// while failures that stop the step are returned to the caller.
+func (p *stepProcessor) try(ctx context.Context, i int, namespacer namespacer.Namespacer, tc engine.Context, operation v1alpha1.Operation, cleaner cleaner.CleanerCollector, report *model.StepReport) tryResult {
+	logger := logging.FromContext(ctx)
+	if operation.Compiler != nil {
+		tc = tc.WithDefaultCompiler(string(*operation.Compiler))
+	}
+	continueOnError := operation.ContinueOnError != nil && *operation.ContinueOnError
+	if operation.If != "" {
+		if run, err := expressions.Bool(ctx, tc.Compilers(), string(operation.If), tc.Bindings()); err != nil {
+			logger.Log(logging.Try, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+			return tryResult{failedNow: true}
+		} else if !run {
+			logger.Log(logging.Try, logging.SkipStatus, color.BoldYellow, logging.Section("CONDITION", operation.If))
+			now := time.Now()
+			report.Add(&model.OperationReport{
+				Type:      operationType(operation),
+				StartTime: now,
+				EndTime:   now,
+				Skipped:   true,
+			})
+			return tryResult{}
+		}
+	}
+	ops, err := p.tryOperation(tc.Compilers(), i, namespacer, tc.Bindings(), operation, cleaner)
+	if err != nil {
+		logger.Log(logging.Try, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		return tryResult{failedNow: true}
+	}
+	var result tryResult
+	for _, operation := range ops {
+		outputs, err := operation.execute(ctx, tc, report)
+		if errors.Is(err, operations.ErrSkip) {
+			result.skip = true
+			return result
+		}
+		if err != nil {
+			if !continueOnError {
+				result.failedNow = true
+				return result
+			}
+			failer.Fail(ctx)
+		}
+		result.outputs = append(result.outputs, outputs)
+	}
+	return result
+}
+
 func (p *stepProcessor) tryOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, bindings apis.Bindings, handler v1alpha1.Operation, cleaner cleaner.CleanerCollector) ([]operation, error) {
 	var ops []operation
 	if handler.Apply != nil {
@@ -256,8 +390,20 @@ func (p *stepProcessor) tryOperation(compilers compilers.Compilers, id int, name
 			return nil, err
 		}
 		ops = append(ops, loaded...)
+	} else if handler.CanI != nil {
+		ops = append(ops, p.canIOperation(compilers, id+1, namespacer, *handler.CanI))
+	} else if handler.Chart != nil {
+		ops = append(ops, p.chartOperation(compilers, id+1, namespacer, *handler.Chart))
 	} else if handler.Command != nil {
 		ops = append(ops, p.commandOperation(compilers, id+1, namespacer, *handler.Command))
+	} else if handler.Compare != nil {
+		ops = append(ops, p.compareOperation(compilers, id+1, *handler.Compare))
+	} else if handler.Cordon != nil {
+		ops = append(ops, p.nodeOperation(id+1, namespacer, nodeCordon, v1alpha1.Drain{
+			ActionClusters: handler.Cordon.ActionClusters,
+			ActionTimeout:  handler.Cordon.ActionTimeout,
+			ActionNode:     handler.Cordon.ActionNode,
+		}))
 	} else if handler.Create != nil {
 		loaded, err := p.createOperation(compilers, id+1, namespacer, cleaner, bindings, *handler.Create)
 		if err != nil {
@@ -270,8 +416,12 @@ func (p *stepProcessor) tryOperation(compilers compilers.Compilers, id int, name
 			return nil, err
 		}
 		ops = append(ops, loaded...)
+	} else if handler.Delta != nil {
+		ops = append(ops, p.deltaOperation(compilers, id+1, namespacer, *handler.Delta))
 	} else if handler.Describe != nil {
 		ops = append(ops, p.describeOperation(compilers, id+1, namespacer, *handler.Describe))
+	} else if handler.Drain != nil {
+		ops = append(ops, p.nodeOperation(id+1, namespacer, nodeDrain, *handler.Drain))
 	} else if handler.Error != nil {
 		loaded, err := p.errorOperation(compilers, id+1, namespacer, bindings, *handler.Error)
 		if err != nil {
@@ -280,9 +430,10 @@ func (p *stepProcessor) tryOperation(compilers compilers.Compilers, id int, name
 		ops = append(ops, loaded...)
 	} else if handler.Events != nil {
 		get := v1alpha1.Get{
-			ActionClusters: handler.Events.ActionClusters,
-			ActionFormat:   handler.Events.ActionFormat,
-			ActionTimeout:  handler.Events.ActionTimeout,
+			ActionClusters:      handler.Events.ActionClusters,
+			ActionFieldSelector: handler.Events.ActionFieldSelector,
+			ActionFormat:        handler.Events.ActionFormat,
+			ActionTimeout:       handler.Events.ActionTimeout,
 			ActionObject: v1alpha1.ActionObject{
 				ObjectType: v1alpha1.ObjectType{
 					APIVersion: "v1",
@@ -292,22 +443,46 @@ func (p *stepProcessor) tryOperation(compilers compilers.Compilers, id int, name
 			},
 		}
 		ops = append(ops, p.getOperation(compilers, id+1, namespacer, get))
+	} else if handler.Exec != nil {
+		ops = append(ops, p.execOperation(compilers, id+1, namespacer, *handler.Exec))
 	} else if handler.Get != nil {
 		ops = append(ops, p.getOperation(compilers, id+1, namespacer, *handler.Get))
+	} else if handler.Health != nil {
+		ops = append(ops, p.healthOperation(compilers, id+1, *handler.Health))
+	} else if handler.Job != nil {
+		ops = append(ops, p.jobOperation(compilers, id+1, namespacer, *handler.Job))
+	} else if handler.NoCrashLoop != nil {
+		ops = append(ops, p.noCrashLoopOperation(compilers, id+1, namespacer, *handler.NoCrashLoop))
 	} else if handler.Patch != nil {
 		loaded, err := p.patchOperation(compilers, id+1, namespacer, bindings, *handler.Patch)
 		if err != nil {
 			return nil, err
 		}
 		ops = append(ops, loaded...)
+	} else if handler.PodCount != nil {
+		ops = append(ops, p.podCountOperation(compilers, id+1, namespacer, *handler.PodCount))
 	} else if handler.PodLogs != nil {
 		ops = append(ops, p.logsOperation(compilers, id+1, namespacer, *handler.PodLogs))
+	} else if handler.Probe != nil {
+		ops = append(ops, p.probeOperation(compilers, id+1, *handler.Probe))
 	} else if handler.Proxy != nil {
 		ops = append(ops, p.proxyOperation(compilers, id+1, namespacer, *handler.Proxy))
+	} else if handler.Rollout != nil {
+		ops = append(ops, p.rolloutOperation(compilers, id+1, namespacer, *handler.Rollout))
+	} else if handler.Scale != nil {
+		ops = append(ops, p.scaleOperation(compilers, id+1, namespacer, *handler.Scale))
 	} else if handler.Script != nil {
 		ops = append(ops, p.scriptOperation(compilers, id+1, namespacer, *handler.Script))
 	} else if handler.Sleep != nil {
 		ops = append(ops, p.sleepOperation(compilers, id+1, *handler.Sleep))
+	} else if handler.Snapshot != nil {
+		ops = append(ops, p.snapshotOperation(compilers, id+1, namespacer, *handler.Snapshot))
+	} else if handler.Uncordon != nil {
+		ops = append(ops, p.nodeOperation(id+1, namespacer, nodeUncordon, v1alpha1.Drain{
+			ActionClusters: handler.Uncordon.ActionClusters,
+			ActionTimeout:  handler.Uncordon.ActionTimeout,
+			ActionNode:     handler.Uncordon.ActionNode,
+		}))
 	} else if handler.Update != nil {
 		loaded, err := p.updateOperation(compilers, id+1, namespacer, bindings, *handler.Update)
 		if err != nil {
@@ -316,21 +491,76 @@ func (p *stepProcessor) tryOperation(compilers compilers.Compilers, id int, name
 		ops = append(ops, loaded...)
 	} else if handler.Wait != nil {
 		ops = append(ops, p.waitOperation(compilers, id+1, namespacer, *handler.Wait))
+	} else if handler.Watch != nil {
+		ops = append(ops, p.watchOperation(compilers, id+1, namespacer, *handler.Watch))
 	} else {
 		return nil, errors.New("no operation found")
 	}
 	return ops, nil
 }
 
+// operationType returns the type reported for an operation of the try block, without building it.
+func operationType(handler v1alpha1.Operation) model.OperationType {
+	switch {
+	case handler.Apply != nil:
+		return model.OperationTypeApply
+	case handler.Assert != nil, handler.Chart != nil:
+		return model.OperationTypeAssert
+	case handler.CanI != nil:
+		return model.OperationTypeCanI
+	case handler.Compare != nil:
+		return model.OperationTypeCompare
+	case handler.Create != nil:
+		return model.OperationTypeCreate
+	case handler.Delete != nil:
+		return model.OperationTypeDelete
+	case handler.Delta != nil:
+		return model.OperationTypeDelta
+	case handler.Error != nil:
+		return model.OperationTypeError
+	case handler.Exec != nil:
+		return model.OperationTypeExec
+	case handler.Health != nil:
+		return model.OperationTypeHealth
+	case handler.Job != nil:
+		return model.OperationTypeJob
+	case handler.NoCrashLoop != nil:
+		return model.OperationTypeNoCrashLoop
+	case handler.Patch != nil:
+		return model.OperationTypePatch
+	case handler.PodCount != nil:
+		return model.OperationTypePodCount
+	case handler.Probe != nil:
+		return model.OperationTypeProbe
+	case handler.Rollout != nil:
+		return model.OperationTypeRollout
+	case handler.Scale != nil:
+		return model.OperationTypeScale
+	case handler.Script != nil:
+		return model.OperationTypeScript
+	case handler.Sleep != nil:
+		return model.OperationTypeSleep
+	case handler.Snapshot != nil:
+		return model.OperationTypeSnapshot
+	case handler.Update != nil:
+		return model.OperationTypeUpdate
+	case handler.Watch != nil:
+		return model.OperationTypeWatch
+	}
+	// cordon, describe, drain, events, get, logs, proxy, uncordon, wait and command run as commands
+	return model.OperationTypeCommand
+}
+
 func (p *stepProcessor) catchOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, bindings apis.Bindings, handler v1alpha1.CatchFinally) ([]operation, error) {
 	var ops []operation
 	if handler.PodLogs != nil {
 		ops = append(ops, p.logsOperation(compilers, id+1, namespacer, *handler.PodLogs))
 	} else if handler.Events != nil {
 		get := v1alpha1.Get{
-			ActionClusters: handler.Events.ActionClusters,
-			ActionFormat:   handler.Events.ActionFormat,
-			ActionTimeout:  handler.Events.ActionTimeout,
+			ActionClusters:      handler.Events.ActionClusters,
+			ActionFieldSelector: handler.Events.ActionFieldSelector,
+			ActionFormat:        handler.Events.ActionFormat,
+			ActionTimeout:       handler.Events.ActionTimeout,
 			ActionObject: v1alpha1.ActionObject{
 				ObjectType: v1alpha1.ObjectType{
 					APIVersion: "v1",
@@ -350,12 +580,20 @@ func (p *stepProcessor) catchOperation(compilers compilers.Compilers, id int, na
 			return nil, err
 		}
 		ops = append(ops, loaded...)
+	} else if handler.Collect != nil {
+		ops = append(ops, p.collectOperation(compilers, id+1, namespacer, *handler.Collect))
 	} else if handler.Command != nil {
 		ops = append(ops, p.commandOperation(compilers, id+1, namespacer, *handler.Command))
 	} else if handler.Script != nil {
 		ops = append(ops, p.scriptOperation(compilers, id+1, namespacer, *handler.Script))
 	} else if handler.Sleep != nil {
 		ops = append(ops, p.sleepOperation(compilers, id+1, *handler.Sleep))
+	} else if handler.Uncordon != nil {
+		ops = append(ops, p.nodeOperation(id+1, namespacer, nodeUncordon, v1alpha1.Drain{
+			ActionClusters: handler.Uncordon.ActionClusters,
+			ActionTimeout:  handler.Uncordon.ActionTimeout,
+			ActionNode:     handler.Uncordon.ActionNode,
+		}))
 	} else if handler.Wait != nil {
 		ops = append(ops, p.waitOperation(compilers, id+1, namespacer, *handler.Wait))
 	} else {
@@ -370,9 +608,10 @@ func (p *stepProcessor) finallyOperation(compilers compilers.Compilers, id int,
 		ops = append(ops, p.logsOperation(compilers, id+1, namespacer, *handler.PodLogs))
 	} else if handler.Events != nil {
 		get := v1alpha1.Get{
-			ActionClusters: handler.Events.ActionClusters,
-			ActionFormat:   handler.Events.ActionFormat,
-			ActionTimeout:  handler.Events.ActionTimeout,
+			ActionClusters:      handler.Events.ActionClusters,
+			ActionFieldSelector: handler.Events.ActionFieldSelector,
+			ActionFormat:        handler.Events.ActionFormat,
+			ActionTimeout:       handler.Events.ActionTimeout,
 			ActionObject: v1alpha1.ActionObject{
 				ObjectType: v1alpha1.ObjectType{
 					APIVersion: "v1",
@@ -392,12 +631,20 @@ func (p *stepProcessor) finallyOperation(compilers compilers.Compilers, id int,
 			return nil, err
 		}
 		ops = append(ops, loaded...)
+	} else if handler.Collect != nil {
+		ops = append(ops, p.collectOperation(compilers, id+1, namespacer, *handler.Collect))
 	} else if handler.Command != nil {
 		ops = append(ops, p.commandOperation(compilers, id+1, namespacer, *handler.Command))
 	} else if handler.Script != nil {
 		ops = append(ops, p.scriptOperation(compilers, id+1, namespacer, *handler.Script))
 	} else if handler.Sleep != nil {
 		ops = append(ops, p.sleepOperation(compilers, id+1, *handler.Sleep))
+	} else if handler.Uncordon != nil {
+		ops = append(ops, p.nodeOperation(id+1, namespacer, nodeUncordon, v1alpha1.Drain{
+			ActionClusters: handler.Uncordon.ActionClusters,
+			ActionTimeout:  handler.Uncordon.ActionTimeout,
+			ActionNode:     handler.Uncordon.ActionNode,
+		}))
 	} else if handler.Wait != nil {
 		ops = append(ops, p.waitOperation(compilers, id+1, namespacer, *handler.Wait))
 	} else {
@@ -407,6 +654,12 @@ func (p *stepProcessor) finallyOperation(compilers compilers.Compilers, id int,
 }
 
 func (p *stepProcessor) applyOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, cleaner cleaner.CleanerCollector, bindings apis.Bindings, op v1alpha1.Apply) ([]operation, error) {
+	if op.Kustomize != "" {
+		if op.File != "" || op.Resource != nil {
+			return nil, errors.New("kustomize cannot be used with file or resource")
+		}
+		return []operation{p.applyKustomizeOperation(id, namespacer, cleaner, op)}, nil
+	}
 	resources, err := p.fileRefOrResource(context.TODO(), compilers, op.ActionResourceRef, bindings)
 	if err != nil {
 		return nil, err
@@ -418,6 +671,9 @@ func (p *stepProcessor) applyOperation(compilers compilers.Compilers, id int, na
 		if err := p.prepareResource(resource); err != nil {
 			return nil, err
 		}
+		if resource, err = p.mergeHostAliases(resource); err != nil {
+			return nil, err
+		}
 		ops = append(ops, newOperation(
 			OperationInfo{
 				Id:         id,
@@ -425,7 +681,10 @@ func (p *stepProcessor) applyOperation(compilers compilers.Compilers, id int, na
 			},
 			model.OperationTypeApply,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Apply.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Apply.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -437,6 +696,8 @@ func (p *stepProcessor) applyOperation(compilers compilers.Compilers, id int, na
 				} else {
 					if _, client, err := tc.CurrentClusterClient(); err != nil {
 						return nil, nil, tc, err
+					} else if resource, err := p.injectMetadata(ctx, tc, resource); err != nil {
+						return nil, nil, tc, err
 					} else {
 						op := opapply.New(
 							tc.Compilers(),
@@ -445,6 +706,9 @@ func (p *stepProcessor) applyOperation(compilers compilers.Compilers, id int, na
 							namespacer,
 							p.getCleanerOrNil(cleaner, tc),
 							template,
+							ptr.Deref(op.ServerSide, false),
+							ptr.Deref(op.ForceConflicts, false),
+							op.Rendered,
 							op.Expect,
 							op.Outputs,
 						)
@@ -457,6 +721,80 @@ func (p *stepProcessor) applyOperation(compilers compilers.Compilers, id int, na
 	return ops, nil
 }
 
+// applyKustomizeOperation builds the kustomization when the operation runs, under the apply timeout,
+// and applies the built resources one after the other.
+func (p *stepProcessor) applyKustomizeOperation(id int, namespacer namespacer.Namespacer, cleaner cleaner.CleanerCollector, op v1alpha1.Apply) operation {
+	template := p.getTemplating(op.Template)
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeApply,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Apply.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			tc, _, err = setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: op.Bindings,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+				dryRun:   op.DryRun,
+			})
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			_, client, err := tc.CurrentClusterClient()
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			exec := func(ctx context.Context, bindings apis.Bindings) (outputs.Outputs, error) {
+				resources, err := p.kustomize(ctx, tc.Compilers(), op.Kustomize, bindings)
+				if err != nil {
+					return nil, err
+				}
+				var results outputs.Outputs
+				for _, resource := range resources {
+					if err := p.prepareResource(resource); err != nil {
+						return nil, err
+					}
+					if resource, err = p.mergeHostAliases(resource); err != nil {
+						return nil, err
+					}
+					if resource, err = p.injectMetadata(ctx, tc, resource); err != nil {
+						return nil, err
+					}
+					outputs, err := opapply.New(
+						tc.Compilers(),
+						client,
+						resource,
+						namespacer,
+						p.getCleanerOrNil(cleaner, tc),
+						template,
+						ptr.Deref(op.ServerSide, false),
+						ptr.Deref(op.ForceConflicts, false),
+						op.Rendered,
+						op.Expect,
+						op.Outputs,
+					).Exec(ctx, bindings)
+					if err != nil {
+						return nil, err
+					}
+					for k, v := range outputs {
+						if results == nil {
+							results = map[string]any{}
+						}
+						results[k] = v
+					}
+				}
+				return results, nil
+			}
+			return operationFunc(exec), timeout, tc, nil
+		},
+	)
+}
+
 func (p *stepProcessor) assertOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, bindings apis.Bindings, op v1alpha1.Assert) ([]operation, error) {
 	resources, err := p.fileRefOrCheck(context.TODO(), compilers, op.ActionCheckRef, bindings)
 	if err != nil {
@@ -473,7 +811,10 @@ func (p *stepProcessor) assertOperation(compilers compilers.Compilers, id int, n
 			},
 			model.OperationTypeAssert,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Assert.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -484,12 +825,66 @@ func (p *stepProcessor) assertOperation(compilers compilers.Compilers, id int, n
 				} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 					return nil, nil, tc, err
 				} else {
+					var jsonSchema *gojsonschema.Schema
+					if op.Schema != "" {
+						path, err := op.Schema.Value(ctx, tc.Compilers(), tc.Bindings())
+						if err != nil {
+							return nil, nil, tc, err
+						}
+						if !filepath.IsAbs(path) {
+							path = filepath.Join(p.basePath, path)
+						}
+						if jsonSchema, err = schema.Load(path); err != nil {
+							return nil, nil, tc, err
+						}
+					}
+					var revision *int64
+					if op.Revision != nil {
+						value, err := expressions.Int(ctx, tc.Compilers(), *op.Revision, tc.Bindings())
+						if err != nil {
+							return nil, nil, tc, err
+						}
+						revision = ptr.To(int64(value))
+					}
+					var namespaces []string
+					for _, namespace := range op.Namespaces {
+						value, err := namespace.Value(ctx, tc.Compilers(), tc.Bindings())
+						if err != nil {
+							return nil, nil, tc, err
+						}
+						namespaces = append(namespaces, value)
+					}
+					namespaceSelector, err := op.NamespaceSelector.Value(ctx, tc.Compilers(), tc.Bindings())
+					if err != nil {
+						return nil, nil, tc, err
+					}
+					annotationSelector, err := op.AnnotationSelector.Value(ctx, tc.Compilers(), tc.Bindings())
+					if err != nil {
+						return nil, nil, tc, err
+					}
+					var stableFor time.Duration
+					if op.StableFor != nil {
+						stableFor = op.StableFor.Duration
+					}
 					op := opassert.New(
 						tc.Compilers(),
 						client,
 						resource,
 						namespacer,
 						template,
+						opassert.Options{
+							Schema:             jsonSchema,
+							Revision:           revision,
+							Generation:         ptr.Deref(op.ObservedGeneration, false),
+							Namespaces:         namespaces,
+							NamespaceSelector:  namespaceSelector,
+							AnnotationSelector: annotationSelector,
+							Subresource:        op.Subresource,
+							Aggregate:          op.Aggregate,
+							AnyOf:              op.AnyOf,
+							StableFor:          stableFor,
+							Tolerances:         op.Tolerances,
+						},
 					)
 					return op, timeout, tc, nil
 				}
@@ -499,6 +894,126 @@ func (p *stepProcessor) assertOperation(compilers compilers.Compilers, id int, n
 	return ops, nil
 }
 
+func (p *stepProcessor) canIOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.CanI) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeCanI,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opcani.New(client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) chartOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Chart) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeAssert,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opchart.New(tc.Compilers(), client, namespacer, op, p.basePath), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) collectOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Collect) operation {
+	ns := ""
+	if namespacer != nil {
+		ns = namespacer.GetNamespace()
+	}
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeCollect,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			collector, err := collectors.Get(op.Type)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				var collectorConfig any
+				if op.Config != nil {
+					collectorConfig = op.Config.Value()
+				}
+				// collectors can call the cluster, they are bounded by the operation timeout
+				collectCtx, cancel := context.WithTimeout(ctx, *timeout)
+				defer cancel()
+				result, err := collector.Collect(collectCtx, tc.Compilers(), client, tc.Bindings(), collectorConfig)
+				if err != nil {
+					return nil, nil, tc, err
+				}
+				if result == nil || result.Entrypoint == "" {
+					var output any
+					if result != nil {
+						output = result.Output
+					}
+					return opcollect.New(output), timeout, tc, nil
+				}
+				op := opcommand.New(
+					tc.Compilers(),
+					v1alpha1.Command{
+						ActionClusters: op.ActionClusters,
+						ActionTimeout:  op.ActionTimeout,
+						Entrypoint:     result.Entrypoint,
+						Args:           result.Args,
+					},
+					p.basePath,
+					ns,
+					config,
+					nil,
+				)
+				return op, timeout, tc, nil
+			}
+		},
+	)
+}
+
 func (p *stepProcessor) commandOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Command) operation {
 	ns := ""
 	if namespacer != nil {
@@ -510,7 +1025,10 @@ func (p *stepProcessor) commandOperation(_ compilers.Compilers, id int, namespac
 		},
 		model.OperationTypeCommand,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
 			if tc, _, err := setupContextData(ctx, tc, contextData{
 				basePath: p.basePath,
 				bindings: op.Bindings,
@@ -527,6 +1045,7 @@ func (p *stepProcessor) commandOperation(_ compilers.Compilers, id int, namespac
 					p.basePath,
 					ns,
 					config,
+					nil,
 				)
 				return op, timeout, tc, nil
 			}
@@ -534,6 +1053,29 @@ func (p *stepProcessor) commandOperation(_ compilers.Compilers, id int, namespac
 	)
 }
 
+func (p *stepProcessor) compareOperation(_ compilers.Compilers, id int, op v1alpha1.Compare) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeCompare,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: op.Bindings,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opcompare.New(tc.Compilers(), op), timeout, tc, nil
+			}
+		},
+	)
+}
+
 func (p *stepProcessor) createOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, cleaner cleaner.CleanerCollector, bindings apis.Bindings, op v1alpha1.Create) ([]operation, error) {
 	resources, err := p.fileRefOrResource(context.TODO(), compilers, op.ActionResourceRef, bindings)
 	if err != nil {
@@ -546,6 +1088,9 @@ func (p *stepProcessor) createOperation(compilers compilers.Compilers, id int, n
 		if err := p.prepareResource(resource); err != nil {
 			return nil, err
 		}
+		if resource, err = p.mergeHostAliases(resource); err != nil {
+			return nil, err
+		}
 		ops = append(ops, newOperation(
 			OperationInfo{
 				Id:         id,
@@ -553,7 +1098,10 @@ func (p *stepProcessor) createOperation(compilers compilers.Compilers, id int, n
 			},
 			model.OperationTypeCreate,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Apply.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Apply.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -564,6 +1112,8 @@ func (p *stepProcessor) createOperation(compilers compilers.Compilers, id int, n
 					return nil, nil, tc, err
 				} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 					return nil, nil, tc, err
+				} else if resource, err := p.injectMetadata(ctx, tc, resource); err != nil {
+					return nil, nil, tc, err
 				} else {
 					op := opcreate.New(
 						tc.Compilers(),
@@ -572,6 +1122,7 @@ func (p *stepProcessor) createOperation(compilers compilers.Compilers, id int, n
 						namespacer,
 						p.getCleanerOrNil(cleaner, tc),
 						template,
+						op.Rendered,
 						op.Expect,
 						op.Outputs,
 					)
@@ -584,23 +1135,22 @@ func (p *stepProcessor) createOperation(compilers compilers.Compilers, id int, n
 }
 
 func (p *stepProcessor) deleteOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, bindings apis.Bindings, op v1alpha1.Delete) ([]operation, error) {
-	ref := v1alpha1.ActionResourceRef{
-		FileRef: v1alpha1.FileRef{
-			File: op.File,
-		},
-	}
+	var resources []unstructured.Unstructured
 	if op.Ref != nil {
-		var resource unstructured.Unstructured
-		resource.SetAPIVersion(string(op.Ref.APIVersion))
-		resource.SetKind(string(op.Ref.Kind))
-		resource.SetName(string(op.Ref.Name))
-		resource.SetNamespace(string(op.Ref.Namespace))
-		resource.SetLabels(op.Ref.Labels)
-		ref.Resource = &resource
-	}
-	resources, err := p.fileRefOrResource(context.TODO(), compilers, ref, bindings)
-	if err != nil {
-		return nil, err
+		// the reference is resolved at execution time to support bindings, this placeholder stands for the resolved object
+		resources = []unstructured.Unstructured{{}}
+	} else {
+		ref := v1alpha1.ActionResourceRef{
+			FileRef: v1alpha1.FileRef{
+				File:       op.File,
+				AllowEmpty: op.AllowEmpty,
+			},
+		}
+		loaded, err := p.fileRefOrResource(context.TODO(), compilers, ref, bindings)
+		if err != nil {
+			return nil, err
+		}
+		resources = loaded
 	}
 	var ops []operation
 	deletionPropagationPolicy := p.getDeletionPropagationPolicy(op.DeletionPropagationPolicy)
@@ -614,7 +1164,10 @@ func (p *stepProcessor) deleteOperation(compilers compilers.Compilers, id int, n
 			},
 			model.OperationTypeDelete,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Delete.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Delete.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -625,6 +1178,13 @@ func (p *stepProcessor) deleteOperation(compilers compilers.Compilers, id int, n
 				} else if _, client, err := tc.CurrentClusterClient(); err != nil {
 					return nil, nil, tc, err
 				} else {
+					if op.Ref != nil {
+						if resolved, err := resolveObjectReference(ctx, tc, *op.Ref); err != nil {
+							return nil, nil, tc, err
+						} else {
+							resource = resolved
+						}
+					}
 					op := opdelete.New(
 						tc.Compilers(),
 						client,
@@ -632,6 +1192,8 @@ func (p *stepProcessor) deleteOperation(compilers compilers.Compilers, id int, n
 						namespacer,
 						template,
 						deletionPropagationPolicy,
+						ptr.Deref(op.Wait, true),
+						op.AllNamespaces != nil && *op.AllNamespaces,
 						op.Expect...,
 					)
 					return op, timeout, tc, nil
@@ -642,20 +1204,53 @@ func (p *stepProcessor) deleteOperation(compilers compilers.Compilers, id int, n
 	return ops, nil
 }
 
-func (p *stepProcessor) describeOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Describe) operation {
-	ns := ""
-	if namespacer != nil {
-		ns = namespacer.GetNamespace()
-	}
+func (p *stepProcessor) deltaOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Delta) operation {
 	return newOperation(
 		OperationInfo{
 			Id: id,
 		},
-		model.OperationTypeCommand,
+		model.OperationTypeDelta,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
-			if tc, _, err := setupContextData(ctx, tc, contextData{
-				basePath: p.basePath,
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opdelta.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) describeOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Describe) operation {
+	ns := ""
+	if namespacer != nil {
+		ns = namespacer.GetNamespace()
+	}
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeCommand,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "describe"); err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
 				bindings: nil,
 				cluster:  op.Cluster,
 				clusters: op.Clusters,
@@ -679,6 +1274,7 @@ func (p *stepProcessor) describeOperation(_ compilers.Compilers, id int, namespa
 					p.basePath,
 					ns,
 					config,
+					nil,
 				)
 				return op, timeout, tc, nil
 			}
@@ -702,7 +1298,10 @@ func (p *stepProcessor) errorOperation(compilers compilers.Compilers, id int, na
 			},
 			model.OperationTypeError,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Error.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Error.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -719,6 +1318,7 @@ func (p *stepProcessor) errorOperation(compilers compilers.Compilers, id int, na
 						resource,
 						namespacer,
 						template,
+						op.Message,
 					)
 					return op, timeout, tc, nil
 				}
@@ -739,7 +1339,13 @@ func (p *stepProcessor) getOperation(_ compilers.Compilers, id int, namespacer n
 		},
 		model.OperationTypeCommand,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "get"); err != nil {
+				return nil, nil, tc, err
+			}
 			if tc, _, err := setupContextData(ctx, tc, contextData{
 				basePath: p.basePath,
 				bindings: nil,
@@ -765,6 +1371,7 @@ func (p *stepProcessor) getOperation(_ compilers.Compilers, id int, namespacer n
 					p.basePath,
 					ns,
 					config,
+					nil,
 				)
 				return op, timeout, tc, nil
 			}
@@ -772,6 +1379,125 @@ func (p *stepProcessor) getOperation(_ compilers.Compilers, id int, namespacer n
 	)
 }
 
+func (p *stepProcessor) healthOperation(_ compilers.Compilers, id int, op v1alpha1.Health) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeHealth,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			return ophealth.New(tc.Compilers(), op), timeout, tc, nil
+		},
+	)
+}
+
+func (p *stepProcessor) jobOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Job) operation {
+	ns := ""
+	if namespacer != nil {
+		ns = namespacer.GetNamespace()
+	}
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeJob,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "job"); err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				logs := func(ctx context.Context, selector string, namespace string, check v1alpha1.Check) (operations.Operation, error) {
+					podLogs := v1alpha1.PodLogs{
+						ActionObjectSelector: v1alpha1.ActionObjectSelector{
+							ObjectName: v1alpha1.ObjectName{
+								Namespace: v1alpha1.Expression(namespace),
+							},
+							Selector: v1alpha1.Expression(selector),
+						},
+						Container: op.Container,
+						// all lines are collected, kubectl only returns the last 10 lines per container when a selector is used
+						Tail: ptr.To(intstr.FromInt(-1)),
+					}
+					// lines are not prefixed so that anchored patterns can match
+					entrypoint, args, err := kubectl.UnprefixedLogs(ctx, tc.Compilers(), tc.Bindings(), &podLogs)
+					if err != nil {
+						return nil, err
+					}
+					return opcommand.New(
+						tc.Compilers(),
+						v1alpha1.Command{
+							ActionCheck: v1alpha1.ActionCheck{
+								Check: &check,
+							},
+							ActionClusters: op.ActionClusters,
+							ActionTimeout:  op.ActionTimeout,
+							Entrypoint:     entrypoint,
+							Args:           args,
+						},
+						p.basePath,
+						ns,
+						config,
+						nil,
+					), nil
+				}
+				return opjob.New(tc.Compilers(), client, namespacer, op, logs), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) execOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Exec) operation {
+	ns := ""
+	if namespacer != nil {
+		ns = namespacer.GetNamespace()
+	}
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeExec,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "exec"); err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				op := opexec.New(tc.Compilers(), client, op, ns, newExecutor(config))
+				return op, timeout, tc, nil
+			}
+		},
+	)
+}
+
 func (p *stepProcessor) logsOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.PodLogs) operation {
 	ns := ""
 	if namespacer != nil {
@@ -783,7 +1509,13 @@ func (p *stepProcessor) logsOperation(_ compilers.Compilers, id int, namespacer
 		},
 		model.OperationTypeCommand,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "podLogs"); err != nil {
+				return nil, nil, tc, err
+			}
 			if tc, _, err := setupContextData(ctx, tc, contextData{
 				basePath: p.basePath,
 				bindings: nil,
@@ -791,16 +1523,28 @@ func (p *stepProcessor) logsOperation(_ compilers.Compilers, id int, namespacer
 				clusters: op.Clusters,
 			}); err != nil {
 				return nil, nil, tc, err
-			} else if config, _, err := tc.CurrentClusterClient(); err != nil {
+			} else if config, client, err := tc.CurrentClusterClient(); err != nil {
 				return nil, nil, tc, err
 			} else {
+				if op.Container == "" && p.config.Execution.PodLogsDefaultContainer {
+					container, err := kubectl.DefaultContainer(ctx, tc.Compilers(), client, tc.Bindings(), ns, &op)
+					if err != nil {
+						return nil, nil, tc, err
+					}
+					op.Container = v1alpha1.Expression(container)
+				}
 				entrypoint, args, err := kubectl.Logs(ctx, tc.Compilers(), tc.Bindings(), &op)
 				if err != nil {
 					return nil, nil, tc, err
 				}
+				var filter func(string) string
+				if len(op.JSONFields) != 0 {
+					filter = kubectl.JSONFields(op.JSONFields...)
+				}
 				op := opcommand.New(
 					tc.Compilers(),
 					v1alpha1.Command{
+						ActionCheck:    op.ActionCheck,
 						ActionClusters: op.ActionClusters,
 						ActionTimeout:  op.ActionTimeout,
 						Entrypoint:     entrypoint,
@@ -809,6 +1553,76 @@ func (p *stepProcessor) logsOperation(_ compilers.Compilers, id int, namespacer
 					p.basePath,
 					ns,
 					config,
+					filter,
+				)
+				return op, timeout, tc, nil
+			}
+		},
+	)
+}
+
+// nodeOperation cordons, drains or uncordons nodes depending on the mode,
+// the drain specific fields of the operation are only used when draining.
+func (p *stepProcessor) nodeOperation(id int, namespacer namespacer.Namespacer, mode nodeMode, op v1alpha1.Drain) operation {
+	ns := ""
+	if namespacer != nil {
+		ns = namespacer.GetNamespace()
+	}
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeCommand,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, string(mode)); err != nil {
+				return nil, nil, tc, err
+			}
+			if mode == nodeDrain {
+				// make sure timeout is set to populate the command flag
+				op.Timeout = &v1alpha1.Timeout{Duration: *timeout}
+				// shift operation timeout
+				shifted := op.Timeout.Duration + 30*time.Second
+				timeout = &shifted
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if config, _, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				var entrypoint string
+				var args []string
+				switch mode {
+				case nodeCordon:
+					entrypoint, args, err = kubectl.Cordon(ctx, tc.Compilers(), tc.Bindings(), &v1alpha1.Cordon{ActionNode: op.ActionNode})
+				case nodeUncordon:
+					entrypoint, args, err = kubectl.Uncordon(ctx, tc.Compilers(), tc.Bindings(), &v1alpha1.Uncordon{ActionNode: op.ActionNode})
+				default:
+					entrypoint, args, err = kubectl.Drain(ctx, tc.Compilers(), tc.Bindings(), &op)
+				}
+				if err != nil {
+					return nil, nil, tc, err
+				}
+				op := opcommand.New(
+					tc.Compilers(),
+					v1alpha1.Command{
+						ActionClusters: op.ActionClusters,
+						ActionTimeout:  op.ActionTimeout,
+						Entrypoint:     entrypoint,
+						Args:           args,
+					},
+					p.basePath,
+					ns,
+					config,
+					nil,
 				)
 				return op, timeout, tc, nil
 			}
@@ -835,7 +1649,10 @@ func (p *stepProcessor) patchOperation(compilers compilers.Compilers, id int, na
 			},
 			model.OperationTypePatch,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Apply.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Apply.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -853,6 +1670,7 @@ func (p *stepProcessor) patchOperation(compilers compilers.Compilers, id int, na
 						resource,
 						namespacer,
 						template,
+						op.Rendered,
 						op.Expect,
 						op.Outputs,
 					)
@@ -864,6 +1682,76 @@ func (p *stepProcessor) patchOperation(compilers compilers.Compilers, id int, na
 	return ops, nil
 }
 
+func (p *stepProcessor) noCrashLoopOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.NoCrashLoop) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeNoCrashLoop,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opnocrashloop.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) podCountOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.PodCount) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypePodCount,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return oppodcount.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) probeOperation(_ compilers.Compilers, id int, op v1alpha1.Probe) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeProbe,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			return opprobe.New(tc.Compilers(), op), timeout, tc, nil
+		},
+	)
+}
+
 func (p *stepProcessor) proxyOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Proxy) operation {
 	ns := ""
 	if namespacer != nil {
@@ -875,7 +1763,13 @@ func (p *stepProcessor) proxyOperation(_ compilers.Compilers, id int, namespacer
 		},
 		model.OperationTypeCommand,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "proxy"); err != nil {
+				return nil, nil, tc, err
+			}
 			if tc, _, err := setupContextData(ctx, tc, contextData{
 				basePath: p.basePath,
 				bindings: nil,
@@ -901,6 +1795,7 @@ func (p *stepProcessor) proxyOperation(_ compilers.Compilers, id int, namespacer
 					p.basePath,
 					ns,
 					config,
+					nil,
 				)
 				return op, timeout, tc, nil
 			}
@@ -908,6 +1803,60 @@ func (p *stepProcessor) proxyOperation(_ compilers.Compilers, id int, namespacer
 	)
 }
 
+func (p *stepProcessor) rolloutOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Rollout) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeRollout,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return oprollout.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
+func (p *stepProcessor) scaleOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Scale) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeScale,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Apply.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opscale.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
 func (p *stepProcessor) scriptOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Script) operation {
 	ns := ""
 	if namespacer != nil {
@@ -919,7 +1868,10 @@ func (p *stepProcessor) scriptOperation(_ compilers.Compilers, id int, namespace
 		},
 		model.OperationTypeScript,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-			timeout := timeout.Get(op.Timeout, p.timeouts.Exec.Duration)
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
 			if tc, _, err := setupContextData(ctx, tc, contextData{
 				basePath: p.basePath,
 				bindings: op.Bindings,
@@ -955,6 +1907,33 @@ func (p *stepProcessor) sleepOperation(_ compilers.Compilers, id int, op v1alpha
 	)
 }
 
+func (p *stepProcessor) snapshotOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Snapshot) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeSnapshot,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opsnapshot.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
 func (p *stepProcessor) updateOperation(compilers compilers.Compilers, id int, namespacer namespacer.Namespacer, bindings apis.Bindings, op v1alpha1.Update) ([]operation, error) {
 	resources, err := p.fileRefOrResource(context.TODO(), compilers, op.ActionResourceRef, bindings)
 	if err != nil {
@@ -974,7 +1953,10 @@ func (p *stepProcessor) updateOperation(compilers compilers.Compilers, id int, n
 			},
 			model.OperationTypeUpdate,
 			func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
-				timeout := timeout.Get(op.Timeout, p.timeouts.Apply.Duration)
+				timeout, err := timeout.Get(op.Timeout, p.timeouts.Apply.Duration, p.timeouts.Named)
+				if err != nil {
+					return nil, nil, tc, err
+				}
 				if tc, _, err := setupContextData(ctx, tc, contextData{
 					basePath: p.basePath,
 					bindings: op.Bindings,
@@ -992,6 +1974,7 @@ func (p *stepProcessor) updateOperation(compilers compilers.Compilers, id int, n
 						resource,
 						namespacer,
 						template,
+						op.Rendered,
 						op.Expect,
 						op.Outputs,
 					)
@@ -1015,7 +1998,14 @@ func (p *stepProcessor) waitOperation(_ compilers.Compilers, id int, namespacer
 		model.OperationTypeCommand,
 		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
 			// make sure timeout is set to populate the command flag
-			op.Timeout = &metav1.Duration{Duration: *timeout.Get(op.Timeout, p.timeouts.Exec.Duration)}
+			resolved, err := timeout.Get(op.Timeout, p.timeouts.Exec.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if err := notReplayable(ctx, "wait"); err != nil {
+				return nil, nil, tc, err
+			}
+			op.Timeout = &v1alpha1.Timeout{Duration: *resolved}
 			// shift operation timeout
 			timeout := op.Timeout.Duration + 30*time.Second
 			if tc, _, err := setupContextData(ctx, tc, contextData{
@@ -1043,6 +2033,7 @@ func (p *stepProcessor) waitOperation(_ compilers.Compilers, id int, namespacer
 					p.basePath,
 					ns,
 					config,
+					nil,
 				)
 				return op, &timeout, tc, nil
 			}
@@ -1050,6 +2041,33 @@ func (p *stepProcessor) waitOperation(_ compilers.Compilers, id int, namespacer
 	)
 }
 
+func (p *stepProcessor) watchOperation(_ compilers.Compilers, id int, namespacer namespacer.Namespacer, op v1alpha1.Watch) operation {
+	return newOperation(
+		OperationInfo{
+			Id: id,
+		},
+		model.OperationTypeWatch,
+		func(ctx context.Context, tc engine.Context) (operations.Operation, *time.Duration, engine.Context, error) {
+			timeout, err := timeout.Get(op.Timeout, p.timeouts.Assert.Duration, p.timeouts.Named)
+			if err != nil {
+				return nil, nil, tc, err
+			}
+			if tc, _, err := setupContextData(ctx, tc, contextData{
+				basePath: p.basePath,
+				bindings: nil,
+				cluster:  op.Cluster,
+				clusters: op.Clusters,
+			}); err != nil {
+				return nil, nil, tc, err
+			} else if _, client, err := tc.CurrentClusterClient(); err != nil {
+				return nil, nil, tc, err
+			} else {
+				return opwatch.New(tc.Compilers(), client, namespacer, op), timeout, tc, nil
+			}
+		},
+	)
+}
+
 func (p *stepProcessor) fileRefOrCheck(ctx context.Context, compilers compilers.Compilers, ref v1alpha1.ActionCheckRef, bindings apis.Bindings) ([]unstructured.Unstructured, error) {
 	if ref.Check != nil && ref.Check.Value() != nil {
 		if object, ok := ref.Check.Value().(map[string]any); !ok {
@@ -1059,62 +2077,267 @@ func (p *stepProcessor) fileRefOrCheck(ctx context.Context, compilers compilers.
 		}
 	}
 	if ref.File != "" {
-		ref, err := ref.File.Value(ctx, compilers, bindings)
-		if err != nil {
-			return nil, err
-		}
-		url, err := url.ParseRequestURI(ref)
-		if err != nil {
-			return resource.Load(filepath.Join(p.basePath, ref), false)
-		} else {
-			return resource.LoadFromURI(url, false)
-		}
+		return p.loadFile(ctx, compilers, ref.File, bindings, false, ref.AllowEmpty)
 	}
 	return nil, errors.New("file or resource must be set")
 }
 
+// notReplayable fails when a cassette is replayed, the operation talks to the api server without the cluster client
+// (with kubectl, an exec stream or the proxy) so the cassette can't answer it and it would reach a cluster instead.
+func notReplayable(ctx context.Context, operation string) error {
+	if _, mode := cassette.FromContext(ctx); mode == cassette.Replay {
+		return fmt.Errorf("%s operations can't be replayed from a cassette, they need a cluster", operation)
+	}
+	return nil
+}
+
+// kustomize returns the resources built from the kustomization directory the expression resolves to.
+func (p *stepProcessor) kustomize(ctx context.Context, compilers compilers.Compilers, ref v1alpha1.Expression, bindings apis.Bindings) ([]unstructured.Unstructured, error) {
+	dir, err := p.resolveFile(ctx, compilers, ref, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(p.basePath, dir)
+	}
+	return kustomize.Build(ctx, dir)
+}
+
 func (p *stepProcessor) fileRefOrResource(ctx context.Context, compilers compilers.Compilers, ref v1alpha1.ActionResourceRef, bindings apis.Bindings) ([]unstructured.Unstructured, error) {
 	if ref.Resource != nil {
 		return []unstructured.Unstructured{*ref.Resource}, nil
 	}
 	if ref.File != "" {
-		ref, err := ref.File.Value(ctx, compilers, bindings)
+		return p.loadFile(ctx, compilers, ref.File, bindings, true, ref.AllowEmpty)
+	}
+	return nil, errors.New("file or resource must be set")
+}
+
+func (p *stepProcessor) loadFile(ctx context.Context, compilers compilers.Compilers, ref v1alpha1.Expression, bindings apis.Bindings, manifest bool, allowEmpty *bool) ([]unstructured.Unstructured, error) {
+	file, err := p.resolveFile(ctx, compilers, ref, bindings)
+	if err != nil {
+		return nil, err
+	}
+	// absolute paths coming from bindings (e.g. ($testDir)/pod.yaml) are used as is, literal paths are handled as before
+	if filepath.IsAbs(file) && expressions.IsTemplatedPath(string(ref)) {
+		return p.loadFileRef(file, manifest, allowEmpty)
+	}
+	url, err := url.ParseRequestURI(file)
+	if err != nil {
+		return p.loadFileRef(filepath.Join(p.basePath, file), manifest, allowEmpty)
+	} else {
+		return resource.LoadFromURI(url, manifest)
+	}
+}
+
+// resolveFile evaluates the expressions in a file reference (e.g. manifests/($kind).yaml).
+// Values coming from expressions are not allowed to escape the test directory.
+func (p *stepProcessor) resolveFile(ctx context.Context, compilers compilers.Compilers, ref v1alpha1.Expression, bindings apis.Bindings) (string, error) {
+	file, err := expressions.Path(ctx, compilers, string(ref), bindings, func(expression string, value string) error {
+		if escapesDir(p.basePath, value) {
+			return fmt.Errorf("file expression %s resolved to %s which escapes the test directory", expression, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	// values can stay inside the test directory on their own and escape it once combined (e.g. ($a)($b)/pod.yaml with a and b set to .),
+	// the resolved path is checked against the directory the literal parts of the path point to
+	if expressions.IsTemplatedPath(string(ref)) {
+		literal := expressions.PathLiteral(string(ref), "_")
+		if !filepath.IsAbs(literal) {
+			root := filepath.Join(p.basePath, literalParents(literal))
+			path := file
+			if !filepath.IsAbs(path) {
+				path, err = filepath.Abs(filepath.Join(p.basePath, path))
+				if err != nil {
+					return "", err
+				}
+			}
+			if escapesDir(root, path) {
+				return "", fmt.Errorf("file %s resolved to %s which escapes the test directory", ref, file)
+			}
+		}
+	}
+	return file, nil
+}
+
+// literalParents returns the leading parent references of a path (e.g. ../.. for ../../shared/pod.yaml).
+func literalParents(path string) string {
+	var parents []string
+	for _, part := range strings.Split(filepath.ToSlash(filepath.Clean(path)), "/") {
+		if part != ".." {
+			break
+		}
+		parents = append(parents, part)
+	}
+	return filepath.Join(parents...)
+}
+
+func escapesDir(dir string, path string) bool {
+	if filepath.IsAbs(path) {
+		abs, err := filepath.Abs(dir)
 		if err != nil {
-			return nil, err
+			return true
 		}
-		url, err := url.ParseRequestURI(ref)
+		rel, err := filepath.Rel(abs, path)
 		if err != nil {
-			return resource.Load(filepath.Join(p.basePath, ref), true)
-		} else {
-			return resource.LoadFromURI(url, true)
+			return true
 		}
+		path = rel
 	}
-	return nil, errors.New("file or resource must be set")
+	path = filepath.ToSlash(filepath.Clean(path))
+	return path == ".." || strings.HasPrefix(path, "../")
+}
+
+func (p *stepProcessor) loadFileRef(pattern string, manifest bool, allowEmpty *bool) ([]unstructured.Unstructured, error) {
+	resources, err := resource.Load(pattern, manifest)
+	if errors.Is(err, resource.ErrNoMatch) && allowEmpty != nil && *allowEmpty {
+		return nil, nil
+	}
+	return resources, err
 }
 
 func (p *stepProcessor) prepareResource(resource unstructured.Unstructured) error {
 	if p.terminationGracePeriod != nil {
 		seconds := int64(p.terminationGracePeriod.Seconds())
-		if seconds != 0 {
-			switch resource.GetKind() {
-			case "Pod":
-				if err := unstructured.SetNestedField(resource.UnstructuredContent(), seconds, "spec", "terminationGracePeriodSeconds"); err != nil {
-					return err
-				}
-			case "Deployment", "StatefulSet", "DaemonSet", "Job":
-				if err := unstructured.SetNestedField(resource.UnstructuredContent(), seconds, "spec", "template", "spec", "terminationGracePeriodSeconds"); err != nil {
-					return err
-				}
-			case "CronJob":
-				if err := unstructured.SetNestedField(resource.UnstructuredContent(), seconds, "spec", "jobTemplate", "spec", "template", "spec", "terminationGracePeriodSeconds"); err != nil {
-					return err
-				}
+		if path := podSpecPath(resource.GetKind()); seconds != 0 && path != nil {
+			if err := unstructured.SetNestedField(resource.UnstructuredContent(), seconds, append(path, "terminationGracePeriodSeconds")...); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
+// podSpecPath returns the path of the pod spec in resources of the given kind, nil if the kind doesn't bear pods.
+func podSpecPath(kind string) []string {
+	switch kind {
+	case "Pod":
+		return []string{"spec"}
+	case "Deployment", "StatefulSet", "DaemonSet", "Job":
+		return []string{"spec", "template", "spec"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	}
+	return nil
+}
+
+// mergeHostAliases merges the injected host aliases into the pod spec of resources bearing pods.
+// Hostnames are added to the host alias declared with the same IP if any.
+func (p *stepProcessor) mergeHostAliases(resource unstructured.Unstructured) (unstructured.Unstructured, error) {
+	path := podSpecPath(resource.GetKind())
+	if len(p.config.Execution.InjectHostAliases) == 0 || path == nil {
+		return resource, nil
+	}
+	resource = *resource.DeepCopy()
+	path = append(path, "hostAliases")
+	aliases, _, err := unstructured.NestedSlice(resource.UnstructuredContent(), path...)
+	if err != nil {
+		return resource, err
+	}
+	for _, inject := range p.config.Execution.InjectHostAliases {
+		var declared map[string]any
+		for _, alias := range aliases {
+			if alias, ok := alias.(map[string]any); ok && alias["ip"] == inject.IP {
+				declared = alias
+				break
+			}
+		}
+		if declared == nil {
+			declared = map[string]any{"ip": inject.IP}
+			aliases = append(aliases, declared)
+		}
+		hostnames, _, err := unstructured.NestedSlice(declared, "hostnames")
+		if err != nil {
+			return resource, err
+		}
+		for _, hostname := range inject.Hostnames {
+			if !slices.Contains(hostnames, any(hostname)) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+		declared["hostnames"] = hostnames
+	}
+	return resource, unstructured.SetNestedSlice(resource.UnstructuredContent(), aliases, path...)
+}
+
+func (p *stepProcessor) injectMetadata(ctx context.Context, tc engine.Context, resource unstructured.Unstructured) (unstructured.Unstructured, error) {
+	if len(p.config.Execution.InjectLabels) == 0 && len(p.config.Execution.InjectAnnotations) == 0 {
+		return resource, nil
+	}
+	resource = *resource.DeepCopy()
+	labels, err := mergeMetadata(ctx, tc, p.config.Execution.InjectLabels, resource.GetLabels())
+	if err != nil {
+		return resource, err
+	}
+	annotations, err := mergeMetadata(ctx, tc, p.config.Execution.InjectAnnotations, resource.GetAnnotations())
+	if err != nil {
+		return resource, err
+	}
+	resource.SetLabels(labels)
+	resource.SetAnnotations(annotations)
+	return resource, nil
+}
+
+// mergeMetadata evaluates injected metadata and merges it with the declared one, declared metadata takes precedence.
+func mergeMetadata(ctx context.Context, tc engine.Context, inject map[string]v1alpha1.Expression, declared map[string]string) (map[string]string, error) {
+	if len(inject) == 0 {
+		return declared, nil
+	}
+	merged := map[string]string{}
+	for key, expression := range inject {
+		value, err := expression.Value(ctx, tc.Compilers(), tc.Bindings())
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate injected metadata %s (%w)", key, err)
+		}
+		merged[key] = value
+	}
+	for key, value := range declared {
+		merged[key] = value
+	}
+	return merged, nil
+}
+
+// resolveObjectReference evaluates the expressions of an object reference (type, name, namespace and label values) against the bindings.
+func resolveObjectReference(ctx context.Context, tc engine.Context, ref v1alpha1.ObjectReference) (unstructured.Unstructured, error) {
+	var resource unstructured.Unstructured
+	gv, err := ref.GroupVersion(ctx, tc.Compilers(), tc.Bindings())
+	if err != nil {
+		return resource, err
+	}
+	kind, err := ref.Kind.Value(ctx, tc.Compilers(), tc.Bindings())
+	if err != nil {
+		return resource, err
+	}
+	name, err := ref.Name.Value(ctx, tc.Compilers(), tc.Bindings())
+	if err != nil {
+		return resource, err
+	}
+	namespace, err := ref.Namespace.Value(ctx, tc.Compilers(), tc.Bindings())
+	if err != nil {
+		return resource, err
+	}
+	var labels map[string]string
+	if len(ref.Labels) != 0 {
+		labels = map[string]string{}
+		for key, value := range ref.Labels {
+			value, err := expressions.String(ctx, tc.Compilers(), value, tc.Bindings())
+			if err != nil {
+				return resource, fmt.Errorf("failed to evaluate label %s (%w)", key, err)
+			}
+			labels[key] = value
+		}
+	}
+	resource.SetAPIVersion(gv.String())
+	resource.SetKind(kind)
+	resource.SetName(name)
+	resource.SetNamespace(namespace)
+	resource.SetLabels(labels)
+	return resource, nil
+}
+
 func (p *stepProcessor) getCleanerOrNil(cleaner cleaner.CleanerCollector, tc engine.Context) cleaner.CleanerCollector {
 	if tc.DryRun() {
 		return nil
@@ -1125,6 +2348,14 @@ func (p *stepProcessor) getCleanerOrNil(cleaner cleaner.CleanerCollector, tc eng
 	return cleaner
 }
 
+// skipCleanup evaluates the skip delete condition against the bindings, resources are deleted when no condition is set.
+func skipCleanup(ctx context.Context, tc engine.Context, condition v1alpha1.Expression) (bool, error) {
+	if condition == "" {
+		return false, nil
+	}
+	return expressions.Bool(ctx, tc.Compilers(), string(condition), tc.Bindings())
+}
+
 func (p *stepProcessor) getTemplating(op *bool) bool {
 	if op != nil {
 		return *op
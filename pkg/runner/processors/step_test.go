@@ -2,24 +2,39 @@ package processors
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/client/cassette"
 	fake "github.com/kyverno/chainsaw/pkg/client/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/collectors"
 	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	fakeLogger "github.com/kyverno/chainsaw/pkg/engine/logging/testing"
+	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	fakeNamespacer "github.com/kyverno/chainsaw/pkg/engine/namespacer/testing"
+	opexec "github.com/kyverno/chainsaw/pkg/engine/operations/exec"
 	"github.com/kyverno/chainsaw/pkg/loaders/config"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/chainsaw/pkg/testing"
+	"github.com/kyverno/kyverno-json/pkg/core/compilers"
 	"github.com/stretchr/testify/assert"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	kerror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
 )
 
@@ -32,6 +47,7 @@ func TestStepProcessor_Run(t *testing.T) {
 	testCases := []struct {
 		name                   string
 		client                 client.Client
+		clusters               map[string]client.Client
 		namespacer             *fakeNamespacer.FakeNamespacer
 		basePath               string
 		terminationGracePeriod *metav1.Duration
@@ -181,6 +197,102 @@ func TestStepProcessor_Run(t *testing.T) {
 				Finally: []v1alpha1.CatchFinally{},
 			},
 		},
+	}, {
+		name: "try operation with assert handler on secondary cluster",
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(v1alpha1.Resource("pod"), "myapp")
+			},
+		},
+		clusters: map[string]client.Client{
+			"secondary": &fake.FakeClient{
+				GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					obj.(*unstructured.Unstructured).Object = map[string]any{
+						"apiVersion": "v1",
+						"kind":       "Pod",
+						"metadata": map[string]any{
+							"name": "myapp",
+							"labels": map[string]any{
+								"name": "myapp",
+							},
+						},
+						"spec": map[string]any{
+							"containers": []any{
+								map[string]any{
+									"name":  "myapp",
+									"image": "myapp:latest",
+									"resources": map[string]any{
+										"limits": map[string]any{
+											"memory": "128Mi",
+											"cpu":    "500m",
+										},
+									},
+								},
+							},
+						},
+					}
+					return nil
+				},
+			},
+		},
+		namespacer: &fakeNamespacer.FakeNamespacer{
+			ApplyFn: func(int, client.Client, client.Object) error {
+				return nil
+			},
+		},
+		basePath: testData,
+		stepSpec: v1alpha1.TestStep{
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Timeouts: &v1alpha1.Timeouts{},
+				Try: []v1alpha1.Operation{
+					{
+						Assert: &v1alpha1.Assert{
+							ActionClusters: v1alpha1.ActionClusters{
+								Cluster: ptr.To("secondary"),
+							},
+							ActionCheckRef: v1alpha1.ActionCheckRef{
+								FileRef: v1alpha1.FileRef{
+									File: "pod.yaml",
+								},
+							},
+						},
+					},
+				},
+				Catch:   []v1alpha1.CatchFinally{},
+				Finally: []v1alpha1.CatchFinally{},
+			},
+		},
+	}, {
+		name:   "try operation with assert handler on unknown cluster",
+		client: &fake.FakeClient{},
+		namespacer: &fakeNamespacer.FakeNamespacer{
+			ApplyFn: func(int, client.Client, client.Object) error {
+				return nil
+			},
+		},
+		basePath: testData,
+		stepSpec: v1alpha1.TestStep{
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Timeouts: &v1alpha1.Timeouts{},
+				Try: []v1alpha1.Operation{
+					{
+						Assert: &v1alpha1.Assert{
+							ActionClusters: v1alpha1.ActionClusters{
+								Cluster: ptr.To("unknown"),
+							},
+							ActionCheckRef: v1alpha1.ActionCheckRef{
+								FileRef: v1alpha1.FileRef{
+									File: "pod.yaml",
+								},
+							},
+						},
+					},
+				},
+				Catch:   []v1alpha1.CatchFinally{},
+				Finally: []v1alpha1.CatchFinally{},
+			},
+		},
+		expectedFail: true,
 	}, {
 		name: "try operation with error handler",
 		client: &fake.FakeClient{
@@ -782,10 +894,12 @@ func TestStepProcessor_Run(t *testing.T) {
 			if tc.client != nil {
 				registry.client = tc.client
 			}
+			registry.clusters = tc.clusters
 			stepProcessor := NewStepProcessor(
 				tc.stepSpec,
 				&model.TestReport{},
 				tc.basePath,
+				config.Spec,
 				nil,
 				tc.terminationGracePeriod,
 				config.Spec.Timeouts,
@@ -807,3 +921,2382 @@ func TestStepProcessor_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestStepProcessor_fileRef(t *testing.T) {
+	testData := filepath.Join("..", "..", "..", "testdata", "runner", "processors")
+	tests := []struct {
+		name       string
+		file       string
+		allowEmpty *bool
+		want       []string
+		wantErr    bool
+	}{{
+		name: "single file",
+		file: "pod.yaml",
+		want: []string{"myapp"},
+	}, {
+		name: "glob",
+		file: "glob/*.yaml",
+		want: []string{"configmap-1", "configmap-2", "configmap-3"},
+	}, {
+		name:    "no match",
+		file:    "nonexistent/*.yaml",
+		wantErr: true,
+	}, {
+		name:       "no match not allowed",
+		file:       "nonexistent/*.yaml",
+		allowEmpty: ptr.To(false),
+		wantErr:    true,
+	}, {
+		name:       "no match allowed",
+		file:       "nonexistent/*.yaml",
+		allowEmpty: ptr.To(true),
+		want:       nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &stepProcessor{
+				basePath: testData,
+			}
+			fileRef := v1alpha1.FileRef{
+				File:       v1alpha1.Expression(tt.file),
+				AllowEmpty: tt.allowEmpty,
+			}
+			names := func(resources []unstructured.Unstructured) []string {
+				var names []string
+				for _, resource := range resources {
+					names = append(names, resource.GetName())
+				}
+				return names
+			}
+			{
+				resources, err := p.fileRefOrResource(context.TODO(), apis.DefaultCompilers, v1alpha1.ActionResourceRef{FileRef: fileRef}, nil)
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, names(resources))
+				}
+			}
+			{
+				resources, err := p.fileRefOrCheck(context.TODO(), apis.DefaultCompilers, v1alpha1.ActionCheckRef{FileRef: fileRef}, nil)
+				if tt.wantErr {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, names(resources))
+				}
+			}
+		})
+	}
+}
+
+func TestStepProcessor_MultiDocumentApply(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	testData := filepath.Join("..", "..", "..", "testdata", "runner", "processors")
+	var created []string
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+				created = append(created, obj.GetName())
+				return nil
+			},
+		},
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Timeouts: &v1alpha1.Timeouts{},
+			Try: []v1alpha1.Operation{{
+				Apply: &v1alpha1.Apply{
+					ActionBindings: v1alpha1.ActionBindings{
+						Bindings: []v1alpha1.Binding{{
+							Name:  "suffix",
+							Value: v1alpha1.NewProjection("foo"),
+						}},
+					},
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						FileRef: v1alpha1.FileRef{
+							File: "multi-document.yaml",
+						},
+					},
+				},
+			}},
+		},
+	}
+	report := &model.TestReport{}
+	stepProcessor := NewStepProcessor(
+		step,
+		report,
+		testData,
+		config.Spec,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		true,
+		config.Spec.Error.Catch...,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+	stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+		ApplyFn: func(int, client.Client, client.Object) error {
+			return nil
+		},
+	}, tcontext)
+	assert.False(t, nt.FailedVar, "expected no error but got one")
+	assert.Equal(t, []string{"first-foo", "second-foo"}, created)
+	assert.Len(t, report.Steps, 1)
+	assert.Len(t, report.Steps[0].Operations, 2)
+	for _, operation := range report.Steps[0].Operations {
+		assert.Equal(t, model.OperationTypeApply, operation.Type)
+		assert.NoError(t, operation.Err)
+	}
+}
+
+func TestStepProcessor_InjectMetadata(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	var created []client.Object
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+				created = append(created, obj)
+				return nil
+			},
+		},
+	}
+	bindings := v1alpha1.ActionBindings{
+		Bindings: []v1alpha1.Binding{{
+			Name:  "run",
+			Value: v1alpha1.NewProjection("run-1"),
+		}},
+	}
+	configMap := func(name string, labels map[string]any) *unstructured.Unstructured {
+		metadata := map[string]any{
+			"name": name,
+		}
+		if labels != nil {
+			metadata["labels"] = labels
+		}
+		return &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   metadata,
+			},
+		}
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Timeouts: &v1alpha1.Timeouts{},
+			Try: []v1alpha1.Operation{{
+				Apply: &v1alpha1.Apply{
+					ActionBindings: bindings,
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						Resource: configMap("applied", nil),
+					},
+				},
+			}, {
+				Create: &v1alpha1.Create{
+					ActionBindings: bindings,
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						Resource: configMap("created", map[string]any{
+							"team": "declared",
+						}),
+					},
+				},
+			}},
+		},
+	}
+	spec := config.Spec
+	spec.Execution.InjectLabels = map[string]v1alpha1.Expression{
+		"run-id": "($run)",
+		"team":   "injected",
+	}
+	spec.Execution.InjectAnnotations = map[string]v1alpha1.Expression{
+		"cost-center": "e2e",
+	}
+	stepProcessor := NewStepProcessor(
+		step,
+		&model.TestReport{},
+		"",
+		spec,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		true,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+	stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+		ApplyFn: func(int, client.Client, client.Object) error {
+			return nil
+		},
+	}, tcontext)
+	assert.False(t, nt.FailedVar, "expected no error but got one")
+	assert.Len(t, created, 2)
+	assert.Equal(t, "applied", created[0].GetName())
+	assert.Equal(t, map[string]string{"run-id": "run-1", "team": "injected"}, created[0].GetLabels())
+	assert.Equal(t, map[string]string{"cost-center": "e2e"}, created[0].GetAnnotations())
+	assert.Equal(t, "created", created[1].GetName())
+	assert.Equal(t, map[string]string{"run-id": "run-1", "team": "declared"}, created[1].GetLabels())
+	assert.Equal(t, map[string]string{"cost-center": "e2e"}, created[1].GetAnnotations())
+	assert.Nil(t, step.Try[0].Apply.Resource.GetLabels(), "injection must not mutate the step definition")
+}
+
+func TestStepProcessor_InjectHostAliases(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	var created []client.Object
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(v1alpha1.Resource("pod"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+				created = append(created, obj)
+				return nil
+			},
+		},
+	}
+	pod := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]any{
+				"name": "pod",
+			},
+			"spec": map[string]any{
+				"hostAliases": []any{
+					map[string]any{
+						"ip":        "10.0.0.1",
+						"hostnames": []any{"declared.local"},
+					},
+				},
+			},
+		},
+	}
+	configMap := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]any{
+				"name": "config-map",
+			},
+		},
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Timeouts: &v1alpha1.Timeouts{},
+			Try: []v1alpha1.Operation{{
+				Create: &v1alpha1.Create{
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						Resource: pod,
+					},
+				},
+			}, {
+				Apply: &v1alpha1.Apply{
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						Resource: configMap,
+					},
+				},
+			}},
+		},
+	}
+	spec := config.Spec
+	spec.Execution.InjectHostAliases = []corev1.HostAlias{{
+		IP:        "10.0.0.1",
+		Hostnames: []string{"mock.local", "declared.local"},
+	}, {
+		IP:        "10.0.0.2",
+		Hostnames: []string{"other.local"},
+	}}
+	stepProcessor := NewStepProcessor(
+		step,
+		&model.TestReport{},
+		"",
+		spec,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		true,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+	stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+		ApplyFn: func(int, client.Client, client.Object) error {
+			return nil
+		},
+	}, tcontext)
+	assert.False(t, nt.FailedVar, "expected no error but got one")
+	assert.Len(t, created, 2)
+	aliases, _, err := unstructured.NestedSlice(created[0].(*unstructured.Unstructured).UnstructuredContent(), "spec", "hostAliases")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{
+		map[string]any{"ip": "10.0.0.1", "hostnames": []any{"declared.local", "mock.local"}},
+		map[string]any{"ip": "10.0.0.2", "hostnames": []any{"other.local"}},
+	}, aliases)
+	_, found, err := unstructured.NestedFieldNoCopy(created[1].(*unstructured.Unstructured).UnstructuredContent(), "spec")
+	assert.NoError(t, err)
+	assert.False(t, found, "host aliases must only be injected in resources bearing pods")
+	assert.Len(t, pod.Object["spec"].(map[string]any)["hostAliases"], 1, "injection must not mutate the step definition")
+}
+
+func TestStepProcessor_CanI(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name         string
+		allowed      bool
+		skip         *bool
+		expectedFail bool
+		expectedSkip bool
+		expectedRest bool
+	}{{
+		name:         "allowed",
+		allowed:      true,
+		expectedRest: true,
+	}, {
+		name:         "denied",
+		allowed:      false,
+		expectedFail: true,
+	}, {
+		name:         "denied with skip",
+		allowed:      false,
+		skip:         ptr.To(true),
+		expectedSkip: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []client.Object
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+						if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+							review.Status.Allowed = tt.allowed
+							return nil
+						}
+						created = append(created, obj)
+						return nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						CanI: &v1alpha1.CanI{
+							Verb:     "create",
+							Resource: "configmaps",
+							Skip:     tt.skip,
+						},
+					}, {
+						Create: &v1alpha1.Create{
+							ActionResourceRef: v1alpha1.ActionResourceRef{
+								Resource: &unstructured.Unstructured{
+									Object: map[string]any{
+										"apiVersion": "v1",
+										"kind":       "ConfigMap",
+										"metadata": map[string]any{
+											"name": "chainsaw",
+										},
+									},
+								},
+							},
+						},
+					}},
+				},
+			}
+			report := &model.TestReport{}
+			stepProcessor := NewStepProcessor(
+				step,
+				report,
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			assert.Equal(t, tt.expectedSkip, nt.SkippedVar)
+			if tt.expectedRest {
+				assert.Len(t, created, 1)
+			} else if tt.expectedSkip {
+				assert.Empty(t, created)
+			}
+			// a skip is reported as a skipped operation, not as an error
+			assert.Len(t, report.Steps, 1)
+			assert.NotEmpty(t, report.Steps[0].Operations)
+			if len(report.Steps) == 1 && len(report.Steps[0].Operations) != 0 {
+				operation := report.Steps[0].Operations[0]
+				assert.Equal(t, tt.expectedSkip, operation.Skipped)
+				assert.Equal(t, tt.expectedFail, operation.Err != nil)
+			}
+		})
+	}
+}
+
+func TestStepProcessor_Health(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	tests := []struct {
+		name         string
+		path         string
+		skip         *bool
+		expectedFail bool
+		expectedSkip bool
+	}{{
+		name: "healthy",
+		path: "/ready",
+	}, {
+		name:         "unhealthy",
+		path:         "/unready",
+		expectedFail: true,
+	}, {
+		name:         "unhealthy with skip",
+		path:         "/unready",
+		skip:         ptr.To(true),
+		expectedSkip: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []client.Object
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+						created = append(created, obj)
+						return nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						Health: &v1alpha1.Health{
+							URL:  "(concat($endpoint, $path))",
+							Skip: tt.skip,
+						},
+					}, {
+						Create: &v1alpha1.Create{
+							ActionResourceRef: v1alpha1.ActionResourceRef{
+								Resource: &unstructured.Unstructured{
+									Object: map[string]any{
+										"apiVersion": "v1",
+										"kind":       "ConfigMap",
+										"metadata": map[string]any{
+											"name": "chainsaw",
+										},
+									},
+								},
+							},
+						},
+					}},
+				},
+			}
+			report := &model.TestReport{}
+			stepProcessor := NewStepProcessor(
+				step,
+				report,
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			bindings := apis.NewBindings().Register("$endpoint", apis.NewBinding(server.URL)).Register("$path", apis.NewBinding(tt.path))
+			tcontext := enginecontext.MakeContext(bindings, registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			assert.Equal(t, tt.expectedSkip, nt.SkippedVar)
+			if tt.expectedSkip {
+				assert.Empty(t, created)
+			} else if !tt.expectedFail {
+				assert.Len(t, created, 1)
+			}
+			// a skip is reported as a skipped operation, not as an error
+			if assert.Len(t, report.Steps, 1) && assert.NotEmpty(t, report.Steps[0].Operations) {
+				operation := report.Steps[0].Operations[0]
+				assert.Equal(t, model.OperationTypeHealth, operation.Type)
+				assert.Equal(t, tt.expectedSkip, operation.Skipped)
+				assert.Equal(t, tt.expectedFail, operation.Err != nil)
+			}
+		})
+	}
+}
+
+func TestStepProcessor_Collect(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	var collected []any
+	assert.NoError(t, collectors.Register("test", collectors.CollectorFunc(
+		func(_ context.Context, _ compilers.Compilers, _ client.Client, _ apis.Bindings, config any) (*collectors.Result, error) {
+			collected = append(collected, config)
+			return &collectors.Result{Output: config}, nil
+		},
+	)))
+	defer collectors.Unregister("test")
+	assert.NoError(t, collectors.Register("hang", collectors.CollectorFunc(
+		func(ctx context.Context, _ compilers.Compilers, _ client.Client, _ apis.Bindings, _ any) (*collectors.Result, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	)))
+	defer collectors.Unregister("hang")
+	tests := []struct {
+		name              string
+		collectorType     string
+		timeout           *v1alpha1.Timeout
+		expectedFail      bool
+		expectedCollected []any
+		expectedLogs      []string
+	}{{
+		name:              "custom collector",
+		collectorType:     "test",
+		expectedCollected: []any{map[string]any{"zone": "a"}},
+		expectedLogs: []string{
+			"COLLECT: RUN - []",
+			"COLLECT: LOG - [=== OUTPUT\nzone: a]",
+			"COLLECT: DONE - []",
+		},
+	}, {
+		name:          "unknown collector",
+		collectorType: "unknown",
+		expectedFail:  true,
+	}, {
+		name:          "collector timeout",
+		collectorType: "hang",
+		timeout:       &v1alpha1.Timeout{Duration: 100 * time.Millisecond},
+		expectedFail:  true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collected = nil
+			registry := registryMock{
+				client: &fake.FakeClient{},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						Sleep: &v1alpha1.Sleep{},
+					}},
+					Finally: []v1alpha1.CatchFinally{{
+						Collect: &v1alpha1.Collect{
+							ActionTimeout: v1alpha1.ActionTimeout{
+								Timeout: tt.timeout,
+							},
+							Type:   tt.collectorType,
+							Config: ptr.To(v1alpha1.NewProjection(map[string]any{"zone": "a"})),
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			logger := &fakeLogger.FakeLogger{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, logger)
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			assert.Equal(t, tt.expectedCollected, collected)
+			for _, log := range tt.expectedLogs {
+				assert.Contains(t, logger.Logs, log)
+			}
+		})
+	}
+}
+
+type fakeExecutor struct{}
+
+func (fakeExecutor) Exec(_ context.Context, namespace, pod, container string, command []string, stdout, _ io.Writer) error {
+	_, err := fmt.Fprintf(stdout, "%s/%s/%s: %s\n", namespace, pod, container, strings.Join(command, " "))
+	return err
+}
+
+func TestStepProcessor_Exec(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	// fake executor printing its target, as if the command ran in the container
+	newExecutor = func(*rest.Config) opexec.Executor {
+		return fakeExecutor{}
+	}
+	t.Cleanup(func() {
+		newExecutor = opexec.NewExecutor
+	})
+	registry := registryMock{
+		client: &fake.FakeClient{
+			ListFn: func(_ context.Context, _ int, list client.ObjectList, _ ...client.ListOption) error {
+				list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{{
+					Object: map[string]any{
+						"apiVersion": "v1",
+						"kind":       "Pod",
+						"metadata": map[string]any{
+							"name": "app-0",
+						},
+						"status": map[string]any{
+							"phase": "Running",
+						},
+					},
+				}}
+				return nil
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		expected     string
+		replay       bool
+		expectedFail bool
+	}{{
+		name:     "output matches",
+		expected: "chainsaw/app-0/app: cat /etc/hostname",
+	}, {
+		name:         "output doesn't match",
+		expected:     "app-0",
+		expectedFail: true,
+	}, {
+		name:         "replayed",
+		expected:     "chainsaw/app-0/app: cat /etc/hostname",
+		replay:       true,
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						Exec: &v1alpha1.Exec{
+							ActionObjectSelector: v1alpha1.ActionObjectSelector{
+								Selector: "app=test",
+							},
+							ActionOutputs: v1alpha1.ActionOutputs{
+								Outputs: []v1alpha1.Output{{
+									Binding: v1alpha1.Binding{
+										Name:  "hostname",
+										Value: v1alpha1.NewProjection("($stdout)"),
+									},
+								}},
+							},
+							Container: "app",
+							Command:   []string{"cat", "/etc/hostname"},
+						},
+					}, {
+						Compare: &v1alpha1.Compare{
+							ActionTimeout: v1alpha1.ActionTimeout{
+								Timeout: &v1alpha1.Timeout{Duration: time.Second},
+							},
+							Actual:   "(trim_space($hostname))",
+							Expected: v1alpha1.Expression(fmt.Sprintf("('%s')", tt.expected)),
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			if tt.replay {
+				// exec streams don't go through the client, they can't be answered by the cassette
+				ctx = cassette.IntoContext(ctx, &cassette.Cassette{}, cassette.Replay)
+			}
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_Chart(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	live := func(replicas int64) *fake.FakeClient {
+		return &fake.FakeClient{
+			GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				labels := map[string]any{
+					"app.kubernetes.io/name":     "tiny",
+					"app.kubernetes.io/instance": "demo",
+				}
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata": map[string]any{
+						"name":      key.Name,
+						"namespace": key.Namespace,
+						"labels":    labels,
+						"uid":       "e0c4b3a5",
+						"annotations": map[string]any{
+							"description": "(not an expression)",
+						},
+					},
+					"spec": map[string]any{
+						"replicas": replicas,
+						"selector": map[string]any{
+							"matchLabels": labels,
+						},
+						"template": map[string]any{
+							"metadata": map[string]any{
+								"labels": labels,
+							},
+							"spec": map[string]any{
+								"containers": []any{
+									map[string]any{
+										"name":            "tiny",
+										"image":           "nginx:1.27.0",
+										"imagePullPolicy": "IfNotPresent",
+									},
+								},
+							},
+						},
+					},
+				}
+				return nil
+			},
+		}
+	}
+	tests := []struct {
+		name         string
+		client       *fake.FakeClient
+		path         string
+		condition    v1alpha1.Expression
+		expectedFail bool
+	}{{
+		name:   "live deployment matches",
+		client: live(3),
+		path:   "../../../testdata/helm/chart",
+	}, {
+		name:         "live deployment doesn't match",
+		client:       live(1),
+		path:         "../../../testdata/helm/chart",
+		expectedFail: true,
+	}, {
+		name:         "not a chart",
+		client:       live(3),
+		path:         "../../../testdata/helm",
+		expectedFail: true,
+	}, {
+		// the chart is not rendered when the operation is skipped
+		name:      "skipped",
+		client:    live(3),
+		path:      "../../../testdata/helm",
+		condition: "(`false`)",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: time.Second},
+					},
+					Bindings: []v1alpha1.Binding{{
+						Name:  "replicas",
+						Value: v1alpha1.NewProjection(3),
+					}},
+					Try: []v1alpha1.Operation{{
+						OperationBase: v1alpha1.OperationBase{
+							If: tt.condition,
+						},
+						Chart: &v1alpha1.Chart{
+							Path:    tt.path,
+							Release: "demo",
+							Values: ptr.To(v1alpha1.NewProjection(map[string]any{
+								"replicas": "($replicas)",
+								"image": map[string]any{
+									"tag": "1.27.0",
+								},
+							})),
+							Templates: []string{"templates/deployment.yaml"},
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registryMock{client: tt.client})
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_ApplyKustomize(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	var created []client.Object
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+				created = append(created, obj)
+				return nil
+			},
+		},
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Timeouts: &v1alpha1.Timeouts{},
+			Try: []v1alpha1.Operation{{
+				Apply: &v1alpha1.Apply{
+					Kustomize: "kustomize/($overlay)",
+				},
+			}},
+		},
+	}
+	stepProcessor := NewStepProcessor(
+		step,
+		&model.TestReport{},
+		filepath.Join("..", "..", "..", "testdata"),
+		config.Spec,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		true,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	bindings := apis.NewBindings().Register("$overlay", apis.NewBinding("overlay")).Register("$environment", apis.NewBinding("staging"))
+	tcontext := enginecontext.MakeContext(bindings, registry)
+	stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+		ApplyFn: func(int, client.Client, client.Object) error {
+			return nil
+		},
+		GetNamespaceFn: func(int) string {
+			return "chainsaw"
+		},
+	}, tcontext)
+	assert.False(t, nt.FailedVar)
+	// the built objects are templated with the bindings
+	assert.Len(t, created, 1)
+	if len(created) == 1 {
+		object := created[0].(*unstructured.Unstructured).Object
+		value, _, _ := unstructured.NestedString(object, "data", "environment")
+		assert.Equal(t, "staging", value)
+		label, _, _ := unstructured.NestedString(object, "metadata", "labels", "app.kubernetes.io/name")
+		assert.Equal(t, "demo", label)
+	}
+}
+
+func TestStepProcessor_PodLogsDefaultContainer(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	// fake kubectl printing its arguments, as if they were the logs
+	bin := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(bin, "kubectl"), []byte("#!/bin/sh\necho \"$@\"\n"), 0o755)) //nolint:gosec
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(_ context.Context, _ int, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				pod := obj.(*unstructured.Unstructured)
+				pod.SetName(key.Name)
+				pod.SetNamespace(key.Namespace)
+				pod.SetAnnotations(map[string]string{
+					"kubectl.kubernetes.io/default-container": "app",
+				})
+				return nil
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		defaultContainer bool
+		expected         string
+	}{{
+		name:             "annotation honored",
+		defaultContainer: true,
+		expected:         "logs --prefix app-0 -n chainsaw -c app",
+	}, {
+		name:             "annotation ignored",
+		defaultContainer: false,
+		expected:         "logs --prefix app-0 -n chainsaw --all-containers",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						PodLogs: &v1alpha1.PodLogs{
+							ActionCheck: v1alpha1.ActionCheck{
+								Check: ptr.To(v1alpha1.NewCheck(map[string]any{
+									"(trim_space($stdout))": tt.expected,
+								})),
+							},
+							ActionObjectSelector: v1alpha1.ActionObjectSelector{
+								ObjectName: v1alpha1.ObjectName{
+									Name: "app-0",
+								},
+							},
+						},
+					}},
+				},
+			}
+			spec := config.Spec
+			spec.Execution.PodLogsDefaultContainer = tt.defaultContainer
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.False(t, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_If(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	configMap := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name": name,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name            string
+		condition       v1alpha1.Expression
+		expectedFail    bool
+		expectedCreated []string
+		expectedSkipped []bool
+	}{{
+		name:            "true",
+		condition:       "($enabled)",
+		expectedCreated: []string{"guarded", "always"},
+		expectedSkipped: []bool{false, false},
+	}, {
+		name:            "false",
+		condition:       "(!$enabled)",
+		expectedCreated: []string{"always"},
+		expectedSkipped: []bool{true, false},
+	}, {
+		name:         "not a boolean",
+		condition:    "($name)",
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []string
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+						created = append(created, obj.GetName())
+						return nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						OperationBase: v1alpha1.OperationBase{
+							If: tt.condition,
+						},
+						Create: &v1alpha1.Create{
+							ActionResourceRef: v1alpha1.ActionResourceRef{
+								Resource: configMap("guarded"),
+							},
+						},
+					}, {
+						Create: &v1alpha1.Create{
+							ActionResourceRef: v1alpha1.ActionResourceRef{
+								Resource: configMap("always"),
+							},
+						},
+					}},
+				},
+			}
+			report := &model.TestReport{}
+			stepProcessor := NewStepProcessor(
+				step,
+				report,
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			bindings := apis.NewBindings().
+				Register("$enabled", apis.NewBinding(true)).
+				Register("$name", apis.NewBinding("chainsaw"))
+			tcontext := enginecontext.MakeContext(bindings, registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			assert.False(t, nt.SkippedVar)
+			if tt.expectedFail {
+				return
+			}
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Len(t, report.Steps, 1)
+			var skipped []bool
+			for _, operation := range report.Steps[0].Operations {
+				skipped = append(skipped, operation.Skipped)
+				assert.Equal(t, model.OperationTypeCreate, operation.Type)
+			}
+			assert.Equal(t, tt.expectedSkipped, skipped)
+		})
+	}
+}
+
+func TestStepProcessor_AppliedOutput(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	var created *unstructured.Unstructured
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if created == nil || key.Name != created.GetName() {
+					return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+				}
+				*obj.(*unstructured.Unstructured) = *created.DeepCopy()
+				return nil
+			},
+			CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+				obj.SetName(obj.GetGenerateName() + "x7k2p")
+				created = obj.(*unstructured.Unstructured).DeepCopy()
+				return nil
+			},
+		},
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Timeouts: &v1alpha1.Timeouts{},
+			Try: []v1alpha1.Operation{{
+				Apply: &v1alpha1.Apply{
+					ActionOutputs: v1alpha1.ActionOutputs{
+						Outputs: []v1alpha1.Output{{
+							Binding: v1alpha1.Binding{
+								Name:  "applied",
+								Value: v1alpha1.NewProjection("(@)"),
+							},
+						}},
+					},
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						Resource: &unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "v1",
+								"kind":       "ConfigMap",
+								"metadata": map[string]any{
+									"generateName": "quick-start-",
+								},
+								"data": map[string]any{
+									"foo": "bar",
+								},
+							},
+						},
+					},
+				},
+			}, {
+				Assert: &v1alpha1.Assert{
+					ActionCheckRef: v1alpha1.ActionCheckRef{
+						Check: ptr.To(v1alpha1.NewProjection(map[string]any{
+							"apiVersion": "v1",
+							"kind":       "ConfigMap",
+							"metadata": map[string]any{
+								"name": "($applied.metadata.name)",
+							},
+							"data": map[string]any{
+								"foo": "bar",
+							},
+						})),
+					},
+				},
+			}},
+		},
+	}
+	report := &model.TestReport{}
+	stepProcessor := NewStepProcessor(
+		step,
+		report,
+		"",
+		config.Spec,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		true,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+	stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+		ApplyFn: func(int, client.Client, client.Object) error {
+			return nil
+		},
+	}, tcontext)
+	assert.False(t, nt.FailedVar, "expected no error but got one")
+	assert.Equal(t, "quick-start-x7k2p", created.GetName())
+	assert.Len(t, report.Steps, 1)
+	assert.Len(t, report.Steps[0].Operations, 2)
+	for _, operation := range report.Steps[0].Operations {
+		assert.NoError(t, operation.Err)
+	}
+}
+
+func TestStepProcessor_SkipDeleteIf(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name            string
+		condition       v1alpha1.Expression
+		expectedFail    bool
+		expectedDeleted []string
+	}{{
+		name:            "no condition",
+		expectedDeleted: []string{"quick-start"},
+	}, {
+		name:      "true",
+		condition: "($keep)",
+	}, {
+		name:            "false",
+		condition:       "(!$keep)",
+		expectedDeleted: []string{"quick-start"},
+	}, {
+		name:         "not a boolean",
+		condition:    "($name)",
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []string
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+						return nil
+					},
+					DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+						deleted = append(deleted, obj.GetName())
+						return nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						Create: &v1alpha1.Create{
+							ActionResourceRef: v1alpha1.ActionResourceRef{
+								Resource: &unstructured.Unstructured{
+									Object: map[string]any{
+										"apiVersion": "v1",
+										"kind":       "ConfigMap",
+										"metadata": map[string]any{
+											"name": "quick-start",
+										},
+									},
+								},
+							},
+						},
+					}},
+				},
+			}
+			spec := config.Spec
+			spec.Cleanup.SkipDeleteIf = tt.condition
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				false,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			bindings := apis.NewBindings().
+				Register("$keep", apis.NewBinding(true)).
+				Register("$name", apis.NewBinding("chainsaw"))
+			tcontext := enginecontext.MakeContext(bindings, registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.False(t, nt.FailedVar)
+			for _, cleanup := range nt.CleanupFuncs {
+				cleanup()
+			}
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			assert.Equal(t, tt.expectedDeleted, deleted)
+		})
+	}
+}
+
+func TestStepProcessor_SnapshotDelta(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name         string
+		replicas     []int64
+		expectedFail bool
+	}{{
+		name:     "increase",
+		replicas: []int64{1, 2},
+	}, {
+		name:         "no change",
+		replicas:     []int64{1, 1},
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						replicas := tt.replicas[min(calls, len(tt.replicas)-1)]
+						calls++
+						return unstructured.SetNestedField(obj.(*unstructured.Unstructured).Object, replicas, "spec", "replicas")
+					},
+				},
+			}
+			object := v1alpha1.ObjectType{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: time.Second},
+					},
+					Try: []v1alpha1.Operation{{
+						Snapshot: &v1alpha1.Snapshot{
+							ObjectType: object,
+							ObjectName: v1alpha1.ObjectName{Name: "autoscaled"},
+							As:         "replicas",
+							Field:      "(spec.replicas)",
+						},
+					}, {
+						Delta: &v1alpha1.Delta{
+							ObjectType: object,
+							ObjectName: v1alpha1.ObjectName{Name: "autoscaled"},
+							Snapshot:   "replicas",
+							Field:      "(spec.replicas)",
+							Operator:   v1alpha1.DeltaOperatorGreaterThanOrEqual,
+							Value:      1,
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_PodCount(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name         string
+		phases       []string
+		expectedFail bool
+	}{{
+		name:   "count reached",
+		phases: []string{"Running", "Running"},
+	}, {
+		name:         "timeout",
+		phases:       []string{"Running", "Pending"},
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := registryMock{
+				client: &fake.FakeClient{
+					ListFn: func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+						for _, phase := range tt.phases {
+							list.(*unstructured.UnstructuredList).Items = append(list.(*unstructured.UnstructuredList).Items, unstructured.Unstructured{
+								Object: map[string]any{
+									"status": map[string]any{"phase": phase},
+								},
+							})
+						}
+						return nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: 500 * time.Millisecond},
+					},
+					Try: []v1alpha1.Operation{{
+						PodCount: &v1alpha1.PodCount{
+							Selector: "app=foo",
+							Count:    2,
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_NoCrashLoop(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name         string
+		reason       string
+		expectedFail bool
+	}{{
+		name:   "healthy",
+		reason: "ContainerCreating",
+	}, {
+		name:         "crash looping",
+		reason:       "CrashLoopBackOff",
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := registryMock{
+				client: &fake.FakeClient{
+					ListFn: func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+						list.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{{
+							Object: map[string]any{
+								"metadata": map[string]any{"name": "foo", "namespace": "chainsaw"},
+								"status": map[string]any{
+									"containerStatuses": []any{map[string]any{
+										"name":  "app",
+										"state": map[string]any{"waiting": map[string]any{"reason": tt.reason}},
+									}},
+								},
+							},
+						}}
+						return nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: 500 * time.Millisecond},
+					},
+					Try: []v1alpha1.Operation{{
+						NoCrashLoop: &v1alpha1.NoCrashLoop{},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_Probe(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "app.example.com" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer server.Close()
+	tests := []struct {
+		name         string
+		host         string
+		expectedFail bool
+	}{{
+		name: "routed",
+		host: "app.example.com",
+	}, {
+		name:         "not routed",
+		host:         "other.example.com",
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: 500 * time.Millisecond},
+					},
+					Try: []v1alpha1.Operation{{
+						Probe: &v1alpha1.Probe{
+							URL:     "(concat('http://', $host))",
+							Address: "($address)",
+							Body:    "^ok$",
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			bindings := apis.NewBindings().Register("$host", apis.NewBinding(tt.host)).Register("$address", apis.NewBinding(server.Listener.Addr().String()))
+			tcontext := enginecontext.MakeContext(bindings, registryMock{})
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_AssertSchema(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	dir := t.TempDir()
+	schemaFile := filepath.Join(dir, "configmap.yaml")
+	assert.NoError(t, os.WriteFile(schemaFile, []byte("type: object\nrequired: [data]\nproperties:\n  data:\n    type: object\n    required: [key]\n"), 0o600))
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				obj.(*unstructured.Unstructured).Object = map[string]any{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]any{
+						"name":      "foo",
+						"namespace": "chainsaw",
+					},
+					"data": map[string]any{
+						"other": "value",
+					},
+				}
+				return nil
+			},
+			IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+				return true, nil
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		schema       string
+		expectedFail bool
+	}{{
+		name:   "no schema",
+		schema: "",
+	}, {
+		name:         "schema from bindings",
+		schema:       "($schema)",
+		expectedFail: true,
+	}, {
+		name:         "missing schema",
+		schema:       "($missing)",
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: 500 * time.Millisecond},
+					},
+					Try: []v1alpha1.Operation{{
+						Assert: &v1alpha1.Assert{
+							ActionCheckRef: v1alpha1.ActionCheckRef{
+								Check: ptr.To(v1alpha1.NewProjection(map[string]any{
+									"apiVersion": "v1",
+									"kind":       "ConfigMap",
+									"metadata": map[string]any{
+										"name": "foo",
+									},
+								})),
+							},
+							Schema: v1alpha1.Expression(tt.schema),
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			bindings := apis.NewBindings().Register("$schema", apis.NewBinding(schemaFile)).Register("$missing", apis.NewBinding(filepath.Join(dir, "missing.yaml")))
+			tcontext := enginecontext.MakeContext(bindings, registry)
+			stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(int) string {
+					return "chainsaw"
+				},
+			}, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+		})
+	}
+}
+
+func TestStepProcessor_DeleteRefFromBindings(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name         string
+		ref          v1alpha1.ObjectReference
+		template     bool
+		expectedKey  client.ObjectKey
+		expectedList client.MatchingLabels
+		expectedFail bool
+	}{{
+		name: "name from bindings",
+		ref: v1alpha1.ObjectReference{
+			ObjectType: v1alpha1.ObjectType{
+				APIVersion: "($apiVersion)",
+				Kind:       "($kind)",
+			},
+			ObjectName: v1alpha1.ObjectName{
+				Namespace: "($namespace)",
+				Name:      "($name)",
+			},
+		},
+		expectedKey: client.ObjectKey{Namespace: "foo", Name: "myapp"},
+	}, {
+		name: "name from bindings with templating",
+		ref: v1alpha1.ObjectReference{
+			ObjectType: v1alpha1.ObjectType{
+				APIVersion: "($apiVersion)",
+				Kind:       "($kind)",
+			},
+			ObjectName: v1alpha1.ObjectName{
+				Namespace: "($namespace)",
+				Name:      "($name)",
+			},
+		},
+		template:    true,
+		expectedKey: client.ObjectKey{Namespace: "foo", Name: "myapp"},
+	}, {
+		name: "group and version from bindings",
+		ref: v1alpha1.ObjectReference{
+			ObjectType: v1alpha1.ObjectType{
+				Group:   "apps",
+				Version: "($version)",
+				Kind:    "($kind)",
+			},
+			ObjectName: v1alpha1.ObjectName{
+				Name: "($name)",
+			},
+		},
+		expectedKey: client.ObjectKey{Namespace: "chainsaw", Name: "myapp"},
+	}, {
+		name: "labels from bindings",
+		ref: v1alpha1.ObjectReference{
+			ObjectType: v1alpha1.ObjectType{
+				APIVersion: "($apiVersion)",
+				Kind:       "($kind)",
+			},
+			Labels: map[string]string{
+				"app": "($name)",
+			},
+		},
+		expectedList: client.MatchingLabels{"app": "myapp"},
+	}, {
+		name: "unknown binding",
+		ref: v1alpha1.ObjectReference{
+			ObjectType: v1alpha1.ObjectType{
+				APIVersion: "($apiVersion)",
+				Kind:       "($unknown)",
+			},
+		},
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted []client.Object
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if len(deleted) != 0 {
+							return kerror.NewNotFound(v1alpha1.Resource("deployment"), key.Name)
+						}
+						assert.Equal(t, "apps/v1, Kind=Deployment", obj.GetObjectKind().GroupVersionKind().String())
+						assert.Equal(t, tt.expectedKey, key)
+						return nil
+					},
+					ListFn: func(ctx context.Context, call int, list client.ObjectList, opts ...client.ListOption) error {
+						assert.Equal(t, "apps/v1, Kind=Deployment", list.GetObjectKind().GroupVersionKind().String())
+						assert.Contains(t, opts, tt.expectedList)
+						return nil
+					},
+					DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+						deleted = append(deleted, obj)
+						return nil
+					},
+					IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+						return true, nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						Delete: &v1alpha1.Delete{
+							Template: ptr.To(tt.template),
+							Ref:      &tt.ref,
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			bindings := apis.NewBindings().
+				Register("$apiVersion", apis.NewBinding("apps/v1")).
+				Register("$version", apis.NewBinding("v1")).
+				Register("$kind", apis.NewBinding("Deployment")).
+				Register("$name", apis.NewBinding("myapp")).
+				Register("$namespace", apis.NewBinding("foo"))
+			tcontext := enginecontext.MakeContext(bindings, registry)
+			stepProcessor.Run(ctx, namespacer.New("chainsaw"), tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			if !tt.expectedFail && tt.expectedList == nil {
+				assert.Len(t, deleted, 1)
+			}
+		})
+	}
+}
+
+func TestStepProcessor_DeleteWait(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	tests := []struct {
+		name         string
+		wait         *bool
+		expectedGets int
+	}{{
+		name:         "default",
+		expectedGets: 2,
+	}, {
+		name:         "wait",
+		wait:         ptr.To(true),
+		expectedGets: 2,
+	}, {
+		// only the initial read is expected, no polling after delete
+		name:         "no wait",
+		wait:         ptr.To(false),
+		expectedGets: 1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets int
+			var deleted bool
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						gets++
+						if deleted {
+							return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+						}
+						return nil
+					},
+					DeleteFn: func(ctx context.Context, call int, obj client.Object, opts ...client.DeleteOption) error {
+						deleted = true
+						return nil
+					},
+					IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+						return true, nil
+					},
+				},
+			}
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					Timeouts: &v1alpha1.Timeouts{},
+					Try: []v1alpha1.Operation{{
+						Delete: &v1alpha1.Delete{
+							Wait: tt.wait,
+							Ref: &v1alpha1.ObjectReference{
+								ObjectType: v1alpha1.ObjectType{
+									APIVersion: "v1",
+									Kind:       "ConfigMap",
+								},
+								ObjectName: v1alpha1.ObjectName{
+									Name: "myapp",
+								},
+							},
+						},
+					}},
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			stepProcessor.Run(ctx, namespacer.New("chainsaw"), tcontext)
+			assert.False(t, nt.FailedVar)
+			assert.True(t, deleted)
+			assert.Equal(t, tt.expectedGets, gets)
+		})
+	}
+}
+
+func TestStepProcessor_ParallelOperations(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	sleep := v1alpha1.Operation{
+		Sleep: &v1alpha1.Sleep{
+			Duration: metav1.Duration{Duration: 300 * time.Millisecond},
+		},
+	}
+	failure := v1alpha1.Operation{
+		Compare: &v1alpha1.Compare{
+			Actual:   "('foo')",
+			Expected: "('bar')",
+		},
+	}
+	tests := []struct {
+		name         string
+		try          []v1alpha1.Operation
+		expectedFail bool
+	}{{
+		name: "concurrent",
+		try:  []v1alpha1.Operation{sleep, sleep, sleep},
+	}, {
+		name:         "aggregated failure",
+		try:          []v1alpha1.Operation{failure, sleep, sleep, sleep},
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					ParallelOperations: ptr.To(true),
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: 100 * time.Millisecond},
+					},
+					Try: tt.try,
+				},
+			}
+			report := &model.TestReport{}
+			stepProcessor := NewStepProcessor(
+				step,
+				report,
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registryMock{})
+			start := time.Now()
+			stepProcessor.Run(ctx, nil, tcontext)
+			// operations run sequentially would take at least 900ms
+			assert.Less(t, time.Since(start), 600*time.Millisecond)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			// all operations ran even when one of them failed
+			assert.Len(t, report.Steps, 1)
+			assert.Len(t, report.Steps[0].Operations, len(tt.try))
+			var failed int
+			for _, operation := range report.Steps[0].Operations {
+				if operation.Err != nil {
+					failed++
+				}
+			}
+			if tt.expectedFail {
+				assert.Equal(t, 1, failed)
+			} else {
+				assert.Equal(t, 0, failed)
+			}
+		})
+	}
+}
+
+func TestStepProcessor_ParallelOperationsSkipAndFailure(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	skip := v1alpha1.Operation{
+		Health: &v1alpha1.Health{
+			Address: "127.0.0.1:1",
+			Skip:    ptr.To(true),
+		},
+	}
+	failure := v1alpha1.Operation{
+		Compare: &v1alpha1.Compare{
+			Actual:   "('foo')",
+			Expected: "('bar')",
+		},
+	}
+	tests := []struct {
+		name            string
+		try             []v1alpha1.Operation
+		expectedFail    bool
+		expectedSkipped bool
+	}{{
+		name:            "skip",
+		try:             []v1alpha1.Operation{skip},
+		expectedSkipped: true,
+	}, {
+		name:         "skip and failure",
+		try:          []v1alpha1.Operation{skip, failure},
+		expectedFail: true,
+	}, {
+		name:         "failure and skip",
+		try:          []v1alpha1.Operation{failure, skip},
+		expectedFail: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := v1alpha1.TestStep{
+				TestStepSpec: v1alpha1.TestStepSpec{
+					ParallelOperations: ptr.To(true),
+					Timeouts: &v1alpha1.Timeouts{
+						Assert: &metav1.Duration{Duration: 100 * time.Millisecond},
+					},
+					Try: tt.try,
+				},
+			}
+			stepProcessor := NewStepProcessor(
+				step,
+				&model.TestReport{},
+				"",
+				config.Spec,
+				nil,
+				nil,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registryMock{})
+			stepProcessor.Run(ctx, nil, tcontext)
+			assert.Equal(t, tt.expectedFail, nt.FailedVar)
+			assert.Equal(t, tt.expectedSkipped, nt.SkippedVar)
+		})
+	}
+}
+
+func TestStepProcessor_ParallelOperationsOutputs(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			ParallelOperations: ptr.To(true),
+			Timeouts: &v1alpha1.Timeouts{
+				Assert: &metav1.Duration{Duration: 100 * time.Millisecond},
+			},
+			Try: []v1alpha1.Operation{{
+				Command: &v1alpha1.Command{
+					Entrypoint: "printf",
+					Args:       []string{"hello"},
+					ActionOutputs: v1alpha1.ActionOutputs{
+						Outputs: []v1alpha1.Output{{
+							Binding: v1alpha1.Binding{Name: "greeting", Value: v1alpha1.NewProjection("($stdout)")},
+						}},
+					},
+				},
+			}, {
+				Compare: &v1alpha1.Compare{
+					Actual:   "('foo')",
+					Expected: "('bar')",
+				},
+			}},
+			// the catch block uses the output of the parallel operation
+			Catch: []v1alpha1.CatchFinally{{
+				Command: &v1alpha1.Command{
+					ActionEnv: v1alpha1.ActionEnv{
+						Env: []v1alpha1.Binding{{Name: "GREETING", Value: v1alpha1.NewProjection("($greeting)")}},
+					},
+					Entrypoint: "test",
+					Args:       []string{"$GREETING", "=", "hello"},
+				},
+			}},
+		},
+	}
+	report := &model.TestReport{}
+	stepProcessor := NewStepProcessor(
+		step,
+		report,
+		"",
+		config.Spec,
+		nil,
+		nil,
+		config.Spec.Timeouts,
+		config.Spec.Deletion.Propagation,
+		config.Spec.Templating.Enabled,
+		true,
+	)
+	nt := &testing.MockT{}
+	ctx := testing.IntoContext(context.Background(), nt)
+	ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+	tcontext := enginecontext.MakeContext(apis.NewBindings(), registryMock{})
+	stepProcessor.Run(ctx, nil, tcontext)
+	assert.True(t, nt.FailedVar)
+	if assert.Len(t, report.Steps, 1) && assert.Len(t, report.Steps[0].Operations, 3) {
+		assert.Nil(t, report.Steps[0].Operations[2].Err)
+	}
+}
+
+func TestStepProcessor_ClusterBinding(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	var created []string
+	clusterClient := func(name string) client.Client {
+		return &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				return kerror.NewNotFound(v1alpha1.Resource("configmap"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj client.Object, opts ...client.CreateOption) error {
+				created = append(created, name+"/"+obj.GetName())
+				return nil
+			},
+		}
+	}
+	registry := registryMock{
+		clusters: map[string]client.Client{
+			"east": clusterClient("east"),
+			"west": clusterClient("west"),
+		},
+	}
+	step := v1alpha1.TestStep{
+		TestStepSpec: v1alpha1.TestStepSpec{
+			Timeouts: &v1alpha1.Timeouts{},
+			Try: []v1alpha1.Operation{{
+				Create: &v1alpha1.Create{
+					ActionClusters: v1alpha1.ActionClusters{
+						Cluster: ptr.To("($target)"),
+					},
+					ActionResourceRef: v1alpha1.ActionResourceRef{
+						Resource: &unstructured.Unstructured{
+							Object: map[string]any{
+								"apiVersion": "v1",
+								"kind":       "ConfigMap",
+								"metadata": map[string]any{
+									"name": "foo",
+								},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+	// every iteration targets a different cluster through the same step definition
+	for _, target := range []string{"east", "west"} {
+		report := &model.TestReport{}
+		stepProcessor := NewStepProcessor(
+			step,
+			report,
+			"",
+			config.Spec,
+			nil,
+			nil,
+			config.Spec.Timeouts,
+			config.Spec.Deletion.Propagation,
+			config.Spec.Templating.Enabled,
+			true,
+		)
+		nt := &testing.MockT{}
+		ctx := testing.IntoContext(context.Background(), nt)
+		ctx = logging.IntoContext(ctx, &fakeLogger.FakeLogger{})
+		bindings := apis.NewBindings().Register("$target", apis.NewBinding(target))
+		tcontext := enginecontext.MakeContext(bindings, registry)
+		stepProcessor.Run(ctx, &fakeNamespacer.FakeNamespacer{
+			ApplyFn: func(int, client.Client, client.Object) error {
+				return nil
+			},
+			GetNamespaceFn: func(int) string {
+				return "chainsaw"
+			},
+		}, tcontext)
+		assert.False(t, nt.FailedVar)
+	}
+	assert.Equal(t, []string{"east/foo", "west/foo"}, created)
+}
+
+func TestStepProcessor_loadFile(t *testing.T) {
+	basePath := filepath.Join("..", "..", "..", "testdata", "runner", "processors")
+	absBasePath, err := filepath.Abs(basePath)
+	assert.NoError(t, err)
+	bindings := apis.NewBindings().
+		Register("$dir", apis.NewBinding("glob")).
+		Register("$index", apis.NewBinding("2")).
+		Register("$testDir", apis.NewBinding(absBasePath)).
+		Register("$parent", apis.NewBinding("../processors/..")).
+		Register("$outside", apis.NewBinding(filepath.Dir(absBasePath))).
+		Register("$dot", apis.NewBinding(".")).
+		Register("$kind", apis.NewBinding("configmap"))
+	tests := []struct {
+		name    string
+		file    v1alpha1.Expression
+		want    []string
+		wantErr string
+	}{{
+		name: "literal",
+		file: "glob/configmap-1.yaml",
+		want: []string{"configmap-1"},
+	}, {
+		name: "embedded bindings",
+		file: "($dir)/configmap-($index).yaml",
+		want: []string{"configmap-2"},
+	}, {
+		name: "embedded bindings with glob",
+		file: "($dir)/*.yaml",
+		want: []string{"configmap-1", "configmap-2", "configmap-3"},
+	}, {
+		name: "absolute test dir",
+		file: "($testDir)/glob/configmap-3.yaml",
+		want: []string{"configmap-3"},
+	}, {
+		name:    "parent traversal",
+		file:    "($parent)/pod.yaml",
+		wantErr: "file expression ($parent) resolved to ../processors/.. which escapes the test directory",
+	}, {
+		name:    "absolute outside test dir",
+		file:    "($outside)/pod.yaml",
+		wantErr: "file expression ($outside) resolved to " + filepath.Dir(absBasePath) + " which escapes the test directory",
+	}, {
+		name:    "combined parent traversal",
+		file:    "($dot)($dot)/pod.yaml",
+		wantErr: "file ($dot)($dot)/pod.yaml resolved to ../pod.yaml which escapes the test directory",
+	}, {
+		name: "literal parent traversal",
+		file: "../processors/glob/($kind)-1.yaml",
+		want: []string{"configmap-1"},
+	}, {
+		name:    "undefined binding",
+		file:    "($missing)/pod.yaml",
+		wantErr: "variable not defined: $missing",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := &stepProcessor{
+				basePath: basePath,
+			}
+			resources, err := processor.loadFile(context.TODO(), apis.DefaultCompilers, tt.file, bindings, true, nil)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				var names []string
+				for _, resource := range resources {
+					names = append(names, resource.GetName())
+				}
+				assert.Equal(t, tt.want, names)
+			}
+		})
+	}
+}
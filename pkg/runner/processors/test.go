@@ -3,9 +3,9 @@ package processors
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"time"
 
-	petname "github.com/dustinkirkland/golang-petname"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	"github.com/kyverno/chainsaw/pkg/discovery"
@@ -14,8 +14,12 @@ import (
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
+	"github.com/kyverno/chainsaw/pkg/runner/names"
+	"github.com/kyverno/chainsaw/pkg/runner/tracing"
 	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/kyverno/pkg/ext/output/color"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 )
@@ -28,6 +32,8 @@ func NewTestProcessor(
 	test discovery.Test,
 	size int,
 	clock clock.PassiveClock,
+	rand *rand.Rand,
+	config model.Configuration,
 	nsTemplate *v1alpha1.Projection,
 	nsTemplateCompiler *v1alpha1.Compiler,
 	delayBeforeCleanup *time.Duration,
@@ -38,6 +44,7 @@ func NewTestProcessor(
 	skipDelete bool,
 	catch ...v1alpha1.CatchFinally,
 ) TestProcessor {
+	stopOnFirstFailure := config.Execution.StopOnFirstFailure
 	if template := test.Test.Spec.NamespaceTemplate; template != nil && template.Value() != nil {
 		nsTemplate = template
 		nsTemplateCompiler = test.Test.Spec.NamespaceTemplateCompiler
@@ -60,11 +67,16 @@ func NewTestProcessor(
 	if test.Test.Spec.SkipDelete != nil {
 		skipDelete = *test.Test.Spec.SkipDelete
 	}
+	if test.Test.Spec.StopOnFirstFailure != nil {
+		stopOnFirstFailure = *test.Test.Spec.StopOnFirstFailure
+	}
 	catch = append(catch, test.Test.Spec.Catch...)
 	return &testProcessor{
 		test:                      test,
 		size:                      size,
 		clock:                     clock,
+		rand:                      rand,
+		config:                    config,
 		nsTemplate:                nsTemplate,
 		nsTemplateCompiler:        nsTemplateCompiler,
 		delayBeforeCleanup:        delayBeforeCleanup,
@@ -73,6 +85,7 @@ func NewTestProcessor(
 		deletionPropagationPolicy: deletionPropagationPolicy,
 		templating:                templating,
 		skipDelete:                skipDelete,
+		stopOnFirstFailure:        stopOnFirstFailure,
 		catch:                     catch,
 	}
 }
@@ -81,6 +94,8 @@ type testProcessor struct {
 	test                      discovery.Test
 	size                      int
 	clock                     clock.PassiveClock
+	rand                      *rand.Rand
+	config                    model.Configuration
 	nsTemplate                *v1alpha1.Projection
 	nsTemplateCompiler        *v1alpha1.Compiler
 	delayBeforeCleanup        *time.Duration
@@ -89,6 +104,7 @@ type testProcessor struct {
 	deletionPropagationPolicy metav1.DeletionPropagation
 	templating                bool
 	skipDelete                bool
+	stopOnFirstFailure        bool
 	catch                     []v1alpha1.CatchFinally
 }
 
@@ -112,8 +128,12 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 		tc.Report.Add(report)
 	})
 	mainCleaner := cleaner.New(p.timeouts.Cleanup.Duration, nil, p.deletionPropagationPolicy)
+	// the namespace has its own cleaner, the namespace cleanup policy must not keep the quota and rbac objects around
+	namespaceCleaner := cleaner.New(p.timeouts.Cleanup.Duration, nil, p.deletionPropagationPolicy)
 	t.Cleanup(func() {
-		if !mainCleaner.Empty() {
+		// the main cleaner only holds the resource quota, service account and role bindings
+		keep := keepNamespace(p.config.Namespace.Cleanup, t.Failed())
+		if !mainCleaner.Empty() || (!namespaceCleaner.Empty() && !keep) {
 			logging.Log(ctx, logging.Cleanup, logging.BeginStatus, color.BoldFgCyan)
 			defer func() {
 				logging.Log(ctx, logging.Cleanup, logging.EndStatus, color.BoldFgCyan)
@@ -126,10 +146,23 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 				stepReport.EndTime = time.Now()
 				report.Add(stepReport)
 			}()
+			if skip, err := skipCleanup(ctx, tc, p.config.Cleanup.SkipDeleteIf); err != nil {
+				logging.Log(ctx, logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+				failer.Fail(ctx)
+			} else if skip {
+				logging.Log(ctx, logging.Cleanup, logging.SkipStatus, color.BoldYellow, logging.Section("CONDITION", p.config.Cleanup.SkipDeleteIf))
+				return
+			}
 			for _, err := range mainCleaner.Run(ctx, stepReport) {
 				logging.Log(ctx, logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 				failer.Fail(ctx)
 			}
+			if !keep {
+				for _, err := range namespaceCleaner.Run(ctx, stepReport) {
+					logging.Log(ctx, logging.Cleanup, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+					failer.Fail(ctx)
+				}
+			}
 		}
 	})
 	if p.test.Test.Spec.Compiler != nil {
@@ -143,12 +176,12 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 	}
 	nsName := p.test.Test.Spec.Namespace
 	if nspacer == nil && nsName == "" {
-		nsName = fmt.Sprintf("chainsaw-%s", petname.Generate(2, "-"))
+		nsName = names.Namespace(p.rand)
 	}
 	if nsName != "" {
 		var nsCleaner cleaner.CleanerCollector
 		if !p.skipDelete {
-			nsCleaner = mainCleaner
+			nsCleaner = namespaceCleaner
 		}
 		// TODO this may not use the right default compiler if the template is coming from the config
 		// but the default compiler is specified at the test level
@@ -166,13 +199,44 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 	tc, namespace, err := setupContextData(ctx, tc, contextData)
 	if err != nil {
 		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		tc.IncSetupFailure()
 		failer.FailNow(ctx)
 	}
 	if namespace != nil {
 		nspacer = namespacer.New(namespace.GetName())
 	}
+	// the quota only applies to an ephemeral namespace, generated and created for this test
+	if namespace != nil && p.test.Test.Spec.Namespace == "" && contextData.namespace.created {
+		var quotaCleaner cleaner.CleanerCollector
+		if !p.skipDelete {
+			quotaCleaner = mainCleaner
+		}
+		if err := setupResourceQuota(ctx, tc, tc.Compilers(), namespace.GetName(), p.config.Namespace.Quota, quotaCleaner); err != nil {
+			logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+			tc.IncSetupFailure()
+			failer.FailNow(ctx)
+		}
+	}
 	if nspacer != nil {
 		report.Namespace = nspacer.GetNamespace()
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("chainsaw.test.namespace", report.Namespace))
+	}
+	if sa := p.test.Test.Spec.ServiceAccount; sa != nil {
+		var saCleaner cleaner.CleanerCollector
+		if !p.skipDelete {
+			saCleaner = mainCleaner
+		}
+		namespace := ""
+		if nspacer != nil {
+			namespace = nspacer.GetNamespace()
+		}
+		if _tc, err := setupServiceAccount(ctx, tc, namespace, p.test.Test.Name, *sa, saCleaner); err != nil {
+			logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+			tc.IncSetupFailure()
+			failer.FailNow(ctx)
+		} else {
+			tc = _tc
+		}
 	}
 	for i, step := range p.test.Test.Spec.Steps {
 		name := step.Name
@@ -185,15 +249,31 @@ func (p *testProcessor) Run(ctx context.Context, nspacer namespacer.Namespacer,
 		}
 		tc := tc.WithBinding(ctx, "step", info)
 		processor := p.createStepProcessor(step, report)
-		processor.Run(ctx, nspacer, tc)
+		p.runStep(ctx, name, i+1, processor, nspacer, tc)
+		if p.stopOnFirstFailure && t.Failed() {
+			break
+		}
 	}
 }
 
+// runStep runs a step processor in its own span, the step fails the span only if the test was not failed already.
+func (p *testProcessor) runStep(ctx context.Context, name string, id int, processor StepProcessor, nspacer namespacer.Namespacer, tc engine.Context) {
+	t := testing.FromContext(ctx)
+	failed := t.Failed()
+	ctx, span := tracing.Start(ctx, name, attribute.Int("chainsaw.step.id", id))
+	// the step may stop the test (fail now), the span is ended in a deferred function
+	defer func() {
+		tracing.End(span, t.Failed() && !failed, false)
+	}()
+	processor.Run(ctx, nspacer, tc)
+}
+
 func (p *testProcessor) createStepProcessor(step v1alpha1.TestStep, report *model.TestReport) StepProcessor {
 	return NewStepProcessor(
 		step,
 		report,
 		p.test.BasePath,
+		p.config,
 		p.delayBeforeCleanup,
 		p.terminationGracePeriod,
 		p.timeouts,
@@ -3,10 +3,13 @@ package processors
 import (
 	"context"
 	"errors"
+	"math/rand"
+	"path/filepath"
 	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/client"
 	fake "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/kyverno/chainsaw/pkg/discovery"
@@ -288,6 +291,8 @@ func TestTestProcessor_Run(t *testing.T) {
 				tc.test,
 				0,
 				tc.clock,
+				rand.New(rand.NewSource(0)),
+				config.Spec,
 				config.Spec.Namespace.Template,
 				nil,
 				nil,
@@ -310,3 +315,282 @@ func TestTestProcessor_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestTestProcessor_StopOnFirstFailure(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	testData := filepath.Join("..", "..", "..", "testdata", "runner", "processors")
+	testCases := []struct {
+		name               string
+		stopOnFirstFailure bool
+		testOverride       *bool
+		expectedCreates    int
+	}{{
+		name:               "default",
+		stopOnFirstFailure: false,
+		expectedCreates:    1,
+	}, {
+		name:               "stop on first failure",
+		stopOnFirstFailure: true,
+		expectedCreates:    0,
+	}, {
+		name:               "stop on first failure from test",
+		stopOnFirstFailure: false,
+		testOverride:       ptr.To(true),
+		expectedCreates:    0,
+	}, {
+		name:               "continue from test",
+		stopOnFirstFailure: true,
+		testOverride:       ptr.To(false),
+		expectedCreates:    1,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			creates := 0
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+						return kerror.NewNotFound(v1alpha1.Resource("pod"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+						creates++
+						return nil
+					},
+				},
+			}
+			test := discovery.Test{
+				BasePath: testData,
+				Test: &model.Test{
+					Spec: v1alpha1.TestSpec{
+						Timeouts:           &v1alpha1.Timeouts{},
+						StopOnFirstFailure: tc.testOverride,
+						Steps: []v1alpha1.TestStep{{
+							TestStepSpec: v1alpha1.TestStepSpec{
+								Timeouts: &v1alpha1.Timeouts{
+									Assert: &v1.Duration{Duration: 100 * time.Millisecond},
+								},
+								// the failure doesn't stop the step, stopping the test is up to the test processor
+								Try: []v1alpha1.Operation{{
+									OperationBase: v1alpha1.OperationBase{
+										ContinueOnError: ptr.To(true),
+									},
+									Assert: &v1alpha1.Assert{
+										ActionCheckRef: v1alpha1.ActionCheckRef{
+											FileRef: v1alpha1.FileRef{
+												File: "pod.yaml",
+											},
+										},
+									},
+								}},
+							},
+						}, {
+							TestStepSpec: v1alpha1.TestStepSpec{
+								Try: []v1alpha1.Operation{{
+									Create: &v1alpha1.Create{
+										ActionResourceRef: v1alpha1.ActionResourceRef{
+											FileRef: v1alpha1.FileRef{
+												File: "pod.yaml",
+											},
+										},
+									},
+								}},
+							},
+						}},
+					},
+				},
+			}
+			spec := config.Spec
+			spec.Execution.StopOnFirstFailure = tc.stopOnFirstFailure
+			processor := NewTestProcessor(
+				test,
+				0,
+				tclock.NewFakePassiveClock(time.Now()),
+				rand.New(rand.NewSource(0)),
+				spec,
+				config.Spec.Namespace.Template,
+				nil,
+				nil,
+				config.Spec.Execution.ForceTerminationGracePeriod,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+				config.Spec.Error.Catch...,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			tcontext := enginecontext.MakeContext(apis.NewBindings(), registry)
+			nspacer := &fakeNamespacer.FakeNamespacer{
+				ApplyFn: func(int, client.Client, client.Object) error {
+					return nil
+				},
+				GetNamespaceFn: func(call int) string {
+					return "chainsaw"
+				},
+			}
+			processor.Run(ctx, nspacer, tcontext)
+			assert.True(t, nt.FailedVar, "expected an error but got none")
+			assert.False(t, nt.ImmeditateFailVar, "expected the test not to fail immediately")
+			assert.Equal(t, tc.expectedCreates, creates)
+		})
+	}
+}
+
+func TestTestProcessor_Quota(t *testing.T) {
+	config, err := config.DefaultConfiguration()
+	if err != nil {
+		assert.NoError(t, err)
+	}
+	config.Spec.Namespace.Quota = ptr.To(v1alpha1.NewProjection(map[string]any{
+		"spec": map[string]any{
+			"hard": map[string]any{
+				"pods": "10",
+			},
+		},
+	}))
+	testCases := []struct {
+		name        string
+		namespace   string
+		exists      bool
+		wantCreated []string
+	}{{
+		name:        "ephemeral namespace",
+		wantCreated: []string{"Namespace", "ResourceQuota"},
+	}, {
+		name:        "named namespace",
+		namespace:   "chainsaw",
+		wantCreated: []string{"Namespace"},
+	}, {
+		name:   "existing namespace",
+		exists: true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var created []string
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+						if tc.exists {
+							return nil
+						}
+						return kerror.NewNotFound(v1alpha1.Resource("namespace"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+						created = append(created, obj.GetObjectKind().GroupVersionKind().Kind)
+						return nil
+					},
+					DeleteFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.DeleteOption) error {
+						return nil
+					},
+				},
+			}
+			processor := NewTestProcessor(
+				discovery.Test{
+					Test: &model.Test{
+						Spec: v1alpha1.TestSpec{
+							Namespace: tc.namespace,
+							Timeouts:  &v1alpha1.Timeouts{},
+						},
+					},
+				},
+				0,
+				tclock.NewFakePassiveClock(time.Now()),
+				rand.New(rand.NewSource(0)),
+				config.Spec,
+				config.Spec.Namespace.Template,
+				nil,
+				nil,
+				config.Spec.Execution.ForceTerminationGracePeriod,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				true,
+				config.Spec.Error.Catch...,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			processor.Run(ctx, nil, enginecontext.MakeContext(apis.NewBindings(), registry))
+			assert.False(t, nt.FailedVar)
+			assert.Equal(t, tc.wantCreated, created)
+		})
+	}
+}
+
+func TestTestProcessor_NamespaceCleanup(t *testing.T) {
+	testCases := []struct {
+		name        string
+		policy      v1alpha2.NamespaceCleanupPolicy
+		wantDeleted []string
+	}{{
+		name:        "always",
+		policy:      v1alpha2.NamespaceCleanupAlways,
+		wantDeleted: []string{"ResourceQuota", "Namespace"},
+	}, {
+		name:        "never",
+		policy:      v1alpha2.NamespaceCleanupNever,
+		wantDeleted: []string{"ResourceQuota"},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := config.DefaultConfiguration()
+			if err != nil {
+				assert.NoError(t, err)
+			}
+			config.Spec.Namespace.Cleanup = tc.policy
+			config.Spec.Namespace.Quota = ptr.To(v1alpha1.NewProjection(map[string]any{
+				"spec": map[string]any{
+					"hard": map[string]any{
+						"pods": "10",
+					},
+				},
+			}))
+			var deleted []string
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+						return kerror.NewNotFound(v1alpha1.Resource("namespace"), key.Name)
+					},
+					CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+						return nil
+					},
+					DeleteFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.DeleteOption) error {
+						deleted = append(deleted, obj.GetObjectKind().GroupVersionKind().Kind)
+						return nil
+					},
+				},
+			}
+			processor := NewTestProcessor(
+				discovery.Test{
+					Test: &model.Test{
+						Spec: v1alpha1.TestSpec{
+							Timeouts: &v1alpha1.Timeouts{},
+						},
+					},
+				},
+				0,
+				tclock.NewFakePassiveClock(time.Now()),
+				rand.New(rand.NewSource(0)),
+				config.Spec,
+				config.Spec.Namespace.Template,
+				nil,
+				nil,
+				config.Spec.Execution.ForceTerminationGracePeriod,
+				config.Spec.Timeouts,
+				config.Spec.Deletion.Propagation,
+				config.Spec.Templating.Enabled,
+				false,
+				config.Spec.Error.Catch...,
+			)
+			nt := &testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), nt)
+			processor.Run(ctx, nil, enginecontext.MakeContext(apis.NewBindings(), registry))
+			for i := len(nt.CleanupFuncs) - 1; i >= 0; i-- {
+				nt.CleanupFuncs[i]()
+			}
+			assert.False(t, nt.FailedVar)
+			assert.Equal(t, tc.wantDeleted, deleted)
+		})
+	}
+}
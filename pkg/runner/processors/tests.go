@@ -2,19 +2,26 @@ package processors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"regexp"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/cleanup/cleaner"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/engine"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
 	"github.com/kyverno/chainsaw/pkg/engine/namespacer"
 	"github.com/kyverno/chainsaw/pkg/model"
+	"github.com/kyverno/chainsaw/pkg/runner/events"
 	"github.com/kyverno/chainsaw/pkg/runner/failer"
 	"github.com/kyverno/chainsaw/pkg/runner/names"
+	"github.com/kyverno/chainsaw/pkg/runner/tracing"
 	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/kyverno/pkg/ext/output/color"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/utils/clock"
 )
 
@@ -22,24 +29,31 @@ type TestsProcessor interface {
 	Run(context.Context, engine.Context, ...discovery.Test)
 }
 
-func NewTestsProcessor(config model.Configuration, clock clock.PassiveClock) TestsProcessor {
+func NewTestsProcessor(config model.Configuration, clock clock.PassiveClock, rand *rand.Rand) TestsProcessor {
 	return &testsProcessor{
 		config: config,
 		clock:  clock,
+		rand:   rand,
 	}
 }
 
 type testsProcessor struct {
 	config model.Configuration
 	clock  clock.PassiveClock
+	rand   *rand.Rand
 }
 
 func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...discovery.Test) {
 	// 1. setup context
 	t := testing.FromContext(ctx)
+	ctx, span := tracing.Start(ctx, "chainsaw")
+	t.Cleanup(func() {
+		tracing.End(span, t.Failed(), false)
+	})
 	mainCleaner := cleaner.New(p.config.Timeouts.Cleanup.Duration, nil, p.config.Deletion.Propagation)
 	t.Cleanup(func() {
-		if !mainCleaner.Empty() {
+		// the main cleaner only holds the shared namespace
+		if !mainCleaner.Empty() && !keepNamespace(p.config.Namespace.Cleanup, t.Failed()) {
 			logging.Log(ctx, logging.Cleanup, logging.BeginStatus, color.BoldFgCyan)
 			defer func() {
 				logging.Log(ctx, logging.Cleanup, logging.EndStatus, color.BoldFgCyan)
@@ -50,11 +64,23 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 			}
 		}
 	})
+	if p.config.Execution.Timeout != nil {
+		// the deadline is shared by all tests, it's released once all (parallel) tests completed
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.Execution.Timeout.Duration)
+		t.Cleanup(cancel)
+	}
+	tc = engine.WithClock(ctx, tc, p.clock)
 	contextData := contextData{
 		basePath: "",
 		clusters: p.config.Clusters,
 	}
-	if p.config.Namespace.Name != "" {
+	nsName := p.config.Namespace.Name
+	if nsName == "" && p.config.Namespace.Strategy == v1alpha2.NamespaceStrategyShared {
+		// a single random namespace is created here and used by all tests
+		nsName = names.Namespace(p.rand)
+	}
+	if nsName != "" {
 		var nsCleaner cleaner.CleanerCollector
 		if !p.config.Cleanup.SkipDelete {
 			nsCleaner = mainCleaner
@@ -64,40 +90,65 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 			compilers = compilers.WithDefaultCompiler(string(*p.config.Namespace.Compiler))
 		}
 		contextData.namespace = &namespaceData{
-			name:      p.config.Namespace.Name,
-			template:  p.config.Namespace.Template,
-			compilers: compilers,
-			cleaner:   nsCleaner,
+			name:            nsName,
+			template:        p.config.Namespace.Template,
+			compilers:       compilers,
+			cleaner:         nsCleaner,
+			existing:        p.config.Namespace.Existing,
+			confirmRecreate: p.config.Namespace.ConfirmRecreate,
+			timeout:         p.config.Timeouts.Delete.Duration,
 		}
 	}
 	tc, namespace, err := setupContextData(ctx, tc, contextData)
 	if err != nil {
 		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 		tc.IncFailed()
+		tc.IncSetupFailure()
 		failer.FailNow(ctx)
 	}
 	var nspacer namespacer.Namespacer
 	if namespace != nil {
 		nspacer = namespacer.New(namespace.GetName())
 	}
-	// 2. loop through tests
+	// 2. select tests by name
+	tests, err = p.grep(tests)
+	if err != nil {
+		logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+		tc.IncFailed()
+		tc.IncSetupFailure()
+		failer.FailNow(ctx)
+	}
+	// 3. loop through tests
 	for i := range tests {
 		test := tests[i]
 		name, err := names.Test(p.config.Discovery.FullName, test)
 		if err != nil {
 			logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
 			tc.IncFailed()
+			tc.IncSetupFailure()
 			failer.FailNow(ctx)
 		}
-		// 3. compute test scenarios
+		// 4. compute test scenarios
 		scenarios := applyScenarios(test)
-		// 4. loop through test scenarios
+		// 5. loop through test scenarios
 		for s := range scenarios {
 			test := scenarios[s]
-			// 5. run each test scenario in a separate T
+			// derive the test random generator here, tests may run in parallel
+			seed := p.rand.Int63()
+			// 6. run each test scenario in a separate T
 			t.Run(name, func(t *testing.T) {
 				t.Helper()
 				ctx := testing.IntoContext(ctx, t)
+				ctx, span := tracing.Start(
+					ctx,
+					name,
+					attribute.String("chainsaw.test.name", test.Test.Name),
+					attribute.String("chainsaw.test.path", test.BasePath),
+					attribute.Int("chainsaw.test.scenario", s+1),
+				)
+				t.Cleanup(func() {
+					tracing.End(span, t.Failed(), t.Skipped())
+				})
 				size := len("@chainsaw")
 				for i, step := range test.Test.Spec.Steps {
 					name := step.Name
@@ -116,19 +167,26 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 				}
 				tc := tc.WithBinding(ctx, "test", info)
 				t.Cleanup(func() {
+					event := events.Event{Id: t.Name(), Name: name}
 					if t.Skipped() {
 						tc.IncSkipped()
+						event.Type = events.TestSkipped
 					} else {
 						if t.Failed() {
 							tc.IncFailed()
+							event.Type = events.TestFailed
 						} else {
 							tc.IncPassed()
+							event.Type = events.TestPassed
 						}
+						tc.RecordOutcome(t.Name(), !t.Failed())
 					}
+					events.Send(ctx, event)
 				})
-				if test.Test.Spec.Concurrent == nil || *test.Test.Spec.Concurrent {
+				if p.concurrent(test) {
 					t.Parallel()
 				}
+				events.Send(ctx, events.Event{Type: events.TestStarted, Id: t.Name(), Name: name})
 				if test.Test.Spec.Skip != nil && *test.Test.Spec.Skip {
 					t.SkipNow()
 				}
@@ -141,14 +199,90 @@ func (p *testsProcessor) Run(ctx context.Context, tc engine.Context, tests ...di
 						t.SkipNow()
 					}
 				}
-				processor := p.createTestProcessor(test, size)
+				if err := p.checkTimeout(ctx); err != nil {
+					logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+					tc.IncTimeout()
+					failer.FailNow(ctx)
+				}
+				t.Cleanup(func() {
+					// the test may have stopped early (fail now), report the timeout when it completes
+					if err := p.checkTimeout(ctx); err != nil {
+						logging.Log(ctx, logging.Internal, logging.ErrorStatus, color.BoldRed, logging.ErrSection(err))
+						tc.IncTimeout()
+						failer.Fail(ctx)
+					}
+				})
+				processor := p.createTestProcessor(test, size, seed)
 				processor.Run(ctx, nspacer, tc)
 			})
 		}
 	}
 }
 
-func (p *testsProcessor) createTestProcessor(test discovery.Test, size int) TestProcessor {
+// grep keeps the tests whose name matches the grep regular expression and doesn't match the grep invert one.
+// Tests failing to load are kept, they are reported as failures.
+func (p *testsProcessor) grep(tests []discovery.Test) ([]discovery.Test, error) {
+	if p.config.Discovery.Grep == "" && p.config.Discovery.GrepInvert == "" {
+		return tests, nil
+	}
+	var include, exclude *regexp.Regexp
+	if p.config.Discovery.Grep != "" {
+		regex, err := regexp.Compile(p.config.Discovery.Grep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep regular expression: %w", err)
+		}
+		include = regex
+	}
+	if p.config.Discovery.GrepInvert != "" {
+		regex, err := regexp.Compile(p.config.Discovery.GrepInvert)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grep invert regular expression: %w", err)
+		}
+		exclude = regex
+	}
+	var selected []discovery.Test
+	for _, test := range tests {
+		if test.Test != nil {
+			name, err := names.Test(p.config.Discovery.FullName, test)
+			if err != nil {
+				return nil, err
+			}
+			if include != nil && !include.MatchString(name) {
+				continue
+			}
+			if exclude != nil && exclude.MatchString(name) {
+				continue
+			}
+		}
+		selected = append(selected, test)
+	}
+	return selected, nil
+}
+
+func (p *testsProcessor) checkTimeout(ctx context.Context) error {
+	if p.config.Execution.Timeout != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("run timeout exceeded (%s)", p.config.Execution.Timeout.Duration)
+	}
+	return nil
+}
+
+// concurrent determines whether a test runs in parallel with other tests.
+// Forcing serial or parallel execution globally takes precedence over the concurrent setting of the test.
+// Tests sharing a generated namespace always run serially, the cleanup of a test could otherwise delete resources of another one.
+func (p *testsProcessor) concurrent(test discovery.Test) bool {
+	if p.config.Execution.ForceSerial {
+		return false
+	}
+	if p.config.Namespace.Name == "" && p.config.Namespace.Strategy == v1alpha2.NamespaceStrategyShared {
+		return false
+	}
+	if p.config.Execution.ForceParallel {
+		return true
+	}
+	return test.Test.Spec.Concurrent == nil || *test.Test.Spec.Concurrent
+}
+
+func (p *testsProcessor) createTestProcessor(test discovery.Test, size int, seed int64) TestProcessor {
 	var delayBeforeCleanup *time.Duration
 	if p.config.Cleanup.DelayBeforeCleanup != nil {
 		delayBeforeCleanup = &p.config.Cleanup.DelayBeforeCleanup.Duration
@@ -157,6 +291,8 @@ func (p *testsProcessor) createTestProcessor(test discovery.Test, size int) Test
 		test,
 		size,
 		p.clock,
+		rand.New(rand.NewSource(seed)),
+		p.config,
 		p.config.Namespace.Template,
 		p.config.Namespace.Compiler,
 		delayBeforeCleanup,
@@ -2,19 +2,38 @@ package processors
 
 import (
 	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/kyverno/chainsaw/pkg/apis"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
 	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/client/cassette"
 	fake "github.com/kyverno/chainsaw/pkg/client/testing"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/chainsaw/pkg/testing"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -157,6 +176,7 @@ func TestTestsProcessor_Run(t *testing.T) {
 			processor := NewTestsProcessor(
 				tc.config,
 				tc.clock,
+				rand.New(rand.NewSource(0)),
 			)
 			nt := testing.MockT{}
 			ctx := testing.IntoContext(context.Background(), &nt)
@@ -172,3 +192,716 @@ func TestTestsProcessor_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestTestsProcessor_Run_ExistingNamespace(t *testing.T) {
+	testCases := []struct {
+		name           string
+		namespace      string
+		existing       v1alpha2.NamespaceExistingPolicy
+		confirm        bool
+		expectedDelete bool
+		expectedCreate bool
+		expectedFail   bool
+	}{{
+		name:      "default",
+		namespace: "chain-saw",
+	}, {
+		name:      "reuse",
+		namespace: "chain-saw",
+		existing:  v1alpha2.NamespaceExistingReuse,
+	}, {
+		name:         "fail",
+		namespace:    "chain-saw",
+		existing:     v1alpha2.NamespaceExistingFail,
+		expectedFail: true,
+	}, {
+		name:           "recreate",
+		namespace:      "chain-saw",
+		existing:       v1alpha2.NamespaceExistingRecreate,
+		confirm:        true,
+		expectedDelete: true,
+		expectedCreate: true,
+	}, {
+		name:         "recreate without confirmation",
+		namespace:    "chain-saw",
+		existing:     v1alpha2.NamespaceExistingRecreate,
+		expectedFail: true,
+	}, {
+		name:         "recreate system namespace",
+		namespace:    "kube-system",
+		existing:     v1alpha2.NamespaceExistingRecreate,
+		confirm:      true,
+		expectedFail: true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted, created bool
+			registry := registryMock{
+				client: &fake.FakeClient{
+					GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+						assert.Equal(t, tc.namespace, key.Name)
+						if deleted {
+							return errors.NewNotFound(v1alpha1.Resource("Namespace"), key.Name)
+						}
+						return nil
+					},
+					CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+						assert.True(t, deleted, "namespace must be deleted before it is created again")
+						created = true
+						return nil
+					},
+					DeleteFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.DeleteOption) error {
+						deleted = true
+						return nil
+					},
+				},
+			}
+			config := model.Configuration{
+				Timeouts: v1alpha1.DefaultTimeouts{
+					Delete: metav1.Duration{Duration: time.Second},
+				},
+				Namespace: v1alpha2.NamespaceOptions{
+					Name:            tc.namespace,
+					Existing:        tc.existing,
+					ConfirmRecreate: tc.confirm,
+				},
+				Cleanup: v1alpha2.CleanupOptions{
+					SkipDelete: true,
+				},
+			}
+			processor := NewTestsProcessor(config, nil, rand.New(rand.NewSource(0)))
+			nt := testing.MockT{}
+			ctx := testing.IntoContext(context.Background(), &nt)
+			processor.Run(ctx, enginecontext.MakeContext(apis.NewBindings(), registry))
+			assert.Equal(t, tc.expectedFail, nt.FailedVar)
+			assert.Equal(t, tc.expectedDelete, deleted)
+			assert.Equal(t, tc.expectedCreate, created)
+		})
+	}
+}
+
+func TestTestsProcessor_Run_Timeout(t *testing.T) {
+	// tests cut off by the timeout fail, run them in a separate process
+	if os.Getenv("CHAINSAW_RUN_TIMEOUT") != "1" {
+		start := time.Now()
+		cmd := exec.Command(os.Args[0], "-test.run=^TestTestsProcessor_Run_Timeout$", "-test.v")
+		cmd.Env = append(os.Environ(), "CHAINSAW_RUN_TIMEOUT=1")
+		out, err := cmd.CombinedOutput()
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 10*time.Second)
+		assert.Equal(t, 2, strings.Count(string(out), "run timeout exceeded (200ms)"), string(out))
+		assert.Contains(t, string(out), "--- FAIL: TestTestsProcessor_Run_Timeout/slow ")
+		assert.Contains(t, string(out), "--- FAIL: TestTestsProcessor_Run_Timeout/slow#01 ")
+		return
+	}
+	config := model.Configuration{
+		Timeouts: v1alpha1.DefaultTimeouts{
+			Cleanup: metav1.Duration{Duration: time.Second},
+		},
+		Namespace: v1alpha2.NamespaceOptions{
+			Name: "default",
+		},
+		Execution: v1alpha2.ExecutionOptions{
+			Timeout: &metav1.Duration{Duration: 200 * time.Millisecond},
+		},
+	}
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return nil
+			},
+		},
+	}
+	slow := discovery.Test{
+		BasePath: "fakePath",
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "slow",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Sleep: &v1alpha1.Sleep{
+								Duration: metav1.Duration{Duration: time.Minute},
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+	ctx := testing.IntoContext(context.Background(), t)
+	processor.Run(ctx, enginecontext.MakeContext(apis.NewBindings(), registry), slow, slow)
+}
+
+func TestTestsProcessor_Run_FailureCategory(t *testing.T) {
+	// failing tests fail the parent test, run them in a separate process
+	if scenario := os.Getenv("CHAINSAW_RUN_FAILURE"); scenario == "" {
+		for scenario, expected := range map[string]model.FailureCategory{
+			"setup":     model.FailureCategorySetup,
+			"assertion": model.FailureCategoryTest,
+		} {
+			t.Run(scenario, func(t *testing.T) {
+				cmd := exec.Command(os.Args[0], "-test.run=^TestTestsProcessor_Run_FailureCategory$", "-test.v")
+				cmd.Env = append(os.Environ(), "CHAINSAW_RUN_FAILURE="+scenario)
+				out, err := cmd.CombinedOutput()
+				assert.Error(t, err)
+				assert.Contains(t, string(out), "failure category: "+string(expected), string(out))
+			})
+		}
+		return
+	}
+	config := model.Configuration{
+		Timeouts: v1alpha1.DefaultTimeouts{
+			Assert: metav1.Duration{Duration: 200 * time.Millisecond},
+		},
+		Namespace: v1alpha2.NamespaceOptions{
+			Name: "default",
+		},
+	}
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				if key.Name == "default" {
+					return nil
+				}
+				return errors.NewNotFound(v1alpha1.Resource("Namespace"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+				return errors.NewBadRequest("failed to create namespace")
+			},
+			IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+				return false, nil
+			},
+		},
+	}
+	test := discovery.Test{
+		BasePath: "fakePath",
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "failing",
+			},
+		},
+	}
+	switch os.Getenv("CHAINSAW_RUN_FAILURE") {
+	case "setup":
+		test.Test.Spec.Namespace = "chain-saw"
+	case "assertion":
+		test.Test.Spec.Steps = []v1alpha1.TestStep{{
+			TestStepSpec: v1alpha1.TestStepSpec{
+				Try: []v1alpha1.Operation{{
+					Assert: &v1alpha1.Assert{
+						ActionCheckRef: v1alpha1.ActionCheckRef{
+							Check: ptr.To(v1alpha1.NewProjection(map[string]any{
+								"apiVersion": "v1",
+								"kind":       "Namespace",
+								"metadata": map[string]any{
+									"name": "missing",
+								},
+							})),
+						},
+					},
+				}},
+			},
+		}}
+	}
+	tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+	t.Cleanup(func() {
+		fmt.Printf("failure category: %s\n", tc.Failure())
+	})
+	processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+	ctx := testing.IntoContext(context.Background(), t)
+	processor.Run(ctx, tc, test)
+}
+
+func TestTestsProcessor_concurrent(t *testing.T) {
+	testCases := []struct {
+		name       string
+		execution  v1alpha2.ExecutionOptions
+		namespace  v1alpha2.NamespaceOptions
+		concurrent *bool
+		want       bool
+	}{{
+		name: "default",
+		want: true,
+	}, {
+		name:       "not concurrent",
+		concurrent: ptr.To(false),
+		want:       false,
+	}, {
+		name:       "force serial",
+		execution:  v1alpha2.ExecutionOptions{ForceSerial: true},
+		concurrent: ptr.To(true),
+		want:       false,
+	}, {
+		name:      "force serial with default",
+		execution: v1alpha2.ExecutionOptions{ForceSerial: true},
+		want:      false,
+	}, {
+		name:       "force parallel",
+		execution:  v1alpha2.ExecutionOptions{ForceParallel: true},
+		concurrent: ptr.To(false),
+		want:       true,
+	}, {
+		name:       "shared namespace",
+		namespace:  v1alpha2.NamespaceOptions{Strategy: v1alpha2.NamespaceStrategyShared},
+		concurrent: ptr.To(true),
+		want:       false,
+	}, {
+		name:       "shared namespace with force parallel",
+		execution:  v1alpha2.ExecutionOptions{ForceParallel: true},
+		namespace:  v1alpha2.NamespaceOptions{Strategy: v1alpha2.NamespaceStrategyShared},
+		concurrent: ptr.To(true),
+		want:       false,
+	}, {
+		name:       "named namespace",
+		namespace:  v1alpha2.NamespaceOptions{Name: "chainsaw", Strategy: v1alpha2.NamespaceStrategyShared},
+		concurrent: ptr.To(true),
+		want:       true,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			processor := &testsProcessor{
+				config: model.Configuration{
+					Execution: tc.execution,
+					Namespace: tc.namespace,
+				},
+			}
+			test := discovery.Test{
+				Test: &model.Test{
+					Spec: v1alpha1.TestSpec{
+						Concurrent: tc.concurrent,
+					},
+				},
+			}
+			assert.Equal(t, tc.want, processor.concurrent(test))
+		})
+	}
+}
+
+func TestTestsProcessor_Run_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	defer func(provider trace.TracerProvider) { otel.SetTracerProvider(provider) }(otel.GetTracerProvider())
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	config := model.Configuration{
+		Namespace: v1alpha2.NamespaceOptions{
+			Name: "default",
+		},
+	}
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return nil
+			},
+		},
+	}
+	sleep := v1alpha1.Operation{
+		Sleep: &v1alpha1.Sleep{
+			Duration: metav1.Duration{Duration: time.Millisecond},
+		},
+	}
+	test := discovery.Test{
+		BasePath: "fakePath",
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "traced",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					Name: "first",
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{sleep, sleep},
+					},
+				}, {
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{sleep},
+					},
+				}},
+			},
+		},
+	}
+	// run in a sub test so that all cleanups complete before asserting on the spans
+	t.Run("run", func(t *testing.T) {
+		processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+		ctx := testing.IntoContext(context.Background(), t)
+		processor.Run(ctx, enginecontext.MakeContext(apis.NewBindings(), registry), test)
+	})
+	spans := recorder.Ended()
+	byName := map[string][]sdktrace.ReadOnlySpan{}
+	for _, span := range spans {
+		byName[span.Name()] = append(byName[span.Name()], span)
+	}
+	assert.Len(t, spans, 7)
+	assert.Len(t, byName["chainsaw"], 1)
+	assert.Len(t, byName["traced"], 1)
+	assert.Len(t, byName["first"], 1)
+	assert.Len(t, byName["step-2"], 1)
+	assert.Len(t, byName["sleep"], 3)
+	root := byName["chainsaw"][0]
+	testSpan := byName["traced"][0]
+	first := byName["first"][0]
+	second := byName["step-2"][0]
+	assert.False(t, root.Parent().IsValid())
+	assert.Equal(t, root.SpanContext().SpanID(), testSpan.Parent().SpanID())
+	assert.Equal(t, testSpan.SpanContext().SpanID(), first.Parent().SpanID())
+	assert.Equal(t, testSpan.SpanContext().SpanID(), second.Parent().SpanID())
+	assert.Contains(t, testSpan.Attributes(), attribute.String("chainsaw.test.name", "traced"))
+	assert.Contains(t, testSpan.Attributes(), attribute.String("chainsaw.test.namespace", "default"))
+	assert.Contains(t, second.Attributes(), attribute.Int("chainsaw.step.id", 2))
+	var operations []trace.SpanID
+	for _, span := range byName["sleep"] {
+		operations = append(operations, span.Parent().SpanID())
+	}
+	assert.ElementsMatch(t, []trace.SpanID{first.SpanContext().SpanID(), first.SpanContext().SpanID(), second.SpanContext().SpanID()}, operations)
+	for _, span := range spans {
+		assert.Equal(t, codes.Ok, span.Status().Code, span.Name())
+	}
+}
+
+func TestTestsProcessor_grep(t *testing.T) {
+	test := func(name string) discovery.Test {
+		return discovery.Test{
+			BasePath: "tests/" + name,
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+			},
+		}
+	}
+	tests := []discovery.Test{test("quick-apply"), test("quick-apply-slow"), test("delete"), test("delete-slow")}
+	testCases := []struct {
+		name       string
+		grep       string
+		grepInvert string
+		want       []string
+		wantErr    string
+	}{{
+		name: "none",
+		want: []string{"quick-apply", "quick-apply-slow", "delete", "delete-slow"},
+	}, {
+		name: "include",
+		grep: "^quick-",
+		want: []string{"quick-apply", "quick-apply-slow"},
+	}, {
+		name:       "exclude",
+		grepInvert: "-slow$",
+		want:       []string{"quick-apply", "delete"},
+	}, {
+		name:       "combined",
+		grep:       "apply",
+		grepInvert: "slow",
+		want:       []string{"quick-apply"},
+	}, {
+		name: "no match",
+		grep: "foo",
+	}, {
+		name:    "invalid grep",
+		grep:    "(",
+		wantErr: "invalid grep regular expression: error parsing regexp: missing closing ): `(`",
+	}, {
+		name:       "invalid grep invert",
+		grepInvert: "[",
+		wantErr:    "invalid grep invert regular expression: error parsing regexp: missing closing ]: `[`",
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			processor := &testsProcessor{
+				config: model.Configuration{
+					Discovery: v1alpha2.DiscoveryOptions{
+						Grep:       tc.grep,
+						GrepInvert: tc.grepInvert,
+					},
+				},
+			}
+			got, err := processor.grep(tests)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			var names []string
+			for _, test := range got {
+				names = append(names, test.Test.Name)
+			}
+			assert.Equal(t, tc.want, names)
+		})
+	}
+}
+
+func TestTestsProcessor_Run_Grep(t *testing.T) {
+	config := model.Configuration{
+		Namespace: v1alpha2.NamespaceOptions{
+			Name: "default",
+		},
+		Discovery: v1alpha2.DiscoveryOptions{
+			Grep:       "^quick-",
+			GrepInvert: "-slow$",
+		},
+	}
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return nil
+			},
+		},
+	}
+	test := func(name string) discovery.Test {
+		return discovery.Test{
+			BasePath: "fakePath",
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+			},
+		}
+	}
+	tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+	// run in a sub test so that all tests complete before asserting on the summary
+	t.Run("run", func(t *testing.T) {
+		processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+		ctx := testing.IntoContext(context.Background(), t)
+		processor.Run(ctx, tc, test("quick-apply"), test("quick-apply-slow"), test("delete"))
+	})
+	assert.Equal(t, int32(1), tc.Passed())
+	assert.Equal(t, int32(0), tc.Failed())
+	assert.Equal(t, int32(0), tc.Skipped())
+	assert.Len(t, tc.Report.Tests, 1)
+}
+
+func TestTestsProcessor_Run_SharedNamespace(t *testing.T) {
+	config := model.Configuration{
+		Namespace: v1alpha2.NamespaceOptions{
+			Strategy: v1alpha2.NamespaceStrategyShared,
+		},
+		Cleanup: v1alpha2.CleanupOptions{
+			SkipDelete: true,
+		},
+	}
+	var created []string
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return errors.NewNotFound(v1alpha1.Resource("Namespace"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+				created = append(created, obj.GetName())
+				return nil
+			},
+		},
+	}
+	test := func(name string) discovery.Test {
+		return discovery.Test{
+			BasePath: "fakePath",
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+			},
+		}
+	}
+	tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+	// run in a sub test so that all tests complete before asserting on the report
+	t.Run("run", func(t *testing.T) {
+		processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+		ctx := testing.IntoContext(context.Background(), t)
+		processor.Run(ctx, tc, test("first"), test("second"))
+	})
+	assert.Equal(t, int32(2), tc.Passed())
+	assert.Len(t, created, 1)
+	assert.Len(t, tc.Report.Tests, 2)
+	assert.Equal(t, created[0], tc.Report.Tests[0].Namespace)
+	assert.Equal(t, created[0], tc.Report.Tests[1].Namespace)
+}
+
+func TestTestsProcessor_Run_RecordReplay(t *testing.T) {
+	config := model.Configuration{
+		Timeouts: v1alpha1.DefaultTimeouts{
+			Apply:   metav1.Duration{Duration: time.Second},
+			Assert:  metav1.Duration{Duration: time.Second},
+			Cleanup: metav1.Duration{Duration: time.Second},
+			Delete:  metav1.Duration{Duration: time.Second},
+		},
+	}
+	// the fake cluster keeps track of created objects by namespaced name
+	objects := map[string]map[string]any{}
+	cluster := &fake.FakeClient{
+		GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+			object, ok := objects[client.Name(key)]
+			if !ok {
+				return errors.NewNotFound(v1alpha1.Resource("object"), key.Name)
+			}
+			return runtime.DefaultUnstructuredConverter.FromUnstructured(runtime.DeepCopyJSON(object), obj)
+		},
+		CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+			object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+			if err != nil {
+				return err
+			}
+			objects[client.Name(client.Key(obj))] = object
+			return nil
+		},
+		DeleteFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.DeleteOption) error {
+			delete(objects, client.Name(client.Key(obj)))
+			return nil
+		},
+		IsObjectNamespacedFn: func(call int, obj runtime.Object) (bool, error) {
+			_, namespace := obj.(*corev1.Namespace)
+			return !namespace, nil
+		},
+	}
+	configMap := func(data map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]any{
+					"name": "foo",
+				},
+				"data": data,
+			},
+		}
+	}
+	test := discovery.Test{
+		BasePath: "fakePath",
+		Test: &model.Test{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "record-replay",
+			},
+			Spec: v1alpha1.TestSpec{
+				Steps: []v1alpha1.TestStep{{
+					TestStepSpec: v1alpha1.TestStepSpec{
+						Try: []v1alpha1.Operation{{
+							Apply: &v1alpha1.Apply{
+								ActionResourceRef: v1alpha1.ActionResourceRef{
+									Resource: configMap(map[string]any{"foo": "bar"}),
+								},
+							},
+						}, {
+							Assert: &v1alpha1.Assert{
+								ActionCheckRef: v1alpha1.ActionCheckRef{
+									Check: ptr.To(v1alpha1.NewProjection(configMap(map[string]any{"foo": "bar"}).Object)),
+								},
+							},
+						}},
+					},
+				}},
+			},
+		},
+	}
+	run := func(c client.Client) *enginecontext.TestContext {
+		tc := enginecontext.MakeContext(apis.NewBindings(), registryMock{client: c})
+		// run in a sub test so that the test completes before asserting on the summary
+		t.Run("run", func(t *testing.T) {
+			// the same seed is used so that random namespace names match the recorded requests
+			processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+			ctx := testing.IntoContext(context.Background(), t)
+			processor.Run(ctx, tc, test)
+		})
+		return &tc
+	}
+	recording := &cassette.Cassette{}
+	recorded := run(cassette.NewRecorder(cluster, recording))
+	assert.Equal(t, int32(1), recorded.Passed())
+	assert.Equal(t, int32(0), recorded.Failed())
+	assert.NotEmpty(t, recording.Interactions)
+	// replay without the fake cluster
+	replayed := run(cassette.NewReplayer(recording))
+	assert.Equal(t, recorded.Passed(), replayed.Passed())
+	assert.Equal(t, recorded.Failed(), replayed.Failed())
+	assert.Equal(t, recorded.Report.Tests[0].Namespace, replayed.Report.Tests[0].Namespace)
+}
+
+func TestTestsProcessor_Run_SharedNamespaceSerial(t *testing.T) {
+	config := model.Configuration{
+		Timeouts: v1alpha1.DefaultTimeouts{
+			Apply:   metav1.Duration{Duration: time.Second},
+			Cleanup: metav1.Duration{Duration: time.Second},
+			Delete:  metav1.Duration{Duration: time.Second},
+		},
+		Namespace: v1alpha2.NamespaceOptions{
+			Strategy: v1alpha2.NamespaceStrategyShared,
+		},
+	}
+	var lock sync.Mutex
+	var calls []string
+	record := func(call string, obj ctrlclient.Object) {
+		if obj.GetObjectKind().GroupVersionKind().Kind == "ConfigMap" {
+			lock.Lock()
+			defer lock.Unlock()
+			calls = append(calls, call+" "+obj.GetNamespace()+"/"+obj.GetName())
+		}
+	}
+	registry := registryMock{
+		client: &fake.FakeClient{
+			GetFn: func(ctx context.Context, call int, key ctrlclient.ObjectKey, obj ctrlclient.Object, opts ...ctrlclient.GetOption) error {
+				return errors.NewNotFound(v1alpha1.Resource("Namespace"), key.Name)
+			},
+			CreateFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.CreateOption) error {
+				record("create", obj)
+				// leave time to a concurrent test to interfere
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			},
+			DeleteFn: func(ctx context.Context, call int, obj ctrlclient.Object, opts ...ctrlclient.DeleteOption) error {
+				record("delete", obj)
+				return nil
+			},
+			IsObjectNamespacedFn: func(int, runtime.Object) (bool, error) {
+				return true, nil
+			},
+		},
+	}
+	test := func(name string) discovery.Test {
+		return discovery.Test{
+			BasePath: "fakePath",
+			Test: &model.Test{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+				},
+				Spec: v1alpha1.TestSpec{
+					Concurrent: ptr.To(true),
+					Steps: []v1alpha1.TestStep{{
+						TestStepSpec: v1alpha1.TestStepSpec{
+							Try: []v1alpha1.Operation{{
+								Create: &v1alpha1.Create{
+									ActionResourceRef: v1alpha1.ActionResourceRef{
+										Resource: &unstructured.Unstructured{
+											Object: map[string]any{
+												"apiVersion": "v1",
+												"kind":       "ConfigMap",
+												"metadata": map[string]any{
+													"name": "shared",
+												},
+											},
+										},
+									},
+								},
+							}},
+						},
+					}},
+				},
+			},
+		}
+	}
+	tc := enginecontext.MakeContext(apis.NewBindings(), registry)
+	// run in a sub test so that all tests complete before asserting on the calls
+	t.Run("run", func(t *testing.T) {
+		processor := NewTestsProcessor(config, clock.RealClock{}, rand.New(rand.NewSource(0)))
+		ctx := testing.IntoContext(context.Background(), t)
+		processor.Run(ctx, tc, test("first"), test("second"))
+	})
+	assert.Equal(t, int32(2), tc.Passed())
+	// each test deletes its resource before the next test creates it
+	if assert.Len(t, calls, 4) {
+		namespace := tc.Report.Tests[0].Namespace
+		assert.Equal(t, []string{
+			"create " + namespace + "/shared",
+			"delete " + namespace + "/shared",
+			"create " + namespace + "/shared",
+			"delete " + namespace + "/shared",
+		}, calls)
+	}
+}
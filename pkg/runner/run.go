@@ -2,21 +2,33 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis"
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha2"
+	"github.com/kyverno/chainsaw/pkg/client"
+	"github.com/kyverno/chainsaw/pkg/client/cassette"
+	"github.com/kyverno/chainsaw/pkg/client/simple"
 	"github.com/kyverno/chainsaw/pkg/discovery"
 	"github.com/kyverno/chainsaw/pkg/engine"
 	"github.com/kyverno/chainsaw/pkg/engine/clusters"
 	enginecontext "github.com/kyverno/chainsaw/pkg/engine/context"
 	"github.com/kyverno/chainsaw/pkg/engine/logging"
+	"github.com/kyverno/chainsaw/pkg/engine/throttle"
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/kyverno/chainsaw/pkg/report"
 	"github.com/kyverno/chainsaw/pkg/runner/internal"
 	"github.com/kyverno/chainsaw/pkg/runner/processors"
+	"github.com/kyverno/chainsaw/pkg/runner/tracing"
 	"github.com/kyverno/chainsaw/pkg/testing"
+	"k8s.io/apimachinery/pkg/version"
+	discoveryclient "k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 )
 
 type mainstart interface {
@@ -26,7 +38,7 @@ type mainstart interface {
 func Run(
 	ctx context.Context,
 	cfg *rest.Config,
-	clock clock.PassiveClock,
+	clock clock.Clock,
 	config model.Configuration,
 	values map[string]any,
 	tests ...discovery.Test,
@@ -37,22 +49,42 @@ func Run(
 func run(
 	ctx context.Context,
 	cfg *rest.Config,
-	clock clock.PassiveClock,
+	clock clock.Clock,
 	config model.Configuration,
 	m mainstart,
 	values map[string]any,
 	tests ...discovery.Test,
 ) (model.SummaryResult, error) {
-	tc, err := setupTestContext(ctx, values, cfg, config)
+	tc, err := setupTestContext(ctx, clock, values, cfg, config)
 	if err != nil {
 		return nil, err
 	}
+	tc, redacted, err := withBindingsFrom(ctx, tc, config.BindingsFrom...)
+	if err != nil {
+		return nil, err
+	}
+	// secret values are masked in the logs of the tests of this run
+	ctx = logging.WithRedaction(ctx, redacted...)
 	if len(tests) == 0 {
 		return tc, nil
 	}
 	if err := internal.SetupFlags(config); err != nil {
 		return nil, err
 	}
+	throttle.SetLimit(ptr.Deref(config.Execution.ParallelCommands, 0))
+	var shutdownTracing func(context.Context) error
+	if config.Tracing != nil && config.Tracing.Endpoint != "" {
+		shutdown, err := setupTracing(ctx, config.Tracing.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		shutdownTracing = shutdown
+	}
+	seed := time.Now().UnixNano()
+	if config.Execution.Seed != nil {
+		seed = *config.Execution.Seed
+	}
+	rand := rand.New(rand.NewSource(seed))
 	internalTests := []testing.InternalTest{{
 		Name: "chainsaw",
 		F: func(t *testing.T) {
@@ -60,7 +92,7 @@ func run(
 			t.Parallel()
 			ctx := testing.IntoContext(ctx, t)
 			ctx = logging.IntoContext(ctx, logging.NewLogger(t, clock, t.Name(), "@chainsaw"))
-			processor := processors.NewTestsProcessor(config, clock)
+			processor := processors.NewTestsProcessor(config, clock, rand)
 			processor.Run(ctx, tc, tests...)
 		},
 	}}
@@ -74,31 +106,109 @@ func run(
 	// - 2 if running the tests was not possible
 	// In our case, we consider an error only when running the tests was not possible.
 	// For now, the case where some of the tests failed will be covered by the summary.
-	if code := m.Run(); code > 1 {
-		return tc.Summary, fmt.Errorf("testing framework exited with non zero code %d", code)
+	code := m.Run()
+	var tracingErr error
+	if shutdownTracing != nil {
+		// flush pending spans, the run is complete and all spans have ended
+		// failing to export them must not prevent the report from being saved and sent
+		if err := shutdownTracing(context.WithoutCancel(ctx)); err != nil {
+			tracingErr = fmt.Errorf("failed to export traces: %w", err)
+		}
+	}
+	if code > 1 {
+		return tc.Summary, errors.Join(fmt.Errorf("testing framework exited with non zero code %d", code), tracingErr)
 	}
+	tc.Report.EndTime = time.Now()
 	if config.Report != nil && config.Report.Format != "" {
-		tc.Report.EndTime = time.Now()
-		if err := report.Save(tc.Report, config.Report.Format, config.Report.Path, config.Report.Name); err != nil {
-			return tc.Summary, err
+		if err := report.Save(tc.Report, config.Report.Format, config.Report.Path, config.Report.Name, config.Report.Properties); err != nil {
+			return tc.Summary, errors.Join(err, tracingErr)
+		}
+	}
+	if config.Notification != nil && config.Notification.URL != "" {
+		if err := report.Notify(ctx, tc.Summary, tc.Report, config.Notification.URL, config.Notification.Template); err != nil {
+			return tc.Summary, errors.Join(err, tracingErr)
 		}
 	}
-	return tc.Summary, nil
+	return tc.Summary, tracingErr
 }
 
-func setupTestContext(ctx context.Context, values any, cluster *rest.Config, config model.Configuration) (engine.Context, error) {
-	tc := enginecontext.EmptyContext()
+func setupTestContext(ctx context.Context, clock clock.Clock, values any, cluster *rest.Config, config model.Configuration) (engine.Context, error) {
+	recording, mode := cassette.FromContext(ctx)
+	registry := clusters.NewRateLimitedRegistry(clientFactory(recording, mode), float32(config.Client.QPS), config.Client.Burst)
+	tc := enginecontext.MakeContext(apis.NewBindings(), registry)
 	if config.Templating.Compiler != nil {
 		tc = tc.WithDefaultCompiler(string(*config.Templating.Compiler))
 	}
 	tc = engine.WithValues(ctx, tc, values)
 	if cluster != nil {
+		var info *version.Info
+		if mode == cassette.Replay {
+			info = recording.Version
+		} else {
+			var err error
+			if info, err = connect(ctx, clock, cluster, config.Client); err != nil {
+				return tc, err
+			}
+			if recording != nil {
+				recording.Version = info
+			}
+		}
 		cluster, err := clusters.NewClusterFromConfig(cluster)
 		if err != nil {
 			return tc, err
 		}
 		tc = tc.WithCluster(ctx, clusters.DefaultClient, cluster)
+		tc = engine.WithClusterInfo(ctx, tc, info)
 		return engine.WithCurrentCluster(ctx, tc, clusters.DefaultClient)
 	}
-	return tc, nil
+	return engine.WithClusterInfo(ctx, tc, nil), nil
+}
+
+// clientFactory returns a factory recording or replaying the client interactions depending on the mode, nil uses the default factory.
+func clientFactory(recording *cassette.Cassette, mode cassette.Mode) func(clusters.Cluster) (*rest.Config, client.Client, error) {
+	switch mode {
+	case cassette.Record:
+		return clusters.NewClientFactory(func(config *rest.Config) (client.Client, error) {
+			inner, err := simple.New(config)
+			if err != nil {
+				return nil, err
+			}
+			return cassette.NewRecorder(inner, recording), nil
+		})
+	case cassette.Replay:
+		return clusters.NewClientFactory(func(*rest.Config) (client.Client, error) {
+			return cassette.NewReplayer(recording), nil
+		})
+	}
+	return nil
+}
+
+// connect returns the version of the cluster.
+// Unless connection attempts are configured, failing to retrieve it is not an error (the cluster version binding is nil in this case).
+func connect(ctx context.Context, clock clock.Clock, config *rest.Config, options v1alpha2.ClientOptions) (*version.Info, error) {
+	if options.ConnectionAttempts == 0 {
+		info, _ := serverVersion(config)
+		return info, nil
+	}
+	var interval time.Duration
+	if options.ConnectionInterval != nil {
+		interval = options.ConnectionInterval.Duration
+	}
+	var info *version.Info
+	err := clusters.Connect(ctx, clock, options.ConnectionAttempts, interval, func() error {
+		var err error
+		info, err = serverVersion(config)
+		return err
+	})
+	return info, err
+}
+
+var setupTracing = tracing.Setup
+
+var serverVersion = func(config *rest.Config) (*version.Info, error) {
+	client, err := discoveryclient.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return client.ServerVersion()
 }
@@ -2,6 +2,8 @@ package runner
 
 import (
 	"context"
+	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/kyverno/chainsaw/pkg/model"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
 	tclock "k8s.io/utils/clock/testing"
 )
@@ -24,7 +27,7 @@ func (m *MockMainStart) Run() int {
 }
 
 func TestRun(t *testing.T) {
-	fakeClock := tclock.NewFakePassiveClock(time.Now())
+	fakeClock := tclock.NewFakeClock(time.Now())
 	config, err := config.DefaultConfiguration()
 	if err != nil {
 		assert.NoError(t, err)
@@ -163,3 +166,96 @@ func TestRun(t *testing.T) {
 		})
 	}
 }
+
+func TestRun_TracingFailure(t *testing.T) {
+	setup := setupTracing
+	defer func() { setupTracing = setup }()
+	setupTracing = func(context.Context, string) (func(context.Context) error, error) {
+		return func(context.Context) error {
+			return errors.New("connection refused")
+		}, nil
+	}
+	defaults, err := config.DefaultConfiguration()
+	assert.NoError(t, err)
+	path := t.TempDir()
+	_, err = run(context.TODO(), nil, tclock.NewFakeClock(time.Now()), model.Configuration{
+		Timeouts: defaults.Spec.Timeouts,
+		Tracing: &v1alpha2.TracingOptions{
+			Endpoint: "localhost:4317",
+		},
+		Report: &v1alpha2.ReportOptions{
+			Format: v1alpha2.JSONFormat,
+			Path:   path,
+			Name:   "chainsaw-report",
+		},
+	}, &MockMainStart{}, nil, discovery.Test{Test: &model.Test{ObjectMeta: metav1.ObjectMeta{Name: "test1"}}})
+	assert.EqualError(t, err, "failed to export traces: connection refused")
+	assert.FileExists(t, filepath.Join(path, "chainsaw-report.json"))
+}
+
+func TestConnect(t *testing.T) {
+	defer func(f func(*rest.Config) (*version.Info, error)) { serverVersion = f }(serverVersion)
+	tests := []struct {
+		name     string
+		options  v1alpha2.ClientOptions
+		failures int
+		want     *version.Info
+		wantErr  string
+	}{{
+		name:     "no attempts",
+		failures: 1,
+	}, {
+		name: "first attempts fail then succeed",
+		options: v1alpha2.ClientOptions{
+			ConnectionAttempts: 3,
+			ConnectionInterval: &metav1.Duration{Duration: time.Second},
+		},
+		failures: 2,
+		want:     &version.Info{GitVersion: "v1.30.0"},
+	}, {
+		name: "all attempts fail",
+		options: v1alpha2.ClientOptions{
+			ConnectionAttempts: 3,
+		},
+		failures: 3,
+		wantErr:  "failed to connect to cluster after 3 attempt(s): connection refused",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			serverVersion = func(*rest.Config) (*version.Info, error) {
+				calls++
+				if calls <= tt.failures {
+					return nil, errors.New("connection refused")
+				}
+				return &version.Info{GitVersion: "v1.30.0"}, nil
+			}
+			fakeClock := tclock.NewFakeClock(time.Now())
+			type result struct {
+				info *version.Info
+				err  error
+			}
+			done := make(chan result)
+			go func() {
+				info, err := connect(context.TODO(), fakeClock, &rest.Config{}, tt.options)
+				done <- result{info, err}
+			}()
+			for {
+				select {
+				case got := <-done:
+					if tt.wantErr != "" {
+						assert.EqualError(t, got.err, tt.wantErr)
+					} else {
+						assert.NoError(t, got.err)
+					}
+					assert.Equal(t, tt.want, got.info)
+					return
+				default:
+					if fakeClock.HasWaiters() {
+						fakeClock.Step(time.Minute)
+					}
+				}
+			}
+		})
+	}
+}
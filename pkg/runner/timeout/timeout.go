@@ -1,14 +1,23 @@
 package timeout
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func Get(operation *metav1.Duration, fallback time.Duration) *time.Duration {
-	if operation != nil {
-		return &operation.Duration
+func Get(operation *v1alpha1.Timeout, fallback time.Duration, named map[string]metav1.Duration) (*time.Duration, error) {
+	if operation == nil {
+		return &fallback, nil
 	}
-	return &fallback
+	if operation.Name != "" {
+		timeout, ok := named[operation.Name]
+		if !ok {
+			return nil, fmt.Errorf("named timeout not found: %s", operation.Name)
+		}
+		return &timeout.Duration, nil
+	}
+	return &operation.Duration, nil
 }
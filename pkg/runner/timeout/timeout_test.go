@@ -4,18 +4,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kyverno/chainsaw/pkg/apis/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGet(t *testing.T) {
-	operation := metav1.Duration{Duration: 4 * time.Minute}
+	operation := v1alpha1.Timeout{Duration: 4 * time.Minute}
 	fallback := 10 * time.Second
+	slowApply := 5 * time.Minute
+	named := map[string]metav1.Duration{
+		"slowApply": {Duration: slowApply},
+	}
 	tests := []struct {
 		name      string
 		fallback  time.Duration
-		operation *metav1.Duration
+		operation *v1alpha1.Timeout
+		named     map[string]metav1.Duration
 		want      *time.Duration
+		wantErr   bool
 	}{{
 		name:      "fallback",
 		fallback:  fallback,
@@ -26,11 +33,35 @@ func TestGet(t *testing.T) {
 		fallback:  fallback,
 		operation: &operation,
 		want:      &operation.Duration,
+	}, {
+		name:      "named",
+		fallback:  fallback,
+		operation: &v1alpha1.Timeout{Name: "slowApply"},
+		named:     named,
+		want:      &slowApply,
+	}, {
+		name:      "named not found",
+		fallback:  fallback,
+		operation: &v1alpha1.Timeout{Name: "fastApply"},
+		named:     named,
+		wantErr:   true,
+	}, {
+		name:      "named without config",
+		fallback:  fallback,
+		operation: &v1alpha1.Timeout{Name: "slowApply"},
+		named:     nil,
+		wantErr:   true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := Get(tt.operation, tt.fallback)
-			assert.Equal(t, tt.want, got)
+			got, err := Get(tt.operation, tt.fallback, tt.named)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
 		})
 	}
 }
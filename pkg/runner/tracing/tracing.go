@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kyverno/chainsaw/pkg/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/kyverno/chainsaw"
+
+// Setup registers a global tracer provider exporting spans to an OTLP (gRPC) endpoint.
+// The endpoint is either a host and port (using TLS) or a URL (http:// disables TLS).
+// The returned function flushes pending spans and must be called before exiting.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	var opts []otlptracegrpc.Option
+	if strings.Contains(endpoint, "://") {
+		opts = append(opts, otlptracegrpc.WithEndpointURL(endpoint))
+	} else {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("chainsaw"),
+			semconv.ServiceVersion(version.Version()),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span using the global tracer provider, spans are not recorded unless a provider was set up.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End sets the status of a span from the outcome of a test, a step or an operation and ends it.
+func End(span trace.Span, failed bool, skipped bool) {
+	span.SetAttributes(attribute.Bool("chainsaw.skipped", skipped))
+	if failed {
+		span.SetStatus(codes.Error, "failed")
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
@@ -9,9 +9,11 @@ type MockT struct {
 	FailedVar         bool
 	ImmeditateFailVar bool
 	SkippedVar        bool
+	CleanupFuncs      []func()
 }
 
 func (c *MockT) Cleanup(f func()) {
+	c.CleanupFuncs = append(c.CleanupFuncs, f)
 }
 
 func (t *MockT) Deadline() (deadline time.Time, ok bool) {
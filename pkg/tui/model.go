@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/runner/events"
+)
+
+// maxRunning is the maximum number of running tests listed in the view.
+const maxRunning = 10
+
+type runningTest struct {
+	id    string
+	name  string
+	start time.Time
+}
+
+// Model holds the state of the progress view, it is updated with runner events and rendered with View.
+type Model struct {
+	running []runningTest
+	passed  int
+	failed  int
+	skipped int
+}
+
+func (m Model) Update(event events.Event, now time.Time) Model {
+	switch event.Type {
+	case events.TestStarted:
+		m.running = append(m.running[:len(m.running):len(m.running)], runningTest{id: event.Id, name: event.Name, start: now})
+		return m
+	case events.TestPassed:
+		m.passed++
+	case events.TestFailed:
+		m.failed++
+	case events.TestSkipped:
+		m.skipped++
+	default:
+		return m
+	}
+	running := make([]runningTest, 0, len(m.running))
+	for _, test := range m.running {
+		if test.id != event.Id {
+			running = append(running, test)
+		}
+	}
+	m.running = running
+	return m
+}
+
+func (m Model) Running() int {
+	return len(m.running)
+}
+
+func (m Model) Passed() int {
+	return m.passed
+}
+
+func (m Model) Failed() int {
+	return m.failed
+}
+
+func (m Model) Skipped() int {
+	return m.skipped
+}
+
+func (m Model) View(now time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Running: %d | Passed: %d | Failed: %d | Skipped: %d\n", len(m.running), m.passed, m.failed, m.skipped)
+	for i, test := range m.running {
+		if i == maxRunning {
+			fmt.Fprintf(&sb, "  ... and %d more\n", len(m.running)-maxRunning)
+			break
+		}
+		fmt.Fprintf(&sb, "  > %s (%s)\n", test.name, now.Sub(test.start).Truncate(time.Second))
+	}
+	return sb.String()
+}
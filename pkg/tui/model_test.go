@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/runner/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		events []events.Event
+		want   string
+	}{{
+		name: "empty",
+		want: "Running: 0 | Passed: 0 | Failed: 0 | Skipped: 0\n",
+	}, {
+		name: "running",
+		events: []events.Event{
+			{Type: events.TestStarted, Id: "chainsaw/foo", Name: "foo"},
+			{Type: events.TestStarted, Id: "chainsaw/bar", Name: "bar"},
+		},
+		want: "Running: 2 | Passed: 0 | Failed: 0 | Skipped: 0\n  > foo (0s)\n  > bar (0s)\n",
+	}, {
+		name: "completed",
+		events: []events.Event{
+			{Type: events.TestStarted, Id: "chainsaw/foo", Name: "foo"},
+			{Type: events.TestStarted, Id: "chainsaw/bar", Name: "bar"},
+			{Type: events.TestStarted, Id: "chainsaw/baz", Name: "baz"},
+			{Type: events.TestPassed, Id: "chainsaw/foo", Name: "foo"},
+			{Type: events.TestFailed, Id: "chainsaw/baz", Name: "baz"},
+			{Type: events.TestSkipped, Id: "chainsaw/qux", Name: "qux"},
+		},
+		want: "Running: 1 | Passed: 1 | Failed: 1 | Skipped: 1\n  > bar (0s)\n",
+	}, {
+		name: "scenarios",
+		events: []events.Event{
+			{Type: events.TestStarted, Id: "chainsaw/foo", Name: "foo"},
+			{Type: events.TestStarted, Id: "chainsaw/foo#01", Name: "foo"},
+			{Type: events.TestPassed, Id: "chainsaw/foo#01", Name: "foo"},
+		},
+		want: "Running: 1 | Passed: 1 | Failed: 0 | Skipped: 0\n  > foo (0s)\n",
+	}, {
+		name: "unknown event",
+		events: []events.Event{
+			{Type: "Unknown", Id: "chainsaw/foo", Name: "foo"},
+		},
+		want: "Running: 0 | Passed: 0 | Failed: 0 | Skipped: 0\n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model Model
+			for _, event := range tt.events {
+				model = model.Update(event, now)
+			}
+			assert.Equal(t, tt.want, model.View(now))
+		})
+	}
+}
+
+func TestModel_Counts(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var model Model
+	for i := range 15 {
+		model = model.Update(events.Event{Type: events.TestStarted, Id: fmt.Sprint(i), Name: fmt.Sprintf("test-%d", i)}, now)
+	}
+	for i := range 3 {
+		model = model.Update(events.Event{Type: events.TestPassed, Id: fmt.Sprint(i)}, now)
+	}
+	model = model.Update(events.Event{Type: events.TestFailed, Id: "3"}, now)
+	assert.Equal(t, 11, model.Running())
+	assert.Equal(t, 3, model.Passed())
+	assert.Equal(t, 1, model.Failed())
+	assert.Equal(t, 0, model.Skipped())
+	view := model.View(now.Add(90 * time.Second))
+	assert.Contains(t, view, "Running: 11 | Passed: 3 | Failed: 1 | Skipped: 0\n")
+	assert.Contains(t, view, "  > test-4 (1m30s)\n")
+	assert.Contains(t, view, "  ... and 1 more\n")
+	assert.NotContains(t, view, "test-14")
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/runner/events"
+	"golang.org/x/term"
+	"k8s.io/utils/clock"
+)
+
+// refreshInterval is the interval at which the view is refreshed to update elapsed times.
+const refreshInterval = time.Second
+
+// IsTerminal returns true if the writer is attached to a terminal.
+func IsTerminal(w io.Writer) bool {
+	if f, ok := w.(*os.File); ok {
+		return term.IsTerminal(int(f.Fd()))
+	}
+	return false
+}
+
+// Program renders the progress view at the bottom of the terminal and updates it in place.
+// While running, the process standard output is captured and printed above the view.
+type Program struct {
+	out    io.Writer
+	stdout *os.File
+	clock  clock.PassiveClock
+	lock   sync.Mutex
+	model  Model
+	lines  int
+	reader *os.File
+	writer *os.File
+	done   chan struct{}
+	stop   chan struct{}
+	once   sync.Once
+}
+
+func New(out io.Writer, clock clock.PassiveClock) *Program {
+	return &Program{
+		out:   out,
+		clock: clock,
+		done:  make(chan struct{}),
+		stop:  make(chan struct{}),
+	}
+}
+
+// Start captures the process standard output and starts rendering the view.
+func (p *Program) Start() error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	p.reader, p.writer = reader, writer
+	p.stdout, os.Stdout = os.Stdout, writer
+	go func() {
+		defer close(p.done)
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			p.println(scanner.Text())
+		}
+	}()
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.lock.Lock()
+				p.render()
+				p.lock.Unlock()
+			}
+		}
+	}()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.render()
+	return nil
+}
+
+// Stop restores the process standard output and prints the final view.
+// It is safe to call Stop multiple times, only the first call has an effect.
+func (p *Program) Stop() {
+	p.once.Do(p.stopOnce)
+}
+
+func (p *Program) stopOnce() {
+	close(p.stop)
+	os.Stdout = p.stdout
+	_ = p.writer.Close()
+	<-p.done
+	_ = p.reader.Close()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.render()
+}
+
+// Send updates the model with the event and renders the view.
+func (p *Program) Send(event events.Event) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.model = p.model.Update(event, p.clock.Now())
+	p.render()
+}
+
+func (p *Program) println(line string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.clear()
+	fmt.Fprintln(p.out, line)
+	p.render()
+}
+
+func (p *Program) clear() {
+	// move the cursor up and erase the lines of the previous view
+	fmt.Fprint(p.out, strings.Repeat("\x1b[1A\x1b[2K", p.lines))
+	p.lines = 0
+}
+
+func (p *Program) render() {
+	p.clear()
+	view := p.model.View(p.clock.Now())
+	fmt.Fprint(p.out, view)
+	p.lines = strings.Count(view, "\n")
+}
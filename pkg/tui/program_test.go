@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyverno/chainsaw/pkg/runner/events"
+	"github.com/stretchr/testify/assert"
+	tclock "k8s.io/utils/clock/testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	assert.False(t, IsTerminal(&bytes.Buffer{}))
+}
+
+func TestProgram_Send(t *testing.T) {
+	var out bytes.Buffer
+	program := New(&out, tclock.NewFakePassiveClock(time.Now()))
+	program.Send(events.Event{Type: events.TestStarted, Id: "chainsaw/foo", Name: "foo"})
+	assert.Equal(t, "Running: 1 | Passed: 0 | Failed: 0 | Skipped: 0\n  > foo (0s)\n", out.String())
+	out.Reset()
+	program.Send(events.Event{Type: events.TestPassed, Id: "chainsaw/foo", Name: "foo"})
+	// the previous view (two lines) is erased before rendering the new one
+	assert.Equal(t, strings.Repeat("\x1b[1A\x1b[2K", 2)+"Running: 0 | Passed: 1 | Failed: 0 | Skipped: 0\n", out.String())
+}
+
+func TestProgram_StartStop(t *testing.T) {
+	var out bytes.Buffer
+	stdout := os.Stdout
+	program := New(&out, tclock.NewFakePassiveClock(time.Now()))
+	assert.NoError(t, program.Start())
+	// the standard output is captured and printed above the view
+	fmt.Fprintln(os.Stdout, "hello")
+	program.Send(events.Event{Type: events.TestStarted, Id: "chainsaw/foo", Name: "foo"})
+	program.Stop()
+	// stopping again has no effect
+	program.Stop()
+	assert.Equal(t, stdout, os.Stdout)
+	assert.Contains(t, out.String(), "hello\n")
+	assert.True(t, strings.HasSuffix(out.String(), "Running: 1 | Passed: 0 | Failed: 0 | Skipped: 0\n  > foo (0s)\n"))
+}
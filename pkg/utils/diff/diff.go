@@ -69,3 +69,20 @@ func PrettyDiff(expected unstructured.Unstructured, actual unstructured.Unstruct
 		return difflib.GetUnifiedDiffString(diffed)
 	}
 }
+
+func PrettyDiffValues(expected any, actual any) (string, error) {
+	if expectedBuf, err := yaml.Marshal(expected); err != nil {
+		return "", err
+	} else if actualBuf, err := yaml.Marshal(actual); err != nil {
+		return "", err
+	} else {
+		diffed := difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(expectedBuf)),
+			B:        difflib.SplitLines(string(actualBuf)),
+			FromFile: "expected",
+			ToFile:   "actual",
+			Context:  3,
+		}
+		return difflib.GetUnifiedDiffString(diffed)
+	}
+}
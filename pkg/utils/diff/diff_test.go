@@ -61,3 +61,35 @@ func TestPrettyDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestPrettyDiffValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected any
+		actual   any
+		want     string
+	}{{
+		name: "empty",
+	}, {
+		name:     "same",
+		expected: map[string]any{"foo": "bar"},
+		actual:   map[string]any{"foo": "bar"},
+	}, {
+		name:     "different scalars",
+		expected: "foo",
+		actual:   "bar",
+		want:     "--- expected\n+++ actual\n@@ -1,2 +1,2 @@\n-foo\n+bar\n \n",
+	}, {
+		name:     "different maps",
+		expected: map[string]any{"foo": "bar", "baz": 1},
+		actual:   map[string]any{"foo": "bar", "baz": 2},
+		want:     "--- expected\n+++ actual\n@@ -1,3 +1,3 @@\n-baz: 1\n+baz: 2\n foo: bar\n \n",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PrettyDiffValues(tt.expected, tt.actual)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
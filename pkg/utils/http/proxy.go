@@ -0,0 +1,59 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/hashicorp/go-getter"
+	"golang.org/x/net/http/httpproxy"
+)
+
+var proxyFunc atomic.Pointer[func(*url.URL) (*url.URL, error)]
+
+// SetProxy configures an explicit proxy URL used for all HTTP and HTTPS requests.
+// NO_PROXY is still honored, an empty URL restores the environment based configuration.
+func SetProxy(proxyURL string) {
+	if proxyURL == "" {
+		proxyFunc.Store(nil)
+		return
+	}
+	config := httpproxy.FromEnvironment()
+	config.HTTPProxy = proxyURL
+	config.HTTPSProxy = proxyURL
+	fn := config.ProxyFunc()
+	proxyFunc.Store(&fn)
+}
+
+// Proxy returns the proxy to use for a given request.
+// It uses the explicit proxy if one was configured and falls back to HTTP_PROXY, HTTPS_PROXY and NO_PROXY otherwise.
+func Proxy(req *http.Request) (*url.URL, error) {
+	if fn := proxyFunc.Load(); fn != nil {
+		return (*fn)(req.URL)
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// Client returns an http client using the configured proxy.
+func Client() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = Proxy
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// Getters returns the go-getter getters, http getters use the configured proxy.
+func Getters() map[string]getter.Getter {
+	getters := map[string]getter.Getter{}
+	for scheme, g := range getter.Getters {
+		getters[scheme] = g
+	}
+	httpGetter := &getter.HttpGetter{
+		Netrc:  true,
+		Client: Client(),
+	}
+	getters["http"] = httpGetter
+	getters["https"] = httpGetter
+	return getters
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy(t *testing.T) {
+	var requested []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.String())
+		_, _ = w.Write([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+	}))
+	defer proxy.Close()
+	SetProxy(proxy.URL)
+	defer SetProxy("")
+	t.Run("client", func(t *testing.T) {
+		resp, err := Client().Get("http://chainsaw.example.com/client")
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("getters", func(t *testing.T) {
+		dst := filepath.Join(t.TempDir(), "manifest.yaml")
+		client := &getter.Client{
+			Ctx:     context.TODO(),
+			Src:     "http://chainsaw.example.com/manifest.yaml",
+			Dst:     dst,
+			Mode:    getter.ClientModeFile,
+			Getters: Getters(),
+		}
+		assert.NoError(t, client.Get())
+		content, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "apiVersion: v1\nkind: ConfigMap\n", string(content))
+	})
+	assert.Contains(t, requested, "http://chainsaw.example.com/client")
+	assert.Contains(t, requested, "http://chainsaw.example.com/manifest.yaml")
+}
+
+func TestProxy_Request(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy string
+		url   string
+		want  string
+	}{{
+		name:  "explicit http",
+		proxy: "http://proxy.example.com:3128",
+		url:   "http://chainsaw.example.com",
+		want:  "http://proxy.example.com:3128",
+	}, {
+		name:  "explicit https",
+		proxy: "http://proxy.example.com:3128",
+		url:   "https://chainsaw.example.com",
+		want:  "http://proxy.example.com:3128",
+	}, {
+		name:  "localhost bypasses proxy",
+		proxy: "http://proxy.example.com:3128",
+		url:   "http://localhost:8080",
+		want:  "",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetProxy(tt.proxy)
+			defer SetProxy("")
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.NoError(t, err)
+			got, err := Proxy(req)
+			assert.NoError(t, err)
+			if tt.want == "" {
+				assert.Nil(t, got)
+			} else {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
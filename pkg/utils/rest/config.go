@@ -3,6 +3,7 @@ package rest
 import (
 	"io"
 
+	httputils "github.com/kyverno/chainsaw/pkg/utils/http"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -18,14 +19,33 @@ func Config(kubeconfigPath string, overrides clientcmd.ConfigOverrides) (*rest.C
 	return load(loader, overrides)
 }
 
+func ConfigFromBytes(kubeconfig []byte, overrides clientcmd.ConfigOverrides) (*rest.Config, error) {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return finalize(clientcmd.NewDefaultClientConfig(*cfg, &overrides))
+}
+
 func load(loader clientcmd.ClientConfigLoader, overrides clientcmd.ConfigOverrides) (*rest.Config, error) {
-	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, &overrides)
+	return finalize(clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, &overrides))
+}
+
+func finalize(kubeConfig clientcmd.ClientConfig) (*rest.Config, error) {
 	config, err := kubeConfig.ClientConfig()
 	if err != nil {
 		return nil, err
 	}
-	config.QPS = 300
-	config.Burst = 300
+	if config.QPS == 0 {
+		config.QPS = 300
+	}
+	if config.Burst == 0 {
+		config.Burst = 300
+	}
+	// keep the proxy configured in the kubeconfig cluster (proxy-url) if any
+	if config.Proxy == nil {
+		config.Proxy = httputils.Proxy
+	}
 	return config, nil
 }
 
@@ -2,6 +2,8 @@ package rest
 
 import (
 	"bytes"
+	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -149,6 +151,112 @@ func TestConfig(t *testing.T) {
 	}
 }
 
+func TestConfigFromBytes(t *testing.T) {
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:53742
+  name: kind-kind
+- cluster:
+    server: https://127.0.0.1:1234
+  name: foo
+- cluster:
+    server: https://127.0.0.1:5678
+    proxy-url: http://proxy.example.com:3128
+  name: proxied
+contexts:
+- context:
+    cluster: kind-kind
+    user: kind-kind
+  name: kind-kind
+- context:
+    cluster: foo
+    user: kind-kind
+  name: foo
+- context:
+    cluster: proxied
+    user: kind-kind
+  name: proxied
+current-context: kind-kind
+users:
+- name: kind-kind
+  user:
+    token: secret
+`
+	tests := []struct {
+		name       string
+		kubeconfig string
+		overrides  clientcmd.ConfigOverrides
+		want       *rest.Config
+		wantProxy  string
+		wantErr    bool
+	}{{
+		name:       "invalid",
+		kubeconfig: "not a kubeconfig",
+		wantErr:    true,
+	}, {
+		name:    "empty",
+		wantErr: true,
+	}, {
+		name:       "default context",
+		kubeconfig: kubeconfig,
+		want: &rest.Config{
+			Host:        "https://127.0.0.1:53742",
+			BearerToken: "secret",
+			QPS:         300,
+			Burst:       300,
+		},
+	}, {
+		name:       "context override",
+		kubeconfig: kubeconfig,
+		overrides: clientcmd.ConfigOverrides{
+			CurrentContext: "foo",
+		},
+		want: &rest.Config{
+			Host:        "https://127.0.0.1:1234",
+			BearerToken: "secret",
+			QPS:         300,
+			Burst:       300,
+		},
+	}, {
+		name:       "kubeconfig proxy",
+		kubeconfig: kubeconfig,
+		overrides: clientcmd.ConfigOverrides{
+			CurrentContext: "proxied",
+		},
+		want: &rest.Config{
+			Host:        "https://127.0.0.1:5678",
+			BearerToken: "secret",
+			QPS:         300,
+			Burst:       300,
+		},
+		wantProxy: "http://proxy.example.com:3128",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConfigFromBytes([]byte(tt.kubeconfig), tt.overrides)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				if assert.NotNil(t, got) {
+					assert.Equal(t, tt.want.Host, got.Host)
+					assert.Equal(t, tt.want.BearerToken, got.BearerToken)
+					assert.Equal(t, tt.want.QPS, got.QPS)
+					assert.Equal(t, tt.want.Burst, got.Burst)
+					if tt.wantProxy != "" {
+						proxy, err := got.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "127.0.0.1:5678"}})
+						assert.NoError(t, err)
+						assert.Equal(t, tt.wantProxy, proxy.String())
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestSave(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,57 @@
+package signal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Context returns a context that is cancelled on the first SIGINT or SIGTERM.
+// Once cancelled, the process is given a grace period to run cleanup before being terminated,
+// a second signal terminates the process immediately.
+func Context(parent context.Context, grace time.Duration, out io.Writer) (context.Context, context.CancelFunc) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := notifyContext(parent, grace, out, signals, os.Exit)
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+func notifyContext(parent context.Context, grace time.Duration, out io.Writer, signals <-chan os.Signal, exit func(int)) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			fmt.Fprintf(out, "Received %s, stopping tests and running cleanup (press Ctrl+C again to exit immediately)...\n", sig)
+			cancel()
+		case <-done:
+			return
+		}
+		var timeout <-chan time.Time
+		if grace > 0 {
+			timer := time.NewTimer(grace)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case sig := <-signals:
+			fmt.Fprintf(out, "Received %s, exiting immediately\n", sig)
+			exit(1)
+		case <-timeout:
+			fmt.Fprintf(out, "Cleanup did not complete within %s, exiting\n", grace)
+			exit(1)
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
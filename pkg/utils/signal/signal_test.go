@@ -0,0 +1,94 @@
+package signal
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	lock  sync.Mutex
+	codes []int
+	out   bytes.Buffer
+}
+
+func (r *recorder) exit(code int) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.codes = append(r.codes, code)
+}
+
+func (r *recorder) exitCodes() []int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.codes
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.out.Write(p)
+}
+
+func Test_notifyContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		grace     time.Duration
+		signals   int
+		wait      time.Duration
+		wantDone  bool
+		wantCodes []int
+	}{{
+		name:     "no signal",
+		grace:    time.Minute,
+		wantDone: false,
+	}, {
+		name:     "first signal cancels",
+		grace:    time.Minute,
+		signals:  1,
+		wantDone: true,
+	}, {
+		name:      "second signal exits",
+		grace:     time.Minute,
+		signals:   2,
+		wantDone:  true,
+		wantCodes: []int{1},
+	}, {
+		name:      "grace period expired",
+		grace:     10 * time.Millisecond,
+		signals:   1,
+		wait:      100 * time.Millisecond,
+		wantDone:  true,
+		wantCodes: []int{1},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recorder recorder
+			signals := make(chan os.Signal)
+			ctx, cancel := notifyContext(context.Background(), tt.grace, &recorder, signals, recorder.exit)
+			for i := 0; i < tt.signals; i++ {
+				signals <- os.Interrupt
+			}
+			if tt.wantDone {
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second):
+					t.Fatal("context was not cancelled")
+				}
+			} else {
+				assert.NoError(t, ctx.Err())
+			}
+			time.Sleep(tt.wait)
+			assert.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual(tt.wantCodes, recorder.exitCodes())
+			}, time.Second, 10*time.Millisecond)
+			cancel()
+			assert.Error(t, ctx.Err())
+		})
+	}
+}
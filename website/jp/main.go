@@ -15,6 +15,9 @@ import (
 //go:embed examples
 var examples embed.FS
 
+//go:embed custom.md
+var customFunctions string
+
 func main() {
 	fmt.Println("# Functions")
 	fmt.Println()
@@ -40,6 +43,7 @@ func main() {
 	fmt.Println()
 	printFunctions(chainsawfunctions.GetFunctions()...)
 	fmt.Println()
+	fmt.Print(customFunctions)
 }
 
 func printFunctions(funcs ...jpfunctions.FunctionEntry) {